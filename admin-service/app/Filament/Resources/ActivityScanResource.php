@@ -0,0 +1,99 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\ActivityScanResource\Pages;
+use App\Models\ActivityScans;
+use App\Models\GeofenceOverrides;
+use Filament\Forms;
+use Filament\Notifications\Notification;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class ActivityScanResource extends Resource
+{
+    protected static ?string $model = ActivityScans::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-map-pin';
+
+    protected static ?string $navigationLabel = 'Activity Scans';
+
+    // scans are recorded by the API when a QR code is scanned
+    public static function canCreate(): bool
+    {
+        return false;
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->defaultSort('created_at', 'desc')
+            ->columns([
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('activity_type')->badge(),
+                Tables\Columns\TextColumn::make('activity_id'),
+                Tables\Columns\TextColumn::make('distance_meters')
+                    ->numeric(0)
+                    ->suffix(' m'),
+                Tables\Columns\TextColumn::make('status')
+                    ->badge()
+                    ->color(fn (string $state): string => match ($state) {
+                        'accepted', 'overridden' => 'success',
+                        'flagged' => 'warning',
+                        'rejected' => 'danger',
+                        default => 'gray',
+                    }),
+                Tables\Columns\TextColumn::make('created_at'),
+            ])
+            ->filters([
+                Tables\Filters\SelectFilter::make('status')
+                    ->options([
+                        'accepted' => 'Accepted',
+                        'flagged' => 'Flagged',
+                        'rejected' => 'Rejected',
+                        'overridden' => 'Overridden',
+                    ]),
+                Tables\Filters\SelectFilter::make('activity_type')
+                    ->options([
+                        'quest' => 'Quest',
+                        'event' => 'Event',
+                        'treasure' => 'Treasure',
+                    ]),
+            ])
+            ->actions([
+                Tables\Actions\Action::make('allow')
+                    ->label('Allow')
+                    ->icon('heroicon-o-check')
+                    ->visible(fn (ActivityScans $record): bool => $record->status === 'rejected')
+                    ->form([
+                        Forms\Components\Textarea::make('reason'),
+                    ])
+                    ->action(function (ActivityScans $record, array $data) {
+                        GeofenceOverrides::updateOrCreate(
+                            [
+                                'user_id' => $record->user_id,
+                                'activity_type' => $record->activity_type,
+                                'activity_id' => $record->activity_id,
+                            ],
+                            [
+                                'reason' => $data['reason'] ?? null,
+                                'created_by' => auth()->id(),
+                            ],
+                        );
+
+                        Notification::make()
+                            ->title('User can now scan this activity from anywhere')
+                            ->success()
+                            ->send();
+                    }),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListActivityScans::route('/'),
+        ];
+    }
+}
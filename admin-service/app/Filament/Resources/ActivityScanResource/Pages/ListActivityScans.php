@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\ActivityScanResource\Pages;
+
+use App\Filament\Resources\ActivityScanResource;
+use Filament\Resources\Pages\ListRecords;
+
+class ListActivityScans extends ListRecords
+{
+    protected static string $resource = ActivityScanResource::class;
+}
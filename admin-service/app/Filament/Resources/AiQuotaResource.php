@@ -0,0 +1,80 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\AiQuotaResource\Pages;
+use App\Models\AiQuotas;
+use Filament\Forms;
+use Filament\Forms\Form;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class AiQuotaResource extends Resource
+{
+    protected static ?string $model = AiQuotas::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-adjustments-horizontal';
+
+    protected static ?string $navigationLabel = 'AI Quotas';
+
+    public static function features(): array
+    {
+        return [
+            'trash_scan' => 'Trash scan',
+            'trash_vision' => 'Trash vision',
+            'greenprint' => 'Greenprint',
+            'ecoach' => 'Ecoach',
+            'recap_weekly' => 'Weekly recap',
+            'recap_monthly' => 'Monthly recap',
+            'total' => 'All features',
+        ];
+    }
+
+    public static function form(Form $form): Form
+    {
+        return $form
+            ->schema([
+                Forms\Components\Select::make('user_id')
+                    ->relationship('user', 'username')
+                    ->searchable()
+                    ->required(),
+                Forms\Components\Select::make('feature')
+                    ->options(static::features())
+                    ->required(),
+                Forms\Components\TextInput::make('daily_limit')
+                    ->numeric()
+                    ->helperText('Leave empty to use the default limit, 0 means unlimited'),
+                Forms\Components\TextInput::make('monthly_limit')
+                    ->numeric()
+                    ->helperText('Leave empty to use the default limit, 0 means unlimited'),
+            ]);
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->columns([
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('feature'),
+                Tables\Columns\TextColumn::make('daily_limit')->placeholder('default'),
+                Tables\Columns\TextColumn::make('monthly_limit')->placeholder('default'),
+                Tables\Columns\TextColumn::make('updated_at'),
+            ])
+            ->actions([
+                Tables\Actions\EditAction::make(),
+            ])
+            ->bulkActions([
+                Tables\Actions\DeleteBulkAction::make(),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListAiQuotas::route('/'),
+            'create' => Pages\CreateAiQuota::route('/create'),
+            'edit' => Pages\EditAiQuota::route('/{record}/edit'),
+        ];
+    }
+}
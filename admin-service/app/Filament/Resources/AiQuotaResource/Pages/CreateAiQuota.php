@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\AiQuotaResource\Pages;
+
+use App\Filament\Resources\AiQuotaResource;
+use Filament\Resources\Pages\CreateRecord;
+
+class CreateAiQuota extends CreateRecord
+{
+    protected static string $resource = AiQuotaResource::class;
+}
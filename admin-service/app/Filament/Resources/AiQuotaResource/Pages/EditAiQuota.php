@@ -0,0 +1,19 @@
+<?php
+
+namespace App\Filament\Resources\AiQuotaResource\Pages;
+
+use App\Filament\Resources\AiQuotaResource;
+use Filament\Actions;
+use Filament\Resources\Pages\EditRecord;
+
+class EditAiQuota extends EditRecord
+{
+    protected static string $resource = AiQuotaResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\DeleteAction::make(),
+        ];
+    }
+}
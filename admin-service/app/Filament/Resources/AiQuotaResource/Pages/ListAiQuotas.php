@@ -0,0 +1,19 @@
+<?php
+
+namespace App\Filament\Resources\AiQuotaResource\Pages;
+
+use App\Filament\Resources\AiQuotaResource;
+use Filament\Actions;
+use Filament\Resources\Pages\ListRecords;
+
+class ListAiQuotas extends ListRecords
+{
+    protected static string $resource = AiQuotaResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\CreateAction::make(),
+        ];
+    }
+}
@@ -0,0 +1,62 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\AiUsageResource\Pages;
+use App\Models\AiUsages;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+use Illuminate\Database\Eloquent\Builder;
+
+class AiUsageResource extends Resource
+{
+    protected static ?string $model = AiUsages::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-cpu-chip';
+
+    protected static ?string $navigationLabel = 'AI Usage';
+
+    public static function canCreate(): bool
+    {
+        return false;
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->defaultSort('created_at', 'desc')
+            ->columns([
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('feature'),
+                Tables\Columns\TextColumn::make('provider'),
+                Tables\Columns\TextColumn::make('model'),
+                Tables\Columns\TextColumn::make('total_tokens')
+                    ->summarize(Tables\Columns\Summarizers\Sum::make()),
+                Tables\Columns\TextColumn::make('latency_ms'),
+                Tables\Columns\TextColumn::make('cost')
+                    ->money('USD')
+                    ->summarize(Tables\Columns\Summarizers\Sum::make()->money('USD')),
+                Tables\Columns\TextColumn::make('status')->badge(),
+                Tables\Columns\TextColumn::make('created_at'),
+            ])
+            ->filters([
+                Tables\Filters\SelectFilter::make('feature')
+                    ->options(AiQuotaResource::features()),
+                Tables\Filters\SelectFilter::make('status')
+                    ->options([
+                        'success' => 'Success',
+                        'failed' => 'Failed',
+                    ]),
+                Tables\Filters\Filter::make('this_month')
+                    ->query(fn (Builder $query) => $query->where('created_at', '>=', now()->startOfMonth())),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListAiUsages::route('/'),
+        ];
+    }
+}
@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\AiUsageResource\Pages;
+
+use App\Filament\Resources\AiUsageResource;
+use Filament\Resources\Pages\ListRecords;
+
+class ListAiUsages extends ListRecords
+{
+    protected static string $resource = AiUsageResource::class;
+}
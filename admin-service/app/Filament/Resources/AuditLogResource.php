@@ -0,0 +1,50 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\AuditLogResource\Pages;
+use App\Models\AuditLogs;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class AuditLogResource extends Resource
+{
+    protected static ?string $model = AuditLogs::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-shield-check';
+
+    protected static ?string $navigationLabel = 'Audit Log';
+
+    // entries are written by the API whenever personal data is accessed
+    public static function canCreate(): bool
+    {
+        return false;
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->defaultSort('created_at', 'desc')
+            ->columns([
+                Tables\Columns\TextColumn::make('created_at'),
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('action')->badge(),
+                Tables\Columns\TextColumn::make('subject_type')->label('Subject'),
+                Tables\Columns\TextColumn::make('subject_id')->label('Subject ID'),
+                Tables\Columns\TextColumn::make('detail'),
+                Tables\Columns\TextColumn::make('ip_address')->label('IP Address'),
+            ])
+            ->filters([
+                Tables\Filters\SelectFilter::make('action')
+                    ->options(fn (): array => AuditLogs::query()->distinct()->pluck('action', 'action')->all()),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListAuditLogs::route('/'),
+        ];
+    }
+}
@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\AuditLogResource\Pages;
+
+use App\Filament\Resources\AuditLogResource;
+use Filament\Resources\Pages\ListRecords;
+
+class ListAuditLogs extends ListRecords
+{
+    protected static string $resource = AuditLogResource::class;
+}
@@ -0,0 +1,59 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\DepositResource\Pages;
+use App\Models\Deposits;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class DepositResource extends Resource
+{
+    protected static ?string $model = Deposits::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-scale';
+
+    protected static ?string $navigationLabel = 'Deposits';
+
+    // deposits are confirmed through the API so the points are awarded
+    public static function canCreate(): bool
+    {
+        return false;
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->defaultSort('created_at', 'desc')
+            ->columns([
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('wasteBank.name'),
+                Tables\Columns\TextColumn::make('total_weight')
+                    ->suffix(' kg')
+                    ->summarize(Tables\Columns\Summarizers\Sum::make()),
+                Tables\Columns\TextColumn::make('payout')
+                    ->money('IDR'),
+                Tables\Columns\TextColumn::make('point_gain'),
+                Tables\Columns\TextColumn::make('status')->badge(),
+                Tables\Columns\TextColumn::make('created_at'),
+            ])
+            ->filters([
+                Tables\Filters\SelectFilter::make('status')
+                    ->options([
+                        'pending' => 'Pending',
+                        'confirmed' => 'Confirmed',
+                        'rejected' => 'Rejected',
+                    ]),
+                Tables\Filters\SelectFilter::make('waste_bank_id')
+                    ->relationship('wasteBank', 'name'),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListDeposits::route('/'),
+        ];
+    }
+}
@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\DepositResource\Pages;
+
+use App\Filament\Resources\DepositResource;
+use Filament\Resources\Pages\ListRecords;
+
+class ListDeposits extends ListRecords
+{
+    protected static string $resource = DepositResource::class;
+}
@@ -0,0 +1,127 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\DropoffPointResource\Pages;
+use App\Models\DropoffPoints;
+use Cheesegrits\FilamentGoogleMaps\Fields\Map;
+use Filament\Forms;
+use Filament\Forms\Form;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class DropoffPointResource extends Resource
+{
+    protected static ?string $model = DropoffPoints::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-map-pin';
+
+    protected static ?string $navigationLabel = 'Drop-off Points';
+
+    public static function form(Form $form): Form
+    {
+        return $form
+            ->schema([
+                Forms\Components\TextInput::make('name')
+                    ->required(),
+                Forms\Components\Textarea::make('address')
+                    ->required(),
+                Forms\Components\Textarea::make('description'),
+                Forms\Components\Select::make('region_id')
+                    ->relationship('region', 'name')
+                    ->searchable(),
+                Forms\Components\TextInput::make('phone')
+                    ->tel(),
+                Forms\Components\Toggle::make('is_active')
+                    ->default(true),
+                Map::make('map_picker')
+                    ->label('Drop-off Point Location (Pick on Map)')
+                    ->defaultLocation([-6.175392, 106.827153])
+                    ->draggable()
+                    ->clickable()
+                    ->afterStateUpdated(function ($state, callable $set) {
+                        if (is_array($state) && count($state) >= 2) {
+                            $set('latitude', $state["lat"]);
+                            $set('longitude', $state["lng"]);
+                        }
+                    }),
+                Forms\Components\TextInput::make('latitude')
+                    ->hidden()
+                    ->dehydrated()
+                    ->required(),
+                Forms\Components\TextInput::make('longitude')
+                    ->hidden()
+                    ->dehydrated()
+                    ->required(),
+                Forms\Components\Repeater::make('materials')
+                    ->label('Accepted Materials')
+                    ->relationship()
+                    ->simple(
+                        Forms\Components\Select::make('material')
+                            ->options(WasteBankResource::materials())
+                            ->distinct()
+                            ->required(),
+                    )
+                    ->columnSpanFull(),
+                Forms\Components\Repeater::make('hours')
+                    ->label('Opening Hours')
+                    ->relationship()
+                    ->schema([
+                        Forms\Components\Select::make('day_of_week')
+                            ->options([
+                                1 => 'Monday',
+                                2 => 'Tuesday',
+                                3 => 'Wednesday',
+                                4 => 'Thursday',
+                                5 => 'Friday',
+                                6 => 'Saturday',
+                                0 => 'Sunday',
+                            ])
+                            ->required(),
+                        Forms\Components\TimePicker::make('opens_at')
+                            ->seconds(false)
+                            ->required(),
+                        Forms\Components\TimePicker::make('closes_at')
+                            ->seconds(false)
+                            ->after('opens_at')
+                            ->required(),
+                    ])
+                    ->columns(3)
+                    ->columnSpanFull(),
+            ]);
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->columns([
+                Tables\Columns\TextColumn::make('name')->searchable(),
+                Tables\Columns\TextColumn::make('region.name'),
+                Tables\Columns\TextColumn::make('materials.material')
+                    ->label('Materials')
+                    ->badge(),
+                Tables\Columns\IconColumn::make('is_active')->boolean(),
+            ])
+            ->filters([
+                Tables\Filters\TernaryFilter::make('is_active'),
+            ])
+            ->actions([
+                Tables\Actions\EditAction::make(),
+            ])
+            ->bulkActions([
+                Tables\Actions\BulkActionGroup::make([
+                    Tables\Actions\DeleteBulkAction::make(),
+                ]),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListDropoffPoints::route('/'),
+            'create' => Pages\CreateDropoffPoint::route('/create'),
+            'edit' => Pages\EditDropoffPoint::route('/{record}/edit'),
+        ];
+    }
+}
@@ -0,0 +1,23 @@
+<?php
+
+namespace App\Filament\Resources\DropoffPointResource\Pages;
+
+use App\Filament\Resources\DropoffPointResource;
+use Filament\Resources\Pages\CreateRecord;
+
+class CreateDropoffPoint extends CreateRecord
+{
+    protected static string $resource = DropoffPointResource::class;
+
+    protected function mutateFormDataBeforeCreate(array $data): array
+    {
+        if (isset($data['map_picker']['lat'], $data['map_picker']['lng'])) {
+            $data['latitude'] = $data['map_picker']['lat'];
+            $data['longitude'] = $data['map_picker']['lng'];
+        }
+
+        unset($data['map_picker']);
+
+        return $data;
+    }
+}
@@ -0,0 +1,31 @@
+<?php
+
+namespace App\Filament\Resources\DropoffPointResource\Pages;
+
+use App\Filament\Resources\DropoffPointResource;
+use Filament\Actions;
+use Filament\Resources\Pages\EditRecord;
+
+class EditDropoffPoint extends EditRecord
+{
+    protected static string $resource = DropoffPointResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\DeleteAction::make(),
+        ];
+    }
+
+    protected function mutateFormDataBeforeSave(array $data): array
+    {
+        if (isset($data['map_picker']['lat'], $data['map_picker']['lng'])) {
+            $data['latitude'] = $data['map_picker']['lat'];
+            $data['longitude'] = $data['map_picker']['lng'];
+        }
+
+        unset($data['map_picker']);
+
+        return $data;
+    }
+}
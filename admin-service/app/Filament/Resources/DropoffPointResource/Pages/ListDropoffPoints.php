@@ -0,0 +1,19 @@
+<?php
+
+namespace App\Filament\Resources\DropoffPointResource\Pages;
+
+use App\Filament\Resources\DropoffPointResource;
+use Filament\Actions;
+use Filament\Resources\Pages\ListRecords;
+
+class ListDropoffPoints extends ListRecords
+{
+    protected static string $resource = DropoffPointResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\CreateAction::make(),
+        ];
+    }
+}
@@ -0,0 +1,60 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\EventFeedbackResource\Pages;
+use App\Models\Event;
+use App\Models\EventFeedbacks;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class EventFeedbackResource extends Resource
+{
+    protected static ?string $model = EventFeedbacks::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-star';
+
+    protected static ?string $navigationLabel = 'Event Feedback';
+
+    // feedback is left by attendees through the API
+    public static function canCreate(): bool
+    {
+        return false;
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->defaultSort('created_at', 'desc')
+            ->columns([
+                Tables\Columns\TextColumn::make('event.detail.name')->label('Event')->searchable(),
+                Tables\Columns\TextColumn::make('user.username')->searchable(),
+                Tables\Columns\TextColumn::make('rating')
+                    ->formatStateUsing(fn (int $state): string => str_repeat('★', $state) . str_repeat('☆', 5 - $state)),
+                Tables\Columns\TextColumn::make('comment')->limit(80)->wrap(),
+                Tables\Columns\ImageColumn::make('photo_key')->disk('s3')->label('Photo'),
+                Tables\Columns\TextColumn::make('created_at'),
+            ])
+            ->filters([
+                Tables\Filters\SelectFilter::make('event_id')
+                    ->label('Event')
+                    ->options(fn (): array => Event::with('detail')->get()->pluck('detail.name', 'id')->all()),
+                Tables\Filters\SelectFilter::make('rating')
+                    ->options([
+                        1 => '1 star',
+                        2 => '2 stars',
+                        3 => '3 stars',
+                        4 => '4 stars',
+                        5 => '5 stars',
+                    ]),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListEventFeedbacks::route('/'),
+        ];
+    }
+}
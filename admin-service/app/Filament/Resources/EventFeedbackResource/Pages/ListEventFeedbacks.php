@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\EventFeedbackResource\Pages;
+
+use App\Filament\Resources\EventFeedbackResource;
+use Filament\Resources\Pages\ListRecords;
+
+class ListEventFeedbacks extends ListRecords
+{
+    protected static string $resource = EventFeedbackResource::class;
+}
@@ -8,9 +8,11 @@
 use Carbon\Carbon;
 use Cheesegrits\FilamentGoogleMaps\Fields\Map;
 use Filament\Forms;
+use Filament\Notifications\Notification;
 use Filament\Tables;
 use Filament\Resources\Resource;
 use Filament\Tables\Columns\TextColumn;
+use Illuminate\Support\Facades\DB;
 use Illuminate\Support\Facades\Storage;
 
 class EventResource extends Resource
@@ -44,8 +46,33 @@ public static function form(Forms\Form $form): Forms\Form
                     ->schema([
                         Forms\Components\TextInput::make('location')->required(),
                         Forms\Components\TextInput::make('contact')->required(),
+                        Forms\Components\TextInput::make('geofence_radius')
+                            ->label('Scan Radius (meters)')
+                            ->helperText('Leave empty to use the default radius')
+                            ->numeric()
+                            ->minValue(10),
                         Forms\Components\DateTimePicker::make('starts_at')->required(),
                         Forms\Components\DateTimePicker::make('ends_at')->required(),
+                        Forms\Components\TextInput::make('credited_minutes')
+                            ->label('Credited Volunteer Minutes')
+                            ->helperText('Leave empty to credit the event duration')
+                            ->numeric()
+                            ->minValue(0),
+                        Forms\Components\TextInput::make('max_attendees')
+                            ->label('Max Attendees')
+                            ->helperText('Leave empty for unlimited registrations, extra users join the waitlist')
+                            ->numeric()
+                            ->minValue(1),
+                        Forms\Components\DateTimePicker::make('registration_closes_at')
+                            ->label('Registration Closes At')
+                            ->helperText('Leave empty to close registration when the event starts')
+                            ->beforeOrEqual('starts_at'),
+                        Forms\Components\Select::make('organizers')
+                            ->label('Organizers')
+                            ->helperText('Users allowed to scan attendee tickets at check-in')
+                            ->relationship('organizers', 'username')
+                            ->multiple()
+                            ->searchable(),
 
                         Forms\Components\FileUpload::make('cover_key')
                             ->label('Cover Image')
@@ -92,6 +119,9 @@ public static function table(Tables\Table $table): Tables\Table
             ->columns([
                 Tables\Columns\TextColumn::make('detail.name')->label('Event Name'),
                 Tables\Columns\TextColumn::make('location'),
+                TextColumn::make('attendee_count')
+                    ->label('Attendees')
+                    ->formatStateUsing(fn ($state, Event $record): string => $record->max_attendees ? "{$state} / {$record->max_attendees}" : (string) $state),
                 TextColumn::make('detail.created_at'),
                 Tables\Columns\ImageColumn::make('code.image_url')->label('QR Code'),
             ])
@@ -99,7 +129,18 @@ public static function table(Tables\Table $table): Tables\Table
                 Tables\Actions\EditAction::make(),
             ])
             ->bulkActions([
-                Tables\Actions\DeleteBulkAction::make(),
+                // certificates sign the event, so events that have them have to stay
+                Tables\Actions\DeleteBulkAction::make()
+                    ->before(function (Tables\Actions\DeleteBulkAction $action, $records) {
+                        if (DB::table('certificates')->whereIn('event_id', $records->pluck('id'))->exists()) {
+                            Notification::make()
+                                ->title('Some of the selected events have certificates and can\'t be deleted')
+                                ->danger()
+                                ->send();
+
+                            $action->cancel();
+                        }
+                    }),
             ]);
     }
 
@@ -3,8 +3,10 @@
 namespace App\Filament\Resources\EventResource\Pages;
 
 use App\Filament\Resources\EventResource;
+use App\Models\Event;
 use Filament\Actions;
 use Filament\Resources\Pages\EditRecord;
+use Illuminate\Support\Facades\DB;
 
 class EditEvent extends EditRecord
 {
@@ -13,7 +15,9 @@ class EditEvent extends EditRecord
     protected function getHeaderActions(): array
     {
         return [
-            Actions\DeleteAction::make(),
+            // certificates sign the event, so it has to stay
+            Actions\DeleteAction::make()
+                ->hidden(fn (Event $record): bool => DB::table('certificates')->where('event_id', $record->id)->exists()),
         ];
     }
 }
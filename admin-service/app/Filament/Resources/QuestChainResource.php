@@ -0,0 +1,69 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\QuestChainResource\Pages;
+use App\Models\QuestChains;
+use Filament\Forms;
+use Filament\Forms\Form;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class QuestChainResource extends Resource
+{
+    protected static ?string $model = QuestChains::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-link';
+
+    protected static ?string $navigationLabel = 'Quest Chains';
+
+    public static function form(Form $form): Form
+    {
+        return $form
+            ->schema([
+                Forms\Components\TextInput::make('name')
+                    ->required()
+                    ->maxLength(255),
+                Forms\Components\Textarea::make('description')
+                    ->required()
+                    ->rows(3),
+                Forms\Components\TextInput::make('completion_point_gain')
+                    ->label('Completion Point Gain')
+                    ->helperText('Awarded on top of the stage points after the last stage')
+                    ->numeric()
+                    ->default(0)
+                    ->required(),
+                Forms\Components\Toggle::make('is_active')
+                    ->default(true),
+            ]);
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->columns([
+                Tables\Columns\TextColumn::make('name')->searchable(),
+                Tables\Columns\TextColumn::make('stages_count')
+                    ->counts('stages')
+                    ->label('Stages'),
+                Tables\Columns\TextColumn::make('completions_count')
+                    ->counts('completions')
+                    ->label('Completions'),
+                Tables\Columns\TextColumn::make('completion_point_gain')->label('Completion Points'),
+                Tables\Columns\IconColumn::make('is_active')->boolean(),
+            ])
+            ->actions([
+                Tables\Actions\EditAction::make(),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListQuestChains::route('/'),
+            'create' => Pages\CreateQuestChain::route('/create'),
+            'edit' => Pages\EditQuestChain::route('/{record}/edit'),
+        ];
+    }
+}
@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Filament\Resources\QuestChainResource\Pages;
+
+use App\Filament\Resources\QuestChainResource;
+use Filament\Resources\Pages\CreateRecord;
+
+class CreateQuestChain extends CreateRecord
+{
+    protected static string $resource = QuestChainResource::class;
+}
@@ -0,0 +1,19 @@
+<?php
+
+namespace App\Filament\Resources\QuestChainResource\Pages;
+
+use App\Filament\Resources\QuestChainResource;
+use Filament\Actions;
+use Filament\Resources\Pages\EditRecord;
+
+class EditQuestChain extends EditRecord
+{
+    protected static string $resource = QuestChainResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\DeleteAction::make(),
+        ];
+    }
+}
@@ -56,7 +56,27 @@ public static function form(Form $form): Form
                             ->numeric()
                             ->required(),
                         Textarea::make("clue")
-                            ->required()
+                            ->required(),
+                        Forms\Components\TextInput::make('geofence_radius')
+                            ->label('Scan Radius (meters)')
+                            ->helperText('Leave empty to use the default radius')
+                            ->numeric()
+                            ->minValue(10),
+                    ]),
+
+                Forms\Components\Section::make('Quest Chain')
+                    ->schema([
+                        Forms\Components\Select::make('chain_id')
+                            ->label('Chain')
+                            ->relationship('chain', 'name')
+                            ->searchable()
+                            ->live(),
+                        Forms\Components\TextInput::make('stage_order')
+                            ->label('Stage')
+                            ->helperText('Stages are played in ascending order, completing one reveals the clue of the next')
+                            ->numeric()
+                            ->minValue(1)
+                            ->required(fn (Forms\Get $get): bool => filled($get('chain_id'))),
                     ]),
 
                     Map::make('map_picker')
@@ -98,6 +118,8 @@ public static function table(Table $table): Table
                 Tables\Columns\TextColumn::make('location'),
                 Tables\Columns\TextColumn::make('max_contributors')->label('Max Contributors'),
                 TextColumn::make('detail.point_gain')->label('Point Gain'),
+                TextColumn::make('chain.name')->label('Chain'),
+                TextColumn::make('stage_order')->label('Stage'),
                 TextColumn::make('detail.created_at')->label("Created At"),
                 Tables\Columns\ImageColumn::make('code.image_url')->label('QR Code'),
             ])
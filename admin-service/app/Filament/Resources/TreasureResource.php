@@ -6,6 +6,7 @@
 use App\Models\Treasure;
 use App\Models\Treasures;
 use Barryvdh\DomPDF\Facade\Pdf;
+use Cheesegrits\FilamentGoogleMaps\Fields\Map;
 use Filament\Forms;
 use Filament\Forms\Form;
 use Filament\Tables;
@@ -26,6 +27,47 @@ public static function form(Form $form): Form
                 Forms\Components\TextInput::make('name')->required(),
                 Forms\Components\TextInput::make('point_gain')->numeric()->required(),
                 Forms\Components\Toggle::make('claimed')->default(false),
+                Forms\Components\TextInput::make('max_claims')
+                    ->label('Quantity')
+                    ->helperText('How many times the treasure can be claimed in total')
+                    ->numeric()
+                    ->minValue(1)
+                    ->default(1)
+                    ->required(),
+                Forms\Components\TextInput::make('per_user_limit')
+                    ->label('Claims per User')
+                    ->numeric()
+                    ->minValue(1)
+                    ->default(1)
+                    ->required(),
+                Forms\Components\DateTimePicker::make('starts_at')
+                    ->label('Available From'),
+                Forms\Components\DateTimePicker::make('expires_at')
+                    ->label('Expires At')
+                    ->after('starts_at'),
+                Forms\Components\TextInput::make('geofence_radius')
+                    ->label('Scan Radius (meters)')
+                    ->helperText('Leave empty to use the default radius')
+                    ->numeric()
+                    ->minValue(10),
+                Map::make('map_picker')
+                    ->label('Treasure Location (Pick on Map, optional)')
+                    ->helperText('Kept hidden from players, they get hot/cold hints and can only claim it within the scan radius')
+                    ->defaultLocation([-6.175392, 106.827153])
+                    ->draggable()
+                    ->clickable()
+                    ->afterStateUpdated(function ($state, callable $set) {
+                        if (is_array($state) && count($state) >= 2) {
+                            $set('latitude', $state["lat"]);
+                            $set('longitude', $state["lng"]);
+                        }
+                    }),
+                Forms\Components\TextInput::make('latitude')
+                    ->hidden()
+                    ->dehydrated(),
+                Forms\Components\TextInput::make('longitude')
+                    ->hidden()
+                    ->dehydrated(),
             ]);
     }
 
@@ -42,6 +84,10 @@ public static function table(Table $table): Table
                 Tables\Columns\TextColumn::make('name'),
                 Tables\Columns\TextColumn::make('point_gain'),
                 Tables\Columns\IconColumn::make('claimed')->boolean(),
+                TextColumn::make('claim_count')
+                    ->label('Claims')
+                    ->formatStateUsing(fn ($state, Treasures $record): string => "{$state} / {$record->max_claims}"),
+                TextColumn::make('expires_at'),
                 TextColumn::make('created_at'),
                 Tables\Columns\ImageColumn::make('code.image_url')->label('QR Code'),
             ])
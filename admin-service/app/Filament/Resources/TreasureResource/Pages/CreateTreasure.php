@@ -18,6 +18,13 @@ class CreateTreasure extends CreateRecord
 
     protected function mutateFormDataBeforeCreate(array $data): array
     {
+        if (isset($data['map_picker']['lat'], $data['map_picker']['lng'])) {
+            $data['latitude'] = $data['map_picker']['lat'];
+            $data['longitude'] = $data['map_picker']['lng'];
+        }
+
+        unset($data['map_picker']);
+
         $uuid = (string) Str::random(12);
 
         $payload = [
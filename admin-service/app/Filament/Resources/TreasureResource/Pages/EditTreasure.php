@@ -16,4 +16,16 @@ protected function getHeaderActions(): array
             Actions\DeleteAction::make(),
         ];
     }
+
+    protected function mutateFormDataBeforeSave(array $data): array
+    {
+        if (isset($data['map_picker']['lat'], $data['map_picker']['lng'])) {
+            $data['latitude'] = $data['map_picker']['lat'];
+            $data['longitude'] = $data['map_picker']['lng'];
+        }
+
+        unset($data['map_picker']);
+
+        return $data;
+    }
 }
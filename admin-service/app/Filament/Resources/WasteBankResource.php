@@ -0,0 +1,118 @@
+<?php
+
+namespace App\Filament\Resources;
+
+use App\Filament\Resources\WasteBankResource\Pages;
+use App\Models\WasteBanks;
+use Cheesegrits\FilamentGoogleMaps\Fields\Map;
+use Filament\Forms;
+use Filament\Forms\Form;
+use Filament\Resources\Resource;
+use Filament\Tables;
+use Filament\Tables\Table;
+
+class WasteBankResource extends Resource
+{
+    protected static ?string $model = WasteBanks::class;
+
+    protected static ?string $navigationIcon = 'heroicon-o-building-storefront';
+
+    protected static ?string $navigationLabel = 'Waste Banks';
+
+    public static function materials(): array
+    {
+        return [
+            'plastic' => 'Plastic',
+            'paper' => 'Paper',
+            'cardboard' => 'Cardboard',
+            'metal' => 'Metal',
+            'glass' => 'Glass',
+            'electronic' => 'Electronic',
+            'cooking_oil' => 'Cooking oil',
+            'other' => 'Other',
+        ];
+    }
+
+    public static function form(Form $form): Form
+    {
+        return $form
+            ->schema([
+                Forms\Components\TextInput::make('name')
+                    ->required(),
+                Forms\Components\Textarea::make('address')
+                    ->required(),
+                Forms\Components\Select::make('region_id')
+                    ->relationship('region', 'name')
+                    ->searchable(),
+                Forms\Components\TextInput::make('phone')
+                    ->tel(),
+                Forms\Components\Toggle::make('is_active')
+                    ->default(true),
+                Map::make('map_picker')
+                    ->label('Waste Bank Location (Pick on Map)')
+                    ->defaultLocation([-6.175392, 106.827153])
+                    ->draggable()
+                    ->clickable()
+                    ->afterStateUpdated(function ($state, callable $set) {
+                        if (is_array($state) && count($state) >= 2) {
+                            $set('latitude', $state["lat"]);
+                            $set('longitude', $state["lng"]);
+                        }
+                    }),
+                Forms\Components\TextInput::make('latitude')
+                    ->hidden()
+                    ->dehydrated()
+                    ->required(),
+                Forms\Components\TextInput::make('longitude')
+                    ->hidden()
+                    ->dehydrated()
+                    ->required(),
+                Forms\Components\Repeater::make('prices')
+                    ->relationship()
+                    ->schema([
+                        Forms\Components\Select::make('material')
+                            ->options(static::materials())
+                            ->required(),
+                        Forms\Components\TextInput::make('price_per_kg')
+                            ->numeric()
+                            ->prefix('Rp')
+                            ->required(),
+                    ])
+                    ->columns(2)
+                    ->columnSpanFull(),
+            ]);
+    }
+
+    public static function table(Table $table): Table
+    {
+        return $table
+            ->columns([
+                Tables\Columns\TextColumn::make('name')->searchable(),
+                Tables\Columns\TextColumn::make('region.name'),
+                Tables\Columns\TextColumn::make('prices_count')
+                    ->counts('prices')
+                    ->label('Materials'),
+                Tables\Columns\IconColumn::make('is_active')->boolean(),
+            ])
+            ->filters([
+                Tables\Filters\TernaryFilter::make('is_active'),
+            ])
+            ->actions([
+                Tables\Actions\EditAction::make(),
+            ])
+            ->bulkActions([
+                Tables\Actions\BulkActionGroup::make([
+                    Tables\Actions\DeleteBulkAction::make(),
+                ]),
+            ]);
+    }
+
+    public static function getPages(): array
+    {
+        return [
+            'index' => Pages\ListWasteBanks::route('/'),
+            'create' => Pages\CreateWasteBank::route('/create'),
+            'edit' => Pages\EditWasteBank::route('/{record}/edit'),
+        ];
+    }
+}
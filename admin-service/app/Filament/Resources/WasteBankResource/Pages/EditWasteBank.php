@@ -0,0 +1,31 @@
+<?php
+
+namespace App\Filament\Resources\WasteBankResource\Pages;
+
+use App\Filament\Resources\WasteBankResource;
+use Filament\Actions;
+use Filament\Resources\Pages\EditRecord;
+
+class EditWasteBank extends EditRecord
+{
+    protected static string $resource = WasteBankResource::class;
+
+    protected function getHeaderActions(): array
+    {
+        return [
+            Actions\DeleteAction::make(),
+        ];
+    }
+
+    protected function mutateFormDataBeforeSave(array $data): array
+    {
+        if (isset($data['map_picker']['lat'], $data['map_picker']['lng'])) {
+            $data['latitude'] = $data['map_picker']['lat'];
+            $data['longitude'] = $data['map_picker']['lng'];
+        }
+
+        unset($data['map_picker']);
+
+        return $data;
+    }
+}
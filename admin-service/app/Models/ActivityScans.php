@@ -0,0 +1,28 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class ActivityScans extends Model
+{
+    protected $table = 'activity_scans';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'user_id',
+        'activity_type',
+        'activity_id',
+        'latitude',
+        'longitude',
+        'distance_meters',
+        'status',
+    ];
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
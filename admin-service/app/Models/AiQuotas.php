@@ -0,0 +1,23 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class AiQuotas extends Model
+{
+    protected $table = 'ai_quotas';
+
+    protected $fillable = [
+        'user_id',
+        'feature',
+        'daily_limit',
+        'monthly_limit',
+    ];
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
@@ -0,0 +1,18 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class AiUsages extends Model
+{
+    const UPDATED_AT = null;
+
+    protected $table = 'ai_usages';
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
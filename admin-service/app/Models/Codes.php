@@ -9,7 +9,9 @@ class Codes extends Model
 {
     protected $fillable = [
         "id",
-        "image_url"
+        "image_url",
+        "token_id",
+        "rotation_seconds",
     ];
 
     public $incrementing = false;   // UUID, not auto-increment
@@ -0,0 +1,27 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class DepositItems extends Model
+{
+    protected $table = 'deposit_items';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'deposit_id',
+        'item_id',
+        'material',
+        'weight',
+        'price_per_kg',
+        'subtotal',
+    ];
+
+    public function deposit(): BelongsTo
+    {
+        return $this->belongsTo(Deposits::class, 'deposit_id');
+    }
+}
@@ -0,0 +1,39 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+use Illuminate\Database\Eloquent\Relations\HasMany;
+
+class Deposits extends Model
+{
+    protected $table = 'deposits';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'user_id',
+        'waste_bank_id',
+        'status',
+        'total_weight',
+        'payout',
+        'point_gain',
+        'note',
+    ];
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+
+    public function wasteBank(): BelongsTo
+    {
+        return $this->belongsTo(WasteBanks::class, 'waste_bank_id');
+    }
+
+    public function items(): HasMany
+    {
+        return $this->hasMany(DepositItems::class, 'deposit_id');
+    }
+}
@@ -0,0 +1,25 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class DropoffHours extends Model
+{
+    protected $table = 'dropoff_hours';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'dropoff_point_id',
+        'day_of_week',
+        'opens_at',
+        'closes_at',
+    ];
+
+    public function dropoffPoint(): BelongsTo
+    {
+        return $this->belongsTo(DropoffPoints::class, 'dropoff_point_id');
+    }
+}
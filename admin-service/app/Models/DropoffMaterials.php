@@ -0,0 +1,23 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class DropoffMaterials extends Model
+{
+    protected $table = 'dropoff_materials';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'dropoff_point_id',
+        'material',
+    ];
+
+    public function dropoffPoint(): BelongsTo
+    {
+        return $this->belongsTo(DropoffPoints::class, 'dropoff_point_id');
+    }
+}
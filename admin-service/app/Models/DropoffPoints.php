@@ -0,0 +1,38 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+use Illuminate\Database\Eloquent\Relations\HasMany;
+
+class DropoffPoints extends Model
+{
+    protected $table = 'dropoff_points';
+
+    protected $fillable = [
+        'name',
+        'address',
+        'description',
+        'latitude',
+        'longitude',
+        'region_id',
+        'phone',
+        'is_active',
+    ];
+
+    public function region(): BelongsTo
+    {
+        return $this->belongsTo(Regions::class);
+    }
+
+    public function materials(): HasMany
+    {
+        return $this->hasMany(DropoffMaterials::class, 'dropoff_point_id');
+    }
+
+    public function hours(): HasMany
+    {
+        return $this->hasMany(DropoffHours::class, 'dropoff_point_id');
+    }
+}
@@ -4,6 +4,7 @@
 
 use Illuminate\Database\Eloquent\Model;
 use Illuminate\Database\Eloquent\Relations\BelongsTo;
+use Illuminate\Database\Eloquent\Relations\BelongsToMany;
 
 class Event extends Model
 {
@@ -26,10 +27,19 @@ public function detail(): BelongsTo
     'ends_at',
     'cover_key',
     'clue',
+    'geofence_radius',
+    'max_attendees',
+    'registration_closes_at',
+    'credited_minutes',
     ];
 
     public function code(): BelongsTo
     {
         return $this->belongsTo(Codes::class, "code_id");
     }
-}
\ No newline at end of file
+
+    public function organizers(): BelongsToMany
+    {
+        return $this->belongsToMany(User::class, "event_organizers", "event_id", "user_id");
+    }
+}
@@ -0,0 +1,31 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class EventFeedbacks extends Model
+{
+    protected $table = 'event_feedbacks';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'event_id',
+        'user_id',
+        'rating',
+        'comment',
+        'photo_key',
+    ];
+
+    public function event(): BelongsTo
+    {
+        return $this->belongsTo(Event::class);
+    }
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
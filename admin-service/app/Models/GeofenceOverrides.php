@@ -0,0 +1,26 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class GeofenceOverrides extends Model
+{
+    protected $table = 'geofence_overrides';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'user_id',
+        'activity_type',
+        'activity_id',
+        'reason',
+        'created_by',
+    ];
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
@@ -14,7 +14,10 @@ class Quest extends Model
             'latitude',
             'longitude',
             'max_contributors',
-            'clue'
+            'clue',
+            'geofence_radius',
+            'chain_id',
+            'stage_order',
     ];
 
     public $timestamps = false;
@@ -28,4 +31,9 @@ public function code(): BelongsTo
     {
         return $this->belongsTo(Codes::class, "code_id");
     }
+
+    public function chain(): BelongsTo
+    {
+        return $this->belongsTo(QuestChains::class, "chain_id");
+    }
 }
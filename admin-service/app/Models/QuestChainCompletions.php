@@ -0,0 +1,23 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class QuestChainCompletions extends Model
+{
+    protected $table = 'quest_chain_completions';
+
+    public $timestamps = false;
+
+    protected $fillable = [
+        'chain_id',
+        'user_id',
+    ];
+
+    public function user(): BelongsTo
+    {
+        return $this->belongsTo(User::class);
+    }
+}
@@ -0,0 +1,28 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\HasMany;
+
+class QuestChains extends Model
+{
+    protected $table = 'quest_chains';
+
+    protected $fillable = [
+        'name',
+        'description',
+        'completion_point_gain',
+        'is_active',
+    ];
+
+    public function stages(): HasMany
+    {
+        return $this->hasMany(Quest::class, 'chain_id')->orderBy('stage_order');
+    }
+
+    public function completions(): HasMany
+    {
+        return $this->hasMany(QuestChainCompletions::class, 'chain_id');
+    }
+}
@@ -17,6 +17,13 @@ public function detail(): BelongsTo
         'point_gain',
         'code_id',
         'claimed',
+        'latitude',
+        'longitude',
+        'geofence_radius',
+        'max_claims',
+        'per_user_limit',
+        'starts_at',
+        'expires_at',
     ];
 
     public function code(): BelongsTo
@@ -0,0 +1,22 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+
+class WasteBankPrices extends Model
+{
+    protected $table = 'waste_bank_prices';
+
+    protected $fillable = [
+        'waste_bank_id',
+        'material',
+        'price_per_kg',
+    ];
+
+    public function wasteBank(): BelongsTo
+    {
+        return $this->belongsTo(WasteBanks::class, 'waste_bank_id');
+    }
+}
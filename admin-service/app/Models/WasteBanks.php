@@ -0,0 +1,32 @@
+<?php
+
+namespace App\Models;
+
+use Illuminate\Database\Eloquent\Model;
+use Illuminate\Database\Eloquent\Relations\BelongsTo;
+use Illuminate\Database\Eloquent\Relations\HasMany;
+
+class WasteBanks extends Model
+{
+    protected $table = 'waste_banks';
+
+    protected $fillable = [
+        'name',
+        'address',
+        'latitude',
+        'longitude',
+        'region_id',
+        'phone',
+        'is_active',
+    ];
+
+    public function region(): BelongsTo
+    {
+        return $this->belongsTo(Regions::class);
+    }
+
+    public function prices(): HasMany
+    {
+        return $this->hasMany(WasteBankPrices::class, 'waste_bank_id');
+    }
+}
@@ -0,0 +1,40 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('ai_usages', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->string("feature");
+            $table->string("provider");
+            $table->string("model");
+            $table->integer("prompt_tokens")->default(0);
+            $table->integer("completion_tokens")->default(0);
+            $table->integer("total_tokens")->default(0);
+            $table->integer("latency_ms")->default(0);
+            $table->double("cost")->default(0);
+            $table->enum("status", ["success", "failed"]);
+            $table->text("error")->nullable();
+            $table->timestamp('created_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->index(["user_id", "feature", "created_at"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('ai_usages');
+    }
+};
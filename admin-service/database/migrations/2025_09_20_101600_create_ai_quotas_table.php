@@ -0,0 +1,32 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('ai_quotas', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->string("feature");
+            $table->integer("daily_limit")->nullable();
+            $table->integer("monthly_limit")->nullable();
+            $table->timestamps();
+            $table->unique(["user_id", "feature"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('ai_quotas');
+    }
+};
@@ -0,0 +1,42 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('scan_images', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("scan_id")->references("id")->on("scans");
+            $table->string("image_key");
+            $table->integer("position")->default(0);
+            $table->timestamp('created_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+        });
+
+        Schema::create('item_images', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("item_id")->references("id")->on("items");
+            $table->foreignId("scan_image_id")->references("id")->on("scan_images");
+        });
+
+        // every existing scan has exactly one image, which all of its items came from
+        DB::statement("INSERT INTO scan_images(scan_id, image_key, position, created_at) SELECT id, image_key, 0, created_at FROM scans");
+        DB::statement("INSERT INTO item_images(item_id, scan_image_id) SELECT items.id, scan_images.id FROM items JOIN scan_images ON scan_images.scan_id = items.scan_id");
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('item_images');
+        Schema::dropIfExists('scan_images');
+    }
+};
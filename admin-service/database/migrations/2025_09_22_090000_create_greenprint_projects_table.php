@@ -0,0 +1,43 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('greenprint_projects', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->foreignId("greenprint_id")->references("id")->on("greenprints");
+            $table->enum("status", ["in_progress", "completed"])->default("in_progress");
+            $table->string("proof_key")->nullable();
+            $table->timestamp('started_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->timestamp('completed_at')->nullable();
+            $table->unique(["user_id", "greenprint_id"]);
+        });
+
+        Schema::create('project_steps', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("project_id")->references("id")->on("greenprint_projects");
+            $table->foreignId("step_id")->references("id")->on("steps");
+            $table->timestamp('completed_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->unique(["project_id", "step_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('project_steps');
+        Schema::dropIfExists('greenprint_projects');
+    }
+};
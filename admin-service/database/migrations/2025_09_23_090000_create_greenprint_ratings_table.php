@@ -0,0 +1,52 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('greenprints', function (Blueprint $table) {
+            $table->boolean("is_public")->default(true);
+            $table->foreignId("source_id")->nullable()->references("id")->on("greenprints");
+            $table->text("search_text")->default("");
+        });
+
+        DB::statement("CREATE INDEX greenprints_search_text_index ON greenprints USING gin (to_tsvector('simple', search_text))");
+
+        // item name, title and material names are what the catalog search looks at
+        DB::statement("UPDATE greenprints SET search_text = concat_ws(' ', items.name, greenprints.title, (SELECT string_agg(materials.name, ' ') FROM materials WHERE materials.greenprint_id = greenprints.id)) FROM items WHERE items.id = greenprints.item_id");
+
+        Schema::create('greenprint_ratings', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("greenprint_id")->references("id")->on("greenprints");
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->integer("rating");
+            $table->timestamps();
+            $table->unique(["greenprint_id", "user_id"]);
+        });
+
+        DB::statement("ALTER TABLE greenprint_ratings ADD CONSTRAINT greenprint_ratings_rating_check CHECK (rating >= 1 AND rating <= 5)");
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('greenprint_ratings');
+
+        DB::statement("DROP INDEX IF EXISTS greenprints_search_text_index");
+
+        Schema::table('greenprints', function (Blueprint $table) {
+            $table->dropForeign(["source_id"]);
+            $table->dropColumn(["is_public", "source_id", "search_text"]);
+        });
+    }
+};
@@ -0,0 +1,29 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        // greenprints stay private until their owner publishes them
+        Schema::table('greenprints', function (Blueprint $table) {
+            $table->boolean("is_public")->default(false)->change();
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('greenprints', function (Blueprint $table) {
+            $table->boolean("is_public")->default(true)->change();
+        });
+    }
+};
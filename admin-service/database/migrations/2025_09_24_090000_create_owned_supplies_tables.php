@@ -0,0 +1,59 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('materials', function (Blueprint $table) {
+            $table->foreignId("step_id")->nullable()->references("id")->on("steps");
+        });
+
+        Schema::table('tools', function (Blueprint $table) {
+            $table->foreignId("step_id")->nullable()->references("id")->on("steps");
+            $table->boolean("is_optional")->default(false);
+        });
+
+        Schema::create('owned_materials', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->foreignId("material_id")->references("id")->on("materials");
+            $table->timestamp('created_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->unique(["user_id", "material_id"]);
+        });
+
+        Schema::create('owned_tools', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->foreignId("tool_id")->references("id")->on("tools");
+            $table->timestamp('created_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->unique(["user_id", "tool_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('owned_tools');
+        Schema::dropIfExists('owned_materials');
+
+        Schema::table('tools', function (Blueprint $table) {
+            $table->dropForeign(["step_id"]);
+            $table->dropColumn(["step_id", "is_optional"]);
+        });
+
+        Schema::table('materials', function (Blueprint $table) {
+            $table->dropForeign(["step_id"]);
+            $table->dropColumn("step_id");
+        });
+    }
+};
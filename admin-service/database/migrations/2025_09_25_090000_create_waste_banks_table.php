@@ -0,0 +1,72 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('waste_banks', function (Blueprint $table) {
+            $table->id();
+            $table->string("name");
+            $table->text("address");
+            $table->double("latitude");
+            $table->double("longitude");
+            $table->foreignId("region_id")->nullable()->references("id")->on("regions");
+            $table->string("phone")->nullable();
+            $table->boolean("is_active")->default(true);
+            $table->timestamps();
+        });
+
+        Schema::create('waste_bank_prices', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("waste_bank_id")->references("id")->on("waste_banks")->cascadeOnDelete();
+            $table->string("material");
+            $table->integer("price_per_kg");
+            $table->timestamps();
+            $table->unique(["waste_bank_id", "material"]);
+        });
+
+        Schema::create('deposits', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->foreignId("waste_bank_id")->references("id")->on("waste_banks");
+            $table->enum("status", ["pending", "confirmed", "rejected"])->default("pending");
+            $table->double("total_weight")->default(0);
+            $table->integer("payout")->default(0);
+            $table->integer("point_gain")->default(0);
+            $table->text("note")->nullable();
+            $table->foreignId("confirmed_by")->nullable()->references("id")->on("users");
+            $table->timestamp('confirmed_at')->nullable();
+            $table->timestamp('created_at')->default(DB::raw('CURRENT_TIMESTAMP'));
+            $table->index(["user_id", "status"]);
+        });
+
+        Schema::create('deposit_items', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("deposit_id")->references("id")->on("deposits");
+            $table->foreignId("item_id")->nullable()->references("id")->on("items");
+            $table->string("material");
+            $table->double("weight");
+            $table->integer("price_per_kg");
+            $table->integer("subtotal");
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('deposit_items');
+        Schema::dropIfExists('deposits');
+        Schema::dropIfExists('waste_bank_prices');
+        Schema::dropIfExists('waste_banks');
+    }
+};
@@ -0,0 +1,60 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('items', function (Blueprint $table) {
+            $table->string("material")->nullable();
+        });
+
+        Schema::create('dropoff_points', function (Blueprint $table) {
+            $table->id();
+            $table->string("name");
+            $table->text("address");
+            $table->text("description")->nullable();
+            $table->double("latitude");
+            $table->double("longitude");
+            $table->foreignId("region_id")->nullable()->references("id")->on("regions");
+            $table->string("phone")->nullable();
+            $table->boolean("is_active")->default(true);
+            $table->timestamps();
+        });
+
+        Schema::create('dropoff_materials', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("dropoff_point_id")->references("id")->on("dropoff_points")->cascadeOnDelete();
+            $table->string("material")->index();
+            $table->unique(["dropoff_point_id", "material"]);
+        });
+
+        Schema::create('dropoff_hours', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("dropoff_point_id")->references("id")->on("dropoff_points")->cascadeOnDelete();
+            $table->smallInteger("day_of_week");
+            $table->time("opens_at");
+            $table->time("closes_at");
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('dropoff_hours');
+        Schema::dropIfExists('dropoff_materials');
+        Schema::dropIfExists('dropoff_points');
+
+        Schema::table('items', function (Blueprint $table) {
+            $table->dropColumn("material");
+        });
+    }
+};
@@ -0,0 +1,71 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Foreign keys that should follow a deleted scan, as [table, column, referenced table].
+     */
+    private array $cascade = [
+        ['items', 'scan_id', 'scans'],
+        ['scan_images', 'scan_id', 'scans'],
+        ['item_images', 'item_id', 'items'],
+        ['item_images', 'scan_image_id', 'scan_images'],
+        ['greenprints', 'item_id', 'items'],
+        ['steps', 'greenprint_id', 'greenprints'],
+        ['materials', 'greenprint_id', 'greenprints'],
+        ['tools', 'greenprint_id', 'greenprints'],
+        ['materials', 'step_id', 'steps'],
+        ['tools', 'step_id', 'steps'],
+        ['owned_materials', 'material_id', 'materials'],
+        ['owned_tools', 'tool_id', 'tools'],
+        ['greenprint_projects', 'greenprint_id', 'greenprints'],
+        ['project_steps', 'project_id', 'greenprint_projects'],
+        ['project_steps', 'step_id', 'steps'],
+        ['greenprint_ratings', 'greenprint_id', 'greenprints'],
+    ];
+
+    /**
+     * Foreign keys that only lose the reference, so copies and deposits stay.
+     */
+    private array $nullify = [
+        ['greenprints', 'source_id', 'greenprints'],
+        ['deposit_items', 'item_id', 'items'],
+    ];
+
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        foreach ($this->cascade as [$name, $column, $on]) {
+            Schema::table($name, function (Blueprint $table) use ($column, $on) {
+                $table->dropForeign([$column]);
+                $table->foreign($column)->references("id")->on($on)->cascadeOnDelete();
+            });
+        }
+
+        foreach ($this->nullify as [$name, $column, $on]) {
+            Schema::table($name, function (Blueprint $table) use ($column, $on) {
+                $table->dropForeign([$column]);
+                $table->foreign($column)->references("id")->on($on)->nullOnDelete();
+            });
+        }
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        foreach (array_merge($this->cascade, $this->nullify) as [$name, $column, $on]) {
+            Schema::table($name, function (Blueprint $table) use ($column, $on) {
+                $table->dropForeign([$column]);
+                $table->foreign($column)->references("id")->on($on);
+            });
+        }
+    }
+};
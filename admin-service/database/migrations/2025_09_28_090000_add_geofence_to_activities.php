@@ -0,0 +1,73 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('quests', function (Blueprint $table) {
+            $table->integer("geofence_radius")->nullable();
+        });
+
+        Schema::table('events', function (Blueprint $table) {
+            $table->integer("geofence_radius")->nullable();
+        });
+
+        Schema::table('treasures', function (Blueprint $table) {
+            $table->double("latitude")->nullable();
+            $table->double("longitude")->nullable();
+            $table->integer("geofence_radius")->nullable();
+        });
+
+        Schema::create('activity_scans', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->enum("activity_type", ["quest", "event", "treasure"]);
+            $table->bigInteger("activity_id");
+            $table->double("latitude")->nullable();
+            $table->double("longitude")->nullable();
+            $table->double("distance_meters")->nullable();
+            $table->enum("status", ["accepted", "flagged", "rejected", "overridden"])->index();
+            $table->timestamp("created_at")->useCurrent();
+            $table->index(["activity_type", "activity_id"]);
+        });
+
+        Schema::create('geofence_overrides', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->enum("activity_type", ["quest", "event", "treasure"]);
+            $table->bigInteger("activity_id");
+            $table->text("reason")->nullable();
+            $table->foreignId("created_by")->nullable()->references("id")->on("users");
+            $table->timestamp("created_at")->useCurrent();
+            $table->unique(["user_id", "activity_type", "activity_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('geofence_overrides');
+        Schema::dropIfExists('activity_scans');
+
+        Schema::table('treasures', function (Blueprint $table) {
+            $table->dropColumn(["latitude", "longitude", "geofence_radius"]);
+        });
+
+        Schema::table('events', function (Blueprint $table) {
+            $table->dropColumn("geofence_radius");
+        });
+
+        Schema::table('quests', function (Blueprint $table) {
+            $table->dropColumn("geofence_radius");
+        });
+    }
+};
@@ -0,0 +1,41 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('codes', function (Blueprint $table) {
+            $table->string("token_id")->nullable();
+            $table->integer("rotation_seconds")->nullable();
+        });
+
+        Schema::create('activity_token_uses', function (Blueprint $table) {
+            $table->id();
+            $table->string("jti");
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->string("code_id");
+            $table->foreign("code_id")->references("id")->on("codes")->cascadeOnDelete();
+            $table->timestamp("used_at")->useCurrent();
+            $table->unique(["jti", "user_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('activity_token_uses');
+
+        Schema::table('codes', function (Blueprint $table) {
+            $table->dropColumn(["token_id", "rotation_seconds"]);
+        });
+    }
+};
@@ -0,0 +1,53 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('quest_chains', function (Blueprint $table) {
+            $table->id();
+            $table->string("name");
+            $table->text("description");
+            $table->bigInteger("completion_point_gain")->default(0);
+            $table->boolean("is_active")->default(true);
+            $table->timestamps();
+        });
+
+        Schema::table('quests', function (Blueprint $table) {
+            $table->foreignId("chain_id")->nullable()->references("id")->on("quest_chains")->nullOnDelete();
+            $table->integer("stage_order")->nullable();
+            $table->unique(["chain_id", "stage_order"]);
+        });
+
+        Schema::create('quest_chain_completions', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("chain_id")->references("id")->on("quest_chains")->cascadeOnDelete();
+            $table->foreignId("user_id")->references("id")->on("users");
+            $table->timestamp("completed_at")->useCurrent();
+            $table->unique(["chain_id", "user_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('quest_chain_completions');
+
+        Schema::table('quests', function (Blueprint $table) {
+            $table->dropUnique(["chain_id", "stage_order"]);
+            $table->dropForeign(["chain_id"]);
+            $table->dropColumn(["chain_id", "stage_order"]);
+        });
+
+        Schema::dropIfExists('quest_chains');
+    }
+};
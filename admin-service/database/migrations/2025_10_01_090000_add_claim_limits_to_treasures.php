@@ -0,0 +1,46 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('treasures', function (Blueprint $table) {
+            $table->integer("max_claims")->default(1);
+            $table->integer("per_user_limit")->default(1);
+            $table->integer("claim_count")->default(0);
+            $table->timestamp("starts_at")->nullable();
+            $table->timestamp("expires_at")->nullable();
+        });
+
+        // treasures claimed before this migration count as fully claimed
+        DB::table('treasures')->where('claimed', true)->update(['claim_count' => 1]);
+
+        Schema::table('claimed', function (Blueprint $table) {
+            $table->integer("claim_number")->default(1);
+            $table->unique(["treasure_id", "user_id", "claim_number"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('claimed', function (Blueprint $table) {
+            $table->dropUnique(["treasure_id", "user_id", "claim_number"]);
+            $table->dropColumn("claim_number");
+        });
+
+        Schema::table('treasures', function (Blueprint $table) {
+            $table->dropColumn(["max_claims", "per_user_limit", "claim_count", "starts_at", "expires_at"]);
+        });
+    }
+};
@@ -0,0 +1,35 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('activity_token_uses', function (Blueprint $table) {
+            $table->integer("use_number")->default(1);
+            $table->dropUnique(["jti", "user_id"]);
+            $table->unique(["jti", "user_id", "use_number"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        DB::table('activity_token_uses')->where('use_number', '>', 1)->delete();
+
+        Schema::table('activity_token_uses', function (Blueprint $table) {
+            $table->dropUnique(["jti", "user_id", "use_number"]);
+            $table->unique(["jti", "user_id"]);
+            $table->dropColumn("use_number");
+        });
+    }
+};
@@ -0,0 +1,56 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('events', function (Blueprint $table) {
+            $table->integer("max_attendees")->nullable();
+            $table->integer("attendee_count")->default(0);
+            $table->timestamp("registration_closes_at")->nullable();
+        });
+
+        DB::statement("UPDATE events SET attendee_count = (SELECT COUNT(*) FROM attendances WHERE attendances.event_id = events.id)");
+
+        Schema::create('event_waitlists', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("event_id")->references("id")->on("events")->cascadeOnDelete();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->string("contact_number");
+            $table->timestamp("created_at")->useCurrent();
+            $table->unique(["event_id", "user_id"]);
+        });
+
+        Schema::create('notifications', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->string("type");
+            $table->string("title");
+            $table->text("body");
+            $table->timestamp("read_at")->nullable();
+            $table->timestamp("created_at")->useCurrent();
+            $table->index(["user_id", "created_at"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('notifications');
+        Schema::dropIfExists('event_waitlists');
+
+        Schema::table('events', function (Blueprint $table) {
+            $table->dropColumn(["max_attendees", "attendee_count", "registration_closes_at"]);
+        });
+    }
+};
@@ -0,0 +1,33 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        // a user holds at most one seat per event, keep their attended or else earliest registration
+        DB::statement("DELETE FROM attendances a USING attendances b WHERE a.event_id = b.event_id AND a.user_id = b.user_id AND (b.attended, -b.id) > (a.attended, -a.id)");
+        DB::statement("UPDATE events SET attendee_count = (SELECT COUNT(*) FROM attendances WHERE attendances.event_id = events.id)");
+
+        Schema::table('attendances', function (Blueprint $table) {
+            $table->unique(["event_id", "user_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('attendances', function (Blueprint $table) {
+            $table->dropUnique(["event_id", "user_id"]);
+        });
+    }
+};
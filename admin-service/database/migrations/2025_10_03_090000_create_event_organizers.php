@@ -0,0 +1,29 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('event_organizers', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("event_id")->references("id")->on("events")->cascadeOnDelete();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->unique(["event_id", "user_id"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('event_organizers');
+    }
+};
@@ -0,0 +1,29 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('calendar_feeds', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->unique()->references("id")->on("users")->cascadeOnDelete();
+            $table->string("token", 64)->unique();
+            $table->timestamp("created_at")->useCurrent();
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('calendar_feeds');
+    }
+};
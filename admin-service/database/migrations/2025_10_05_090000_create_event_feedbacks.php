@@ -0,0 +1,36 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('event_feedbacks', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("event_id")->references("id")->on("events")->cascadeOnDelete();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->smallInteger("rating");
+            $table->text("comment")->default("");
+            $table->string("photo_key")->nullable();
+            $table->timestamp("created_at")->useCurrent();
+            $table->unique(["event_id", "user_id"]);
+        });
+
+        DB::statement("ALTER TABLE event_feedbacks ADD CONSTRAINT event_feedbacks_rating_check CHECK (rating >= 1 AND rating <= 5)");
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('event_feedbacks');
+    }
+};
@@ -0,0 +1,61 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\DB;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('events', function (Blueprint $table) {
+            $table->integer("credited_minutes")->nullable();
+        });
+
+        Schema::table('statistics', function (Blueprint $table) {
+            $table->integer("volunteer_minutes")->default(0);
+        });
+
+        DB::statement("
+            UPDATE statistics SET volunteer_minutes = COALESCE((
+                SELECT SUM((EXTRACT(EPOCH FROM (e.ends_at - e.starts_at)) / 60)::int)
+                FROM attendances a
+                JOIN events e ON a.event_id = e.id
+                WHERE a.user_id = statistics.user_id AND a.attended = true
+            ), 0)
+        ");
+
+        Schema::create('certificates', function (Blueprint $table) {
+            $table->id();
+            $table->string("code", 20)->unique();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->foreignId("event_id")->nullable()->references("id")->on("events")->nullOnDelete();
+            $table->date("period_from")->nullable();
+            $table->date("period_to")->nullable();
+            $table->integer("event_count");
+            $table->integer("total_minutes");
+            $table->string("image_key");
+            $table->timestamp("issued_at")->useCurrent();
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('certificates');
+
+        Schema::table('statistics', function (Blueprint $table) {
+            $table->dropColumn("volunteer_minutes");
+        });
+
+        Schema::table('events', function (Blueprint $table) {
+            $table->dropColumn("credited_minutes");
+        });
+    }
+};
@@ -0,0 +1,31 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::table('certificates', function (Blueprint $table) {
+            // the signed code covers the event, an event with certificates can't be deleted
+            $table->dropForeign(["event_id"]);
+            $table->foreign("event_id")->references("id")->on("events")->restrictOnDelete();
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('certificates', function (Blueprint $table) {
+            $table->dropForeign(["event_id"]);
+            $table->foreign("event_id")->references("id")->on("events")->nullOnDelete();
+        });
+    }
+};
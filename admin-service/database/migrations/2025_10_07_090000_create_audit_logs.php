@@ -0,0 +1,35 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('audit_logs', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("user_id")->nullable()->references("id")->on("users")->nullOnDelete();
+            $table->string("action");
+            $table->string("subject_type");
+            $table->bigInteger("subject_id");
+            $table->text("detail")->default("");
+            $table->string("ip_address", 45);
+            $table->timestamp("created_at")->useCurrent();
+            $table->index(["subject_type", "subject_id"]);
+            $table->index(["user_id", "created_at"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::dropIfExists('audit_logs');
+    }
+};
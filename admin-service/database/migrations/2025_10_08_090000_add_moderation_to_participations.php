@@ -0,0 +1,37 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        // participations made before moderation already earned their points
+        Schema::table('participations', function (Blueprint $table) {
+            $table->enum("status", ["pending_upload", "pending_review", "approved", "rejected"])->default("approved");
+            $table->integer("point_gain")->default(0);
+            $table->text("note")->nullable();
+            $table->foreignId("reviewed_by")->nullable()->references("id")->on("users")->nullOnDelete();
+            $table->timestamp("reviewed_at")->nullable();
+            $table->timestamp("uploaded_at")->nullable();
+            $table->index(["status", "created_at"]);
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('participations', function (Blueprint $table) {
+            $table->dropIndex(["status", "created_at"]);
+            $table->dropConstrainedForeignId("reviewed_by");
+            $table->dropColumn(["status", "point_gain", "note", "reviewed_at", "uploaded_at"]);
+        });
+    }
+};
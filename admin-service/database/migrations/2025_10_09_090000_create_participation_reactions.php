@@ -0,0 +1,47 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    /**
+     * Run the migrations.
+     */
+    public function up(): void
+    {
+        Schema::create('participation_reactions', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId("participation_id")->references("id")->on("participations")->cascadeOnDelete();
+            $table->foreignId("user_id")->references("id")->on("users")->cascadeOnDelete();
+            $table->enum("reaction", ["like", "love", "inspiring", "clap"]);
+            $table->timestamp("created_at")->useCurrent();
+            $table->unique(["participation_id", "user_id"]);
+        });
+
+        Schema::table('participations', function (Blueprint $table) {
+            $table->integer("bonus_points")->default(0);
+        });
+
+        Schema::table('challenges', function (Blueprint $table) {
+            $table->timestamp("bonus_settled_at")->nullable();
+        });
+    }
+
+    /**
+     * Reverse the migrations.
+     */
+    public function down(): void
+    {
+        Schema::table('challenges', function (Blueprint $table) {
+            $table->dropColumn("bonus_settled_at");
+        });
+
+        Schema::table('participations', function (Blueprint $table) {
+            $table->dropColumn("bonus_points");
+        });
+
+        Schema::dropIfExists('participation_reactions');
+    }
+};
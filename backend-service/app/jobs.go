@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/helpers"
+	"log/slog"
+	"time"
+)
+
+// StartJobs runs the background jobs every JOB_INTERVAL_SECONDS until ctx is
+// done. A failed run is logged and retried on the next tick.
+func (r *AppRouter) StartJobs(ctx context.Context) {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("JOB_INTERVAL_SECONDS", 60)
+	interval := time.Duration(cnf.GetInt("JOB_INTERVAL_SECONDS")) * time.Second
+
+	go runEvery(ctx, interval, "fill event waitlists", r.EventHandler.FillOpenWaitlists)
+	go runEvery(ctx, interval, "settle challenge bonuses", r.ChallengeHandler.ChallengeService.SettleClosedChallenges)
+}
+
+func runEvery(ctx context.Context, interval time.Duration, name string, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				slog.Error("Failed to run job", "job", name, "err", err)
+			}
+		}
+	}
+}
@@ -31,6 +31,16 @@ type AppRouter struct {
 	*handlers.HistoryHandler
 	*handlers.PointHandler
 	*handlers.RegionHandler
+	*handlers.UsageHandler
+	*handlers.ProjectHandler
+	*handlers.GreenprintHandler
+	*handlers.DepositHandler
+	*handlers.DropoffHandler
+	*handlers.GeofenceHandler
+	*handlers.ActivityTokenHandler
+	*handlers.NotificationHandler
+	*handlers.CalendarHandler
+	*handlers.CertificateHandler
 }
 
 func NewAppRouter(
@@ -52,30 +62,54 @@ func NewAppRouter(
 	memoryService := services.NewMemoryService(r)
 	pointService := services.NewPointService(r, leaderboardService)
 	fileService := services.NewFileService(awsClient)
+	usageService := services.NewUsageService(r)
+	visionService := services.NewVisionService(awsClient, aiClient, usageService)
+	mediaService := services.NewMediaService(awsClient)
+	greenprintService := services.NewGreenprintService(r)
+	dropoffService := services.NewDropoffService(r)
+	geofenceService := services.NewGeofenceService(r)
+	activityTokenService := services.NewActivityTokenService(r, mediaService)
+	treasureHintService := services.NewTreasureHintService(rd, r)
+	notificationService := services.NewNotificationService(r)
+	waitlistService := services.NewWaitlistService(r)
+	calendarService := services.NewCalendarService(r)
+	certificateService := services.NewCertificateService(rd, r, mediaService)
+	auditService := services.NewAuditService(r)
+	challengeService := services.NewChallengeService(r, pointService, journalService, notificationService)
 
-	treasureHandler := handlers.NewTreasureHandler(v, r, pointService, journalService, streakService)
+	treasureHandler := handlers.NewTreasureHandler(v, r, pointService, journalService, streakService, treasureHintService)
 	questHandler := handlers.NewQuestHandler(v, r, pointService, journalService, streakService)
-	eventHandler := handlers.NewEventHandler(v, r, pointService, journalService, streakService)
+	eventHandler := handlers.NewEventHandler(v, r, pointService, journalService, streakService, notificationService, waitlistService, calendarService, mediaService, auditService)
 
 	return &AppRouter{
-		AuthHandler:        handlers.NewAuthHandler(v, r, leaderboardService),
-		JournalHandler:     handlers.NewJournalHandler(v, r, journalService, streakService),
-		LeaderboardHandler: handlers.NewLeaderboardHandler(leaderboardService),
-		StreakHandler:      handlers.NewStreakHandler(rd, streakService),
-		PacketHandler:      handlers.NewPacketHandler(v, r, aiClient, journalService, packetService, streakService),
-		TaskHandler:        handlers.NewTaskHandler(r, streakService, habitService, journalService, expService),
-		UserHandler:        handlers.NewUserHandler(v, r, userService, leaderboardService, fileService, awsClient),
-		MemoryHandler:      handlers.NewMemoryHandler(v, r, memoryService, fileService, streakService, awsClient),
-		RecapHandler:       handlers.NewRecapHandler(r, aiClient, journalService, streakService),
-		ChallengeHandler:   handlers.NewChallengeHandler(v, r, memoryService, pointService, journalService, fileService, streakService),
-		TreasureHandler:    treasureHandler,
-		QuestHandler:       questHandler,
-		EventHandler:       eventHandler,
-		ScanHandler:        handlers.NewScanHandler(v, r, treasureHandler, questHandler, eventHandler, awsClient, aiClient),
-		ActivityHandler:    handlers.NewActivityHandler(v, r),
-		HistoryHandler:     handlers.NewHistoryHandler(r),
-		PointHandler:       handlers.NewPointHandler(v, r, pointService, journalService),
-		RegionHandler:      handlers.NewRegionHandler(v, r),
+		AuthHandler:          handlers.NewAuthHandler(v, r, leaderboardService),
+		JournalHandler:       handlers.NewJournalHandler(v, r, journalService, streakService),
+		LeaderboardHandler:   handlers.NewLeaderboardHandler(leaderboardService),
+		StreakHandler:        handlers.NewStreakHandler(rd, streakService),
+		PacketHandler:        handlers.NewPacketHandler(v, r, aiClient, journalService, packetService, streakService, usageService),
+		TaskHandler:          handlers.NewTaskHandler(r, streakService, habitService, journalService, expService),
+		UserHandler:          handlers.NewUserHandler(v, r, userService, leaderboardService, fileService, mediaService),
+		MemoryHandler:        handlers.NewMemoryHandler(v, r, memoryService, fileService, mediaService, streakService, awsClient),
+		RecapHandler:         handlers.NewRecapHandler(r, aiClient, journalService, streakService, usageService),
+		ChallengeHandler:     handlers.NewChallengeHandler(v, r, memoryService, pointService, journalService, fileService, streakService, notificationService, challengeService),
+		TreasureHandler:      treasureHandler,
+		QuestHandler:         questHandler,
+		EventHandler:         eventHandler,
+		ScanHandler:          handlers.NewScanHandler(v, r, treasureHandler, questHandler, eventHandler, awsClient, aiClient, usageService, visionService, mediaService, greenprintService, dropoffService, geofenceService, activityTokenService),
+		ActivityHandler:      handlers.NewActivityHandler(v, r),
+		HistoryHandler:       handlers.NewHistoryHandler(r),
+		PointHandler:         handlers.NewPointHandler(v, r, pointService, journalService),
+		RegionHandler:        handlers.NewRegionHandler(v, r),
+		UsageHandler:         handlers.NewUsageHandler(usageService),
+		ProjectHandler:       handlers.NewProjectHandler(v, r, pointService, expService, journalService, streakService, mediaService),
+		GreenprintHandler:    handlers.NewGreenprintHandler(v, r, greenprintService),
+		DepositHandler:       handlers.NewDepositHandler(v, r, pointService, journalService, streakService),
+		DropoffHandler:       handlers.NewDropoffHandler(v, r, dropoffService),
+		GeofenceHandler:      handlers.NewGeofenceHandler(v, r),
+		ActivityTokenHandler: handlers.NewActivityTokenHandler(v, r, activityTokenService),
+		NotificationHandler:  handlers.NewNotificationHandler(r),
+		CalendarHandler:      handlers.NewCalendarHandler(calendarService),
+		CertificateHandler:   handlers.NewCertificateHandler(v, certificateService),
 	}
 }
 
@@ -98,4 +132,14 @@ func (r *AppRouter) RegisterRoute(router fiber.Router) {
 	r.HistoryHandler.RegisterRoutes(router)
 	r.PointHandler.RegisterRoutes(router)
 	r.RegionHandler.RegisterRoutes(router)
+	r.UsageHandler.RegisterRoutes(router)
+	r.ProjectHandler.RegisterRoutes(router)
+	r.GreenprintHandler.RegisterRoutes(router)
+	r.DepositHandler.RegisterRoutes(router)
+	r.DropoffHandler.RegisterRoutes(router)
+	r.GeofenceHandler.RegisterRoutes(router)
+	r.ActivityTokenHandler.RegisterRoutes(router)
+	r.NotificationHandler.RegisterRoutes(router)
+	r.CalendarHandler.RegisterRoutes(router)
+	r.CertificateHandler.RegisterRoutes(router)
 }
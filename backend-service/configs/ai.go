@@ -15,8 +15,18 @@ const (
 	RecapMonthly int8 = 2
 	RecapWeekly  int8 = 3
 	GreenPrint   int8 = 4
+	Vision       int8 = 5
 )
 
+// trashScannerInput is appended to the trash scanner prompt so the
+// description of the request stays in sync with what the scan handler sends.
+const trashScannerInput = `
+
+The input is a JSON array of labels detected on one or more photos of the same pile of trash. ` +
+	`Every label has a name, a confidence, its categories and "images", the zero based indexes of the photos it was seen on. ` +
+	`Labels seen on several photos describe the same object, do not return it twice. ` +
+	`For every item set "sources" to the indexes of the photos the item is visible on, taken from the "images" of the labels it is based on.`
+
 type AIClient struct {
 	Genai *genai.Client
 }
@@ -43,7 +53,7 @@ func InitModel(client *genai.Client, cnf *viper.Viper, modelType int8) (*genai.G
 	var systemInstruction = ""
 	switch modelType {
 	case TrashScanner:
-		systemInstruction = cnf.GetString("TRASH_SCANNER_SYSTEM_INSTRUCTION")
+		systemInstruction = cnf.GetString("TRASH_SCANNER_SYSTEM_INSTRUCTION") + trashScannerInput
 		trashScannerConfig(generativeModel)
 	case RecapMonthly:
 		systemInstruction = cnf.GetString("MONTHLY_RECAP_SYSTEM_INSTRUCTION")
@@ -57,6 +67,9 @@ func InitModel(client *genai.Client, cnf *viper.Viper, modelType int8) (*genai.G
 	case GreenPrint:
 		systemInstruction = cnf.GetString("GREENPRINT_SYSTEM_INSTRUCTION")
 		greenprintConfig(generativeModel)
+	case Vision:
+		systemInstruction = cnf.GetString("VISION_SYSTEM_INSTRUCTION")
+		visionConfig(generativeModel)
 	default:
 		return nil, fiber.NewError(fiber.StatusInternalServerError, "Internal server error")
 	}
@@ -99,8 +112,19 @@ func trashScannerConfig(generativeModel *genai.GenerativeModel) {
 							Type: genai.TypeString,
 							Enum: []string{"high", "mid", "low"},
 						},
+						"material": {
+							Type: genai.TypeString,
+							Enum: MaterialCategories,
+						},
+						"sources": {
+							Type:        genai.TypeArray,
+							Description: "Zero based indexes of the photos the item is visible on",
+							Items: &genai.Schema{
+								Type: genai.TypeInteger,
+							},
+						},
 					},
-					Required: []string{"name", "description", "value"},
+					Required: []string{"name", "description", "value", "material"},
 				},
 			},
 		},
@@ -108,6 +132,39 @@ func trashScannerConfig(generativeModel *genai.GenerativeModel) {
 	}
 }
 
+func visionConfig(generativeModel *genai.GenerativeModel) {
+	generativeModel.SetTemperature(0.2)
+	generativeModel.SetMaxOutputTokens(2048)
+	generativeModel.ResponseMIMEType = "application/json"
+	generativeModel.ResponseSchema = &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"labels": {
+				Type: genai.TypeArray,
+				Items: &genai.Schema{
+					Type: genai.TypeObject,
+					Properties: map[string]*genai.Schema{
+						"name": {
+							Type: genai.TypeString,
+						},
+						"confidence": {
+							Type: genai.TypeNumber,
+						},
+						"categories": {
+							Type: genai.TypeArray,
+							Items: &genai.Schema{
+								Type: genai.TypeString,
+							},
+						},
+					},
+					Required: []string{"name", "confidence"},
+				},
+			},
+		},
+		Required: []string{"labels"},
+	}
+}
+
 func ecoachConfig(generativeModel *genai.GenerativeModel) {
 	generativeModel.SetTemperature(1.6)
 	generativeModel.SetTopK(40)
@@ -213,6 +270,10 @@ func greenprintConfig(generativeModel *genai.GenerativeModel) {
 						"quantity": {
 							Type: genai.TypeInteger,
 						},
+						"step": {
+							Type:        genai.TypeInteger,
+							Description: "Number of the first step that needs this material, starting from 1",
+						},
 					},
 					Required: []string{"name", "description", "price", "quantity"},
 				},
@@ -231,6 +292,14 @@ func greenprintConfig(generativeModel *genai.GenerativeModel) {
 						"price": {
 							Type: genai.TypeInteger,
 						},
+						"step": {
+							Type:        genai.TypeInteger,
+							Description: "Number of the first step that needs this tool, starting from 1",
+						},
+						"optional": {
+							Type:        genai.TypeBoolean,
+							Description: "Whether the project can be finished without this tool",
+						},
 					},
 					Required: []string{"name", "description", "price"},
 				},
@@ -0,0 +1,14 @@
+package configs
+
+// MaterialCategories are the material types scanned items are classified
+// into, the same list drop-off points and waste banks accept in the admin panel.
+var MaterialCategories = []string{
+	"plastic",
+	"paper",
+	"cardboard",
+	"metal",
+	"glass",
+	"electronic",
+	"cooking_oil",
+	"other",
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -24,6 +26,26 @@ func (f FailedValidationError) Error() string {
 	return "Failed validation"
 }
 
+type QuotaExceededError struct {
+	Feature  string
+	Period   string
+	Limit    int
+	ResetsAt time.Time
+}
+
+func NewQuotaExceededError(feature string, period string, limit int, resetsAt time.Time) QuotaExceededError {
+	return QuotaExceededError{
+		Feature:  feature,
+		Period:   period,
+		Limit:    limit,
+		ResetsAt: resetsAt,
+	}
+}
+
+func (q QuotaExceededError) Error() string {
+	return "Quota exceeded"
+}
+
 func ErrorHandler(c *fiber.Ctx, err error) error {
 	if errors.As(err, &FailedValidationError{}) {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -35,6 +57,21 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 		})
 	}
 
+	var quotaErr QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(time.Until(quotaErr.ResetsAt).Seconds())))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"success": false,
+			"error": fiber.Map{
+				"message":   "Kuota AI anda sudah habis, silakan coba lagi nanti",
+				"feature":   quotaErr.Feature,
+				"period":    quotaErr.Period,
+				"limit":     quotaErr.Limit,
+				"resets_at": quotaErr.ResetsAt.Format("2006-01-02 15:04"),
+			},
+		})
+	}
+
 	var fiberErr *fiber.Error
 	if !errors.As(err, &fiberErr) {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
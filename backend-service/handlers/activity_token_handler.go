@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ActivityTokenHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+	*services.ActivityTokenService
+}
+
+func NewActivityTokenHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+	ats *services.ActivityTokenService,
+) *ActivityTokenHandler {
+	return &ActivityTokenHandler{
+		Validator:            v,
+		Repository:           r,
+		ActivityTokenService: ats,
+	}
+}
+
+func (h *ActivityTokenHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/activity-token")
+	g.Use(helpers.TokenMiddleware)
+	g.Use(helpers.AdminMiddleware(h.Repository))
+	g.Post("/", h.handleIssueToken)
+	g.Get("/:type/:id", h.handleGetCurrentToken)
+}
+
+func (h *ActivityTokenHandler) handleIssueToken(c *fiber.Ctx) error {
+	req := &models.PostActivityToken{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	res, err := h.ActivityTokenService.Issue(context.Background(), req.ActivityType, req.ActivityID, req.RotationSeconds)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *ActivityTokenHandler) handleGetCurrentToken(c *fiber.Ctx) error {
+	activityType := c.Params("type")
+	if !slices.Contains([]string{"quest", "event", "treasure"}, activityType) {
+		return fiber.NewError(fiber.StatusBadRequest, "Type is not valid")
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	res, err := h.ActivityTokenService.Current(context.Background(), activityType, int64(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarHandler struct {
+	*services.CalendarService
+}
+
+func NewCalendarHandler(
+	cs *services.CalendarService,
+) *CalendarHandler {
+	return &CalendarHandler{
+		CalendarService: cs,
+	}
+}
+
+func (h *CalendarHandler) RegisterRoutes(router fiber.Router) {
+	// calendar apps can't send the Authorization header, the token in the
+	// URL is the only credential of the feed
+	router.Get("/ical/:token.ics", h.handleGetUserFeed)
+
+	g := router.Group("/calendar")
+	g.Use(helpers.TokenMiddleware)
+	g.Get("/", h.handleGetFeedUrl)
+	g.Post("/reset", h.handleResetFeedUrl)
+}
+
+func (h *CalendarHandler) handleGetFeedUrl(c *fiber.Ctx) error {
+	return h.respondFeedUrl(c, false)
+}
+
+func (h *CalendarHandler) handleResetFeedUrl(c *fiber.Ctx) error {
+	return h.respondFeedUrl(c, true)
+}
+
+func (h *CalendarHandler) respondFeedUrl(c *fiber.Ctx, reset bool) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	token, err := h.CalendarService.FeedToken(context.Background(), int64(userId), reset)
+	if err != nil {
+		return err
+	}
+
+	baseUrl := helpers.NewConfig().GetString("APP_URL")
+	if baseUrl == "" {
+		baseUrl = c.BaseURL()
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"url": baseUrl + "/api/ical/" + token + ".ics",
+		},
+	})
+}
+
+func (h *CalendarHandler) handleGetUserFeed(c *fiber.Ctx) error {
+	feed, err := h.CalendarService.UserFeed(context.Background(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=900")
+	return c.Status(fiber.StatusOK).Send(feed)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CertificateHandler struct {
+	Validator *validator.Validate
+	*services.CertificateService
+}
+
+func NewCertificateHandler(
+	v *validator.Validate,
+	cs *services.CertificateService,
+) *CertificateHandler {
+	return &CertificateHandler{
+		Validator:          v,
+		CertificateService: cs,
+	}
+}
+
+func (h *CertificateHandler) RegisterRoutes(router fiber.Router) {
+	// verification is opened from the QR code printed on the certificate, so
+	// it is registered ahead of the token middleware of the group
+	router.Get("/certificate/verify/:code", h.handleVerifyCertificate)
+
+	g := router.Group("/certificate")
+	g.Use(helpers.TokenMiddleware)
+	g.Post("/", h.handleIssueCertificate)
+}
+
+func (h *CertificateHandler) handleIssueCertificate(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	req := &models.PostCertificate{}
+	err = c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	baseUrl := helpers.NewConfig().GetString("APP_URL")
+	if baseUrl == "" {
+		baseUrl = c.BaseURL()
+	}
+
+	res, err := h.CertificateService.Issue(context.Background(), int64(userId), req, baseUrl+"/api/certificate/verify/")
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *CertificateHandler) handleVerifyCertificate(c *fiber.Ctx) error {
+	res, err := h.CertificateService.Verify(context.Background(), c.Params("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
@@ -10,15 +10,20 @@ import (
 	"jirbthagoras/raksana-backend/repositories"
 	"jirbthagoras/raksana-backend/services"
 	"log/slog"
+	"slices"
 	"strconv"
 	"time"
 	_ "time/tzdata"
 
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+var participationStatuses = []string{"pending_upload", "pending_review", "approved", "rejected"}
+
 type ChallengeHandler struct {
 	Validator  *validator.Validate
 	Repository *repositories.Queries
@@ -27,6 +32,8 @@ type ChallengeHandler struct {
 	*services.JournalService
 	*services.FileService
 	*services.StreakService
+	*services.NotificationService
+	*services.ChallengeService
 }
 
 func NewChallengeHandler(
@@ -37,15 +44,23 @@ func NewChallengeHandler(
 	js *services.JournalService,
 	fs *services.FileService,
 	ss *services.StreakService,
+	ns *services.NotificationService,
+	cs *services.ChallengeService,
 ) *ChallengeHandler {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("CHALLENGE_PROOF_MAX_BYTES", 10*1024*1024)
+	cnf.SetDefault("CHALLENGE_AUTO_APPROVE", false)
+
 	return &ChallengeHandler{
-		Validator:      v,
-		Repository:     r,
-		MemoryService:  ms,
-		PointService:   ps,
-		JournalService: js,
-		FileService:    fs,
-		StreakService:  ss,
+		Validator:           v,
+		Repository:          r,
+		MemoryService:       ms,
+		PointService:        ps,
+		JournalService:      js,
+		FileService:         fs,
+		StreakService:       ss,
+		NotificationService: ns,
+		ChallengeService:    cs,
 	}
 }
 
@@ -53,8 +68,15 @@ func (h *ChallengeHandler) RegisterRoutes(router fiber.Router) {
 	g := router.Group("/challenge")
 	g.Use(helpers.TokenMiddleware)
 	g.Post("/", h.handleParticipate)
+	g.Post("/participation/:id/confirm", h.handleConfirmUpload)
+	g.Put("/participation/:id/reaction", h.handleReact)
+	g.Delete("/participation/:id/reaction", h.handleRemoveReaction)
+	g.Get("/moderation", helpers.AdminMiddleware(h.Repository), h.handleGetModerationQueue)
+	g.Post("/moderation/:id/approve", helpers.AdminMiddleware(h.Repository), h.handleApproveParticipation)
+	g.Post("/moderation/:id/reject", helpers.AdminMiddleware(h.Repository), h.handleRejectParticipation)
 	g.Get("/today", h.handleGetTodayChallenge)
 	g.Get("/", h.handleGetAllChallenges)
+	g.Get("/:id/top", h.handleGetTopSubmissions)
 	g.Get("/:id", h.handleGetChallengeParticipants)
 }
 
@@ -110,6 +132,38 @@ func (h *ChallengeHandler) handleParticipate(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid challenge")
 	}
 
+	// a previous attempt whose upload never happened is replaced
+	staleMemoryIds, err := h.Repository.DeletePendingUploadParticipations(ctx, repositories.DeletePendingUploadParticipationsParams{
+		UserID:      int64(userId),
+		ChallengeID: challenge.ChallengeID,
+	})
+	if err != nil {
+		slog.Error("Failed to delete pending participations", "err", err)
+		return err
+	}
+	for _, memoryId := range staleMemoryIds {
+		stale, err := h.Repository.DeleteMemory(ctx, repositories.DeleteMemoryParams{
+			ID:     memoryId,
+			UserID: int64(userId),
+		})
+		if err != nil {
+			slog.Error("Failed to delete stale memory", "err", err)
+			return err
+		}
+
+		// the upload may never have happened, a missing object is fine
+		err = h.FileService.DeleteUploadedObject(stale.FileKey)
+		if err != nil {
+			slog.Error("Failed to delete stale memory object", "key", stale.FileKey, "err", err)
+		}
+		if stale.ThumbnailKey.Valid {
+			err = h.FileService.DeleteUploadedObject(stale.ThumbnailKey.String)
+			if err != nil {
+				slog.Error("Failed to delete stale memory object", "key", stale.ThumbnailKey.String, "err", err)
+			}
+		}
+	}
+
 	presignedUrl, fileKey, err := h.FileService.CreatePresignedURL(
 		"memory",
 		strconv.Itoa(userId),
@@ -125,58 +179,434 @@ func (h *ChallengeHandler) handleParticipate(c *fiber.Ctx) error {
 		return err
 	}
 
-	_, err = h.Repository.CreateParticipation(context.Background(), repositories.CreateParticipationParams{
+	created, err := h.Repository.CreateParticipation(ctx, repositories.CreateParticipationParams{
 		MemoryID:    int64(memoryId),
 		UserID:      int64(userId),
 		ChallengeID: int64(challenge.ChallengeID),
+		PointGain:   int32(challenge.PointGain),
 	})
 	if err != nil {
 		slog.Error("Failed to insert row to participation", "err", err)
 		return err
 	}
 
-	profile, err := h.Repository.GetUserProfile(ctx, int64(userId))
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": fiber.Map{
+			"participation_id": created.ID,
+			"status":           created.Status,
+			"presigned_url":    presignedUrl,
+		},
+	})
+}
+
+// handleConfirmUpload is called once the proof was PUT to the presigned URL.
+// The object is looked up in S3 and checked before the participation goes to
+// the moderation queue, points are only granted once it is approved.
+func (h *ChallengeHandler) handleConfirmUpload(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	participation, err := h.getParticipation(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if participation.UserID != int64(userId) {
+		return fiber.NewError(fiber.StatusNotFound, "Partisipasi tidak ditemukan")
+	}
+
+	if participation.Status != "pending_upload" {
+		return fiber.NewError(fiber.StatusBadRequest, "Bukti partisipasi sudah dikonfirmasi")
+	}
+
+	object, found, err := h.FileService.HeadUploadedObject(ctx, participation.FileKey)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fiber.NewError(fiber.StatusBadRequest, "File bukti belum diunggah")
+	}
+
+	cnf := helpers.NewConfig()
+
+	if reason := checkProof(object, cnf.GetInt64("CHALLENGE_PROOF_MAX_BYTES")); reason != "" {
+		_, err = h.Repository.RejectParticipation(ctx, repositories.RejectParticipationParams{
+			Note: pgtype.Text{String: reason, Valid: true},
+			ID:   participation.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fiber.NewError(fiber.StatusBadRequest, "Bukti partisipasi sudah dikonfirmasi")
+			}
+			slog.Error("Failed to reject participation", "err", err)
+			return err
+		}
+
+		err = h.FileService.DeleteUploadedObject(participation.FileKey)
+		if err != nil {
+			return err
+		}
+
+		return h.respondParticipation(c, ctx, participation.ID)
+	}
+
+	_, err = h.Repository.MarkParticipationUploaded(ctx, participation.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Bukti partisipasi sudah dikonfirmasi")
+		}
+		slog.Error("Failed to mark participation uploaded", "err", err)
+		return err
+	}
+
+	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	if cnf.GetBool("CHALLENGE_AUTO_APPROVE") {
+		err = h.approveParticipation(ctx, repositories.ApproveParticipationParams{
+			ID: participation.ID,
+		}, participation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return h.respondParticipation(c, ctx, participation.ID)
+}
+
+// checkProof runs the automatic checks on an uploaded proof and returns why
+// it is refused, or an empty string when it can be reviewed.
+func checkProof(object services.UploadedObject, maxBytes int64) string {
+	switch {
+	case object.Size == 0:
+		return "File bukti kosong"
+	case object.Size > maxBytes:
+		return fmt.Sprintf("Ukuran file bukti melebihi %d MB", maxBytes/(1024*1024))
+	case !mimetype.EqualsAny(object.ContentType, "image/jpeg", "image/png", "image/webp"):
+		return "File bukti harus berupa gambar JPEG, PNG atau WebP"
+	}
+	return ""
+}
+
+func (h *ChallengeHandler) handleGetModerationQueue(c *fiber.Ctx) error {
+	status := c.Query("status", "pending_review")
+	if !slices.Contains(participationStatuses, status) {
+		return fiber.NewError(fiber.StatusBadRequest, "Status harus pending_upload, pending_review, approved atau rejected")
+	}
+
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 50)
+
+	ctx := context.Background()
+
+	rows, err := h.Repository.GetParticipationQueue(ctx, repositories.GetParticipationQueueParams{
+		Status: status,
+		Limit:  int32(limit),
+		Offset: int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get participation queue", "err", err)
+		return err
+	}
+
+	total, err := h.Repository.CountParticipationQueue(ctx, status)
+	if err != nil {
+		slog.Error("Failed to count participation queue", "err", err)
+		return err
+	}
+
+	participations := []models.ResponseParticipation{}
+	for _, row := range rows {
+		participations = append(participations, toResponseParticipation(repositories.GetParticipationByIdRow(row)))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"participations": participations,
+			"total":          total,
+			"page":           page,
+			"limit":          limit,
+		},
+	})
+}
+
+func (h *ChallengeHandler) handleApproveParticipation(c *fiber.Ctx) error {
+	req, adminId, err := h.parseReview(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	participation, err := h.getParticipation(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	err = h.approveParticipation(ctx, repositories.ApproveParticipationParams{
+		Note:       pgtype.Text{String: req.Note, Valid: req.Note != ""},
+		ReviewedBy: pgtype.Int8{Int64: int64(adminId), Valid: true},
+		ID:         participation.ID,
+	}, participation)
+	if err != nil {
+		return err
+	}
+
+	return h.respondParticipation(c, ctx, participation.ID)
+}
+
+func (h *ChallengeHandler) handleRejectParticipation(c *fiber.Ctx) error {
+	req, adminId, err := h.parseReview(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	participation, err := h.getParticipation(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if participation.Status == "approved" {
+		err = h.revokeParticipation(ctx, req, adminId, participation)
+		if err != nil {
+			return err
+		}
+		return h.respondParticipation(c, ctx, participation.ID)
+	}
+
+	_, err = h.Repository.RejectParticipation(ctx, repositories.RejectParticipationParams{
+		Note:       pgtype.Text{String: req.Note, Valid: req.Note != ""},
+		ReviewedBy: pgtype.Int8{Int64: int64(adminId), Valid: true},
+		ID:         participation.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Partisipasi sudah diproses")
+		}
+		slog.Error("Failed to reject participation", "err", err)
+		return err
+	}
+
+	body := fmt.Sprintf("Bukti partisipasi challenge %s ditolak, poin sebesar %d tidak diberikan.", participation.ChallengeName, participation.PointGain)
+	if req.Note != "" {
+		body += " Catatan: " + req.Note
+	}
+	err = h.NotificationService.Notify(ctx, participation.UserID, services.NotificationChallengeRejected, "Partisipasi ditolak", body)
+	if err != nil {
+		return err
+	}
+
+	return h.respondParticipation(c, ctx, participation.ID)
+}
+
+func (h *ChallengeHandler) parseReview(c *fiber.Ctx) (*models.PostParticipationReview, int, error) {
+	req := &models.PostParticipationReview{}
+
+	err := c.BodyParser(req)
+	if err != nil && len(c.Body()) > 0 {
+		slog.Error("Failed to parse payload", "err", err)
+		return nil, 0, err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return nil, 0, exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	adminId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return req, adminId, nil
+}
+
+// approveParticipation approves a participation that waits for review and
+// books its points in one transaction. The participation keeps the points
+// actually granted after the level multiplier, so a later revoke takes back
+// exactly that.
+func (h *ChallengeHandler) approveParticipation(ctx context.Context, params repositories.ApproveParticipationParams, participation repositories.GetParticipationByIdRow) error {
+	profile, err := h.Repository.GetUserProfile(ctx, participation.UserID)
 	if err != nil {
 		slog.Error("Failed to get user profile", "err", err)
 		return err
 	}
 
-	historyMsg := fmt.Sprintf("Mendapat poin challenge %s", challenge.Name)
-	_, err = h.PointService.UpdateUserPoint(int64(userId), challenge.PointGain, historyMsg, "challenge", int(profile.Level))
+	var points int64
+	historyMsg := fmt.Sprintf("Mendapat poin challenge %s", participation.ChallengeName)
+	err = h.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		approved, err := q.ApproveParticipation(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		points, err = h.PointService.AddUserPoint(ctx, q, approved.UserID, int64(approved.PointGain), historyMsg, "challenge", int(profile.Level))
+		if err != nil {
+			return err
+		}
+
+		err = q.SetParticipationPointGain(ctx, repositories.SetParticipationPointGainParams{
+			PointGain: int32(points),
+			ID:        approved.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.IncreaseChallengesFieldByOne(ctx, approved.UserID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Partisipasi tidak sedang menunggu review")
+		}
+		slog.Error("Failed to approve participation", "err", err)
+		return err
+	}
+
+	err = h.PointService.IncrLeaderboard(participation.UserID, points)
 	if err != nil {
 		return err
 	}
 
-	logMsg := fmt.Sprintf("Baru saja berpartisipasi dalam challenge harian day-%v, dan mendapatkan poin sebesar %v ", challenge.Day, challenge.PointGain)
+	logMsg := fmt.Sprintf("Baru saja berpartisipasi dalam challenge harian day-%v, dan mendapatkan poin sebesar %v ", participation.Day, points)
 	err = h.JournalService.AppendLog(&models.PostLogAppend{
 		Text:      logMsg,
 		IsSystem:  true,
 		IsPrivate: false,
-	}, userId)
+	}, int(participation.UserID))
 	if err != nil {
 		return err
 	}
 
-	_, err = h.Repository.IncreaseChallengesFieldByOne(ctx, int64(userId))
+	body := fmt.Sprintf("Bukti partisipasi challenge %s disetujui, kamu mendapatkan %d poin.", participation.ChallengeName, points)
+	return h.NotificationService.Notify(ctx, participation.UserID, services.NotificationChallengeApproved, "Partisipasi disetujui", body)
+}
+
+// revokeParticipation rejects an already approved participation and takes
+// back the points it granted, in one transaction.
+func (h *ChallengeHandler) revokeParticipation(ctx context.Context, req *models.PostParticipationReview, adminId int, participation repositories.GetParticipationByIdRow) error {
+	var revoked repositories.RevokeParticipationRow
+	historyMsg := fmt.Sprintf("Poin challenge %s ditarik kembali", participation.ChallengeName)
+	err := h.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		var err error
+		revoked, err = q.RevokeParticipation(ctx, repositories.RevokeParticipationParams{
+			ID:         participation.ID,
+			Note:       pgtype.Text{String: req.Note, Valid: req.Note != ""},
+			ReviewedBy: pgtype.Int8{Int64: int64(adminId), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.DecreaseUserPoints(ctx, repositories.DecreaseUserPointsParams{
+			Points: int64(revoked.RevokedPoints),
+			UserID: revoked.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.AppendHistry(ctx, repositories.AppendHistryParams{
+			UserID:   revoked.UserID,
+			Amount:   revoked.RevokedPoints,
+			Type:     "output",
+			Category: "challenge",
+			Name:     historyMsg,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DecreaseChallengesFieldByOne(ctx, revoked.UserID)
+	})
 	if err != nil {
-		slog.Error("Failed to increase challenges field by one", "err", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Partisipasi sudah diproses")
+		}
+		slog.Error("Failed to revoke participation", "err", err)
 		return err
 	}
 
-	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	err = h.PointService.IncrLeaderboard(revoked.UserID, -int64(revoked.RevokedPoints))
 	if err != nil {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"data": fiber.Map{
-			"presigned_url": presignedUrl,
-		},
+	body := fmt.Sprintf("Bukti partisipasi challenge %s ditolak setelah ditinjau ulang, %d poin yang sudah diberikan ditarik kembali.", participation.ChallengeName, revoked.RevokedPoints)
+	if req.Note != "" {
+		body += " Catatan: " + req.Note
+	}
+	return h.NotificationService.Notify(ctx, revoked.UserID, services.NotificationChallengeRejected, "Partisipasi ditolak", body)
+}
+
+func (h *ChallengeHandler) getParticipation(ctx context.Context, c *fiber.Ctx) (repositories.GetParticipationByIdRow, error) {
+	var participation repositories.GetParticipationByIdRow
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return participation, fiber.NewError(fiber.StatusBadRequest, "Id partisipasi tidak valid")
+	}
+
+	participation, err = h.Repository.GetParticipationById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return participation, fiber.NewError(fiber.StatusNotFound, "Partisipasi tidak ditemukan")
+		}
+		slog.Error("Failed to get participation", "err", err)
+		return participation, err
+	}
+
+	return participation, nil
+}
+
+func (h *ChallengeHandler) respondParticipation(c *fiber.Ctx, ctx context.Context, id int64) error {
+	participation, err := h.Repository.GetParticipationById(ctx, id)
+	if err != nil {
+		slog.Error("Failed to get participation", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": toResponseParticipation(participation),
 	})
 }
 
+func toResponseParticipation(row repositories.GetParticipationByIdRow) models.ResponseParticipation {
+	res := models.ResponseParticipation{
+		ID:            row.ID,
+		ChallengeID:   row.ChallengeID,
+		ChallengeName: row.ChallengeName,
+		Day:           row.Day,
+		UserID:        row.UserID,
+		Username:      row.Username,
+		FileURL:       helpers.NewConfig().GetString("AWS_URL") + row.FileKey,
+		Description:   row.Description,
+		Status:        row.Status,
+		PointGain:     row.PointGain,
+		Note:          row.Note.String,
+		CreatedAt:     row.CreatedAt.Time.Format("2006-01-02 15:04"),
+	}
+	if row.UploadedAt.Valid {
+		res.UploadedAt = row.UploadedAt.Time.Format("2006-01-02 15:04")
+	}
+	if row.ReviewedAt.Valid {
+		res.ReviewedAt = row.ReviewedAt.Time.Format("2006-01-02 15:04")
+	}
+	return res
+}
+
 func (h *ChallengeHandler) handleGetTodayChallenge(c *fiber.Ctx) error {
 	ctx := context.Background()
+
 	res, err := h.Repository.GetTodayChallenge(ctx)
 	if err != nil {
 		slog.Error("Failed to get today challenge", "err", err)
@@ -236,7 +666,14 @@ func (h *ChallengeHandler) handleGetChallengeParticipants(c *fiber.Ctx) error {
 		return err
 	}
 
-	_, err = h.Repository.GetChallengeWithDetailById(context.Background(), int64(challengeId))
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	_, err = h.Repository.GetChallengeWithDetailById(ctx, int64(challengeId))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return fiber.NewError(fiber.StatusBadRequest, "Challenge not found")
@@ -245,7 +682,10 @@ func (h *ChallengeHandler) handleGetChallengeParticipants(c *fiber.Ctx) error {
 		return err
 	}
 
-	res, err := h.Repository.GetMemoriesByChallengeID(context.Background(), int64(challengeId))
+	res, err := h.Repository.GetMemoriesByChallengeID(ctx, repositories.GetMemoriesByChallengeIDParams{
+		UserID:      int64(userId),
+		ChallengeID: int64(challengeId),
+	})
 	if err != nil {
 		slog.Error("Failed to get memories relaetdd to challenge", "err", err)
 		return err
@@ -255,12 +695,16 @@ func (h *ChallengeHandler) handleGetChallengeParticipants(c *fiber.Ctx) error {
 	cnf := helpers.NewConfig()
 	bucketUrl := cnf.GetString("AWS_URL")
 	for _, memory := range res {
+		reactions := toResponseReactions(memory.Votes, memory.Likes, memory.Loves, memory.Inspirings, memory.Claps, memory.MyReaction)
 		challengeMemories = append(challengeMemories, models.ResponseMemory{
-			UserID:      memory.UserID,
-			UserName:    memory.Username,
-			FileURL:     bucketUrl + memory.FileKey,
-			Description: memory.Description,
-			CreatedAt:   memory.MemoryCreatedAt.Time.Format("2006-01-02 15:04"),
+			UserID:          memory.UserID,
+			UserName:        memory.Username,
+			FileURL:         bucketUrl + memory.FileKey,
+			Description:     memory.Description,
+			CreatedAt:       memory.MemoryCreatedAt.Time.Format("2006-01-02 15:04"),
+			ParticipationID: memory.ParticipationID,
+			BonusPoints:     memory.BonusPoints,
+			Reactions:       &reactions,
 		})
 	}
 
@@ -270,3 +714,193 @@ func (h *ChallengeHandler) handleGetChallengeParticipants(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// handleGetTopSubmissions ranks the approved submissions of a challenge by
+// votes, the first CHALLENGE_TOP_BONUS_WINNERS get a bonus when the day closes.
+func (h *ChallengeHandler) handleGetTopSubmissions(c *fiber.Ctx) error {
+	challengeId, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id challenge tidak valid")
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	challenge, err := h.Repository.GetChallengeWithDetailById(ctx, int64(challengeId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Challenge tidak ditemukan")
+		}
+		slog.Error("Failed to get challenge detail", "err", err)
+		return err
+	}
+
+	open, err := h.ChallengeService.IsOpen(challenge.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	limit := min(max(c.QueryInt("limit", 10), 1), 50)
+
+	rows, err := h.Repository.GetTopParticipations(ctx, repositories.GetTopParticipationsParams{
+		UserID:      int64(userId),
+		ChallengeID: int64(challengeId),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get top participations", "err", err)
+		return err
+	}
+
+	bucketUrl := helpers.NewConfig().GetString("AWS_URL")
+	submissions := []models.ResponseTopSubmission{}
+	for i, row := range rows {
+		submissions = append(submissions, models.ResponseTopSubmission{
+			Rank:            i + 1,
+			ParticipationID: row.ParticipationID,
+			UserID:          row.UserID,
+			UserName:        row.Username,
+			FileURL:         bucketUrl + row.FileKey,
+			Description:     row.Description,
+			CreatedAt:       row.MemoryCreatedAt.Time.Format("2006-01-02 15:04"),
+			BonusPoints:     row.BonusPoints,
+			Reactions:       toResponseReactions(row.Votes, row.Likes, row.Loves, row.Inspirings, row.Claps, row.MyReaction),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"submissions": submissions,
+			"open":        open,
+		},
+	})
+}
+
+// handleReact sets the reaction of the user on a submission. Any reaction is
+// the user's single vote on it, sending another one only changes its kind.
+func (h *ChallengeHandler) handleReact(c *fiber.Ctx) error {
+	req := &models.PostParticipationReaction{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	participation, err := h.getVotableParticipation(ctx, c, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.Repository.UpsertParticipationReaction(ctx, repositories.UpsertParticipationReactionParams{
+		ParticipationID: participation.ID,
+		UserID:          int64(userId),
+		Reaction:        req.Reaction,
+	})
+	if err != nil {
+		slog.Error("Failed to upsert participation reaction", "err", err)
+		return err
+	}
+
+	return h.respondReactions(c, ctx, participation.ID, userId)
+}
+
+func (h *ChallengeHandler) handleRemoveReaction(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	participation, err := h.getVotableParticipation(ctx, c, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.Repository.DeleteParticipationReaction(ctx, repositories.DeleteParticipationReactionParams{
+		ParticipationID: participation.ID,
+		UserID:          int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to delete participation reaction", "err", err)
+		return err
+	}
+
+	return h.respondReactions(c, ctx, participation.ID, userId)
+}
+
+// getVotableParticipation loads the submission from the :id param when the
+// user may vote on it: approved, someone else's, and its challenge day open.
+func (h *ChallengeHandler) getVotableParticipation(ctx context.Context, c *fiber.Ctx, userId int) (repositories.GetParticipationByIdRow, error) {
+	participation, err := h.getParticipation(ctx, c)
+	if err != nil {
+		return participation, err
+	}
+
+	if participation.Status != "approved" {
+		return participation, fiber.NewError(fiber.StatusNotFound, "Partisipasi tidak ditemukan")
+	}
+
+	if participation.UserID == int64(userId) {
+		return participation, fiber.NewError(fiber.StatusBadRequest, "Tidak bisa memberi vote pada partisipasi sendiri")
+	}
+
+	challenge, err := h.Repository.GetChallengeWithDetailById(ctx, participation.ChallengeID)
+	if err != nil {
+		slog.Error("Failed to get challenge detail", "err", err)
+		return participation, err
+	}
+
+	open, err := h.ChallengeService.IsOpen(challenge.CreatedAt)
+	if err != nil {
+		return participation, err
+	}
+	if !open {
+		return participation, fiber.NewError(fiber.StatusBadRequest, "Challenge sudah ditutup, vote tidak dapat diubah")
+	}
+
+	return participation, nil
+}
+
+func (h *ChallengeHandler) respondReactions(c *fiber.Ctx, ctx context.Context, participationId int64, userId int) error {
+	res, err := h.Repository.GetParticipationReactions(ctx, repositories.GetParticipationReactionsParams{
+		UserID:          int64(userId),
+		ParticipationID: participationId,
+	})
+	if err != nil {
+		slog.Error("Failed to get participation reactions", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": toResponseReactions(res.Votes, res.Likes, res.Loves, res.Inspirings, res.Claps, res.MyReaction),
+	})
+}
+
+func toResponseReactions(votes int32, likes int32, loves int32, inspirings int32, claps int32, mine pgtype.Text) models.ResponseReactions {
+	return models.ResponseReactions{
+		Votes:     votes,
+		Like:      likes,
+		Love:      loves,
+		Inspiring: inspirings,
+		Clap:      claps,
+		Mine:      mine.String,
+	}
+}
@@ -0,0 +1,668 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+	"math"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DepositHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+	*services.PointService
+	*services.JournalService
+	*services.StreakService
+}
+
+func NewDepositHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+	ps *services.PointService,
+	js *services.JournalService,
+	ss *services.StreakService,
+) *DepositHandler {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("DEPOSIT_POINTS_PER_KG", 10)
+
+	return &DepositHandler{
+		Validator:      v,
+		Repository:     r,
+		PointService:   ps,
+		JournalService: js,
+		StreakService:  ss,
+	}
+}
+
+func (h *DepositHandler) RegisterRoutes(router fiber.Router) {
+	wb := router.Group("/waste-bank")
+	wb.Use(helpers.TokenMiddleware)
+	wb.Get("/", h.handleGetWasteBanks)
+	wb.Get("/:id", h.handleGetWasteBank)
+
+	g := router.Group("/deposit")
+	g.Use(helpers.TokenMiddleware)
+	g.Post("/", h.handleCreateDeposit)
+	g.Get("/me", h.handleGetCurrentUserDeposits)
+	g.Get("/statistics", h.handleGetDepositStatistic)
+	g.Get("/statistics/regions", h.handleGetRegionDepositStatistics)
+	g.Get("/:id", h.handleGetDeposit)
+	g.Post("/:id/confirm", helpers.AdminMiddleware(h.Repository), h.handleConfirmDeposit)
+	g.Post("/:id/reject", helpers.AdminMiddleware(h.Repository), h.handleRejectDeposit)
+}
+
+func (h *DepositHandler) handleGetWasteBanks(c *fiber.Ctx) error {
+	latitude := c.QueryFloat("latitude")
+	longitude := c.QueryFloat("longitude")
+	hasLocation := c.Query("latitude") != "" && c.Query("longitude") != ""
+
+	ctx := context.Background()
+
+	banks, err := h.Repository.GetWasteBanks(ctx, repositories.GetWasteBanksParams{
+		Latitude:    latitude,
+		Longitude:   longitude,
+		HasLocation: hasLocation,
+	})
+	if err != nil {
+		slog.Error("Failed to get waste banks", "err", err)
+		return err
+	}
+
+	prices, err := h.Repository.GetAllWasteBankPrices(ctx)
+	if err != nil {
+		slog.Error("Failed to get waste bank prices", "err", err)
+		return err
+	}
+
+	pricesByBank := map[int64][]models.ResponseWasteBankPrice{}
+	for _, p := range prices {
+		pricesByBank[p.WasteBankID] = append(pricesByBank[p.WasteBankID], toResponseWasteBankPrice(p))
+	}
+
+	res := []models.ResponseWasteBank{}
+	for _, b := range banks {
+		bank := models.ResponseWasteBank{
+			ID:        b.ID,
+			Name:      b.Name,
+			Address:   b.Address,
+			Latitude:  b.Latitude,
+			Longitude: b.Longitude,
+			Phone:     b.Phone.String,
+			Prices:    pricesByBank[b.ID],
+		}
+		if hasLocation {
+			bank.DistanceMeters = math.Round(b.DistanceMeters)
+		}
+		if bank.Prices == nil {
+			bank.Prices = []models.ResponseWasteBankPrice{}
+		}
+
+		res = append(res, bank)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleGetWasteBank(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	ctx := context.Background()
+
+	bank, err := h.Repository.GetWasteBankById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Bank sampah tidak ditemukan")
+		}
+		slog.Error("Failed to get waste bank", "err", err)
+		return err
+	}
+
+	prices, err := h.Repository.GetWasteBankPrices(ctx, bank.ID)
+	if err != nil {
+		slog.Error("Failed to get waste bank prices", "err", err)
+		return err
+	}
+
+	res := models.ResponseWasteBank{
+		ID:        bank.ID,
+		Name:      bank.Name,
+		Address:   bank.Address,
+		Latitude:  bank.Latitude,
+		Longitude: bank.Longitude,
+		Phone:     bank.Phone.String,
+		Prices:    []models.ResponseWasteBankPrice{},
+	}
+	for _, p := range prices {
+		res.Prices = append(res.Prices, toResponseWasteBankPrice(p))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleCreateDeposit(c *fiber.Ctx) error {
+	req := &models.PostDeposit{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	bank, err := h.Repository.GetWasteBankById(ctx, req.WasteBankID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Bank sampah tidak ditemukan")
+		}
+		slog.Error("Failed to get waste bank", "err", err)
+		return err
+	}
+
+	if !bank.IsActive {
+		return fiber.NewError(fiber.StatusBadRequest, "Bank sampah sedang tidak aktif")
+	}
+
+	prices, err := h.Repository.GetWasteBankPrices(ctx, bank.ID)
+	if err != nil {
+		slog.Error("Failed to get waste bank prices", "err", err)
+		return err
+	}
+
+	priceByMaterial := map[string]int32{}
+	for _, p := range prices {
+		priceByMaterial[p.Material] = p.PricePerKg
+	}
+
+	var totalWeight float64
+	var payout int32
+	items := []repositories.CreateDepositItemParams{}
+	seenItems := map[int64]bool{}
+	for _, i := range req.Items {
+		price, ok := priceByMaterial[i.Material]
+		if !ok {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Bank sampah ini tidak menerima material '%s'", i.Material))
+		}
+
+		itemId := pgtype.Int8{}
+		if i.ItemID != 0 {
+			if seenItems[i.ItemID] {
+				return fiber.NewError(fiber.StatusBadRequest, "Item yang sama tidak dapat disetor dua kali")
+			}
+			seenItems[i.ItemID] = true
+
+			err = h.checkDepositableItem(ctx, i.ItemID, int64(userId))
+			if err != nil {
+				return err
+			}
+			itemId = pgtype.Int8{Int64: i.ItemID, Valid: true}
+		}
+
+		subtotal := int32(math.Round(i.Weight * float64(price)))
+		totalWeight += i.Weight
+		payout += subtotal
+
+		items = append(items, repositories.CreateDepositItemParams{
+			ItemID:     itemId,
+			Material:   i.Material,
+			Weight:     i.Weight,
+			PricePerKg: price,
+			Subtotal:   subtotal,
+		})
+	}
+
+	var deposit repositories.Deposit
+	err = h.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		deposit, err = q.CreateDeposit(ctx, repositories.CreateDepositParams{
+			UserID:      int64(userId),
+			WasteBankID: bank.ID,
+			TotalWeight: totalWeight,
+			Payout:      payout,
+		})
+		if err != nil {
+			slog.Error("Failed to create deposit", "err", err)
+			return err
+		}
+
+		for _, i := range items {
+			i.DepositID = deposit.ID
+			err = q.CreateDepositItem(ctx, i)
+			if err != nil {
+				slog.Error("Failed to create deposit item", "err", err)
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := h.getDepositDetail(ctx, deposit, bank.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleGetCurrentUserDeposits(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.Repository.GetUserDeposits(context.Background(), int64(userId))
+	if err != nil {
+		slog.Error("Failed to get user deposits", "err", err)
+		return err
+	}
+
+	res := []models.ResponseDeposit{}
+	for _, row := range rows {
+		deposit := models.ResponseDeposit{
+			ID:            row.ID,
+			WasteBankID:   row.WasteBankID,
+			WasteBankName: row.WasteBankName,
+			Status:        row.Status,
+			TotalWeight:   row.TotalWeight,
+			Payout:        row.Payout,
+			PayoutText:    helpers.FormatRupiah(int64(row.Payout)),
+			PointGain:     row.PointGain,
+			Note:          row.Note.String,
+			CreatedAt:     row.CreatedAt.Time.Format("2006-01-02 15:04"),
+		}
+		if row.ConfirmedAt.Valid {
+			deposit.ConfirmedAt = row.ConfirmedAt.Time.Format("2006-01-02 15:04")
+		}
+
+		res = append(res, deposit)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleGetDepositStatistic(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	stat, err := h.Repository.GetUserDepositStatistic(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get deposit statistic", "err", err)
+		return err
+	}
+
+	materials, err := h.Repository.GetUserDepositMaterials(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get deposit materials", "err", err)
+		return err
+	}
+
+	res := models.ResponseDepositStatistic{
+		Deposits:    stat.Deposits,
+		TotalWeight: stat.TotalWeight,
+		TotalPayout: stat.TotalPayout,
+		PayoutText:  helpers.FormatRupiah(stat.TotalPayout),
+		TotalPoints: stat.TotalPoints,
+		Materials:   []models.ResponseDepositMaterial{},
+	}
+	for _, m := range materials {
+		res.Materials = append(res.Materials, models.ResponseDepositMaterial{
+			Material: m.Material,
+			Weight:   m.Weight,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleGetRegionDepositStatistics(c *fiber.Ctx) error {
+	rows, err := h.Repository.GetRegionDepositStatistics(context.Background())
+	if err != nil {
+		slog.Error("Failed to get region deposit statistics", "err", err)
+		return err
+	}
+
+	res := []models.ResponseRegionDepositStatistic{}
+	for _, row := range rows {
+		res = append(res, models.ResponseRegionDepositStatistic{
+			RegionID:    row.ID,
+			Name:        row.Name,
+			Deposits:    row.Deposits,
+			TotalWeight: row.TotalWeight,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleGetDeposit(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	deposit, err := h.getDeposit(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if deposit.UserID != int64(userId) {
+		user, err := h.Repository.GetUserById(ctx, int64(userId))
+		if err != nil {
+			slog.Error("Failed to get user", "err", err)
+			return err
+		}
+		if !user.IsAdmin {
+			return fiber.NewError(fiber.StatusForbidden, "Setoran ini bukan milik anda")
+		}
+	}
+
+	bank, err := h.Repository.GetWasteBankById(ctx, deposit.WasteBankID)
+	if err != nil {
+		slog.Error("Failed to get waste bank", "err", err)
+		return err
+	}
+
+	res, err := h.getDepositDetail(ctx, deposit, bank.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleConfirmDeposit(c *fiber.Ctx) error {
+	req := &models.PostDepositReview{}
+
+	err := c.BodyParser(req)
+	if err != nil && len(c.Body()) > 0 {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	adminId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	deposit, err := h.getDeposit(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if deposit.Status != "pending" {
+		return fiber.NewError(fiber.StatusBadRequest, "Setoran sudah diproses")
+	}
+
+	cnf := helpers.NewConfig()
+	pointGain := int32(math.Round(deposit.TotalWeight * cnf.GetFloat64("DEPOSIT_POINTS_PER_KG")))
+
+	bank, err := h.Repository.GetWasteBankById(ctx, deposit.WasteBankID)
+	if err != nil {
+		slog.Error("Failed to get waste bank", "err", err)
+		return err
+	}
+
+	profile, err := h.Repository.GetUserProfile(ctx, deposit.UserID)
+	if err != nil {
+		slog.Error("Failed to get user profile", "err", err)
+		return err
+	}
+
+	// the status change and the points are booked together, so a confirmed
+	// deposit always paid out and a failed payout leaves it pending
+	var points int64
+	err = h.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		deposit, err = q.ConfirmDeposit(ctx, repositories.ConfirmDepositParams{
+			PointGain:   pointGain,
+			Note:        pgtype.Text{String: req.Note, Valid: req.Note != ""},
+			ConfirmedBy: pgtype.Int8{Int64: int64(adminId), Valid: true},
+			ID:          deposit.ID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fiber.NewError(fiber.StatusBadRequest, "Setoran sudah diproses")
+			}
+			slog.Error("Failed to confirm deposit", "err", err)
+			return err
+		}
+
+		historyMsg := fmt.Sprintf("Setor %.1f kg sampah ke %s", deposit.TotalWeight, bank.Name)
+		points, err = h.PointService.AddUserPoint(ctx, q, deposit.UserID, int64(pointGain), historyMsg, "deposit", int(profile.Level))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = h.PointService.IncrLeaderboard(deposit.UserID, points)
+	if err != nil {
+		return err
+	}
+
+	err = h.StreakService.UpdateStreak(ctx, deposit.UserID)
+	if err != nil {
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Menyetor %.1f kg sampah ke bank sampah '%s' dan mendapatkan %s", deposit.TotalWeight, bank.Name, helpers.FormatRupiah(int64(deposit.Payout)))
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, int(deposit.UserID))
+	if err != nil {
+		return err
+	}
+
+	res, err := h.getDepositDetail(ctx, deposit, bank.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *DepositHandler) handleRejectDeposit(c *fiber.Ctx) error {
+	req := &models.PostDepositReview{}
+
+	err := c.BodyParser(req)
+	if err != nil && len(c.Body()) > 0 {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	adminId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	deposit, err := h.getDeposit(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	deposit, err = h.Repository.RejectDeposit(ctx, repositories.RejectDepositParams{
+		Note:        pgtype.Text{String: req.Note, Valid: req.Note != ""},
+		ConfirmedBy: pgtype.Int8{Int64: int64(adminId), Valid: true},
+		ID:          deposit.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Setoran sudah diproses")
+		}
+		slog.Error("Failed to reject deposit", "err", err)
+		return err
+	}
+
+	bank, err := h.Repository.GetWasteBankById(ctx, deposit.WasteBankID)
+	if err != nil {
+		slog.Error("Failed to get waste bank", "err", err)
+		return err
+	}
+
+	res, err := h.getDepositDetail(ctx, deposit, bank.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+// checkDepositableItem makes sure a scanned item belongs to the user and
+// is not already part of another pending or confirmed deposit.
+func (h *DepositHandler) checkDepositableItem(ctx context.Context, itemId int64, userId int64) error {
+	item, err := h.Repository.GetItemsById(ctx, itemId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Item tidak ditemukan")
+		}
+		slog.Error("Failed to get item", "err", err)
+		return err
+	}
+
+	if item.UserID != userId {
+		return fiber.NewError(fiber.StatusForbidden, "Item ini bukan milik anda")
+	}
+
+	count, err := h.Repository.CountActiveItemDeposits(ctx, pgtype.Int8{Int64: itemId, Valid: true})
+	if err != nil {
+		slog.Error("Failed to count item deposits", "err", err)
+		return err
+	}
+
+	if count > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Item '%s' sudah disetor", item.Name))
+	}
+
+	return nil
+}
+
+func (h *DepositHandler) getDeposit(ctx context.Context, c *fiber.Ctx) (repositories.Deposit, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return repositories.Deposit{}, fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	deposit, err := h.Repository.GetDepositById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return deposit, fiber.NewError(fiber.StatusNotFound, "Setoran tidak ditemukan")
+		}
+		slog.Error("Failed to get deposit", "err", err)
+		return deposit, err
+	}
+
+	return deposit, nil
+}
+
+func (h *DepositHandler) getDepositDetail(ctx context.Context, deposit repositories.Deposit, bankName string) (models.ResponseDeposit, error) {
+	res := models.ResponseDeposit{
+		ID:            deposit.ID,
+		WasteBankID:   deposit.WasteBankID,
+		WasteBankName: bankName,
+		Status:        deposit.Status,
+		TotalWeight:   deposit.TotalWeight,
+		Payout:        deposit.Payout,
+		PayoutText:    helpers.FormatRupiah(int64(deposit.Payout)),
+		PointGain:     deposit.PointGain,
+		Note:          deposit.Note.String,
+		CreatedAt:     deposit.CreatedAt.Time.Format("2006-01-02 15:04"),
+		Items:         []models.ResponseDepositItem{},
+	}
+	if deposit.ConfirmedAt.Valid {
+		res.ConfirmedAt = deposit.ConfirmedAt.Time.Format("2006-01-02 15:04")
+	}
+
+	items, err := h.Repository.GetDepositItems(ctx, deposit.ID)
+	if err != nil {
+		slog.Error("Failed to get deposit items", "err", err)
+		return res, err
+	}
+
+	for _, i := range items {
+		res.Items = append(res.Items, models.ResponseDepositItem{
+			ID:         i.ID,
+			ItemID:     i.ItemID.Int64,
+			ItemName:   i.ItemName.String,
+			Material:   i.Material,
+			Weight:     i.Weight,
+			PricePerKg: i.PricePerKg,
+			Subtotal:   i.Subtotal,
+		})
+	}
+
+	return res, nil
+}
+
+func toResponseWasteBankPrice(p repositories.WasteBankPrice) models.ResponseWasteBankPrice {
+	return models.ResponseWasteBankPrice{
+		Material:   p.Material,
+		PricePerKg: p.PricePerKg,
+		PriceText:  helpers.FormatRupiah(int64(p.PricePerKg)),
+	}
+}
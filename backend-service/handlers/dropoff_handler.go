@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/configs"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type DropoffHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+	*services.DropoffService
+}
+
+func NewDropoffHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+	ds *services.DropoffService,
+) *DropoffHandler {
+	return &DropoffHandler{
+		Validator:      v,
+		Repository:     r,
+		DropoffService: ds,
+	}
+}
+
+func (h *DropoffHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/dropoff")
+	g.Use(helpers.TokenMiddleware)
+	g.Get("/nearby", h.handleGetNearbyDropoff)
+	g.Get("/materials", h.handleGetMaterials)
+	g.Get("/:id", h.handleGetDropoff)
+}
+
+func (h *DropoffHandler) handleGetNearbyDropoff(c *fiber.Ctx) error {
+	req := &models.GetNearbyDropoff{
+		Latitude:  helpers.QueryCoordinate(c, "latitude"),
+		Longitude: helpers.QueryCoordinate(c, "longitude"),
+		Radius:    c.QueryFloat("radius"),
+		Material:  c.Query("material"),
+		Limit:     c.QueryInt("limit"),
+	}
+
+	err := h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	if req.Material != "" && !slices.Contains(configs.MaterialCategories, req.Material) {
+		return fiber.NewError(fiber.StatusBadRequest, "Material tidak dikenali")
+	}
+
+	points, err := h.DropoffService.GetNearbyPoints(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": points,
+	})
+}
+
+func (h *DropoffHandler) handleGetMaterials(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": configs.MaterialCategories,
+	})
+}
+
+func (h *DropoffHandler) handleGetDropoff(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	ctx := context.Background()
+
+	point, err := h.Repository.GetDropoffPointById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Drop-off point tidak ditemukan")
+		}
+		slog.Error("Failed to get drop-off point", "err", err)
+		return err
+	}
+
+	if !point.IsActive {
+		return fiber.NewError(fiber.StatusNotFound, "Drop-off point tidak ditemukan")
+	}
+
+	res, err := h.DropoffService.GetPointDetail(ctx, point)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"jirbthagoras/raksana-backend/exceptions"
@@ -10,11 +13,18 @@ import (
 	"jirbthagoras/raksana-backend/repositories"
 	"jirbthagoras/raksana-backend/services"
 	"log/slog"
+	"math"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/skip2/go-qrcode"
 )
 
 type EventHandler struct {
@@ -23,6 +33,11 @@ type EventHandler struct {
 	*services.PointService
 	*services.JournalService
 	*services.StreakService
+	*services.NotificationService
+	*services.WaitlistService
+	*services.CalendarService
+	*services.MediaService
+	*services.AuditService
 	Mu sync.Mutex
 }
 
@@ -32,22 +47,45 @@ func NewEventHandler(
 	ps *services.PointService,
 	js *services.JournalService,
 	ss *services.StreakService,
+	ns *services.NotificationService,
+	ws *services.WaitlistService,
+	cs *services.CalendarService,
+	ms *services.MediaService,
+	as *services.AuditService,
 ) *EventHandler {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("EVENT_CHECKIN_OPEN_MINUTES", 30)
+	cnf.SetDefault("EVENT_CHECKIN_CLOSE_MINUTES", 30)
+	cnf.SetDefault("EVENT_SEARCH_RADIUS", 10000)
+	cnf.SetDefault("EVENT_FEEDBACK_POINT_GAIN", 10)
+
 	return &EventHandler{
-		Validator:      v,
-		Repository:     r,
-		PointService:   ps,
-		JournalService: js,
-		StreakService:  ss,
+		Validator:           v,
+		Repository:          r,
+		PointService:        ps,
+		JournalService:      js,
+		StreakService:       ss,
+		NotificationService: ns,
+		WaitlistService:     ws,
+		CalendarService:     cs,
+		MediaService:        ms,
+		AuditService:        as,
 	}
 }
 
 func (h *EventHandler) RegisterRoutes(router fiber.Router) {
 	g := router.Group("/event")
 	g.Use(helpers.TokenMiddleware)
+	g.Post("/checkin", h.handleOrganizerCheckIn)
 	g.Post("/:id", h.handlerRegisterEvent)
+	g.Delete("/:id", h.handleCancelRegistration)
 	g.Get("/", h.handleGetEvents)
 	g.Get("/pending", h.handleGetAllPendingAttendance)
+	g.Get("/:id/ticket", h.handleGetTicket)
+	g.Get("/:id/ics", h.handleGetEventICal)
+	g.Post("/:id/feedback", h.handleCreateFeedback)
+	g.Get("/:id/feedback", h.handleGetFeedbackReport)
+	g.Get("/:id/attendees", h.handleExportAttendees)
 	g.Get("/:id", h.handleGetAttendanceDetail)
 }
 
@@ -87,6 +125,10 @@ func (h *EventHandler) handlerRegisterEvent(c *fiber.Ctx) error {
 		return err
 	}
 
+	if event.RegistrationClosed {
+		return fiber.NewError(fiber.StatusBadRequest, "Pendaftaran event ini sudah ditutup")
+	}
+
 	pendingAttendance, err := h.Repository.GetUserAttendances(ctx, int64(userId))
 	if err != nil {
 		slog.Error("Failed to get user pending attendance", "err", err)
@@ -99,16 +141,44 @@ func (h *EventHandler) handlerRegisterEvent(c *fiber.Ctx) error {
 		}
 	}
 
-	_, err = h.Repository.CreateAttendance(ctx, repositories.CreateAttendanceParams{
-		UserID:        int64(userId),
+	position, err := h.Repository.GetEventWaitlistPosition(ctx, repositories.GetEventWaitlistPositionParams{
+		EventID: event.ID,
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to get waitlist position", "err", err)
+		return err
+	}
+	if position > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah berada di daftar tunggu event ini!")
+	}
+
+	// seats freed up since the last registration, e.g. by raising the
+	// capacity, go to the users already waiting before anyone new
+	err = h.fillWaitlist(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.Repository.RegisterForEvent(ctx, repositories.RegisterForEventParams{
 		EventID:       event.ID,
+		UserID:        int64(userId),
 		ContactNumber: req.ContactNumber,
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah berpartisipasi pada event ini!")
+		}
 		slog.Error("Failed to create attendance", "err", err)
 		return err
 	}
 
+	// no seat left, or others are already waiting for one
+	if errors.Is(err, pgx.ErrNoRows) {
+		return h.joinWaitlist(c, event, userId, req.ContactNumber)
+	}
+
 	logMsg := fmt.Sprintf("Baru saja mendaftar di event: %s! Tunggu kabar saya!", event.Name)
 	err = h.JournalService.AppendLog(&models.PostLogAppend{
 		Text:      logMsg,
@@ -119,28 +189,315 @@ func (h *EventHandler) handlerRegisterEvent(c *fiber.Ctx) error {
 		return err
 	}
 
+	err = h.NotificationService.Notify(ctx, int64(userId), services.NotificationEventRegistered,
+		"Pendaftaran berhasil",
+		fmt.Sprintf("Kamu terdaftar di event %s.", event.Name),
+	)
+	if err != nil {
+		return err
+	}
+
 	err = h.StreakService.UpdateStreak(ctx, int64(userId))
 	if err != nil {
 		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"data": fiber.Map{
-			"message": "success",
+		"data": models.ResponseEventRegistration{
+			Status: "registered",
+		},
+	})
+}
+
+func (h *EventHandler) joinWaitlist(c *fiber.Ctx, event repositories.GetEventByIdRow, userId int, contactNumber string) error {
+	ctx := context.Background()
+
+	_, err := h.Repository.CreateEventWaitlist(ctx, repositories.CreateEventWaitlistParams{
+		EventID:       event.ID,
+		UserID:        int64(userId),
+		ContactNumber: contactNumber,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah berada di daftar tunggu event ini!")
+		}
+		slog.Error("Failed to join waitlist", "err", err)
+		return err
+	}
+
+	position, err := h.Repository.GetEventWaitlistPosition(ctx, repositories.GetEventWaitlistPositionParams{
+		EventID: event.ID,
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to get waitlist position", "err", err)
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Masuk daftar tunggu event: %s. Semoga kebagian tempat!", event.Name)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, userId)
+	if err != nil {
+		return err
+	}
+
+	err = h.NotificationService.Notify(ctx, int64(userId), services.NotificationEventWaitlisted,
+		"Masuk daftar tunggu",
+		fmt.Sprintf("Event %s sudah penuh. Kamu berada di urutan %d daftar tunggu.", event.Name, position),
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": models.ResponseEventRegistration{
+			Status:           "waitlisted",
+			WaitlistPosition: position,
 		},
 	})
 }
 
+func (h *EventHandler) handleCancelRegistration(c *fiber.Ctx) error {
+	eventId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take event id", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	event, err := h.Repository.GetEventById(ctx, int64(eventId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event does not exists")
+		}
+		slog.Error("Failed to get the event", "err", err)
+		return err
+	}
+
+	if event.Started {
+		return fiber.NewError(fiber.StatusBadRequest, "Event sudah dimulai, pendaftaran tidak bisa dibatalkan")
+	}
+
+	cancelled, err := h.Repository.CancelAttendance(ctx, repositories.CancelAttendanceParams{
+		EventID: event.ID,
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to cancel attendance", "err", err)
+		return err
+	}
+
+	status := "cancelled"
+	if cancelled == 0 {
+		left, err := h.Repository.DeleteEventWaitlist(ctx, repositories.DeleteEventWaitlistParams{
+			EventID: event.ID,
+			UserID:  int64(userId),
+		})
+		if err != nil {
+			slog.Error("Failed to leave waitlist", "err", err)
+			return err
+		}
+		if left == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Sepertinya anda belum terdaftar dalam event")
+		}
+		status = "waitlist_left"
+	}
+
+	logMsg := fmt.Sprintf("Membatalkan pendaftaran event: %s.", event.Name)
+	if status == "waitlist_left" {
+		logMsg = fmt.Sprintf("Keluar dari daftar tunggu event: %s.", event.Name)
+	}
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: true,
+	}, userId)
+	if err != nil {
+		return err
+	}
+
+	err = h.NotificationService.Notify(ctx, int64(userId), services.NotificationEventCancelled,
+		"Pendaftaran dibatalkan",
+		logMsg,
+	)
+	if err != nil {
+		return err
+	}
+
+	if status == "cancelled" {
+		err = h.fillWaitlist(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": models.ResponseEventRegistration{
+			Status: status,
+		},
+	})
+}
+
+// fillWaitlist promotes waiting users for as long as the event has free seats.
+// Once registration closes the waitlist stays as it is.
+func (h *EventHandler) fillWaitlist(ctx context.Context, event repositories.GetEventByIdRow) error {
+	if event.RegistrationClosed {
+		return nil
+	}
+
+	for {
+		promoted, err := h.promoteWaitlist(ctx, event)
+		if err != nil || !promoted {
+			return err
+		}
+	}
+}
+
+// FillOpenWaitlists fills the waitlists of every upcoming event that has free
+// seats again, which happens when an admin raises the capacity of a full
+// event. It runs as a scheduled job.
+func (h *EventHandler) FillOpenWaitlists(ctx context.Context) error {
+	eventIds, err := h.Repository.GetEventsWithPromotableWaitlist(ctx)
+	if err != nil {
+		slog.Error("Failed to get events with promotable waitlist", "err", err)
+		return err
+	}
+
+	for _, eventId := range eventIds {
+		event, err := h.Repository.GetEventById(ctx, eventId)
+		if err != nil {
+			slog.Error("Failed to get the event", "err", err)
+			return err
+		}
+
+		err = h.fillWaitlist(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promoteWaitlist gives a free seat to the user that has waited the longest,
+// tells them about it and reports whether the waitlist moved.
+func (h *EventHandler) promoteWaitlist(ctx context.Context, event repositories.GetEventByIdRow) (bool, error) {
+	entry, promoted, err := h.WaitlistService.PromoteNext(ctx, event.ID)
+	if err != nil || !promoted || entry.UserID == 0 {
+		return promoted, err
+	}
+
+	logMsg := fmt.Sprintf("Dapat tempat di event: %s dari daftar tunggu! Tunggu kabar saya!", event.Name)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, int(entry.UserID))
+	if err != nil {
+		return false, err
+	}
+
+	err = h.NotificationService.Notify(ctx, entry.UserID, services.NotificationEventPromoted,
+		"Kamu dapat tempat!",
+		fmt.Sprintf("Ada tempat kosong, kamu sekarang terdaftar di event %s.", event.Name),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// handleGetEvents lists events, filtered by status, date range, distance and
+// a text search over the name and description.
 func (h *EventHandler) handleGetEvents(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	req := &models.GetEvents{
+		Status:    c.Query("status"),
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+		Search:    strings.TrimSpace(c.Query("q")),
+		Latitude:  c.QueryFloat("latitude"),
+		Longitude: c.QueryFloat("longitude"),
+		Radius:    c.QueryFloat("radius"),
+		Sort:      c.Query("sort"),
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	hasLocation := c.Query("latitude") != "" && c.Query("longitude") != ""
+	if req.Sort == "distance" && !hasLocation {
+		return fiber.NewError(fiber.StatusBadRequest, "Urutan jarak membutuhkan latitude dan longitude")
+	}
+	if req.Radius == 0 {
+		req.Radius = helpers.NewConfig().GetFloat64("EVENT_SEARCH_RADIUS")
+	}
+
+	var dateFrom, dateTo pgtype.Timestamp
+	if req.From != "" {
+		from, _ := time.Parse("2006-01-02", req.From)
+		dateFrom = pgtype.Timestamp{Time: from, Valid: true}
+	}
+	if req.To != "" {
+		// the whole "to" day is included
+		to, _ := time.Parse("2006-01-02", req.To)
+		dateTo = pgtype.Timestamp{Time: to.AddDate(0, 0, 1), Valid: true}
+	}
+
+	search := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(req.Search)
+
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 50)
+
 	ctx := context.Background()
-	res, err := h.Repository.GetAllEvents(ctx)
+
+	res, err := h.Repository.SearchEvents(ctx, repositories.SearchEventsParams{
+		HasLocation: hasLocation,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Status:      req.Status,
+		DateFrom:    dateFrom,
+		DateTo:      dateTo,
+		Search:      search,
+		Radius:      req.Radius,
+		SortBy:      req.Sort,
+		PageLimit:   int32(limit),
+		PageOffset:  int32((page - 1) * limit),
+	})
 	if err != nil {
 		slog.Error("Failed to get events", "err", err)
 		return err
 	}
 
-	userId, err := helpers.GetSubjectFromToken(c)
+	total, err := h.Repository.CountSearchEvents(ctx, repositories.CountSearchEventsParams{
+		Status:      req.Status,
+		DateFrom:    dateFrom,
+		DateTo:      dateTo,
+		Search:      search,
+		HasLocation: hasLocation,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Radius:      req.Radius,
+	})
 	if err != nil {
+		slog.Error("Failed to count events", "err", err)
 		return err
 	}
 
@@ -150,16 +507,36 @@ func (h *EventHandler) handleGetEvents(c *fiber.Ctx) error {
 		return err
 	}
 
-	var events []models.ResponseEvent
+	waitlists, err := h.Repository.GetUserEventWaitlists(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get waitlists", "err", err)
+		return err
+	}
+
+	events := []models.ResponseEvent{}
 	for _, event := range res {
 		var participated bool = false
-		if len(attendances) > 0 {
-			for _, p := range attendances {
-				if p.EventID == event.ID {
-					participated = true
-				}
+		for _, p := range attendances {
+			if p.EventID == event.ID {
+				participated = true
 			}
 		}
+		var waitlisted bool = false
+		for _, w := range waitlists {
+			if w.EventID == event.ID {
+				waitlisted = true
+			}
+		}
+		var remainingSeats *int
+		if event.MaxAttendees.Valid {
+			remaining := max(int(event.MaxAttendees.Int32-event.AttendeeCount), 0)
+			remainingSeats = &remaining
+		}
+		var distance *float64
+		if event.DistanceMeters.Valid {
+			rounded := math.Round(event.DistanceMeters.Float64)
+			distance = &rounded
+		}
 		events = append(events, models.ResponseEvent{
 			ID:           event.ID,
 			Name:         event.DetailName,
@@ -174,17 +551,37 @@ func (h *EventHandler) handleGetEvents(c *fiber.Ctx) error {
 			Contact:      event.Contact,
 			IsEnded:      event.Ended,
 			Participated: participated,
-			CoverUrl:     "https://raksana-admin.s3.ap-southeast-2.amazonaws.com/" + event.CoverKey.String,
+			Waitlisted:   waitlisted,
+			CoverUrl:     coverUrl(event.CoverKey),
+
+			MaxAttendees:         event.MaxAttendees.Int32,
+			RemainingSeats:       remainingSeats,
+			RegistrationClosesAt: event.RegistrationClosesAt.Time.Format("2006-01-02 15:04"),
+			RegistrationClosed:   event.RegistrationClosed,
+			DistanceMeters:       distance,
+			RatingAverage:        math.Round(event.RatingAverage*10) / 10,
+			RatingCount:          event.RatingCount,
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": fiber.Map{
 			"events": events,
+			"page":   page,
+			"limit":  limit,
+			"total":  total,
 		},
 	})
 }
 
+// coverUrl builds the public URL of an event cover from the configured bucket URL.
+func coverUrl(key pgtype.Text) string {
+	if !key.Valid || key.String == "" {
+		return ""
+	}
+	return helpers.NewConfig().GetString("AWS_URL") + key.String
+}
+
 func (h *EventHandler) handleGetAllPendingAttendance(c *fiber.Ctx) error {
 	userId, err := helpers.GetSubjectFromToken(c)
 	if err != nil {
@@ -269,13 +666,70 @@ func (h *EventHandler) handleAttend(c *fiber.Ctx) error {
 		return err
 	}
 
-	err = h.Repository.Attend(ctx, attendance.AttendanceID)
+	_, err = h.checkInWindow(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	err = h.completeAttendance(ctx, userId, attendance.AttendanceID, event.Name, event.PointGain)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": fiber.Map{
+			"message": "success",
+			"type":    "event",
+			"event": fiber.Map{
+				"name":        event.Name,
+				"description": event.Description,
+				"point_gain":  event.PointGain,
+			},
+		},
+	})
+}
+
+// checkInWindow rejects attendance outside of EVENT_CHECKIN_OPEN_MINUTES before
+// the event starts until EVENT_CHECKIN_CLOSE_MINUTES after it ends.
+func (h *EventHandler) checkInWindow(ctx context.Context, eventId int64) (repositories.GetEventCheckInWindowRow, error) {
+	cnf := helpers.NewConfig()
+
+	window, err := h.Repository.GetEventCheckInWindow(ctx, repositories.GetEventCheckInWindowParams{
+		OpenMinutes:  cnf.GetInt32("EVENT_CHECKIN_OPEN_MINUTES"),
+		CloseMinutes: cnf.GetInt32("EVENT_CHECKIN_CLOSE_MINUTES"),
+		EventID:      eventId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return window, fiber.NewError(fiber.StatusBadRequest, "Event not found")
+		}
+		slog.Error("Failed to get check-in window", "err", err)
+		return window, err
+	}
+
+	if window.NotOpenYet {
+		return window, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Check-in baru dibuka pada %s", window.OpensAt.Time.Format("2006-01-02 15:04")))
+	}
+	if window.Closed {
+		return window, fiber.NewError(fiber.StatusBadRequest, "Check-in event ini sudah ditutup")
+	}
+
+	return window, nil
+}
+
+// completeAttendance marks the attendance as attended and rewards the attendee,
+// whether they scanned the event QR code or an organizer scanned their ticket.
+func (h *EventHandler) completeAttendance(ctx context.Context, userId int, attendanceId int64, eventName string, pointGain int64) error {
+	checkedIn, err := h.Repository.CheckInAttendance(ctx, attendanceId)
 	if err != nil {
 		slog.Error("Failed to finish the attend", "err", err)
 		return err
 	}
+	if checkedIn == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Kehadiran di event ini sudah tercatat")
+	}
 
-	logMsg := fmt.Sprintf("Baru saja menghadiri event: %s!", event.Name)
+	logMsg := fmt.Sprintf("Baru saja menghadiri event: %s!", eventName)
 	err = h.JournalService.AppendLog(&models.PostLogAppend{
 		Text:      logMsg,
 		IsSystem:  true,
@@ -291,42 +745,529 @@ func (h *EventHandler) handleAttend(c *fiber.Ctx) error {
 		return err
 	}
 
-	historyMsg := fmt.Sprintf("Mendapat poin event: %s", event.Name)
-	_, err = h.PointService.UpdateUserPoint(int64(userId), event.PointGain, historyMsg, "event", int(profile.Level))
+	historyMsg := fmt.Sprintf("Mendapat poin event: %s", eventName)
+	_, err = h.PointService.UpdateUserPoint(int64(userId), pointGain, historyMsg, "event", int(profile.Level))
 	if err != nil {
 		return err
 	}
 
-	err = h.Repository.UpdaAttendedAt(ctx, attendance.AttendanceID)
+	err = h.StreakService.UpdateStreak(ctx, int64(userId))
 	if err != nil {
-		slog.Error("Failed to update attended_at", "err", err)
 		return err
 	}
 
-	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	_, err = h.Repository.IncreaseEventStatistics(ctx, repositories.IncreaseEventStatisticsParams{
+		AttendanceID: attendanceId,
+		UserID:       int64(userId),
+	})
 	if err != nil {
+		slog.Error("Failed to increase", "er", err)
 		return err
 	}
 
-	_, err = h.Repository.IncreaseEventsFieldByOne(ctx, int64(userId))
+	return nil
+}
+
+func (h *EventHandler) handleGetTicket(c *fiber.Ctx) error {
+	attendanceId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take attendance id", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	attendance, err := h.Repository.GetAttendanceCheckIn(ctx, int64(attendanceId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "attendance tidak ditemukan")
+		}
+		slog.Error("Failed to get attendance", "err", err)
+		return err
+	}
+
+	if attendance.UserID != int64(userId) {
+		return fiber.NewError(fiber.StatusNotFound, "attendance tidak ditemukan")
+	}
+	if attendance.Attended {
+		return fiber.NewError(fiber.StatusBadRequest, "Kehadiran di event ini sudah tercatat")
+	}
+
+	cnf := helpers.NewConfig()
+	window, err := h.Repository.GetEventCheckInWindow(ctx, repositories.GetEventCheckInWindowParams{
+		OpenMinutes:  cnf.GetInt32("EVENT_CHECKIN_OPEN_MINUTES"),
+		CloseMinutes: cnf.GetInt32("EVENT_CHECKIN_CLOSE_MINUTES"),
+		EventID:      attendance.EventID,
+	})
+	if err != nil {
+		slog.Error("Failed to get check-in window", "err", err)
+		return err
+	}
+	if window.Closed {
+		return fiber.NewError(fiber.StatusBadRequest, "Check-in event ini sudah ditutup")
+	}
+
+	token, err := helpers.GenerateTicketToken(attendance.AttendanceID, attendance.EventID, window.ClosesAtTz.Time)
+	if err != nil {
+		slog.Error("Failed to sign ticket", "err", err)
+		return err
+	}
+
+	image, err := qrcode.Encode(token, qrcode.Medium, cnf.GetInt("ACTIVITY_QR_SIZE"))
+	if err != nil {
+		slog.Error("Failed to encode QR code", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": models.ResponseEventTicket{
+			AttendanceID: attendance.AttendanceID,
+			EventName:    attendance.Name,
+			Token:        token,
+			QRCode:       "data:image/png;base64," + base64.StdEncoding.EncodeToString(image),
+			OpensAt:      window.OpensAt.Time.Format("2006-01-02 15:04"),
+			ClosesAt:     window.ClosesAt.Time.Format("2006-01-02 15:04"),
+		},
+	})
+}
+
+func (h *EventHandler) handleOrganizerCheckIn(c *fiber.Ctx) error {
+	req := &models.RequestTicketCheckIn{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	organizerId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	claims, err := helpers.ValidateTicketToken(req.Ticket)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Tiket tidak valid")
+	}
+
+	ctx := context.Background()
+
+	attendance, err := h.Repository.GetAttendanceCheckIn(ctx, claims.AttendanceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the registration was cancelled after the ticket was issued
+			return fiber.NewError(fiber.StatusBadRequest, "Tiket tidak valid")
+		}
+		slog.Error("Failed to get attendance", "err", err)
+		return err
+	}
+
+	canCheckIn, err := h.Repository.CanCheckInEvent(ctx, repositories.CanCheckInEventParams{
+		EventID: attendance.EventID,
+		UserID:  int64(organizerId),
+	})
+	if err != nil {
+		slog.Error("Failed to check event organizer", "err", err)
+		return err
+	}
+	if !canCheckIn {
+		return fiber.NewError(fiber.StatusForbidden, "Hanya penyelenggara event yang dapat memindai tiket")
+	}
+
+	_, err = h.checkInWindow(ctx, attendance.EventID)
+	if err != nil {
+		return err
+	}
+
+	err = h.completeAttendance(ctx, int(attendance.UserID), attendance.AttendanceID, attendance.Name, attendance.PointGain)
 	if err != nil {
-		slog.Error("Failed to increase", "er", err)
 		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"data": fiber.Map{
-			"message": "success",
-			"type":    "event",
+			"message":       "success",
+			"attendance_id": attendance.AttendanceID,
+			"user_id":       attendance.UserID,
+			"username":      attendance.Username,
 			"event": fiber.Map{
-				"name":        event.Name,
-				"description": event.Description,
-				"point_gain":  event.PointGain,
+				"id":   attendance.EventID,
+				"name": attendance.Name,
 			},
 		},
 	})
 }
 
+func (h *EventHandler) handleGetEventICal(c *fiber.Ctx) error {
+	eventId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take event id", "err", err)
+		return err
+	}
+
+	file, err := h.CalendarService.EventFile(context.Background(), int64(eventId))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="event-%d.ics"`, eventId))
+	return c.Status(fiber.StatusOK).Send(file)
+}
+
+// handleCreateFeedback lets a user who attended the event rate it once, with
+// an optional comment and photo. Feedback earns EVENT_FEEDBACK_POINT_GAIN points.
+func (h *EventHandler) handleCreateFeedback(c *fiber.Ctx) error {
+	req := &models.PostEventFeedback{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	eventId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take event id", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	event, err := h.Repository.GetEventById(ctx, int64(eventId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event does not exists")
+		}
+		slog.Error("Failed to get the event", "err", err)
+		return err
+	}
+
+	attendance, err := h.Repository.GetUserAttendanceByUserId(ctx, repositories.GetUserAttendanceByUserIdParams{
+		UserID: int64(userId),
+		ID:     event.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Sepertinya anda belum terdaftar dalam event")
+		}
+		slog.Error("Failed to get the attendance", "err", err)
+		return err
+	}
+	if !attendance.Attended {
+		return fiber.NewError(fiber.StatusForbidden, "Hanya peserta yang hadir yang dapat memberi ulasan")
+	}
+
+	reviewed, err := h.Repository.HasEventFeedback(ctx, repositories.HasEventFeedbackParams{
+		EventID: event.ID,
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to check feedback", "err", err)
+		return err
+	}
+	if reviewed {
+		return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah memberi ulasan untuk event ini")
+	}
+
+	var photoKey pgtype.Text
+	file, err := c.FormFile("image")
+	if err == nil {
+		image, err := h.MediaService.UploadImage(ctx, file, "feedbacks", userId)
+		if err != nil {
+			return err
+		}
+		photoKey = pgtype.Text{String: image.Key, Valid: true}
+	}
+
+	feedback, err := h.Repository.CreateEventFeedback(ctx, repositories.CreateEventFeedbackParams{
+		EventID:  event.ID,
+		UserID:   int64(userId),
+		Rating:   int16(req.Rating),
+		Comment:  strings.TrimSpace(req.Comment),
+		PhotoKey: photoKey,
+	})
+	if err != nil {
+		if photoKey.Valid {
+			if deleteErr := h.MediaService.DeleteImage(photoKey.String); deleteErr != nil {
+				slog.Error("Failed to delete feedback photo", "err", deleteErr)
+			}
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah memberi ulasan untuk event ini")
+		}
+		slog.Error("Failed to create feedback", "err", err)
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Memberi ulasan %d bintang untuk event: %s", feedback.Rating, event.Name)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, userId)
+	if err != nil {
+		return err
+	}
+
+	pointGain := helpers.NewConfig().GetInt64("EVENT_FEEDBACK_POINT_GAIN")
+	if pointGain > 0 {
+		profile, err := h.Repository.GetUserProfile(ctx, int64(userId))
+		if err != nil {
+			slog.Error("Failed to get user profile", "err", err)
+			return err
+		}
+
+		historyMsg := fmt.Sprintf("Bonus ulasan event: %s", event.Name)
+		_, err = h.PointService.UpdateUserPoint(int64(userId), pointGain, historyMsg, "event", int(profile.Level))
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": fiber.Map{
+			"feedback":   toResponseEventFeedback(feedback.ID, feedback.Rating, feedback.Comment, feedback.PhotoKey, feedback.CreatedAt),
+			"point_gain": max(pointGain, 0),
+		},
+	})
+}
+
+// handleGetFeedbackReport summarizes the ratings of an event for its
+// organizers and admins.
+func (h *EventHandler) handleGetFeedbackReport(c *fiber.Ctx) error {
+	eventId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take event id", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	canView, err := h.Repository.CanCheckInEvent(ctx, repositories.CanCheckInEventParams{
+		EventID: int64(eventId),
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to check event organizer", "err", err)
+		return err
+	}
+	if !canView {
+		return fiber.NewError(fiber.StatusForbidden, "Hanya penyelenggara event yang dapat melihat ulasan")
+	}
+
+	summary, err := h.Repository.GetEventFeedbackSummary(ctx, int64(eventId))
+	if err != nil {
+		slog.Error("Failed to get feedback summary", "err", err)
+		return err
+	}
+
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 50)
+
+	rows, err := h.Repository.GetEventFeedbacks(ctx, repositories.GetEventFeedbacksParams{
+		EventID: int64(eventId),
+		Limit:   int32(limit),
+		Offset:  int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get feedbacks", "err", err)
+		return err
+	}
+
+	feedbacks := []models.ResponseEventFeedback{}
+	for _, row := range rows {
+		feedback := toResponseEventFeedback(row.ID, row.Rating, row.Comment, row.PhotoKey, row.CreatedAt)
+		feedback.UserID = row.UserID
+		feedback.Username = row.Username
+		feedbacks = append(feedbacks, feedback)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"summary": models.ResponseEventFeedbackSummary{
+				Total:   summary.Total,
+				Average: math.Round(summary.Average*10) / 10,
+				Distribution: map[int]int64{
+					1: summary.OneStar,
+					2: summary.TwoStars,
+					3: summary.ThreeStars,
+					4: summary.FourStars,
+					5: summary.FiveStars,
+				},
+			},
+			"feedbacks": feedbacks,
+			"page":      page,
+			"limit":     limit,
+		},
+	})
+}
+
+// handleExportAttendees hands the registrants of an event with their contact
+// number and attendance status to its organizers and admins. Every export is
+// written to the audit log since it contains personal data.
+func (h *EventHandler) handleExportAttendees(c *fiber.Ctx) error {
+	eventId, err := c.ParamsInt("id")
+	if err != nil {
+		slog.Error("Failed to take event id", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" {
+		return fiber.NewError(fiber.StatusBadRequest, "Format harus csv atau json")
+	}
+
+	ctx := context.Background()
+
+	canExport, err := h.Repository.CanCheckInEvent(ctx, repositories.CanCheckInEventParams{
+		EventID: int64(eventId),
+		UserID:  int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to check event organizer", "err", err)
+		return err
+	}
+	if !canExport {
+		return fiber.NewError(fiber.StatusForbidden, "Hanya penyelenggara event yang dapat mengunduh daftar peserta")
+	}
+
+	_, err = h.Repository.GetEventById(ctx, int64(eventId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Event tidak ditemukan")
+		}
+		slog.Error("Failed to get event", "err", err)
+		return err
+	}
+
+	rows, err := h.Repository.GetEventAttendees(ctx, int64(eventId))
+	if err != nil {
+		slog.Error("Failed to get event attendees", "err", err)
+		return err
+	}
+
+	attendees := []models.ResponseEventAttendee{}
+	for _, row := range rows {
+		attendee := models.ResponseEventAttendee{
+			AttendanceID:  row.ID,
+			UserID:        row.UserID,
+			Name:          row.Name,
+			Username:      row.Username,
+			ContactNumber: row.ContactNumber,
+			Status:        "registered",
+			RegisteredAt:  row.RegisteredAt.Time.Format("2006-01-02 15:04"),
+		}
+		if row.Attended {
+			attendee.Status = "attended"
+		}
+		if row.AttendedAt.Valid {
+			attendee.AttendedAt = row.AttendedAt.Time.Format("2006-01-02 15:04")
+		}
+		attendees = append(attendees, attendee)
+	}
+
+	err = h.AuditService.RecordAudit(ctx, int64(userId), services.AuditEventAttendeesExport, "event", int64(eventId), c.IP(),
+		fmt.Sprintf("format=%s rows=%d", format, len(attendees)),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if format == "csv" {
+		content, err := attendeesCSV(attendees)
+		if err != nil {
+			slog.Error("Failed to write attendees csv", "err", err)
+			return err
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="event-%d-attendees.csv"`, eventId))
+		return c.Status(fiber.StatusOK).Send(content)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"attendees": attendees,
+			"total":     len(attendees),
+		},
+	})
+}
+
+func attendeesCSV(attendees []models.ResponseEventAttendee) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.Write([]string{"attendance_id", "user_id", "name", "username", "contact_number", "status", "registered_at", "attended_at"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range attendees {
+		err = w.Write([]string{
+			strconv.FormatInt(a.AttendanceID, 10),
+			strconv.FormatInt(a.UserID, 10),
+			helpers.CSVCell(a.Name),
+			helpers.CSVCell(a.Username),
+			helpers.CSVCell(a.ContactNumber),
+			a.Status,
+			a.RegisteredAt,
+			a.AttendedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func toResponseEventFeedback(id int64, rating int16, comment string, photoKey pgtype.Text, createdAt pgtype.Timestamp) models.ResponseEventFeedback {
+	feedback := models.ResponseEventFeedback{
+		ID:        id,
+		Rating:    int(rating),
+		Comment:   comment,
+		CreatedAt: createdAt.Time.Format("2006-01-02 15:04"),
+	}
+	if photoKey.Valid {
+		feedback.PhotoUrl = helpers.NewConfig().GetString("AWS_URL") + photoKey.String
+	}
+	return feedback
+}
+
 func (h *EventHandler) handleGetAttendanceDetail(c *fiber.Ctx) error {
 	attendanceId, err := c.ParamsInt("id")
 	if err != nil {
@@ -343,8 +1284,6 @@ func (h *EventHandler) handleGetAttendanceDetail(c *fiber.Ctx) error {
 		return err
 	}
 
-	bucketUrl := "https://raksana-admin.s3.ap-southeast-2.amazonaws.com/"
-
 	var resAttendance = models.ResponseAttendance{
 		ID:                attendance.AttendanceID,
 		RegisteredAt:      attendance.CreatedAt.Time.Format("2006-01-02 15:04"),
@@ -354,7 +1293,7 @@ func (h *EventHandler) handleGetAttendanceDetail(c *fiber.Ctx) error {
 		Longitude:         attendance.Longitude,
 		StartsAt:          attendance.StartsAt.Time.Format("2006-01-02 15:04"),
 		EndsAt:            attendance.EndsAt.Time.Format("2006-01-02 15:04"),
-		CoverUrl:          bucketUrl + attendance.CoverKey.String,
+		CoverUrl:          coverUrl(attendance.CoverKey),
 		ContactNumber:     attendance.ContactNumber,
 		DetailName:        attendance.DetailName,
 		DetailDescription: attendance.DetailDescription,
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type GeofenceHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+}
+
+func NewGeofenceHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+) *GeofenceHandler {
+	return &GeofenceHandler{
+		Validator:  v,
+		Repository: r,
+	}
+}
+
+func (h *GeofenceHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/geofence")
+	g.Use(helpers.TokenMiddleware)
+	g.Use(helpers.AdminMiddleware(h.Repository))
+	g.Get("/scans", h.handleGetActivityScans)
+	g.Post("/override", h.handleCreateOverride)
+}
+
+func (h *GeofenceHandler) handleGetActivityScans(c *fiber.Ctx) error {
+	statuses := strings.Split(c.Query("status", services.ScanFlagged+","+services.ScanRejected), ",")
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 100)
+
+	rows, err := h.Repository.GetActivityScansByStatus(context.Background(), repositories.GetActivityScansByStatusParams{
+		Statuses:   statuses,
+		PageLimit:  int32(limit),
+		PageOffset: int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get activity scans", "err", err)
+		return err
+	}
+
+	scans := []models.ResponseActivityScan{}
+	for _, row := range rows {
+		scan := models.ResponseActivityScan{
+			ID:           row.ID,
+			UserID:       row.UserID,
+			ActivityType: row.ActivityType,
+			ActivityID:   row.ActivityID,
+			Status:       row.Status,
+			CreatedAt:    row.CreatedAt.Time.Format("2006-01-02 15:04"),
+		}
+		if row.Latitude.Valid && row.Longitude.Valid {
+			scan.Latitude = &row.Latitude.Float64
+			scan.Longitude = &row.Longitude.Float64
+		}
+		if row.DistanceMeters.Valid {
+			scan.DistanceMeters = &row.DistanceMeters.Float64
+		}
+
+		scans = append(scans, scan)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"scans": scans,
+			"page":  page,
+			"limit": limit,
+		},
+	})
+}
+
+func (h *GeofenceHandler) handleCreateOverride(c *fiber.Ctx) error {
+	req := &models.PostGeofenceOverride{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	adminId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.Repository.CreateGeofenceOverride(context.Background(), repositories.CreateGeofenceOverrideParams{
+		UserID:       req.UserID,
+		ActivityType: req.ActivityType,
+		ActivityID:   req.ActivityID,
+		Reason:       pgtype.Text{String: req.Reason, Valid: req.Reason != ""},
+		CreatedBy:    pgtype.Int8{Int64: int64(adminId), Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusBadRequest, "User tidak ditemukan")
+		}
+		slog.Error("Failed to create geofence override", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": fiber.Map{
+			"message": "success",
+		},
+	})
+}
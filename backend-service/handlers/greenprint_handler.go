@@ -0,0 +1,538 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+type GreenprintHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+	*services.GreenprintService
+}
+
+func NewGreenprintHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+	gs *services.GreenprintService,
+) *GreenprintHandler {
+	return &GreenprintHandler{
+		Validator:         v,
+		Repository:        r,
+		GreenprintService: gs,
+	}
+}
+
+func (h *GreenprintHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/greenprint")
+	g.Use(helpers.TokenMiddleware)
+	g.Get("/catalog", h.handleSearchCatalog)
+	g.Get("/catalog/:id", h.handleGetCatalogGreenprint)
+	g.Post("/:id/use", h.handleUseGreenprint)
+	g.Post("/:id/rate", h.handleRateGreenprint)
+	g.Put("/:id/visibility", h.handleUpdateVisibility)
+	g.Get("/:id/cost", h.handleGetCost)
+	g.Put("/:id/owned", h.handleUpdateOwned)
+	g.Get("/:id/shopping-list", h.handleExportShoppingList)
+}
+
+func (h *GreenprintHandler) handleSearchCatalog(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 50)
+
+	rows, err := h.Repository.SearchGreenprintCatalog(context.Background(), repositories.SearchGreenprintCatalogParams{
+		Query:      query,
+		PageLimit:  int32(limit),
+		PageOffset: int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to search greenprint catalog", "err", err)
+		return err
+	}
+
+	greenprints := []models.ResponseCatalogGreenprint{}
+	for _, row := range rows {
+		greenprints = append(greenprints, models.ResponseCatalogGreenprint{
+			ID:                  row.ID,
+			Title:               row.Title,
+			Description:         row.Description,
+			ItemName:            row.ItemName,
+			SustainabilityScore: row.SustainabilityScore,
+			EstimatedTime:       row.EstimatedTime,
+			Rating:              row.Rating,
+			RatingCount:         row.RatingCount,
+			Uses:                row.Uses,
+			CreatedAt:           row.CreatedAt.Time.Format("2006-01-02 15:04"),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"greenprints": greenprints,
+			"page":        page,
+			"limit":       limit,
+		},
+	})
+}
+
+func (h *GreenprintHandler) handleGetCatalogGreenprint(c *fiber.Ctx) error {
+	ctx := context.Background()
+
+	gp, err := h.getCatalogGreenprint(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.GreenprintService.GetGreenprintDetail(ctx, gp)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *GreenprintHandler) handleUseGreenprint(c *fiber.Ctx) error {
+	req := &models.PostGreenprintUse{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	source, err := h.getCatalogGreenprint(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	item, err := h.Repository.GetItemsById(ctx, req.ItemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Item not found")
+		}
+		slog.Error("Failed to get item by id", "err", err)
+		return err
+	}
+
+	if item.UserID != int64(userId) {
+		return fiber.NewError(fiber.StatusBadRequest, "Item not found")
+	}
+
+	_, err = h.Repository.GetGreenprints(ctx, item.ID)
+	if err == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Item ini sudah memiliki greenprint")
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	gp, err := h.GreenprintService.CopyGreenprint(ctx, source.ID, item.ID)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.GreenprintService.GetGreenprintDetail(ctx, gp)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *GreenprintHandler) handleRateGreenprint(c *fiber.Ctx) error {
+	req := &models.PostGreenprintRate{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Greenprint id tidak valid")
+	}
+
+	ctx := context.Background()
+
+	gp, err := h.Repository.GetGreenprintsById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+		}
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	// ratings always go to the original, whichever copy the user rates
+	rootId := h.GreenprintService.RootId(gp)
+
+	used, err := h.Repository.HasUsedGreenprint(ctx, repositories.HasUsedGreenprintParams{
+		UserID:       int64(userId),
+		GreenprintID: rootId,
+	})
+	if err != nil {
+		slog.Error("Failed to check greenprint usage", "err", err)
+		return err
+	}
+
+	if !used {
+		return fiber.NewError(fiber.StatusBadRequest, "Gunakan greenprint ini terlebih dahulu sebelum memberi rating")
+	}
+
+	err = h.Repository.UpsertGreenprintRating(ctx, repositories.UpsertGreenprintRatingParams{
+		GreenprintID: rootId,
+		UserID:       int64(userId),
+		Rating:       int32(req.Rating),
+	})
+	if err != nil {
+		slog.Error("Failed to rate greenprint", "err", err)
+		return err
+	}
+
+	rating, err := h.Repository.GetGreenprintRating(ctx, rootId)
+	if err != nil {
+		slog.Error("Failed to get greenprint rating", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"greenprint_id": rootId,
+			"rating":        rating.Rating,
+			"rating_count":  rating.RatingCount,
+		},
+	})
+}
+
+func (h *GreenprintHandler) handleUpdateVisibility(c *fiber.Ctx) error {
+	req := &models.PutGreenprintVisibility{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Greenprint id tidak valid")
+	}
+
+	ctx := context.Background()
+
+	gp, err := h.Repository.GetGreenprintsById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+		}
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	item, err := h.Repository.GetItemsById(ctx, gp.ItemID)
+	if err != nil {
+		slog.Error("Failed to get item by id", "err", err)
+		return err
+	}
+
+	if item.UserID != int64(userId) {
+		return fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+	}
+
+	if gp.SourceID.Valid {
+		return fiber.NewError(fiber.StatusBadRequest, "Greenprint salinan tidak dapat dipublikasikan")
+	}
+
+	err = h.Repository.UpdateGreenprintVisibility(ctx, repositories.UpdateGreenprintVisibilityParams{
+		IsPublic: *req.IsPublic,
+		ID:       gp.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to update greenprint visibility", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"greenprint_id": gp.ID,
+			"is_public":     *req.IsPublic,
+		},
+	})
+}
+
+func (h *GreenprintHandler) handleGetCost(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	gp, err := h.getViewableGreenprint(ctx, c, userId)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.GreenprintService.GetCostEstimate(ctx, gp, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *GreenprintHandler) handleUpdateOwned(c *fiber.Ctx) error {
+	req := &models.PutGreenprintOwned{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	gp, err := h.getViewableGreenprint(ctx, c, userId)
+	if err != nil {
+		return err
+	}
+
+	materials, err := h.Repository.GetMaterials(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get materials", "err", err)
+		return err
+	}
+
+	tools, err := h.Repository.GetTools(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get tools", "err", err)
+		return err
+	}
+
+	for _, id := range req.MaterialIDs {
+		if !slices.ContainsFunc(materials, func(m repositories.Material) bool { return m.ID == id }) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Material %d tidak ada di greenprint ini", id))
+		}
+	}
+
+	for _, id := range req.ToolIDs {
+		if !slices.ContainsFunc(tools, func(t repositories.Tool) bool { return t.ID == id }) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Alat %d tidak ada di greenprint ini", id))
+		}
+	}
+
+	// the request holds the full list, anything left out is no longer owned
+	err = h.Repository.DeleteOwnedMaterials(ctx, repositories.DeleteOwnedMaterialsParams{
+		UserID:       int64(userId),
+		GreenprintID: gp.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to delete owned materials", "err", err)
+		return err
+	}
+
+	err = h.Repository.DeleteOwnedTools(ctx, repositories.DeleteOwnedToolsParams{
+		UserID:       int64(userId),
+		GreenprintID: gp.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to delete owned tools", "err", err)
+		return err
+	}
+
+	for _, id := range req.MaterialIDs {
+		err = h.Repository.CreateOwnedMaterial(ctx, repositories.CreateOwnedMaterialParams{
+			UserID:     int64(userId),
+			MaterialID: id,
+		})
+		if err != nil {
+			slog.Error("Failed to create owned material", "err", err)
+			return err
+		}
+	}
+
+	for _, id := range req.ToolIDs {
+		err = h.Repository.CreateOwnedTool(ctx, repositories.CreateOwnedToolParams{
+			UserID: int64(userId),
+			ToolID: id,
+		})
+		if err != nil {
+			slog.Error("Failed to create owned tool", "err", err)
+			return err
+		}
+	}
+
+	res, err := h.GreenprintService.GetCostEstimate(ctx, gp, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *GreenprintHandler) handleExportShoppingList(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	gp, err := h.getViewableGreenprint(ctx, c, userId)
+	if err != nil {
+		return err
+	}
+
+	cost, err := h.GreenprintService.GetCostEstimate(ctx, gp, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	list := h.GreenprintService.GetShoppingList(cost)
+	filename := fmt.Sprintf("greenprint-%d", gp.ID)
+
+	switch c.Query("format", "json") {
+	case "text":
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.txt"`, filename))
+		return c.Status(fiber.StatusOK).SendString(h.GreenprintService.ShoppingListText(list))
+	case "csv":
+		content, err := h.GreenprintService.ShoppingListCSV(list)
+		if err != nil {
+			slog.Error("Failed to write shopping list csv", "err", err)
+			return err
+		}
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		return c.Status(fiber.StatusOK).Send(content)
+	case "json":
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"data": list,
+		})
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Format harus text, csv atau json")
+	}
+}
+
+// getViewableGreenprint loads the greenprint from the :id param when it is
+// either the user's own or part of the public catalog.
+func (h *GreenprintHandler) getViewableGreenprint(ctx context.Context, c *fiber.Ctx, userId int) (repositories.Greenprint, error) {
+	var gp repositories.Greenprint
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint id tidak valid")
+	}
+
+	gp, err = h.Repository.GetGreenprintsById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+		}
+		slog.Error("Failed to get greenprint", "err", err)
+		return gp, err
+	}
+
+	if gp.IsPublic && !gp.SourceID.Valid {
+		return gp, nil
+	}
+
+	item, err := h.Repository.GetItemsById(ctx, gp.ItemID)
+	if err != nil {
+		slog.Error("Failed to get item by id", "err", err)
+		return gp, err
+	}
+
+	if item.UserID != int64(userId) {
+		return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+	}
+
+	return gp, nil
+}
+
+// getCatalogGreenprint loads the greenprint from the :id param, only public
+// originals are part of the catalog.
+func (h *GreenprintHandler) getCatalogGreenprint(ctx context.Context, c *fiber.Ctx) (repositories.Greenprint, error) {
+	var gp repositories.Greenprint
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint id tidak valid")
+	}
+
+	gp, err = h.Repository.GetGreenprintsById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+		}
+		slog.Error("Failed to get greenprint", "err", err)
+		return gp, err
+	}
+
+	if !gp.IsPublic || gp.SourceID.Valid {
+		return gp, fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+	}
+
+	return gp, nil
+}
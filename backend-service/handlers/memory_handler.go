@@ -23,6 +23,7 @@ type MemoryHandler struct {
 	*services.MemoryService
 	*services.FileService
 	*configs.AWSClient
+	*services.MediaService
 	*services.StreakService
 }
 
@@ -31,6 +32,7 @@ func NewMemoryHandler(
 	r *repositories.Queries,
 	ms *services.MemoryService,
 	fs *services.FileService,
+	mds *services.MediaService,
 	ss *services.StreakService,
 	a *configs.AWSClient,
 ) *MemoryHandler {
@@ -40,6 +42,7 @@ func NewMemoryHandler(
 		AWSClient:     a,
 		MemoryService: ms,
 		FileService:   fs,
+		MediaService:  mds,
 		StreakService: ss,
 	}
 }
@@ -48,6 +51,7 @@ func (h *MemoryHandler) RegisterRoutes(router fiber.Router) {
 	g := router.Group("/memory")
 	g.Use(helpers.TokenMiddleware)
 	g.Post("/", h.handleCreateMemory)
+	g.Post("/upload", h.handleUploadMemory)
 	g.Get("/me", h.handleGetMemories)
 	g.Get("/:id", h.handleGetMemoriesByUserId)
 	g.Delete("/:id", h.handleDeleteMemory)
@@ -95,6 +99,60 @@ func (h *MemoryHandler) handleCreateMemory(c *fiber.Ctx) error {
 	})
 }
 
+func (h *MemoryHandler) handleUploadMemory(c *fiber.Ctx) error {
+	req := &models.PostMemoryUpload{}
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err.Error())
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		slog.Error("Failed to take image", "err", err)
+		return fiber.NewError(fiber.StatusBadRequest, "The image field is required")
+	}
+
+	ctx := context.Background()
+
+	image, err := h.MediaService.UploadImage(ctx, file, "memories", userId)
+	if err != nil {
+		return err
+	}
+
+	memoryId, err := h.MemoryService.CreateImageMemory(req.Description, image, userId)
+	if err != nil {
+		_ = h.MediaService.DeleteImage(image.Key)
+		return err
+	}
+
+	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	cnf := helpers.NewConfig()
+	bucketUrl := cnf.GetString("AWS_URL")
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": fiber.Map{
+			"memory_id":     memoryId,
+			"file_url":      bucketUrl + image.Key,
+			"thumbnail_url": bucketUrl + image.ThumbnailKey,
+		},
+	})
+}
+
 func (h *MemoryHandler) handleGetMemories(c *fiber.Ctx) error {
 	userId, err := helpers.GetSubjectFromToken(c)
 	if err != nil {
@@ -170,7 +228,7 @@ func (h *MemoryHandler) handleDeleteMemory(c *fiber.Ctx) error {
 
 	ctx := context.Background()
 
-	key, err := h.Repository.DeleteMemory(ctx, repositories.DeleteMemoryParams{
+	memory, err := h.Repository.DeleteMemory(ctx, repositories.DeleteMemoryParams{
 		UserID: int64(userId),
 		ID:     int64(memoryId),
 	})
@@ -182,10 +240,12 @@ func (h *MemoryHandler) handleDeleteMemory(c *fiber.Ctx) error {
 		return err
 	}
 
-	cnf := helpers.NewConfig()
-	bucketName := cnf.GetString("AWS_BUCKET")
-
-	err = h.AWSClient.DeleteObject(bucketName, key)
+	if memory.ThumbnailKey.Valid {
+		err = h.MediaService.DeleteImage(memory.FileKey)
+	} else {
+		cnf := helpers.NewConfig()
+		err = h.AWSClient.DeleteObject(cnf.GetString("AWS_BUCKET"), memory.FileKey)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	Repository *repositories.Queries
+}
+
+func NewNotificationHandler(
+	r *repositories.Queries,
+) *NotificationHandler {
+	return &NotificationHandler{
+		Repository: r,
+	}
+}
+
+func (h *NotificationHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/notification")
+	g.Use(helpers.TokenMiddleware)
+	g.Get("/", h.handleGetNotifications)
+	g.Post("/read", h.handleReadAllNotifications)
+	g.Post("/:id/read", h.handleReadNotification)
+}
+
+func (h *NotificationHandler) handleGetNotifications(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 20), 1), 50)
+	ctx := context.Background()
+
+	rows, err := h.Repository.GetUserNotifications(ctx, repositories.GetUserNotificationsParams{
+		UserID: int64(userId),
+		Limit:  int32(limit),
+		Offset: int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get notifications", "err", err)
+		return err
+	}
+
+	count, err := h.Repository.CountUserNotifications(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to count notifications", "err", err)
+		return err
+	}
+
+	notifications := []models.ResponseNotification{}
+	for _, n := range rows {
+		notifications = append(notifications, models.ResponseNotification{
+			ID:        n.ID,
+			Type:      n.Type,
+			Title:     n.Title,
+			Body:      n.Body,
+			IsRead:    n.ReadAt.Valid,
+			CreatedAt: n.CreatedAt.Time.Format("2006-01-02 15:04"),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"notifications": notifications,
+			"unread":        count.Unread,
+			"page":          page,
+			"limit":         limit,
+			"total":         count.Total,
+		},
+	})
+}
+
+func (h *NotificationHandler) handleReadNotification(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	affected, err := h.Repository.MarkNotificationRead(context.Background(), repositories.MarkNotificationReadParams{
+		ID:     int64(id),
+		UserID: int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to mark notification as read", "err", err)
+		return err
+	}
+	if affected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "Notifikasi tidak ditemukan")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"message": "success",
+		},
+	})
+}
+
+func (h *NotificationHandler) handleReadAllNotifications(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.Repository.MarkAllNotificationsRead(context.Background(), int64(userId))
+	if err != nil {
+		slog.Error("Failed to mark notifications as read", "err", err)
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"message": "success",
+		},
+	})
+}
@@ -25,6 +25,7 @@ type PacketHandler struct {
 	*services.JournalService
 	*services.PacketService
 	*services.StreakService
+	*services.UsageService
 }
 
 func NewPacketHandler(
@@ -34,6 +35,7 @@ func NewPacketHandler(
 	js *services.JournalService,
 	ps *services.PacketService,
 	ss *services.StreakService,
+	us *services.UsageService,
 ) *PacketHandler {
 	return &PacketHandler{
 		Validator:      v,
@@ -42,6 +44,7 @@ func NewPacketHandler(
 		JournalService: js,
 		PacketService:  ps,
 		StreakService:  ss,
+		UsageService:   us,
 	}
 }
 
@@ -98,6 +101,12 @@ func (h *PacketHandler) handleGeneratePacket(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Anda sudah memiliki beberapa packet aktif!")
 	}
 
+	reservation, err := h.UsageService.Reserve(int64(userId), services.FeatureEcoach)
+	if err != nil {
+		return err
+	}
+	defer h.UsageService.Release(reservation)
+
 	msg := fmt.Sprintf("Deskripsi: %s, target: %s", req.Description, req.Target)
 
 	cnf := helpers.NewConfig()
@@ -109,7 +118,7 @@ func (h *PacketHandler) handleGeneratePacket(c *fiber.Ctx) error {
 	session := aiModel.StartChat()
 	session.History = []*genai.Content{}
 
-	resp, err := session.SendMessage(ctx, genai.Text(msg))
+	resp, err := h.UsageService.SendMessage(ctx, session, reservation, genai.Text(msg))
 	if err != nil {
 		slog.Error("Failed to send message to generative ai", "err", err)
 		return err
@@ -0,0 +1,475 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ProjectHandler struct {
+	Validator  *validator.Validate
+	Repository *repositories.Queries
+	*services.PointService
+	*services.ExpService
+	*services.JournalService
+	*services.StreakService
+	*services.MediaService
+}
+
+func NewProjectHandler(
+	v *validator.Validate,
+	r *repositories.Queries,
+	ps *services.PointService,
+	es *services.ExpService,
+	js *services.JournalService,
+	ss *services.StreakService,
+	mds *services.MediaService,
+) *ProjectHandler {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("PROJECT_POINT_GAIN", 100)
+	cnf.SetDefault("PROJECT_EXP_GAIN", 50)
+
+	return &ProjectHandler{
+		Validator:      v,
+		Repository:     r,
+		PointService:   ps,
+		ExpService:     es,
+		JournalService: js,
+		StreakService:  ss,
+		MediaService:   mds,
+	}
+}
+
+func (h *ProjectHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/project")
+	g.Use(helpers.TokenMiddleware)
+	g.Post("/", h.handleStartProject)
+	g.Get("/me", h.handleGetCurrentUserProjects)
+	g.Get("/:id", h.handleGetProject)
+	g.Post("/:id/step/:stepId", h.handleCheckStep)
+	g.Delete("/:id/step/:stepId", h.handleUncheckStep)
+	g.Post("/:id/complete", h.handleCompleteProject)
+}
+
+func (h *ProjectHandler) handleStartProject(c *fiber.Ctx) error {
+	req := &models.PostProjectStart{}
+
+	err := c.BodyParser(req)
+	if err != nil {
+		slog.Error("Failed to parse payload", "err", err)
+		return err
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	greenprint, err := h.Repository.GetGreenprintsById(ctx, req.GreenprintID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Greenprint not found")
+		}
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	item, err := h.Repository.GetItemsById(ctx, greenprint.ItemID)
+	if err != nil {
+		slog.Error("Failed to get item", "err", err)
+		return err
+	}
+
+	if item.UserID != int64(userId) {
+		return fiber.NewError(fiber.StatusForbidden, "Greenprint ini bukan milik anda")
+	}
+
+	project, err := h.Repository.CreateGreenprintProject(ctx, repositories.CreateGreenprintProjectParams{
+		UserID:       int64(userId),
+		GreenprintID: greenprint.ID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusBadRequest, "Project untuk greenprint ini sudah dimulai")
+		}
+		slog.Error("Failed to create greenprint project", "err", err)
+		return err
+	}
+
+	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Mulai mengerjakan project daur ulang: '%s'", greenprint.Title)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, userId)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.getProjectDetail(ctx, project, greenprint.Title)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *ProjectHandler) handleGetCurrentUserProjects(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.Repository.GetUserGreenprintProjects(context.Background(), int64(userId))
+	if err != nil {
+		slog.Error("Failed to get greenprint projects", "err", err)
+		return err
+	}
+
+	bucketUrl := helpers.NewConfig().GetString("AWS_URL")
+
+	projects := []models.ResponseProject{}
+	for _, row := range rows {
+		project := models.ResponseProject{
+			ID:             row.ID,
+			GreenprintID:   row.GreenprintID,
+			Title:          row.Title,
+			Status:         row.Status,
+			TotalSteps:     int(row.TotalSteps),
+			CompletedSteps: int(row.CompletedSteps),
+			StartedAt:      row.StartedAt.Time.Format("2006-01-02 15:04"),
+		}
+		if row.ProofKey.Valid {
+			project.ProofURL = bucketUrl + row.ProofKey.String
+			project.ProofThumbnailURL = bucketUrl + helpers.ThumbnailKey(row.ProofKey.String)
+		}
+		if row.CompletedAt.Valid {
+			project.CompletedAt = row.CompletedAt.Time.Format("2006-01-02 15:04")
+		}
+		projects = append(projects, project)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"projects": projects,
+		},
+	})
+}
+
+func (h *ProjectHandler) handleGetProject(c *fiber.Ctx) error {
+	ctx := context.Background()
+
+	project, err := h.getUserProject(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	greenprint, err := h.Repository.GetGreenprintsById(ctx, project.GreenprintID)
+	if err != nil {
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	res, err := h.getProjectDetail(ctx, project, greenprint.Title)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *ProjectHandler) handleCheckStep(c *fiber.Ctx) error {
+	return h.updateStep(c, true)
+}
+
+func (h *ProjectHandler) handleUncheckStep(c *fiber.Ctx) error {
+	return h.updateStep(c, false)
+}
+
+func (h *ProjectHandler) updateStep(c *fiber.Ctx, done bool) error {
+	stepId, err := c.ParamsInt("stepId")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Step id tidak valid")
+	}
+
+	ctx := context.Background()
+
+	project, err := h.getUserProject(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if project.Status == "completed" {
+		return fiber.NewError(fiber.StatusBadRequest, "Project sudah selesai")
+	}
+
+	steps, err := h.Repository.GetProjectSteps(ctx, project.ID)
+	if err != nil {
+		slog.Error("Failed to get project steps", "err", err)
+		return err
+	}
+
+	found := false
+	for _, s := range steps {
+		if s.ID == int64(stepId) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fiber.NewError(fiber.StatusBadRequest, "Step tidak ditemukan di project ini")
+	}
+
+	if done {
+		err = h.Repository.CreateProjectStep(ctx, repositories.CreateProjectStepParams{
+			ProjectID: project.ID,
+			StepID:    int64(stepId),
+		})
+	} else {
+		err = h.Repository.DeleteProjectStep(ctx, repositories.DeleteProjectStepParams{
+			ProjectID: project.ID,
+			StepID:    int64(stepId),
+		})
+	}
+	if err != nil {
+		slog.Error("Failed to update project step", "err", err)
+		return err
+	}
+
+	greenprint, err := h.Repository.GetGreenprintsById(ctx, project.GreenprintID)
+	if err != nil {
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	res, err := h.getProjectDetail(ctx, project, greenprint.Title)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *ProjectHandler) handleCompleteProject(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	project, err := h.getUserProject(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	if project.Status == "completed" {
+		return fiber.NewError(fiber.StatusBadRequest, "Project sudah selesai")
+	}
+
+	steps, err := h.Repository.GetProjectSteps(ctx, project.ID)
+	if err != nil {
+		slog.Error("Failed to get project steps", "err", err)
+		return err
+	}
+
+	for _, s := range steps {
+		if !s.CompletedAt.Valid {
+			return fiber.NewError(fiber.StatusBadRequest, "Selesaikan semua step terlebih dahulu")
+		}
+	}
+
+	// a greenprint without steps has nothing to check off, so the proof
+	// photo is the only thing the reward is given for
+	file, err := c.FormFile("image")
+	if err == nil && file.Size == 0 {
+		err = fmt.Errorf("empty proof image")
+	}
+	if err != nil {
+		slog.Error("Failed to take image", "err", err)
+		if len(steps) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Greenprint ini tidak memiliki step, foto bukti wajib diunggah")
+		}
+		return fiber.NewError(fiber.StatusBadRequest, "The image field is required")
+	}
+
+	image, err := h.MediaService.UploadImage(ctx, file, "projects", userId)
+	if err != nil {
+		return err
+	}
+
+	project, err = h.Repository.CompleteGreenprintProject(ctx, repositories.CompleteGreenprintProjectParams{
+		ProofKey: pgtype.Text{String: image.Key, Valid: true},
+		ID:       project.ID,
+	})
+	if err != nil {
+		// another request completed it first, the reward was already given
+		if errors.Is(err, pgx.ErrNoRows) {
+			_ = h.MediaService.DeleteImage(image.Key)
+			return fiber.NewError(fiber.StatusBadRequest, "Project sudah selesai")
+		}
+		slog.Error("Failed to complete greenprint project", "err", err)
+		return err
+	}
+
+	greenprint, err := h.Repository.GetGreenprintsById(ctx, project.GreenprintID)
+	if err != nil {
+		slog.Error("Failed to get greenprint", "err", err)
+		return err
+	}
+
+	profile, err := h.Repository.GetUserProfile(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get user profile", "err", err)
+		return err
+	}
+
+	cnf := helpers.NewConfig()
+	pointGain := cnf.GetInt64("PROJECT_POINT_GAIN")
+	expGain := cnf.GetInt("PROJECT_EXP_GAIN")
+
+	historyMsg := fmt.Sprintf("Menyelesaikan project: %s", greenprint.Title)
+	_, err = h.PointService.UpdateUserPoint(int64(userId), pointGain, historyMsg, "greenprint", int(profile.Level))
+	if err != nil {
+		return err
+	}
+
+	levelUp, level, err := h.ExpService.IncreaseExp(userId, expGain)
+	if err != nil {
+		return err
+	}
+
+	err = h.StreakService.UpdateStreak(ctx, int64(userId))
+	if err != nil {
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Baru saja menyelesaikan project daur ulang: '%s', memperoleh poin: %v", greenprint.Title, pointGain)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, userId)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.getProjectDetail(ctx, project, greenprint.Title)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"project":       res,
+			"point_gain":    pointGain,
+			"exp_gain":      expGain,
+			"leveled_up":    levelUp,
+			"current_level": level,
+		},
+	})
+}
+
+// getUserProject loads the project from the :id param and makes sure it
+// belongs to the current user.
+func (h *ProjectHandler) getUserProject(ctx context.Context, c *fiber.Ctx) (repositories.GreenprintProject, error) {
+	var project repositories.GreenprintProject
+
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return project, err
+	}
+
+	projectId, err := c.ParamsInt("id")
+	if err != nil {
+		return project, fiber.NewError(fiber.StatusBadRequest, "Project id tidak valid")
+	}
+
+	project, err = h.Repository.GetGreenprintProjectById(ctx, int64(projectId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return project, fiber.NewError(fiber.StatusBadRequest, "Project tidak ditemukan")
+		}
+		slog.Error("Failed to get greenprint project", "err", err)
+		return project, err
+	}
+
+	if project.UserID != int64(userId) {
+		return project, fiber.NewError(fiber.StatusBadRequest, "Project tidak ditemukan")
+	}
+
+	return project, nil
+}
+
+func (h *ProjectHandler) getProjectDetail(ctx context.Context, project repositories.GreenprintProject, title string) (models.ResponseProject, error) {
+	res := models.ResponseProject{
+		ID:           project.ID,
+		GreenprintID: project.GreenprintID,
+		Title:        title,
+		Status:       project.Status,
+		StartedAt:    project.StartedAt.Time.Format("2006-01-02 15:04"),
+		Steps:        []models.ResponseProjectStep{},
+	}
+
+	if project.ProofKey.Valid {
+		bucketUrl := helpers.NewConfig().GetString("AWS_URL")
+		res.ProofURL = bucketUrl + project.ProofKey.String
+		res.ProofThumbnailURL = bucketUrl + helpers.ThumbnailKey(project.ProofKey.String)
+	}
+	if project.CompletedAt.Valid {
+		res.CompletedAt = project.CompletedAt.Time.Format("2006-01-02 15:04")
+	}
+
+	steps, err := h.Repository.GetProjectSteps(ctx, project.ID)
+	if err != nil {
+		slog.Error("Failed to get project steps", "err", err)
+		return res, err
+	}
+
+	for _, s := range steps {
+		step := models.ResponseProjectStep{
+			ID:          s.ID,
+			Description: s.Description,
+			Done:        s.CompletedAt.Valid,
+		}
+		if s.CompletedAt.Valid {
+			step.CompletedAt = s.CompletedAt.Time.Format("2006-01-02 15:04")
+			res.CompletedSteps++
+		}
+		res.Steps = append(res.Steps, step)
+	}
+	res.TotalSteps = len(steps)
+
+	return res, nil
+}
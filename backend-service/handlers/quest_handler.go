@@ -10,6 +10,7 @@ import (
 	"jirbthagoras/raksana-backend/repositories"
 	"jirbthagoras/raksana-backend/services"
 	"log/slog"
+	"math"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
@@ -33,6 +34,9 @@ func NewQuestHandler(
 	js *services.JournalService,
 	ss *services.StreakService,
 ) *QuestHandler {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("QUEST_NEARBY_RADIUS", 1000)
+
 	return &QuestHandler{
 		Validator:      v,
 		Repository:     r,
@@ -46,6 +50,10 @@ func (h *QuestHandler) RegisterRoutes(router fiber.Router) {
 	g := router.Group("/quest")
 	g.Use(helpers.TokenMiddleware)
 	g.Get("/nearest", h.handleGetNearestQuest)
+	g.Get("/nearby", h.handleGetNearbyQuests)
+	g.Get("/chains", h.handleGetQuestChains)
+	g.Get("/chains/:id", h.handleGetQuestChain)
+	g.Get("/chains/:id/leaderboard", h.handleGetQuestChainLeaderboard)
 	g.Get("/:id", h.handleGetContributedQuestDetails)
 }
 
@@ -104,14 +112,31 @@ func (h *QuestHandler) handleContribute(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Anda sudah berkontribusi pada quest ini")
 	}
 
+	if quest.ChainID.Valid {
+		locked, err := h.Repository.CountLockedChainStages(ctx, repositories.CountLockedChainStagesParams{
+			ChainID:    quest.ChainID.Int64,
+			StageOrder: quest.StageOrder.Int32,
+			UserID:     int64(userId),
+		})
+		if err != nil {
+			slog.Error("Failed to count locked chain stages", "err", err)
+			return err
+		}
+		if locked > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Selesaikan tahap sebelumnya dari quest chain ini terlebih dahulu")
+		}
+	}
+
 	contributors, err := h.Repository.CountQuestContributors(ctx, quest.ID)
 	if err != nil {
 		slog.Error("Failed to count", "err", err)
 		return err
 	}
 
+	// Chain stages stay open for every player, otherwise the first stage
+	// would be finished once it fills up and nobody could progress past it.
 	var contributorAmount int = len(contributors)
-	if contributorAmount >= int(quest.MaxContributors) {
+	if !quest.ChainID.Valid && contributorAmount >= int(quest.MaxContributors) {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Maksimal kontributor dari quest ini adalah %v orang", quest.MaxContributors))
 	}
 
@@ -124,7 +149,7 @@ func (h *QuestHandler) handleContribute(c *fiber.Ctx) error {
 		return err
 	}
 
-	if contributorAmount+1 == int(quest.MaxContributors) {
+	if !quest.ChainID.Valid && contributorAmount+1 == int(quest.MaxContributors) {
 		h.Repository.FinsihQuest(ctx, quest.ID)
 	}
 
@@ -162,18 +187,95 @@ func (h *QuestHandler) handleContribute(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"data": fiber.Map{
-			"message": "success",
-			"type":    "quest",
-			"quest": fiber.Map{
-				"name":        quest.Name,
-				"contributor": len(contributors),
-				"point_gain":  quest.PointGain,
-				"description": quest.Description,
-			},
+	data := fiber.Map{
+		"message": "success",
+		"type":    "quest",
+		"quest": fiber.Map{
+			"name":        quest.Name,
+			"contributor": len(contributors),
+			"point_gain":  quest.PointGain,
+			"description": quest.Description,
 		},
+	}
+
+	if quest.ChainID.Valid {
+		progress, err := h.advanceChain(ctx, userId, quest, int(profile.Level))
+		if err != nil {
+			return err
+		}
+		data["chain"] = progress
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"data": data,
+	})
+}
+
+// advanceChain reveals the clue of the next stage after a chain stage was
+// completed, or hands out the chain completion reward after the last one.
+func (h *QuestHandler) advanceChain(ctx context.Context, userId int, quest repositories.GetUncompletedQuestByCodeIdRow, level int) (*models.ResponseChainProgress, error) {
+	progress := &models.ResponseChainProgress{
+		ChainID:    quest.ChainID.Int64,
+		StageOrder: int(quest.StageOrder.Int32),
+	}
+
+	next, err := h.Repository.GetNextChainStage(ctx, repositories.GetNextChainStageParams{
+		ChainID:    quest.ChainID.Int64,
+		StageOrder: quest.StageOrder.Int32,
+	})
+	if err == nil {
+		progress.NextStage = &models.ResponseChainStage{
+			StageOrder: int(next.StageOrder.Int32),
+			Status:     "unlocked",
+			Name:       next.Name,
+			Clue:       next.Clue.String,
+		}
+		return progress, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		slog.Error("Failed to get next chain stage", "err", err)
+		return nil, err
+	}
+
+	rows, err := h.Repository.CreateQuestChainCompletion(ctx, repositories.CreateQuestChainCompletionParams{
+		ChainID: quest.ChainID.Int64,
+		UserID:  int64(userId),
 	})
+	if err != nil {
+		slog.Error("Failed to complete quest chain", "err", err)
+		return nil, err
+	}
+	progress.Completed = true
+	if rows == 0 {
+		return progress, nil
+	}
+
+	chain, err := h.Repository.GetQuestChainById(ctx, quest.ChainID.Int64)
+	if err != nil {
+		slog.Error("Failed to get quest chain", "err", err)
+		return nil, err
+	}
+	progress.CompletionPointGain = chain.CompletionPointGain
+
+	if chain.CompletionPointGain > 0 {
+		historyMsg := fmt.Sprintf("Menyelesaikan quest chain: %s", chain.Name)
+		_, err = h.PointService.UpdateUserPoint(int64(userId), chain.CompletionPointGain, historyMsg, "quest", level)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logMsg := fmt.Sprintf("Baru saja menyelesaikan seluruh tahap quest chain: %s!", chain.Name)
+	err = h.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress, nil
 }
 
 func (h *QuestHandler) handleGetContributedQuestDetails(c *fiber.Ctx) error {
@@ -228,24 +330,32 @@ func (h *QuestHandler) handleGetContributedQuestDetails(c *fiber.Ctx) error {
 }
 
 func (h *QuestHandler) handleGetNearestQuest(c *fiber.Ctx) error {
-	req := &models.GetNearestQuest{}
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
 
-	req.Latitude = c.QueryFloat("latitude")
-	req.Longitude = c.QueryFloat("longitude")
+	req := &models.GetNearestQuest{
+		Latitude:  helpers.QueryCoordinate(c, "latitude"),
+		Longitude: helpers.QueryCoordinate(c, "longitude"),
+	}
 
-	err := h.Validator.Struct(req)
+	err = h.Validator.Struct(req)
 	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
 		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
 	}
 
+	radius := helpers.NewConfig().GetFloat64("QUEST_NEARBY_RADIUS")
+
 	nearestQuest, err := h.Repository.GetNearestQuestWithinRadius(context.Background(), repositories.GetNearestQuestWithinRadiusParams{
-		LlToEarth:   req.Latitude,
-		LlToEarth_2: req.Longitude,
-		Latitude:    1000,
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		Radius:    radius,
+		UserID:    int64(userId),
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fiber.NewError(fiber.StatusNoContent, "Untuk sekarang tidak ada quest terdekat dalam radius 1km")
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Untuk sekarang tidak ada quest terdekat dalam radius %.0f m", radius))
 		}
 		slog.Error("Failed to get nearest", "err", err)
 		return err
@@ -257,3 +367,242 @@ func (h *QuestHandler) handleGetNearestQuest(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// handleGetNearbyQuests lists the unfinished quests around the user. The
+// exact quest location stays hidden, players get the distance, the compass
+// bearing and the clue to find it.
+func (h *QuestHandler) handleGetNearbyQuests(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	req := &models.GetNearbyQuests{
+		Latitude:  helpers.QueryCoordinate(c, "latitude"),
+		Longitude: helpers.QueryCoordinate(c, "longitude"),
+		Radius:    c.QueryFloat("radius"),
+		Sort:      c.Query("sort"),
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	if req.Radius == 0 {
+		req.Radius = helpers.NewConfig().GetFloat64("QUEST_NEARBY_RADIUS")
+	}
+
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 10), 1), 50)
+
+	ctx := context.Background()
+
+	rows, err := h.Repository.GetNearbyQuests(ctx, repositories.GetNearbyQuestsParams{
+		UserID:     int64(userId),
+		Latitude:   *req.Latitude,
+		Longitude:  *req.Longitude,
+		Radius:     req.Radius,
+		SortBy:     req.Sort,
+		PageLimit:  int32(limit),
+		PageOffset: int32((page - 1) * limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get nearby quests", "err", err)
+		return err
+	}
+
+	total, err := h.Repository.CountNearbyQuests(ctx, repositories.CountNearbyQuestsParams{
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		Radius:    req.Radius,
+		UserID:    int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to count nearby quests", "err", err)
+		return err
+	}
+
+	quests := []models.ResponseNearbyQuest{}
+	for _, row := range rows {
+		bearing := helpers.Bearing(*req.Latitude, *req.Longitude, row.Latitude, row.Longitude)
+
+		// Chain stages have no contributor cap, so they report no slots.
+		var remaining *int
+		if !row.ChainID.Valid {
+			slots := max(int(row.MaxContributors-row.ContributorCount), 0)
+			remaining = &slots
+		}
+
+		quests = append(quests, models.ResponseNearbyQuest{
+			ID:             row.ID,
+			Name:           row.Name,
+			Description:    row.Description,
+			Clue:           row.Clue.String,
+			PointGain:      row.PointGain,
+			DistanceMeters: math.Round(row.DistanceMeters),
+			Bearing:        math.Round(bearing),
+			Direction:      helpers.CompassDirection(bearing),
+			RemainingSlots: remaining,
+			Contributed:    row.Contributed,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"quests": quests,
+			"radius": req.Radius,
+			"page":   page,
+			"limit":  limit,
+			"total":  total,
+		},
+	})
+}
+
+func (h *QuestHandler) handleGetQuestChains(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	rows, err := h.Repository.GetActiveQuestChains(context.Background(), int64(userId))
+	if err != nil {
+		slog.Error("Failed to get quest chains", "err", err)
+		return err
+	}
+
+	chains := []models.ResponseQuestChain{}
+	for _, row := range rows {
+		chains = append(chains, models.ResponseQuestChain{
+			ID:                  row.ID,
+			Name:                row.Name,
+			Description:         row.Description,
+			CompletionPointGain: row.CompletionPointGain,
+			TotalStages:         int(row.TotalStages),
+			CompletedStages:     int(row.CompletedStages),
+			Completed:           row.Completed,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": chains,
+	})
+}
+
+// handleGetQuestChain shows the user's progress through a chain. Completed
+// stages are shown in full, the next stage only shows its clue and the
+// stages after it stay hidden.
+func (h *QuestHandler) handleGetQuestChain(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	ctx := context.Background()
+
+	chain, err := h.Repository.GetQuestChainById(ctx, int64(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Quest chain tidak ditemukan")
+		}
+		slog.Error("Failed to get quest chain", "err", err)
+		return err
+	}
+
+	if !chain.IsActive {
+		return fiber.NewError(fiber.StatusNotFound, "Quest chain tidak ditemukan")
+	}
+
+	stages, err := h.Repository.GetChainStagesWithProgress(ctx, repositories.GetChainStagesWithProgressParams{
+		UserID:  int64(userId),
+		ChainID: chain.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to get quest chain stages", "err", err)
+		return err
+	}
+
+	res := models.ResponseQuestChain{
+		ID:                  chain.ID,
+		Name:                chain.Name,
+		Description:         chain.Description,
+		CompletionPointGain: chain.CompletionPointGain,
+		TotalStages:         len(stages),
+		Stages:              []models.ResponseChainStage{},
+	}
+
+	unlocked := true
+	for _, stage := range stages {
+		item := models.ResponseChainStage{
+			StageOrder: int(stage.StageOrder.Int32),
+			PointGain:  stage.PointGain,
+		}
+
+		switch {
+		case stage.ContributedAt.Valid:
+			item.Status = "completed"
+			item.Name = stage.Name
+			item.Description = stage.Description
+			item.Clue = stage.Clue.String
+			item.Location = stage.Location
+			item.ContributedAt = stage.ContributedAt.Time.Format("2006-01-02 15:04")
+			res.CompletedStages++
+		case unlocked:
+			item.Status = "unlocked"
+			item.Clue = stage.Clue.String
+			unlocked = false
+		default:
+			item.Status = "locked"
+		}
+
+		res.Stages = append(res.Stages, item)
+	}
+	res.Completed = len(stages) > 0 && res.CompletedStages == len(stages)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": res,
+	})
+}
+
+func (h *QuestHandler) handleGetQuestChainLeaderboard(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	limit := min(max(c.QueryInt("limit", 10), 1), 50)
+
+	rows, err := h.Repository.GetQuestChainLeaderboard(context.Background(), repositories.GetQuestChainLeaderboardParams{
+		ChainID:   int64(id),
+		PageLimit: int32(limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get quest chain leaderboard", "err", err)
+		return err
+	}
+
+	leaderboard := []models.ResponseChainLeaderboard{}
+	for i, row := range rows {
+		entry := models.ResponseChainLeaderboard{
+			Rank:            i + 1,
+			UserID:          row.ID,
+			Username:        row.Username,
+			CompletedStages: int(row.CompletedStages),
+			Completed:       row.CompletedAt.Valid,
+		}
+		if row.CompletedAt.Valid {
+			entry.CompletedAt = row.CompletedAt.Time.Format("2006-01-02 15:04")
+		}
+
+		leaderboard = append(leaderboard, entry)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": leaderboard,
+	})
+}
@@ -25,6 +25,7 @@ type RecapHandler struct {
 	*configs.AIClient
 	*services.JournalService
 	*services.StreakService
+	*services.UsageService
 }
 
 func NewRecapHandler(
@@ -32,12 +33,14 @@ func NewRecapHandler(
 	ai *configs.AIClient,
 	js *services.JournalService,
 	ss *services.StreakService,
+	us *services.UsageService,
 ) *RecapHandler {
 	return &RecapHandler{
 		Repository:     r,
 		AIClient:       ai,
 		JournalService: js,
 		StreakService:  ss,
+		UsageService:   us,
 	}
 }
 
@@ -92,6 +95,12 @@ func (h *RecapHandler) handleCreateWeeklyRecap(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Anda sudah mengambil weekly recap minggu ini")
 	}
 
+	reservation, err := h.UsageService.Reserve(int64(userId), services.FeatureRecapWeekly)
+	if err != nil {
+		return err
+	}
+	defer h.UsageService.Release(reservation)
+
 	res, err := h.Repository.GetLastWeekTasks(ctx, int64(userId))
 	if err != nil {
 		slog.Error("Failed to get last week tasks", "err", err)
@@ -150,7 +159,7 @@ func (h *RecapHandler) handleCreateWeeklyRecap(c *fiber.Ctx) error {
 		return err
 	}
 
-	resp, err := session.SendMessage(ctx, genai.Text(msg))
+	resp, err := h.UsageService.SendMessage(ctx, session, reservation, genai.Text(msg))
 	if err != nil {
 		slog.Error("Failed to send message to generative ai", "err", err)
 		return err
@@ -318,27 +327,33 @@ func (h *RecapHandler) handleCreateMonthlyRecap(c *fiber.Ctx) error {
 		return err
 	}
 
+	latestRecap, err := h.Repository.GetLatestMonhtlyRecap(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get latest recap", "err", err)
+	}
+
+	if latestRecap.IsThisMonth {
+		return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah merekap bulan ini")
+	}
+
+	reservation, err := h.UsageService.Reserve(int64(userId), services.FeatureRecapMonthly)
+	if err != nil {
+		return err
+	}
+	defer h.UsageService.Release(reservation)
+
 	cnf := helpers.NewConfig()
 	model, err := configs.InitModel(h.AIClient.Genai, cnf, configs.RecapMonthly)
 
 	session := model.StartChat()
 	session.History = []*genai.Content{}
 
-	resp, err := session.SendMessage(ctx, genai.Text(reqMsg))
+	resp, err := h.UsageService.SendMessage(ctx, session, reservation, genai.Text(reqMsg))
 	if err != nil {
 		slog.Error("Something error while sending message to GenAI", "err", err)
 		return err
 	}
 
-	latestRecap, err := h.Repository.GetLatestMonhtlyRecap(ctx, int64(userId))
-	if err != nil {
-		slog.Error("Failed to get latest recap", "err", err)
-	}
-
-	if latestRecap.IsThisMonth {
-		return fiber.NewError(fiber.StatusBadRequest, "Kamu sudah merekap bulan ini")
-	}
-
 	responseMsg := ""
 	for _, part := range resp.Candidates[0].Content.Parts {
 		responseMsg += fmt.Sprintf("%v\n", part)
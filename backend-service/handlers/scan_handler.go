@@ -1,27 +1,26 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"jirbthagoras/raksana-backend/configs"
 	"jirbthagoras/raksana-backend/exceptions"
 	"jirbthagoras/raksana-backend/helpers"
 	"jirbthagoras/raksana-backend/models"
 	"jirbthagoras/raksana-backend/repositories"
+	"jirbthagoras/raksana-backend/services"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/rekognition"
-	"github.com/aws/aws-sdk-go-v2/service/rekognition/types"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/generative-ai-go/genai"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type ScanHandler struct {
@@ -32,6 +31,13 @@ type ScanHandler struct {
 	*EventHandler
 	*configs.AWSClient
 	*configs.AIClient
+	*services.UsageService
+	*services.VisionService
+	*services.MediaService
+	*services.GreenprintService
+	*services.DropoffService
+	*services.GeofenceService
+	*services.ActivityTokenService
 }
 
 func NewScanHandler(
@@ -42,15 +48,29 @@ func NewScanHandler(
 	eh *EventHandler,
 	aws *configs.AWSClient,
 	ai *configs.AIClient,
+	us *services.UsageService,
+	vs *services.VisionService,
+	mds *services.MediaService,
+	gs *services.GreenprintService,
+	ds *services.DropoffService,
+	gfs *services.GeofenceService,
+	ats *services.ActivityTokenService,
 ) *ScanHandler {
 	return &ScanHandler{
-		Validator:       v,
-		Repository:      r,
-		TreasureHandler: th,
-		QuestHandler:    qh,
-		EventHandler:    eh,
-		AWSClient:       aws,
-		AIClient:        ai,
+		Validator:            v,
+		Repository:           r,
+		TreasureHandler:      th,
+		QuestHandler:         qh,
+		EventHandler:         eh,
+		AWSClient:            aws,
+		AIClient:             ai,
+		UsageService:         us,
+		VisionService:        vs,
+		MediaService:         mds,
+		GreenprintService:    gs,
+		DropoffService:       ds,
+		GeofenceService:      gfs,
+		ActivityTokenService: ats,
 	}
 }
 
@@ -60,6 +80,8 @@ func (h *ScanHandler) RegisterRoutes(router fiber.Router) {
 	g.Post("/", h.handleScan)
 	g.Post("/trash", h.handleScanTrash)
 	g.Get("/trash", h.handleGetAllScans)
+	g.Get("/trash/:id", h.handleGetScan)
+	g.Delete("/trash/:id", h.handleDeleteScan)
 	g.Post("/greenprint/:id", h.handleGenerateGreenprint)
 	g.Get("/greenprint/:id", h.handleGetGreenprint)
 }
@@ -84,16 +106,47 @@ func (h *ScanHandler) handleScan(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Token Invalid")
 	}
 
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	useNumber, err := h.ActivityTokenService.Claim(ctx, int64(userId), payload)
+	if err != nil {
+		return err
+	}
+
+	// the token stays used only when the activity went through
+	redeemed := false
+	defer func() {
+		if !redeemed {
+			h.ActivityTokenService.Release(ctx, int64(userId), payload, useNumber)
+		}
+	}()
+
+	err = h.GeofenceService.Verify(ctx, int64(userId), payload.Type, payload.Subject, req.Latitude, req.Longitude)
+	if err != nil {
+		return err
+	}
+
 	switch payload.Type {
 	case "treasure":
-		return h.TreasureHandler.handleClaimTreasure(c)
+		err = h.TreasureHandler.handleClaimTreasure(c)
 	case "quest":
-		return h.QuestHandler.handleContribute(c)
+		err = h.QuestHandler.handleContribute(c)
 	case "event":
-		return h.EventHandler.handleAttend(c)
+		err = h.EventHandler.handleAttend(c)
 	default:
 		return fiber.ErrBadRequest
 	}
+	if err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest {
+		return err
+	}
+
+	redeemed = true
+	return nil
 }
 
 func (h *ScanHandler) handleScanTrash(c *fiber.Ctx) error {
@@ -103,51 +156,66 @@ func (h *ScanHandler) handleScanTrash(c *fiber.Ctx) error {
 		return fiber.ErrUnauthorized
 	}
 
-	file, err := c.FormFile("image")
+	form, err := c.MultipartForm()
 	if err != nil {
-		slog.Error("Failed to take image", "err", err)
-		return err
+		slog.Error("Failed to parse multipart form", "err", err)
+		return fiber.NewError(fiber.StatusBadRequest, "The image field is required")
 	}
 
-	src, err := file.Open()
-	if err != nil {
-		slog.Error("Failed to open the image", "err", err)
-		return err
+	files := append(form.File["image"], form.File["images"]...)
+	if len(files) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "The image field is required")
 	}
-	defer src.Close()
 
-	fileBytes, err := io.ReadAll(src)
+	cnf := helpers.NewConfig()
+	maxImages := cnf.GetInt("SCAN_MAX_IMAGES")
+	if len(files) > maxImages {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Maksimal %d gambar per scan", maxImages))
+	}
+
+	reservation, err := h.UsageService.Reserve(int64(userId), services.FeatureTrashScan)
 	if err != nil {
 		return err
 	}
+	defer h.UsageService.Release(reservation)
 
 	ctx := context.Background()
 
-	cnf := helpers.NewConfig()
-	bucketName := cnf.GetString("AWS_BUCKET")
-	key := fmt.Sprintf("scans/%v/%s", userId, file.Filename)
-
-	_, err = h.AWSClient.S3Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(bucketName),
-		Key:         aws.String(key),
-		Body:        bytes.NewReader(fileBytes),
-		ContentType: aws.String(file.Header.Get("Content-Type")),
-	})
-	if err != nil {
-		slog.Error("Failed to upload to S3", "err", err)
-		return err
+	var images []models.UploadedImage
+	saved := false
+	// uploads are only kept once a scan row references them
+	defer func() {
+		if saved {
+			return
+		}
+		for _, image := range images {
+			err := h.MediaService.DeleteImage(image.Key)
+			if err != nil {
+				slog.Error("Failed to delete orphaned scan image", "key", image.Key, "err", err)
+			}
+		}
+	}()
+
+	var perImage [][]models.RequestScannedObject
+	for _, file := range files {
+		image, err := h.MediaService.UploadImage(ctx, file, "scans", userId)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+
+		labels, err := h.VisionService.DetectLabels(ctx, int64(userId), image.Data, image.ContentType)
+		if err != nil {
+			slog.Error("Something wrong with the image scanning", "err", err)
+			return err
+		}
+
+		perImage = append(perImage, labels)
 	}
 
-	output, err := h.AWSClient.RekognitionClient.DetectLabels(ctx, &rekognition.DetectLabelsInput{
-		Image: &types.Image{
-			Bytes: fileBytes,
-		},
-		MaxLabels:     aws.Int32(10),
-		MinConfidence: aws.Float32(75.0),
-	})
-	if err != nil {
-		slog.Error("Something wrong with the image scanning", "err", err)
-		return err
+	labels := services.MergeLabels(perImage)
+	if len(labels) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Tidak ada objek yang dapat dikenali pada gambar")
 	}
 
 	model, err := configs.InitModel(h.AIClient.Genai, cnf, configs.TrashScanner)
@@ -159,13 +227,13 @@ func (h *ScanHandler) handleScanTrash(c *fiber.Ctx) error {
 	session := model.StartChat()
 	session.History = []*genai.Content{}
 
-	reqMsg, err := json.Marshal(output.Labels)
+	reqMsg, err := json.Marshal(labels)
 	if err != nil {
 		slog.Error("Failed to parse the json to []bytes", "err", err)
 		return err
 	}
 
-	resp, err := session.SendMessage(ctx, genai.Text(reqMsg))
+	resp, err := h.UsageService.SendMessage(ctx, session, reservation, genai.Text(reqMsg))
 	if err != nil {
 		slog.Error("Failed to send the message", "err", err)
 		return err
@@ -183,102 +251,445 @@ func (h *ScanHandler) handleScanTrash(c *fiber.Ctx) error {
 		return err
 	}
 
-	scan, err := h.Repository.CreateScans(ctx, repositories.CreateScansParams{
-		UserID:      int64(userId),
-		Title:       modelResponse.Title,
-		Description: modelResponse.Description,
-		ImageKey:    key,
+	bucketUrl := cnf.GetString("AWS_URL")
+	modelResponse.Items = mergeScanItems(modelResponse.Items, len(images))
+
+	var scan repositories.Scan
+	err = h.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		scan, err = q.CreateScans(ctx, repositories.CreateScansParams{
+			UserID:      int64(userId),
+			Title:       modelResponse.Title,
+			Description: modelResponse.Description,
+			ImageKey:    images[0].Key,
+		})
+		if err != nil {
+			slog.Error("Failed to insert scan", "err", err)
+			return err
+		}
+
+		var scanImageIds []int64
+		modelResponse.Images = []models.ResponseScanImage{}
+		for position, image := range images {
+			scanImage, err := q.CreateScanImage(ctx, repositories.CreateScanImageParams{
+				ScanID:   scan.ID,
+				ImageKey: image.Key,
+				Position: int32(position),
+			})
+			if err != nil {
+				slog.Error("Failed to insert scan image", "err", err)
+				return err
+			}
+
+			scanImageIds = append(scanImageIds, scanImage.ID)
+			modelResponse.Images = append(modelResponse.Images, models.ResponseScanImage{
+				ImageUrl:     bucketUrl + image.Key,
+				ThumbnailUrl: bucketUrl + image.ThumbnailKey,
+			})
+		}
+
+		for idx, i := range modelResponse.Items {
+			if !slices.Contains(configs.MaterialCategories, i.Material) {
+				i.Material = ""
+				modelResponse.Items[idx].Material = ""
+			}
+
+			item, err := q.CreateItems(ctx, repositories.CreateItemsParams{
+				ScanID:      scan.ID,
+				UserID:      int64(userId),
+				Name:        i.Name,
+				Description: i.Description,
+				Value:       i.Value,
+				Material:    pgtype.Text{String: i.Material, Valid: i.Material != ""},
+			})
+			if err != nil {
+				slog.Error("Failed to insert items", "err", err)
+				return err
+			}
+
+			modelResponse.Items[idx].Id = int(item.ID)
+			modelResponse.Items[idx].SourceImages = []string{}
+			for _, source := range i.Sources {
+				err = q.CreateItemImage(ctx, repositories.CreateItemImageParams{
+					ItemID:      item.ID,
+					ScanImageID: scanImageIds[source],
+				})
+				if err != nil {
+					slog.Error("Failed to insert item image", "err", err)
+					return err
+				}
+
+				modelResponse.Items[idx].SourceImages = append(modelResponse.Items[idx].SourceImages, bucketUrl+images[source].Key)
+			}
+			// the indexes only mean something to the model, clients get the urls
+			modelResponse.Items[idx].Sources = nil
+		}
+
+		return nil
 	})
 	if err != nil {
-		slog.Error("Failed to insert scan", "err", err)
 		return err
 	}
+	saved = true
 
-	for _, i := range modelResponse.Items {
-		_, err := h.Repository.CreateItems(ctx, repositories.CreateItemsParams{
-			ScanID:      scan.ID,
-			UserID:      int64(userId),
-			Name:        i.Name,
-			Description: i.Description,
-			Value:       i.Value,
-		})
+	if latitude, longitude, ok := requestLocation(c); ok {
+		err = h.attachNearestDropoff(ctx, latitude, longitude, modelResponse.Items)
 		if err != nil {
-			slog.Error("Failed to insert items", "err", err)
 			return err
 		}
 	}
 
-	bucketUrl := cnf.GetString("AWS_URL")
-
+	modelResponse.ID = scan.ID
+	modelResponse.CreatedAt = scan.CreatedAt.Time.Format("2006-01-02 15:04")
 	modelResponse.ImageKey = bucketUrl + scan.ImageKey
+	modelResponse.Thumbnail = bucketUrl + helpers.ThumbnailKey(scan.ImageKey)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": modelResponse,
 	})
 }
 
+// attachNearestDropoff gives every item with a known material the closest
+// drop-off point that accepts it.
+func (h *ScanHandler) attachNearestDropoff(ctx context.Context, latitude float64, longitude float64, itemGroups ...[]models.ResponseItems) error {
+	var materials []string
+	for _, items := range itemGroups {
+		for _, i := range items {
+			if i.Material != "" && !slices.Contains(materials, i.Material) {
+				materials = append(materials, i.Material)
+			}
+		}
+	}
+
+	nearest, err := h.DropoffService.NearestForMaterials(ctx, latitude, longitude, materials)
+	if err != nil {
+		return err
+	}
+
+	for _, items := range itemGroups {
+		for idx, i := range items {
+			if point, ok := nearest[i.Material]; ok {
+				items[idx].NearestDropoff = &point
+			}
+		}
+	}
+
+	return nil
+}
+
+// requestLocation reads the optional latitude and longitude of the user from
+// the query string or, for multipart uploads, the form fields.
+func requestLocation(c *fiber.Ctx) (float64, float64, bool) {
+	lat, lon := c.Query("latitude"), c.Query("longitude")
+	if lat == "" || lon == "" {
+		lat, lon = c.FormValue("latitude"), c.FormValue("longitude")
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, false
+	}
+
+	longitude, err := strconv.ParseFloat(lon, 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, false
+	}
+
+	return latitude, longitude, true
+}
+
+// mergeScanItems drops duplicate items the model returned for the same
+// object, keeping the highest value and the union of their source images.
+// Sources pointing outside of the uploaded images are discarded, and an item
+// without any source is attributed to every image.
+func mergeScanItems(items []models.ResponseItems, imageCount int) []models.ResponseItems {
+	rank := map[string]int{"low": 0, "mid": 1, "high": 2}
+	merged := []models.ResponseItems{}
+	index := map[string]int{}
+
+	for _, item := range items {
+		var sources []int
+		for _, source := range item.Sources {
+			if source >= 0 && source < imageCount && !slices.Contains(sources, source) {
+				sources = append(sources, source)
+			}
+		}
+		if len(sources) == 0 {
+			for i := range imageCount {
+				sources = append(sources, i)
+			}
+		}
+		item.Sources = sources
+
+		name := strings.ToLower(strings.TrimSpace(item.Name))
+		pos, ok := index[name]
+		if !ok {
+			index[name] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+
+		existing := &merged[pos]
+		if rank[item.Value] > rank[existing.Value] {
+			existing.Value = item.Value
+		}
+		for _, source := range item.Sources {
+			if !slices.Contains(existing.Sources, source) {
+				existing.Sources = append(existing.Sources, source)
+			}
+		}
+		slices.Sort(existing.Sources)
+	}
+
+	return merged
+}
+
 func (h *ScanHandler) handleGetAllScans(c *fiber.Ctx) error {
 	userId, err := helpers.GetSubjectFromToken(c)
 	if err != nil {
 		slog.Error("Failed to get subject from token", "err", err)
-		return nil
+		return err
 	}
 
+	page := max(c.QueryInt("page", 1), 1)
+	limit := min(max(c.QueryInt("limit", 10), 1), 50)
+
 	ctx := context.Background()
 
-	scanRes, err := h.Repository.GetAllUserScans(ctx, int64(userId))
+	scanRes, err := h.Repository.GetUserScans(ctx, repositories.GetUserScansParams{
+		UserID:     int64(userId),
+		PageLimit:  int32(limit),
+		PageOffset: int32((page - 1) * limit),
+	})
 	if err != nil {
 		slog.Error("Failed to get scan result", "err", err)
 		return err
 	}
 
-	var scans []models.AIResponseScan
-	for _, s := range scanRes {
-		resItem, err := h.Repository.GetItemsByScanId(ctx, s.ID)
+	total, err := h.Repository.CountUserScans(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to count scans", "err", err)
+		return err
+	}
+
+	scans, err := h.getScanDetails(ctx, c, scanRes)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"scans": scans,
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+func (h *ScanHandler) handleGetScan(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	scanId, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	ctx := context.Background()
+
+	scan, err := h.Repository.GetUserScanById(ctx, repositories.GetUserScanByIdParams{
+		ID:     int64(scanId),
+		UserID: int64(userId),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Scan tidak ditemukan")
+		}
+		slog.Error("Failed to get scan", "err", err)
+		return err
+	}
+
+	scans, err := h.getScanDetails(ctx, c, []repositories.Scan{scan})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": scans[0],
+	})
+}
+
+func (h *ScanHandler) handleDeleteScan(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	scanId, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	ctx := context.Background()
+
+	scan, err := h.Repository.GetUserScanById(ctx, repositories.GetUserScanByIdParams{
+		ID:     int64(scanId),
+		UserID: int64(userId),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Scan tidak ditemukan")
+		}
+		slog.Error("Failed to get scan", "err", err)
+		return err
+	}
+
+	keys, err := h.Repository.GetScanObjectKeys(ctx, scan.ID)
+	if err != nil {
+		slog.Error("Failed to get scan object keys", "err", err)
+		return err
+	}
+
+	// items, greenprints, projects and images go with the scan through
+	// ON DELETE CASCADE, deposits only lose the link to the item
+	_, err = h.Repository.DeleteScan(ctx, repositories.DeleteScanParams{
+		ID:     scan.ID,
+		UserID: int64(userId),
+	})
+	if err != nil {
+		slog.Error("Failed to delete scan", "err", err)
+		return err
+	}
+
+	for _, key := range keys {
+		err = h.MediaService.DeleteImage(key)
 		if err != nil {
-			slog.Error("Failed to get item", "err", err)
-			return err
+			slog.Error("Failed to delete scan object", "key", key, "err", err)
 		}
+	}
 
-		var items []models.ResponseItems
-		for _, i := range resItem {
-			var isHavingGreenPrint bool = true
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"message": "success",
+		},
+	})
+}
 
-			_, err := h.Repository.GetGreenprints(ctx, i.ID)
-			if err != nil {
-				if errors.Is(err, pgx.ErrNoRows) {
-					isHavingGreenPrint = false
-				} else {
-					slog.Error("Failed to get greenprint", "err", err)
-					return err
-				}
-			}
+// getScanDetails builds the responses for a page of scans with a fixed
+// number of queries regardless of how many scans and items there are.
+func (h *ScanHandler) getScanDetails(ctx context.Context, c *fiber.Ctx, scanRes []repositories.Scan) ([]models.AIResponseScan, error) {
+	scans := []models.AIResponseScan{}
+	if len(scanRes) == 0 {
+		return scans, nil
+	}
 
-			items = append(items, models.ResponseItems{
-				Id:               int(i.ID),
-				Name:             i.Name,
-				Description:      i.Description,
-				Value:            i.Value,
-				HavingGreenprint: isHavingGreenPrint,
-			})
+	var scanIds []int64
+	for _, s := range scanRes {
+		scanIds = append(scanIds, s.ID)
+	}
+
+	resItems, err := h.Repository.GetItemsByScanIds(ctx, scanIds)
+	if err != nil {
+		slog.Error("Failed to get items", "err", err)
+		return nil, err
+	}
+
+	resImages, err := h.Repository.GetScanImagesByScanIds(ctx, scanIds)
+	if err != nil {
+		slog.Error("Failed to get scan images", "err", err)
+		return nil, err
+	}
+
+	resSources, err := h.Repository.GetItemImagesByScanIds(ctx, scanIds)
+	if err != nil {
+		slog.Error("Failed to get item images", "err", err)
+		return nil, err
+	}
+
+	resDeposits, err := h.Repository.GetDepositItemsByScanIds(ctx, scanIds)
+	if err != nil {
+		slog.Error("Failed to get item deposits", "err", err)
+		return nil, err
+	}
+
+	cnf := helpers.NewConfig()
+	bucketUrl := cnf.GetString("AWS_URL")
+
+	images := map[int64][]models.ResponseScanImage{}
+	for _, image := range resImages {
+		images[image.ScanID] = append(images[image.ScanID], models.ResponseScanImage{
+			ImageUrl:     bucketUrl + image.ImageKey,
+			ThumbnailUrl: bucketUrl + helpers.ThumbnailKey(image.ImageKey),
+		})
+	}
+
+	sources := map[int64][]string{}
+	for _, source := range resSources {
+		sources[source.ItemID] = append(sources[source.ItemID], bucketUrl+source.ImageKey)
+	}
+
+	deposits := map[int64]repositories.GetDepositItemsByScanIdsRow{}
+	for _, deposit := range resDeposits {
+		deposits[deposit.ItemID.Int64] = deposit
+	}
+
+	items := map[int64][]models.ResponseItems{}
+	for _, i := range resItems {
+		itemSources := sources[i.ID]
+		if itemSources == nil {
+			itemSources = []string{}
 		}
 
-		cnf := helpers.NewConfig()
-		bucketUrl := cnf.GetString("AWS_URL")
+		items[i.ScanID] = append(items[i.ScanID], models.ResponseItems{
+			Id:               int(i.ID),
+			Name:             i.Name,
+			Description:      i.Description,
+			Value:            i.Value,
+			Material:         i.Material.String,
+			HavingGreenprint: i.HasGreenprint,
+			SourceImages:     itemSources,
+			DepositID:        deposits[i.ID].DepositID,
+			DepositStatus:    deposits[i.ID].Status,
+		})
+	}
+
+	for _, s := range scanRes {
+		scanImages := images[s.ID]
+		if scanImages == nil {
+			scanImages = []models.ResponseScanImage{}
+		}
+		scanItems := items[s.ID]
+		if scanItems == nil {
+			scanItems = []models.ResponseItems{}
+		}
 
 		scans = append(scans, models.AIResponseScan{
+			ID:          s.ID,
 			Title:       s.Title,
 			Description: s.Description,
 			ImageKey:    bucketUrl + s.ImageKey,
-			Items:       items,
+			Thumbnail:   bucketUrl + helpers.ThumbnailKey(s.ImageKey),
+			Images:      scanImages,
+			Items:       scanItems,
+			CreatedAt:   s.CreatedAt.Time.Format("2006-01-02 15:04"),
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": fiber.Map{
-			"scans": scans,
-		},
-	})
+	if latitude, longitude, ok := requestLocation(c); ok {
+		var groups [][]models.ResponseItems
+		for _, s := range scans {
+			groups = append(groups, s.Items)
+		}
+
+		err = h.attachNearestDropoff(ctx, latitude, longitude, groups...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return scans, nil
 }
 
 func (h *ScanHandler) handleGenerateGreenprint(c *fiber.Ctx) error {
@@ -309,6 +720,34 @@ func (h *ScanHandler) handleGenerateGreenprint(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Item not found")
 	}
 
+	// a well rated greenprint for the same item is handed out without asking Gemini
+	sourceId, found, err := h.GreenprintService.FindReusable(ctx, resItem.Name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		gp, err := h.GreenprintService.CopyGreenprint(ctx, sourceId, resItem.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := h.GreenprintService.GetGreenprintDetail(ctx, gp)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"data": res,
+		})
+	}
+
+	reservation, err := h.UsageService.Reserve(int64(userId), services.FeatureGreenprint)
+	if err != nil {
+		return err
+	}
+	defer h.UsageService.Release(reservation)
+
 	cnf := helpers.NewConfig()
 
 	// Generate greeprint
@@ -321,9 +760,10 @@ func (h *ScanHandler) handleGenerateGreenprint(c *fiber.Ctx) error {
 	session.History = []*genai.Content{}
 
 	aiMsg := fmt.Sprintf("Saya hendak membuat sebuah tutorial atau langkah-langkah terperinci untuk membuat: %s, dengan deskripsi sebagai berikut: %s", resItem.Name, resItem.Description)
-	resp, err := session.SendMessage(ctx, genai.Text(aiMsg))
+	resp, err := h.UsageService.SendMessage(ctx, session, reservation, genai.Text(aiMsg))
 	if err != nil {
 		slog.Error("Failed to send message", "err", err)
+		return err
 	}
 
 	responseMsg := ""
@@ -344,14 +784,17 @@ func (h *ScanHandler) handleGenerateGreenprint(c *fiber.Ctx) error {
 		Description:         greenprintRes.Description,
 		Title:               greenprintRes.Title,
 		SustainabilityScore: greenprintRes.SustainabilityScore,
+		EstimatedTime:       greenprintRes.EstimatedTime,
 	})
 	if err != nil {
 		slog.Error("Failed to create greenprint", "err", err)
 		return err
 	}
 
-	for _, s := range greenprintRes.Steps {
-		_, err := h.Repository.CreateSteps(ctx, repositories.CreateStepsParams{
+	greenprintRes.ID = gp.ID
+	greenprintRes.IsPublic = gp.IsPublic
+	for i, s := range greenprintRes.Steps {
+		step, err := h.Repository.CreateSteps(ctx, repositories.CreateStepsParams{
 			GreenprintID: gp.ID,
 			Description:  s.Description,
 		})
@@ -359,25 +802,55 @@ func (h *ScanHandler) handleGenerateGreenprint(c *fiber.Ctx) error {
 			slog.Error("Failed to create greenprint", "err", err)
 			return err
 		}
+		greenprintRes.Steps[i].ID = step.ID
+	}
+
+	// Gemini points at steps by their number, link them to the stored rows
+	stepId := func(step int) pgtype.Int8 {
+		if step < 1 || step > len(greenprintRes.Steps) {
+			return pgtype.Int8{}
+		}
+		return pgtype.Int8{Int64: greenprintRes.Steps[step-1].ID, Valid: true}
 	}
 
-	for _, m := range greenprintRes.Materials {
-		_, err = h.Repository.CreateMaterials(ctx, repositories.CreateMaterialsParams{
+	for i, m := range greenprintRes.Materials {
+		material, err := h.Repository.CreateMaterials(ctx, repositories.CreateMaterialsParams{
 			GreenprintID: gp.ID,
 			Name:         m.Name,
 			Description:  m.Description,
 			Price:        m.Price,
 			Quantity:     m.Quantity,
+			StepID:       stepId(m.Step),
 		})
+		if err != nil {
+			slog.Error("Failed to create material", "err", err)
+			return err
+		}
+		greenprintRes.Materials[i].ID = material.ID
+		greenprintRes.Materials[i].StepID = material.StepID.Int64
 	}
 
-	for _, t := range greenprintRes.Tools {
-		_, err = h.Repository.CreateTools(ctx, repositories.CreateToolsParams{
+	for i, t := range greenprintRes.Tools {
+		tool, err := h.Repository.CreateTools(ctx, repositories.CreateToolsParams{
 			GreenprintID: gp.ID,
 			Name:         t.Name,
 			Description:  t.Description,
 			Price:        t.Price,
+			StepID:       stepId(t.Step),
+			IsOptional:   t.Optional,
 		})
+		if err != nil {
+			slog.Error("Failed to create tool", "err", err)
+			return err
+		}
+		greenprintRes.Tools[i].ID = tool.ID
+		greenprintRes.Tools[i].StepID = tool.StepID.Int64
+	}
+
+	err = h.Repository.UpdateGreenprintSearchText(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to index greenprint", "err", err)
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -402,59 +875,11 @@ func (h *ScanHandler) handleGetGreenprint(c *fiber.Ctx) error {
 		return err
 	}
 
-	materialsRes, err := h.Repository.GetMaterials(ctx, greenprintRes.ID)
-	if err != nil {
-		slog.Error("Failed to get materials", "err", err)
-		return err
-	}
-
-	toolsRes, err := h.Repository.GetTools(ctx, greenprintRes.ID)
+	res, err := h.GreenprintService.GetGreenprintDetail(ctx, greenprintRes)
 	if err != nil {
-		slog.Error("Failed to get tools", "err", err)
 		return err
 	}
 
-	stepsRes, err := h.Repository.GetSteps(ctx, greenprintRes.ID)
-	if err != nil {
-		slog.Error("Failed to get tools", "err", err)
-		return err
-	}
-
-	var res = models.AIResponseGreenprint{
-		Title:               greenprintRes.Title,
-		Description:         greenprintRes.Description,
-		EstimatedTime:       greenprintRes.EstimatedTime,
-		SustainabilityScore: greenprintRes.SustainabilityScore,
-		CreatedAt:           greenprintRes.CreatedAt.Time.Format("2006-01-02 15:04"),
-		Tools:               []models.ResponseTool{},
-		Materials:           []models.ResponseMaterial{},
-		Steps:               []models.ResponseStep{},
-		Text:                "",
-	}
-
-	for _, m := range materialsRes {
-		res.Materials = append(res.Materials, models.ResponseMaterial{
-			Name:        m.Name,
-			Description: m.Description,
-			Price:       m.Price,
-			Quantity:    m.Quantity,
-		})
-	}
-
-	for _, t := range toolsRes {
-		res.Tools = append(res.Tools, models.ResponseTool{
-			Name:        t.Name,
-			Description: t.Description,
-			Price:       t.Price,
-		})
-	}
-
-	for _, s := range stepsRes {
-		res.Steps = append(res.Steps, models.ResponseStep{
-			Description: s.Description,
-		})
-	}
-
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": res,
 	})
@@ -14,6 +14,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type TreasureHandler struct {
@@ -22,6 +23,7 @@ type TreasureHandler struct {
 	*services.PointService
 	*services.JournalService
 	*services.StreakService
+	*services.TreasureHintService
 }
 
 func NewTreasureHandler(
@@ -30,13 +32,15 @@ func NewTreasureHandler(
 	ps *services.PointService,
 	js *services.JournalService,
 	ss *services.StreakService,
+	ths *services.TreasureHintService,
 ) *TreasureHandler {
 	return &TreasureHandler{
-		Validator:      v,
-		Repository:     r,
-		PointService:   ps,
-		JournalService: js,
-		StreakService:  ss,
+		Validator:           v,
+		Repository:          r,
+		PointService:        ps,
+		JournalService:      js,
+		StreakService:       ss,
+		TreasureHintService: ths,
 	}
 }
 
@@ -44,6 +48,8 @@ func (h *TreasureHandler) RegisterRoutes(router fiber.Router) {
 	g := router.Group("/treasure")
 	g.Use(helpers.TokenMiddleware)
 	g.Get("/me", h.handlGetCurrentUserTreasures)
+	g.Get("/hunt", h.handleGetHuntableTreasures)
+	g.Get("/:id/hint", h.handleGetTreasureHint)
 	g.Get("/:id", h.handleGetUserTreasure)
 }
 
@@ -83,18 +89,16 @@ func (h *TreasureHandler) handleClaimTreasure(c *fiber.Ctx) error {
 		return err
 	}
 
-	err = h.Repository.CreateClaimed(ctx, repositories.CreateClaimedParams{
-		UserID:     int64(userId),
+	claim, err := h.Repository.ClaimTreasure(ctx, repositories.ClaimTreasureParams{
 		TreasureID: treasure.ID,
+		UserID:     int64(userId),
 	})
 	if err != nil {
-		slog.Error("Failed to insert into db", "err", err)
-		return err
-	}
-
-	err = h.Repository.DeactivateTreasure(ctx, treasure.ID)
-	if err != nil {
-		slog.Error("Failed to update the row", "err", err)
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "23505") {
+			return h.claimRejection(ctx, treasure, int64(userId))
+		}
+		slog.Error("Failed to claim treasure", "err", err)
 		return err
 	}
 
@@ -136,13 +140,40 @@ func (h *TreasureHandler) handleClaimTreasure(c *fiber.Ctx) error {
 			"type":    "treasures",
 			"message": "success",
 			"treasure": fiber.Map{
-				"name":       treasure.Name,
-				"point_gain": treasure.PointGain,
+				"name":             treasure.Name,
+				"point_gain":       treasure.PointGain,
+				"remaining_claims": claim.RemainingClaims,
+				"user_claims_left": claim.UserClaimsLeft,
 			},
 		},
 	})
 }
 
+// claimRejection explains why ClaimTreasure didn't let the user claim.
+func (h *TreasureHandler) claimRejection(ctx context.Context, treasure repositories.Treasure, userId int64) error {
+	availability, err := h.Repository.GetTreasureAvailability(ctx, repositories.GetTreasureAvailabilityParams{
+		ID:     treasure.ID,
+		UserID: userId,
+	})
+	if err != nil {
+		slog.Error("Failed to get treasure availability", "err", err)
+		return err
+	}
+
+	switch {
+	case availability.NotStarted:
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Treasure ini baru bisa diklaim mulai %s", treasure.StartsAt.Time.Format("2006-01-02 15:04")))
+	case availability.Expired:
+		return fiber.NewError(fiber.StatusBadRequest, "Treasure ini sudah kedaluwarsa")
+	case availability.SoldOut:
+		return fiber.NewError(fiber.StatusBadRequest, "Treasure ini sudah habis diklaim")
+	case availability.UserClaims >= availability.PerUserLimit:
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Anda sudah mengklaim treasure ini %d kali, batas per orang tercapai", availability.UserClaims))
+	default:
+		return fiber.NewError(fiber.StatusConflict, "Treasure sedang diklaim, coba lagi")
+	}
+}
+
 func (h *TreasureHandler) handlGetCurrentUserTreasures(c *fiber.Ctx) error {
 	userId, err := helpers.GetSubjectFromToken(c)
 	if err != nil {
@@ -196,3 +227,61 @@ func (h *TreasureHandler) handleGetUserTreasure(c *fiber.Ctx) error {
 		},
 	})
 }
+
+func (h *TreasureHandler) handleGetHuntableTreasures(c *fiber.Ctx) error {
+	rows, err := h.Repository.GetHuntableTreasures(context.Background())
+	if err != nil {
+		slog.Error("Failed to get huntable treasures", "err", err)
+		return err
+	}
+
+	treasures := []models.ResponseHuntableTreasure{}
+	for _, row := range rows {
+		treasure := models.ResponseHuntableTreasure{
+			ID:              row.ID,
+			Name:            row.Name,
+			PointGain:       row.PointGain,
+			RemainingClaims: int(row.RemainingClaims),
+		}
+		if row.ExpiresAt.Valid {
+			treasure.ExpiresAt = row.ExpiresAt.Time.Format("2006-01-02 15:04")
+		}
+
+		treasures = append(treasures, treasure)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": treasures,
+	})
+}
+
+func (h *TreasureHandler) handleGetTreasureHint(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Id is not valid")
+	}
+
+	req := &models.GetTreasureHint{
+		Latitude:  helpers.QueryCoordinate(c, "latitude"),
+		Longitude: helpers.QueryCoordinate(c, "longitude"),
+	}
+
+	err = h.Validator.Struct(req)
+	if err != nil && errors.As(err, &validator.ValidationErrors{}) {
+		return exceptions.NewFailedValidationError(*req, err.(validator.ValidationErrors))
+	}
+
+	hint, err := h.TreasureHintService.Hint(context.Background(), int64(userId), int64(id), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": hint,
+	})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UsageHandler struct {
+	*services.UsageService
+}
+
+func NewUsageHandler(
+	us *services.UsageService,
+) *UsageHandler {
+	return &UsageHandler{
+		UsageService: us,
+	}
+}
+
+func (h *UsageHandler) RegisterRoutes(router fiber.Router) {
+	g := router.Group("/usage")
+	g.Use(helpers.TokenMiddleware)
+	g.Get("/me", h.handleGetMyUsage)
+}
+
+func (h *UsageHandler) handleGetMyUsage(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.UsageService.GetUsageSummary(int64(userId))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": summary,
+	})
+}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"context"
 	"errors"
-	"jirbthagoras/raksana-backend/configs"
 	"jirbthagoras/raksana-backend/exceptions"
 	"jirbthagoras/raksana-backend/helpers"
 	"jirbthagoras/raksana-backend/models"
@@ -20,10 +19,10 @@ import (
 type UserHandler struct {
 	Repository *repositories.Queries
 	Validator  *validator.Validate
-	*configs.AWSClient
 	*services.UserService
 	*services.LeaderboardService
 	*services.FileService
+	*services.MediaService
 	Mu sync.Mutex
 }
 
@@ -33,7 +32,7 @@ func NewUserHandler(
 	us *services.UserService,
 	ls *services.LeaderboardService,
 	fs *services.FileService,
-	a *configs.AWSClient,
+	mds *services.MediaService,
 ) *UserHandler {
 	return &UserHandler{
 		Validator:          v,
@@ -41,7 +40,7 @@ func NewUserHandler(
 		UserService:        us,
 		LeaderboardService: ls,
 		FileService:        fs,
-		AWSClient:          a,
+		MediaService:       mds,
 	}
 }
 
@@ -55,6 +54,7 @@ func (h *UserHandler) RegisterRoutes(router fiber.Router) {
 	g2.Get("/me", h.handleGetProfile)
 	g2.Get("/:id", h.handleGetProfileById)
 	g2.Put("/picture", h.handleUpdateProfilePicture)
+	g2.Put("/picture/upload", h.handleUploadProfilePicture)
 }
 
 func (h *UserHandler) handleGetProfile(c *fiber.Ctx) error {
@@ -116,7 +116,6 @@ func (h *UserHandler) handleUpdateProfilePicture(c *fiber.Ctx) error {
 	}
 
 	cnf := helpers.NewConfig()
-	bucketName := cnf.GetString("AWS_BUCKET")
 	bucketUrl := cnf.GetString("AWS_URL")
 
 	presignedUrl, key, err := h.FileService.CreatePresignedURL("profile", strconv.Itoa(userId), req.Filename, req.ContentType)
@@ -125,7 +124,7 @@ func (h *UserHandler) handleUpdateProfilePicture(c *fiber.Ctx) error {
 	}
 
 	if profile.ProfileKey != "profiles/Portrait_Placeholder.png" {
-		_ = h.AWSClient.DeleteObject(bucketName, profile.ProfileKey)
+		_ = h.MediaService.DeleteImage(profile.ProfileKey)
 	}
 
 	err = h.Repository.UpdateUserProfile(ctx, repositories.UpdateUserProfileParams{
@@ -147,6 +146,63 @@ func (h *UserHandler) handleUpdateProfilePicture(c *fiber.Ctx) error {
 	})
 }
 
+func (h *UserHandler) handleUploadProfilePicture(c *fiber.Ctx) error {
+	userId, err := helpers.GetSubjectFromToken(c)
+	if err != nil {
+		return err
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		slog.Error("Failed to take image", "err", err)
+		return fiber.NewError(fiber.StatusBadRequest, "The image field is required")
+	}
+
+	ctx := context.Background()
+
+	profile, err := h.Repository.GetUserProfile(ctx, int64(userId))
+	if err != nil {
+		slog.Error("Failed to get user prodile", "err", err)
+		return err
+	}
+
+	cnf := helpers.NewConfig()
+	bucketUrl := cnf.GetString("AWS_URL")
+
+	image, err := h.MediaService.UploadImage(ctx, file, "profiles", userId)
+	if err != nil {
+		return err
+	}
+
+	err = h.Repository.UpdateUserProfile(ctx, repositories.UpdateUserProfileParams{
+		UserID:     int64(userId),
+		ProfileKey: image.Key,
+	})
+	if err != nil {
+		slog.Error("Failed to update user profile", "err", err)
+		_ = h.MediaService.DeleteImage(image.Key)
+		return err
+	}
+
+	if profile.ProfileKey != "profiles/Portrait_Placeholder.png" {
+		_ = h.MediaService.DeleteImage(profile.ProfileKey)
+	}
+
+	imageUrl := bucketUrl + image.Key
+
+	err = h.LeaderboardService.UpdateProfile(strconv.Itoa(userId), imageUrl)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": fiber.Map{
+			"image_url":     imageUrl,
+			"thumbnail_url": bucketUrl + image.ThumbnailKey,
+		},
+	})
+}
+
 func (h *UserHandler) handleGetAllUsers(c *fiber.Ctx) error {
 	ctx := context.Background()
 	res, err := h.Repository.GetAllUser(ctx)
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminMiddleware only lets users flagged as admin through, it expects
+// TokenMiddleware to be registered before it.
+func AdminMiddleware(r *repositories.Queries) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userId, err := GetSubjectFromToken(c)
+		if err != nil {
+			return err
+		}
+
+		user, err := r.GetUserById(context.Background(), int64(userId))
+		if err != nil {
+			slog.Error("Failed to get user", "err", err)
+			return err
+		}
+
+		if !user.IsAdmin {
+			return fiber.NewError(fiber.StatusForbidden, "Hanya admin yang dapat mengakses fitur ini")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,12 @@
+package helpers
+
+import "strings"
+
+// CSVCell quotes user supplied values that a spreadsheet would otherwise
+// evaluate as a formula.
+func CSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
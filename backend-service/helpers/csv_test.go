@@ -0,0 +1,29 @@
+package helpers
+
+import "testing"
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "empty", value: "", want: ""},
+		{name: "plain text", value: "Botol plastik", want: "Botol plastik"},
+		{name: "formula", value: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{name: "plus", value: "+62812", want: "'+62812"},
+		{name: "minus", value: "-1+1", want: "'-1+1"},
+		{name: "at", value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", value: "\t=1", want: "'\t=1"},
+		{name: "carriage return", value: "\r=1", want: "'\r=1"},
+		{name: "formula char later", value: "a=1", want: "a=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CSVCell(tt.value); got != tt.want {
+				t.Errorf("CSVCell(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"strconv"
+)
+
+// FormatRupiah formats an amount the Indonesian way, e.g. 15000 -> Rp15.000.
+func FormatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+
+	return sign + "Rp" + string(out)
+}
@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var compassDirections = []string{"U", "TL", "T", "TG", "S", "BD", "B", "BL"}
+
+// Bearing returns the initial compass bearing in degrees, 0 to 360 with 0 as
+// north, to travel from the first point to the second.
+func Bearing(fromLatitude float64, fromLongitude float64, toLatitude float64, toLongitude float64) float64 {
+	lat1 := fromLatitude * math.Pi / 180
+	lat2 := toLatitude * math.Pi / 180
+	deltaLon := (toLongitude - fromLongitude) * math.Pi / 180
+
+	y := math.Sin(deltaLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLon)
+
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// CompassDirection names the eight-wind direction of a bearing in
+// Indonesian, e.g. 45 -> TL (timur laut).
+func CompassDirection(bearing float64) string {
+	return compassDirections[int(math.Round(bearing/45))%8]
+}
+
+// QueryCoordinate reads a latitude or longitude query param. It is nil when
+// the param is missing or not a number, so 0 stays a valid coordinate.
+func QueryCoordinate(c *fiber.Ctx, key string) *float64 {
+	value, err := strconv.ParseFloat(c.Query(key), 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
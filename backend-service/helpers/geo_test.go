@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"io"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name             string
+		fromLat, fromLon float64
+		toLat, toLon     float64
+		want             float64
+	}{
+		{name: "north", fromLat: 0, fromLon: 0, toLat: 1, toLon: 0, want: 0},
+		{name: "east", fromLat: 0, fromLon: 0, toLat: 0, toLon: 1, want: 90},
+		{name: "south", fromLat: 0, fromLon: 0, toLat: -1, toLon: 0, want: 180},
+		{name: "west", fromLat: 0, fromLon: 0, toLat: 0, toLon: -1, want: 270},
+		{name: "across the antimeridian", fromLat: 0, fromLon: 179.5, toLat: 0, toLon: -179.5, want: 90},
+		{name: "monas to bundaran hi", fromLat: -6.1754, fromLon: 106.8272, toLat: -6.1950, toLon: 106.8230, want: 192.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Bearing(tt.fromLat, tt.fromLon, tt.toLat, tt.toLon)
+			if math.Abs(got-tt.want) > 0.1 {
+				t.Errorf("Bearing() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompassDirection(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		want    string
+	}{
+		{bearing: 0, want: "U"},
+		{bearing: 22.4, want: "U"},
+		{bearing: 22.5, want: "TL"},
+		{bearing: 90, want: "T"},
+		{bearing: 180, want: "S"},
+		{bearing: 270, want: "B"},
+		{bearing: 315, want: "BL"},
+		{bearing: 359.9, want: "U"},
+	}
+
+	for _, tt := range tests {
+		if got := CompassDirection(tt.bearing); got != tt.want {
+			t.Errorf("CompassDirection(%v) = %q, want %q", tt.bearing, got, tt.want)
+		}
+	}
+}
+
+func TestQueryCoordinate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *float64
+	}{
+		{name: "missing", query: "", want: nil},
+		{name: "not a number", query: "?latitude=abc", want: nil},
+		{name: "zero", query: "?latitude=0", want: ptr(0)},
+		{name: "negative", query: "?latitude=-6.2088", want: ptr(-6.2088)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *float64
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				got = QueryCoordinate(c, "latitude")
+				return nil
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			io.Copy(io.Discard, resp.Body)
+
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("QueryCoordinate() = %v, want %v", got, tt.want)
+			}
+			if got != nil && *got != *tt.want {
+				t.Errorf("QueryCoordinate() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -1,6 +1,11 @@
 package helpers
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -19,3 +24,11 @@ func CheckPassword(password, hashed string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
 	return err == nil
 }
+
+// SignCode returns a short HMAC of the payload, keyed with the JWT secret,
+// for codes that are printed and typed back in by hand.
+func SignCode(payload string) string {
+	mac := hmac.New(sha256.New, getSecretKey())
+	mac.Write([]byte(payload))
+	return strings.ToUpper(hex.EncodeToString(mac.Sum(nil)[:4]))
+}
@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icalTimeLayout = "20060102T150405Z"
+
+type ICalEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Latitude     float64
+	Longitude    float64
+	Start        time.Time
+	End          time.Time
+	LastModified time.Time
+}
+
+// BuildICalendar renders the events as an RFC 5545 calendar. Times are
+// written in UTC, clients convert them to the zone of the device.
+func BuildICalendar(name string, events []ICalEvent) []byte {
+	var b strings.Builder
+	now := time.Now().UTC().Format(icalTimeLayout)
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Raksana//Events//ID")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+	writeICalLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICalLine(&b, "X-PUBLISHED-TTL:PT1H")
+
+	for _, e := range events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+e.UID)
+		writeICalLine(&b, "DTSTAMP:"+now)
+		if !e.LastModified.IsZero() {
+			writeICalLine(&b, "LAST-MODIFIED:"+e.LastModified.UTC().Format(icalTimeLayout))
+		}
+		writeICalLine(&b, "DTSTART:"+e.Start.UTC().Format(icalTimeLayout))
+		writeICalLine(&b, "DTEND:"+e.End.UTC().Format(icalTimeLayout))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(e.Summary))
+		writeICalLine(&b, "DESCRIPTION:"+escapeICalText(e.Description))
+		writeICalLine(&b, "LOCATION:"+escapeICalText(e.Location))
+		if e.Latitude != 0 || e.Longitude != 0 {
+			writeICalLine(&b, fmt.Sprintf("GEO:%.6f;%.6f", e.Latitude, e.Longitude))
+		}
+		writeICalLine(&b, "STATUS:CONFIRMED")
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeICalLine folds lines longer than 75 octets without splitting a UTF-8
+// character, continuation lines start with a space.
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
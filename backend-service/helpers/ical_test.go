@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Bersih Pantai", want: "Bersih Pantai"},
+		{in: "Pantai Ancol, Jakarta", want: `Pantai Ancol\, Jakarta`},
+		{in: "Bawa: sarung tangan; karung", want: `Bawa: sarung tangan\; karung`},
+		{in: `C:\Users`, want: `C:\\Users`},
+		{in: "Baris satu\nBaris dua", want: `Baris satu\nBaris dua`},
+		{in: "Baris satu\r\nBaris dua", want: `Baris satu\nBaris dua`},
+		{in: `sudah \, escaped`, want: `sudah \\\, escaped`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeICalText(tt.in); got != tt.want {
+			t.Errorf("escapeICalText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteICalLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short", line: "SUMMARY:Bersih Pantai"},
+		{name: "exactly 75 octets", line: strings.Repeat("a", 75)},
+		{name: "long ascii", line: "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{name: "long multibyte", line: "DESCRIPTION:" + strings.Repeat("ñ🌱", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			writeICalLine(&b, tt.line)
+			out := b.String()
+
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q does not end with CRLF", out)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, l := range lines {
+				if len(l) > 75 {
+					t.Errorf("line %d is %d octets, want at most 75", i, len(l))
+				}
+				if !utf8.ValidString(l) {
+					t.Errorf("line %d splits a UTF-8 character", i)
+				}
+				if i > 0 && !strings.HasPrefix(l, " ") {
+					t.Errorf("continuation line %d does not start with a space", i)
+				}
+			}
+
+			unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", "")
+			if unfolded != tt.line {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
@@ -25,6 +25,13 @@ type ActivityClaims struct {
 	jwt.RegisteredClaims
 }
 
+type TicketClaims struct {
+	Type         string `json:"type"`
+	AttendanceID int64  `json:"attendance_id"`
+	EventID      int64  `json:"event_id"`
+	jwt.RegisteredClaims
+}
+
 func getSecretKey() []byte {
 	// taking the cached secret key
 	if secretKey != nil {
@@ -75,6 +82,44 @@ func GenerateToken(id int, username string, email string, expiry time.Time) (str
 	return token.SignedString(secret)
 }
 
+// GenerateActivityToken signs the token behind a quest, event or treasure QR
+// code. The jti lets a token be revoked and tracked per user.
+func GenerateActivityToken(activityType string, codeId string, jti string, expiry time.Time) (string, error) {
+	claims := &ActivityClaims{
+		Type: activityType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   codeId,
+			Issuer:    "Raksana",
+			ExpiresAt: jwt.NewNumericDate(expiry),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(getSecretKey())
+}
+
+// GenerateTicketToken signs the personal ticket of an event attendee, scanned
+// by organizers at check-in. The subject is not a user id on purpose, so a
+// ticket can never pass as a session token.
+func GenerateTicketToken(attendanceId int64, eventId int64, expiry time.Time) (string, error) {
+	claims := &TicketClaims{
+		Type:         "ticket",
+		AttendanceID: attendanceId,
+		EventID:      eventId,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fmt.Sprintf("attendance:%d", attendanceId),
+			Issuer:    "Raksana",
+			ExpiresAt: jwt.NewNumericDate(expiry),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(getSecretKey())
+}
+
 func ValidateToken(tokenStr string) (*jwt.Token, *Claims, error) {
 	// Create a instance or new claims to make sure if the parsed claims are type of RegisteredClaims
 	claims := &Claims{}
@@ -111,6 +156,22 @@ func ValidateActivityToken(tokenStr string) (*jwt.Token, *ActivityClaims, error)
 	return token, claims, nil
 }
 
+func ValidateTicketToken(tokenStr string) (*TicketClaims, error) {
+	claims := &TicketClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return getSecretKey(), nil
+	})
+
+	if err != nil || !token.Valid || claims.Type != "ticket" {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
+
 func GetTokenFromRequest(c *fiber.Ctx) (string, error) {
 	//  get the token
 	token := c.Get("Authorization")
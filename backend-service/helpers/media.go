@@ -0,0 +1,12 @@
+package helpers
+
+import (
+	"path"
+	"strings"
+)
+
+// ThumbnailKey returns the key the thumbnail of an uploaded image is stored
+// under, e.g. scans/1/abc.png -> scans/1/abc_thumb.jpg.
+func ThumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_thumb.jpg"
+}
@@ -3,13 +3,16 @@ package helpers
 import "time"
 
 func SecondsUntilMidnight() int {
+	return int(time.Until(NextMidnight()).Seconds())
+}
+
+func NextMidnight() time.Time {
 	now := time.Now()
-	midnight := time.Date(
+	return time.Date(
 		now.Year(),
 		now.Month(),
 		now.Day()+1,
 		0, 0, 0, 0,
 		now.Location(),
 	)
-	return int(midnight.Sub(now).Seconds())
 }
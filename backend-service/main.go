@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"jirbthagoras/raksana-backend/app"
 	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
 	"jirbthagoras/raksana-backend/repositories"
 	"log/slog"
 	"os"
@@ -12,8 +14,15 @@ import (
 )
 
 func main() {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("MEDIA_MAX_UPLOAD_BYTES", 10<<20)
+	cnf.SetDefault("SCAN_MAX_IMAGES", 5)
+
 	server := fiber.New(fiber.Config{
 		ErrorHandler: exceptions.ErrorHandler,
+		// a trash scan carries up to SCAN_MAX_IMAGES images, leave room for
+		// the multipart overhead on top of them
+		BodyLimit: cnf.GetInt("MEDIA_MAX_UPLOAD_BYTES")*cnf.GetInt("SCAN_MAX_IMAGES") + 1<<20,
 	})
 
 	// open connection
@@ -31,6 +40,7 @@ func main() {
 
 	router := app.NewAppRouter(validator, repository, redisConn)
 	router.RegisterRoute(api)
+	router.StartJobs(context.Background())
 
 	// go func() {
 	if err := server.Listen(":3000"); err != nil {
@@ -1,7 +1,43 @@
 package models
 
 type ActivityRequest struct {
-	Token string `json:"token" validate:"required"`
+	Token     string   `json:"token" validate:"required"`
+	Latitude  *float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" validate:"omitempty,longitude"`
+}
+
+type PostGeofenceOverride struct {
+	UserID       int64  `json:"user_id" validate:"required"`
+	ActivityType string `json:"activity_type" validate:"required,oneof=quest event treasure"`
+	ActivityID   int64  `json:"activity_id" validate:"required"`
+	Reason       string `json:"reason" validate:"max=255"`
+}
+
+type ResponseActivityScan struct {
+	ID             int64    `json:"id"`
+	UserID         int64    `json:"user_id"`
+	ActivityType   string   `json:"activity_type"`
+	ActivityID     int64    `json:"activity_id"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	DistanceMeters *float64 `json:"distance_meters"`
+	Status         string   `json:"status"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+type PostActivityToken struct {
+	ActivityType    string `json:"activity_type" validate:"required,oneof=quest event treasure"`
+	ActivityID      int64  `json:"activity_id" validate:"required"`
+	RotationSeconds int    `json:"rotation_seconds" validate:"omitempty,min=10,max=3600"`
+}
+
+type ResponseActivityToken struct {
+	CodeID          string `json:"code_id"`
+	Token           string `json:"token"`
+	ImageUrl        string `json:"image_url,omitempty"`
+	QRCode          string `json:"qr_code,omitempty"`
+	RotationSeconds int    `json:"rotation_seconds,omitempty"`
+	ExpiresAt       string `json:"expires_at"`
 }
 
 type ResponseContributions struct {
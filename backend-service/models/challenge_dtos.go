@@ -9,3 +9,49 @@ type ResponseChallenge struct {
 	PointGain    int    `json:"point_gain,omitempty"`
 	Participants int    `json:"participants"`
 }
+
+type PostParticipationReview struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ResponseParticipation struct {
+	ID            int64  `json:"id"`
+	ChallengeID   int64  `json:"challenge_id"`
+	ChallengeName string `json:"challenge_name"`
+	Day           int32  `json:"day"`
+	UserID        int64  `json:"user_id"`
+	Username      string `json:"username"`
+	FileURL       string `json:"file_url"`
+	Description   string `json:"description"`
+	Status        string `json:"status"`
+	PointGain     int32  `json:"point_gain"`
+	Note          string `json:"note,omitempty"`
+	UploadedAt    string `json:"uploaded_at,omitempty"`
+	ReviewedAt    string `json:"reviewed_at,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type PostParticipationReaction struct {
+	Reaction string `json:"reaction" validate:"required,oneof=like love inspiring clap"`
+}
+
+type ResponseReactions struct {
+	Votes     int32  `json:"votes"`
+	Like      int32  `json:"like"`
+	Love      int32  `json:"love"`
+	Inspiring int32  `json:"inspiring"`
+	Clap      int32  `json:"clap"`
+	Mine      string `json:"mine,omitempty"`
+}
+
+type ResponseTopSubmission struct {
+	Rank            int               `json:"rank"`
+	ParticipationID int64             `json:"participation_id"`
+	UserID          int64             `json:"user_id"`
+	UserName        string            `json:"user_name"`
+	FileURL         string            `json:"file_url"`
+	Description     string            `json:"description"`
+	CreatedAt       string            `json:"created_at"`
+	BonusPoints     int32             `json:"bonus_points"`
+	Reactions       ResponseReactions `json:"reactions"`
+}
@@ -0,0 +1,79 @@
+package models
+
+type PostDeposit struct {
+	WasteBankID int64             `json:"waste_bank_id" validate:"required"`
+	Items       []PostDepositItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type PostDepositItem struct {
+	ItemID   int64   `json:"item_id"`
+	Material string  `json:"material" validate:"required"`
+	Weight   float64 `json:"weight" validate:"required,gt=0,lte=1000"`
+}
+
+type PostDepositReview struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ResponseWasteBank struct {
+	ID             int64                    `json:"id"`
+	Name           string                   `json:"name"`
+	Address        string                   `json:"address"`
+	Latitude       float64                  `json:"latitude"`
+	Longitude      float64                  `json:"longitude"`
+	Phone          string                   `json:"phone,omitempty"`
+	DistanceMeters float64                  `json:"distance_meters,omitempty"`
+	Prices         []ResponseWasteBankPrice `json:"prices"`
+}
+
+type ResponseWasteBankPrice struct {
+	Material   string `json:"material"`
+	PricePerKg int32  `json:"price_per_kg"`
+	PriceText  string `json:"price_text"`
+}
+
+type ResponseDeposit struct {
+	ID            int64                 `json:"id"`
+	WasteBankID   int64                 `json:"waste_bank_id"`
+	WasteBankName string                `json:"waste_bank_name,omitempty"`
+	Status        string                `json:"status"`
+	TotalWeight   float64               `json:"total_weight"`
+	Payout        int32                 `json:"payout"`
+	PayoutText    string                `json:"payout_text"`
+	PointGain     int32                 `json:"point_gain"`
+	Note          string                `json:"note,omitempty"`
+	ConfirmedAt   string                `json:"confirmed_at,omitempty"`
+	CreatedAt     string                `json:"created_at"`
+	Items         []ResponseDepositItem `json:"items,omitempty"`
+}
+
+type ResponseDepositItem struct {
+	ID         int64   `json:"id"`
+	ItemID     int64   `json:"item_id,omitempty"`
+	ItemName   string  `json:"item_name,omitempty"`
+	Material   string  `json:"material"`
+	Weight     float64 `json:"weight"`
+	PricePerKg int32   `json:"price_per_kg"`
+	Subtotal   int32   `json:"subtotal"`
+}
+
+type ResponseDepositStatistic struct {
+	Deposits    int64                     `json:"deposits"`
+	TotalWeight float64                   `json:"total_weight"`
+	TotalPayout int64                     `json:"total_payout"`
+	PayoutText  string                    `json:"payout_text"`
+	TotalPoints int64                     `json:"total_points"`
+	Materials   []ResponseDepositMaterial `json:"materials"`
+}
+
+type ResponseDepositMaterial struct {
+	Material string  `json:"material"`
+	Weight   float64 `json:"weight"`
+}
+
+type ResponseRegionDepositStatistic struct {
+	RegionID    int64   `json:"region_id"`
+	Name        string  `json:"name"`
+	Deposits    int64   `json:"deposits"`
+	TotalWeight float64 `json:"total_weight"`
+}
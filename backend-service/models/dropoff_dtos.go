@@ -0,0 +1,30 @@
+package models
+
+type GetNearbyDropoff struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+	Radius    float64  `json:"radius" validate:"gte=0,lte=50000"`
+	Material  string   `json:"material"`
+	Limit     int      `json:"limit" validate:"gte=0,lte=50"`
+}
+
+type ResponseDropoffPoint struct {
+	ID             int64                 `json:"id"`
+	Name           string                `json:"name"`
+	Address        string                `json:"address"`
+	Description    string                `json:"description,omitempty"`
+	Latitude       float64               `json:"latitude"`
+	Longitude      float64               `json:"longitude"`
+	Phone          string                `json:"phone,omitempty"`
+	DistanceMeters float64               `json:"distance_meters,omitempty"`
+	IsOpenNow      bool                  `json:"is_open_now"`
+	Materials      []string              `json:"materials"`
+	OpeningHours   []ResponseDropoffHour `json:"opening_hours"`
+}
+
+type ResponseDropoffHour struct {
+	Day      int    `json:"day"`
+	DayName  string `json:"day_name"`
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
@@ -15,12 +15,38 @@ type ResponseEvent struct {
 	CreatedAt    string  `json:"created_at,omitempty"`
 	IsEnded      bool    `json:"is_ended,omitempty"`
 	Participated bool    `json:"participated"`
+	Waitlisted   bool    `json:"waitlisted"`
+
+	MaxAttendees         int32  `json:"max_attendees,omitempty"`
+	RemainingSeats       *int   `json:"remaining_seats,omitempty"`
+	RegistrationClosesAt string `json:"registration_closes_at,omitempty"`
+	RegistrationClosed   bool   `json:"registration_closed,omitempty"`
+
+	DistanceMeters *float64 `json:"distance_meters,omitempty"`
+	RatingAverage  float64  `json:"rating_average"`
+	RatingCount    int32    `json:"rating_count"`
+}
+
+type GetEvents struct {
+	Status    string  `json:"status" validate:"omitempty,oneof=upcoming ongoing past"`
+	From      string  `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To        string  `json:"to" validate:"omitempty,datetime=2006-01-02"`
+	Search    string  `json:"q" validate:"max=100"`
+	Latitude  float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude float64 `json:"longitude" validate:"omitempty,longitude"`
+	Radius    float64 `json:"radius" validate:"gte=0,lte=100000"`
+	Sort      string  `json:"sort" validate:"omitempty,oneof=date date_desc distance newest"`
 }
 
 type RequestRegisterAttendance struct {
 	ContactNumber string `json:"contact_number" validate:"required"`
 }
 
+type ResponseEventRegistration struct {
+	Status           string `json:"status"`
+	WaitlistPosition int64  `json:"waitlist_position,omitempty"`
+}
+
 type ResponseAttendance struct {
 	ID                int64   `json:"id"`
 	RegisteredAt      string  `json:"registered_at"`
@@ -37,3 +63,48 @@ type ResponseAttendance struct {
 	PointGain         int64   `json:"point_gain"`
 	AttendedAt        string  `json:"attended_at,omitempty"`
 }
+
+type RequestTicketCheckIn struct {
+	Ticket string `json:"ticket" validate:"required"`
+}
+
+type ResponseEventTicket struct {
+	AttendanceID int64  `json:"attendance_id"`
+	EventName    string `json:"event_name"`
+	Token        string `json:"token"`
+	QRCode       string `json:"qr_code"`
+	OpensAt      string `json:"opens_at"`
+	ClosesAt     string `json:"closes_at"`
+}
+
+type PostEventFeedback struct {
+	Rating  int    `json:"rating" form:"rating" validate:"required,min=1,max=5"`
+	Comment string `json:"comment" form:"comment" validate:"max=1000"`
+}
+
+type ResponseEventFeedback struct {
+	ID        int64  `json:"id"`
+	UserID    int64  `json:"user_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Rating    int    `json:"rating"`
+	Comment   string `json:"comment"`
+	PhotoUrl  string `json:"photo_url,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ResponseEventFeedbackSummary struct {
+	Total        int64         `json:"total"`
+	Average      float64       `json:"average"`
+	Distribution map[int]int64 `json:"distribution"`
+}
+
+type ResponseEventAttendee struct {
+	AttendanceID  int64  `json:"attendance_id"`
+	UserID        int64  `json:"user_id"`
+	Name          string `json:"name"`
+	Username      string `json:"username"`
+	ContactNumber string `json:"contact_number"`
+	Status        string `json:"status"`
+	RegisteredAt  string `json:"registered_at"`
+	AttendedAt    string `json:"attended_at,omitempty"`
+}
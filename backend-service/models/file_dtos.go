@@ -4,3 +4,12 @@ type PostFilePresigned struct {
 	Filename    string `json:"filename" validate:"required"`
 	ContentType string `json:"content_type" validate:"required"`
 }
+
+type UploadedImage struct {
+	Key          string `json:"key"`
+	ThumbnailKey string `json:"thumbnail_key"`
+	ContentType  string `json:"content_type"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Data         []byte `json:"-"`
+}
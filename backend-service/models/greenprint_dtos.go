@@ -0,0 +1,91 @@
+package models
+
+type PostGreenprintUse struct {
+	ItemID int64 `json:"item_id" validate:"required"`
+}
+
+type PostGreenprintRate struct {
+	Rating int `json:"rating" validate:"required,min=1,max=5"`
+}
+
+type PutGreenprintVisibility struct {
+	IsPublic *bool `json:"is_public" validate:"required"`
+}
+
+type ResponseCatalogGreenprint struct {
+	ID                  int64   `json:"id"`
+	Title               string  `json:"title"`
+	Description         string  `json:"description"`
+	ItemName            string  `json:"item_name"`
+	SustainabilityScore string  `json:"sustainability_score"`
+	EstimatedTime       string  `json:"estimated_time"`
+	Rating              float64 `json:"rating"`
+	RatingCount         int64   `json:"rating_count"`
+	Uses                int64   `json:"uses"`
+	CreatedAt           string  `json:"created_at"`
+}
+
+type PutGreenprintOwned struct {
+	MaterialIDs []int64 `json:"material_ids"`
+	ToolIDs     []int64 `json:"tool_ids"`
+}
+
+type ResponseGreenprintCost struct {
+	GreenprintID       int64                  `json:"greenprint_id"`
+	Title              string                 `json:"title"`
+	Currency           string                 `json:"currency"`
+	Total              int64                  `json:"total"`
+	MaterialsTotal     int64                  `json:"materials_total"`
+	RequiredToolsTotal int64                  `json:"required_tools_total"`
+	OptionalToolsTotal int64                  `json:"optional_tools_total"`
+	OwnedTotal         int64                  `json:"owned_total"`
+	ToBuyTotal         int64                  `json:"to_buy_total"`
+	Unassigned         int64                  `json:"unassigned"`
+	Steps              []ResponseStepCost     `json:"steps"`
+	Materials          []ResponseCostMaterial `json:"materials"`
+	Tools              []ResponseCostTool     `json:"tools"`
+}
+
+type ResponseStepCost struct {
+	StepID      int64  `json:"step_id"`
+	Position    int    `json:"position"`
+	Description string `json:"description"`
+	Cost        int64  `json:"cost"`
+}
+
+type ResponseCostMaterial struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Price    int32  `json:"price"`
+	Quantity int32  `json:"quantity"`
+	Subtotal int64  `json:"subtotal"`
+	StepID   int64  `json:"step_id,omitempty"`
+	Owned    bool   `json:"owned"`
+}
+
+type ResponseCostTool struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Price    int32  `json:"price"`
+	StepID   int64  `json:"step_id,omitempty"`
+	Optional bool   `json:"optional"`
+	Owned    bool   `json:"owned"`
+}
+
+type ResponseShoppingList struct {
+	GreenprintID  int64                  `json:"greenprint_id"`
+	Title         string                 `json:"title"`
+	Currency      string                 `json:"currency"`
+	Items         []ResponseShoppingItem `json:"items"`
+	Total         int64                  `json:"total"`
+	OptionalTotal int64                  `json:"optional_total"`
+}
+
+type ResponseShoppingItem struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Quantity int32  `json:"quantity"`
+	Price    int32  `json:"price"`
+	Subtotal int64  `json:"subtotal"`
+	Optional bool   `json:"optional"`
+}
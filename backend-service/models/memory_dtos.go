@@ -8,16 +8,22 @@ import (
 type ResponseMemory struct {
 	MemoryID        int64   `json:"memory_id,omitempty"`
 	FileURL         string  `json:"file_url"`
+	ThumbnailURL    string  `json:"thumbnail_url,omitempty"`
 	Description     string  `json:"description"`
 	CreatedAt       string  `json:"created_at"`
 	UserID          int64   `json:"user_id"`
 	UserName        string  `json:"user_name"`
 	IsParticipation bool    `json:"is_participation,omitempty"`
+	Status          *string `json:"participation_status,omitempty"`
 	ChallengeID     *int64  `json:"challenge_id,omitempty"`
 	Day             *int32  `json:"day,omitempty"`
 	Difficulty      *string `json:"difficulty,omitempty"`
 	ChallengeName   *string `json:"challenge_name,omitempty"`
 	PointGain       *int64  `json:"point_gain,omitempty"`
+
+	ParticipationID int64              `json:"participation_id,omitempty"`
+	BonusPoints     int32              `json:"bonus_points,omitempty"`
+	Reactions       *ResponseReactions `json:"reactions,omitempty"`
 }
 
 type PostMemoryCreate struct {
@@ -26,6 +32,10 @@ type PostMemoryCreate struct {
 	Description string `json:"description" validate:"required"`
 }
 
+type PostMemoryUpload struct {
+	Description string `form:"description" validate:"required"`
+}
+
 func ToResponseMemory(row repositories.GetMemoryWithParticipationRow) ResponseMemory {
 
 	cnf := helpers.NewConfig()
@@ -40,9 +50,17 @@ func ToResponseMemory(row repositories.GetMemoryWithParticipationRow) ResponseMe
 		IsParticipation: row.IsParticipation,
 	}
 
+	// only images uploaded through the media pipeline have a thumbnail
+	if row.ThumbnailKey.Valid {
+		resp.ThumbnailURL = bucketUrl + row.ThumbnailKey.String
+	}
+
 	if row.ChallengeID.Valid {
 		resp.ChallengeID = &row.ChallengeID.Int64
 	}
+	if row.ParticipationStatus.Valid {
+		resp.Status = &row.ParticipationStatus.String
+	}
 	if row.Day.Valid {
 		resp.Day = &row.Day.Int32
 	}
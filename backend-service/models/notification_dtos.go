@@ -0,0 +1,10 @@
+package models
+
+type ResponseNotification struct {
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	IsRead    bool   `json:"is_read"`
+	CreatedAt string `json:"created_at"`
+}
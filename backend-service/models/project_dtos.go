@@ -0,0 +1,26 @@
+package models
+
+type PostProjectStart struct {
+	GreenprintID int64 `json:"greenprint_id" validate:"required"`
+}
+
+type ResponseProject struct {
+	ID                int64                 `json:"id"`
+	GreenprintID      int64                 `json:"greenprint_id"`
+	Title             string                `json:"title"`
+	Status            string                `json:"status"`
+	ProofURL          string                `json:"proof_url,omitempty"`
+	ProofThumbnailURL string                `json:"proof_thumbnail_url,omitempty"`
+	TotalSteps        int                   `json:"total_steps"`
+	CompletedSteps    int                   `json:"completed_steps"`
+	StartedAt         string                `json:"started_at"`
+	CompletedAt       string                `json:"completed_at,omitempty"`
+	Steps             []ResponseProjectStep `json:"steps,omitempty"`
+}
+
+type ResponseProjectStep struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	Done        bool   `json:"done"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
@@ -20,6 +20,65 @@ type Contributors struct {
 }
 
 type GetNearestQuest struct {
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+}
+
+type GetNearbyQuests struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+	Radius    float64  `json:"radius" validate:"gte=0,lte=50000"`
+	Sort      string   `json:"sort" validate:"omitempty,oneof=distance reward"`
+}
+
+type ResponseNearbyQuest struct {
+	ID             int64   `json:"id"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+	Clue           string  `json:"clue"`
+	PointGain      int64   `json:"point_gain"`
+	DistanceMeters float64 `json:"distance_meters"`
+	Bearing        float64 `json:"bearing"`
+	Direction      string  `json:"direction"`
+	RemainingSlots *int    `json:"remaining_slots"`
+	Contributed    bool    `json:"contributed"`
+}
+
+type ResponseQuestChain struct {
+	ID                  int64                `json:"id"`
+	Name                string               `json:"name"`
+	Description         string               `json:"description"`
+	CompletionPointGain int64                `json:"completion_point_gain"`
+	TotalStages         int                  `json:"total_stages"`
+	CompletedStages     int                  `json:"completed_stages"`
+	Completed           bool                 `json:"completed"`
+	Stages              []ResponseChainStage `json:"stages,omitempty"`
+}
+
+type ResponseChainStage struct {
+	StageOrder    int    `json:"stage_order"`
+	Status        string `json:"status"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	PointGain     int64  `json:"point_gain"`
+	Clue          string `json:"clue,omitempty"`
+	Location      string `json:"location,omitempty"`
+	ContributedAt string `json:"contributed_at,omitempty"`
+}
+
+type ResponseChainProgress struct {
+	ChainID             int64               `json:"chain_id"`
+	StageOrder          int                 `json:"stage_order"`
+	NextStage           *ResponseChainStage `json:"next_stage"`
+	Completed           bool                `json:"completed"`
+	CompletionPointGain int64               `json:"completion_point_gain,omitempty"`
+}
+
+type ResponseChainLeaderboard struct {
+	Rank            int    `json:"rank"`
+	UserID          int64  `json:"user_id"`
+	Username        string `json:"username"`
+	CompletedStages int    `json:"completed_stages"`
+	Completed       bool   `json:"completed"`
+	CompletedAt     string `json:"completed_at,omitempty"`
 }
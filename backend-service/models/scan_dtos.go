@@ -2,7 +2,19 @@ package models
 
 type RequestScannedObject struct {
 	Name       string     `json:"name"`
+	Confidence float32    `json:"confidence"`
 	Categories []Category `json:"categories"`
+	Images     []int      `json:"images,omitempty"`
+}
+
+type AIResponseVision struct {
+	Labels []AIResponseVisionLabel `json:"labels"`
+}
+
+type AIResponseVisionLabel struct {
+	Name       string   `json:"name"`
+	Confidence float32  `json:"confidence"`
+	Categories []string `json:"categories"`
 }
 
 type Category struct {
@@ -10,21 +22,37 @@ type Category struct {
 }
 
 type AIResponseScan struct {
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	ImageKey    string          `json:"image_key"`
-	Items       []ResponseItems `json:"items"`
+	ID          int64               `json:"id,omitempty"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	ImageKey    string              `json:"image_key"`
+	Thumbnail   string              `json:"thumbnail_url"`
+	Images      []ResponseScanImage `json:"images"`
+	Items       []ResponseItems     `json:"items"`
+	CreatedAt   string              `json:"created_at,omitempty"`
+}
+
+type ResponseScanImage struct {
+	ImageUrl     string `json:"image_url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
 }
 
 type ResponseItems struct {
-	Id               int    `json:"id,omitempty"`
-	Name             string `json:"name"`
-	Description      string `json:"description"`
-	Value            string `json:"value"`
-	HavingGreenprint bool   `json:"having_greenprint"`
+	Id               int                   `json:"id,omitempty"`
+	Name             string                `json:"name"`
+	Description      string                `json:"description"`
+	Value            string                `json:"value"`
+	Material         string                `json:"material,omitempty"`
+	HavingGreenprint bool                  `json:"having_greenprint"`
+	Sources          []int                 `json:"sources,omitempty"`
+	SourceImages     []string              `json:"source_images"`
+	DepositID        int64                 `json:"deposit_id,omitempty"`
+	DepositStatus    string                `json:"deposit_status,omitempty"`
+	NearestDropoff   *ResponseDropoffPoint `json:"nearest_dropoff,omitempty"`
 }
 
 type AIResponseGreenprint struct {
+	ID                  int64              `json:"id,omitempty"`
 	Title               string             `json:"'title"`
 	Description         string             `json:"description"`
 	SustainabilityScore string             `json:"sustainability_score"`
@@ -33,7 +61,11 @@ type AIResponseGreenprint struct {
 	Materials           []ResponseMaterial `json:"materials"`
 	Steps               []ResponseStep     `json:"steps"`
 	Text                string
-	CreatedAt           string `json:"created_at,omitempty"`
+	SourceID            int64   `json:"source_id,omitempty"`
+	IsPublic            bool    `json:"is_public"`
+	Rating              float64 `json:"rating"`
+	RatingCount         int64   `json:"rating_count"`
+	CreatedAt           string  `json:"created_at,omitempty"`
 }
 
 type ResponseTool struct {
@@ -42,6 +74,9 @@ type ResponseTool struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	Price        int32  `json:"price"`
+	Step         int    `json:"step,omitempty"`
+	StepID       int64  `json:"step_id,omitempty"`
+	Optional     bool   `json:"optional"`
 }
 
 type ResponseMaterial struct {
@@ -51,6 +86,8 @@ type ResponseMaterial struct {
 	Price        int32  `json:"price"`
 	Quantity     int32  `json:"Quantity"`
 	GreenprintID int64  `json:"greenprint_id,omitempty"`
+	Step         int    `json:"step,omitempty"`
+	StepID       int64  `json:"step_id,omitempty"`
 }
 
 type ResponseStep struct {
@@ -6,3 +6,24 @@ type ResponseTreasure struct {
 	PointGain int    `json:"point_gain"`
 	ClaimedAt string `json:"claimed_at"`
 }
+
+type GetTreasureHint struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+}
+
+type ResponseHuntableTreasure struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	PointGain       int64  `json:"point_gain"`
+	RemainingClaims int    `json:"remaining_claims"`
+	ExpiresAt       string `json:"expires_at,omitempty"`
+}
+
+type ResponseTreasureHint struct {
+	TreasureID int64  `json:"treasure_id"`
+	Band       string `json:"band"`
+	Trend      string `json:"trend,omitempty"`
+	InRange    bool   `json:"in_range"`
+	HintsLeft  int    `json:"hints_left"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type AIUsageRecord struct {
+	Feature          string
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Latency          time.Duration
+	Err              error
+}
+
+type ResponseUsageSummary struct {
+	DailyResetsAt   string                 `json:"daily_resets_at"`
+	MonthlyResetsAt string                 `json:"monthly_resets_at"`
+	Features        []ResponseFeatureUsage `json:"features"`
+}
+
+type ResponseFeatureUsage struct {
+	Feature      string  `json:"feature"`
+	DailyUsed    int     `json:"daily_used"`
+	DailyLimit   int     `json:"daily_limit"`
+	MonthlyUsed  int     `json:"monthly_used"`
+	MonthlyLimit int     `json:"monthly_limit"`
+	Tokens       int64   `json:"tokens"`
+	Cost         float64 `json:"cost"`
+}
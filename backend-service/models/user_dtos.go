@@ -17,6 +17,7 @@ type ResponseGetUserProfileStatistic struct {
 	LongestStreak          int32   `json:"longest_streak"`
 	CurrentStreak          int     `json:"current_Streak"`
 	TreeGrown              int32   `json:"tree_grown"`
+	VolunteerHours         float64 `json:"volunteer_hours"`
 	NeededExpPreviousLevel int     `json:"needed_exp_previous_level"`
 	CompletedTask          int32   `json:"completed_task"`
 	AssignedTask           int32   `json:"assigend_task"`
@@ -65,3 +66,31 @@ type RequestStatistics struct {
 	Treasures     int `json:"treasures"`
 	LongestStreak int `json:"longest_streak"`
 }
+
+type PostCertificate struct {
+	EventID int64  `json:"event_id" validate:"omitempty,gt=0"`
+	From    string `json:"from" validate:"omitempty,datetime=2006-01-02"`
+	To      string `json:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type ResponseCertificateEvent struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	StartsAt string  `json:"starts_at"`
+	Hours    float64 `json:"hours"`
+}
+
+type ResponseCertificate struct {
+	Code       string                     `json:"code"`
+	Holder     string                     `json:"holder"`
+	EventName  string                     `json:"event_name,omitempty"`
+	PeriodFrom string                     `json:"period_from,omitempty"`
+	PeriodTo   string                     `json:"period_to,omitempty"`
+	EventCount int32                      `json:"event_count"`
+	Hours      float64                    `json:"hours"`
+	ImageUrl   string                     `json:"image_url"`
+	VerifyUrl  string                     `json:"verify_url,omitempty"`
+	IssuedAt   string                     `json:"issued_at"`
+	Events     []ResponseCertificateEvent `json:"events,omitempty"`
+	Valid      bool                       `json:"valid"`
+}
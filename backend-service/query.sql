@@ -165,7 +165,8 @@ SELECT
     s.quests,
     s.treasures,
     s.longest_streak,
-    s.tree_grown
+    s.tree_grown,
+    s.volunteer_minutes
 FROM users u
 JOIN profiles p ON u.id = p.user_id
 JOIN statistics s ON u.id = s.user_id
@@ -190,14 +191,15 @@ JOIN users u ON u.id = p.user_id
 WHERE p.id = $1;
 
 -- name: CreateMemory :one
-INSERT INTO memories(user_id, file_key, description)
-VALUES ($1, $2, $3)
+INSERT INTO memories(user_id, file_key, description, thumbnail_key)
+VALUES ($1, $2, $3, $4)
 RETURNING id;
 
 -- name: GetMemoryWithParticipation :many
 SELECT 
     m.id AS memory_id,
     m.file_key,
+    m.thumbnail_key,
     m.description AS memory_description,
     m.created_at AS memory_created_at,
     u.id AS user_id,
@@ -207,6 +209,7 @@ SELECT
         ELSE FALSE 
     END AS is_participation,
     p.challenge_id,
+    p.status AS participation_status,
     c.day,
     c.difficulty,
     d.name AS challenge_name,
@@ -222,7 +225,7 @@ ORDER BY m.created_at DESC;
 -- name: DeleteMemory :one
 DELETE FROM memories
 WHERE id = $1 AND user_id = $2
-RETURNING file_key;
+RETURNING file_key, thumbnail_key;
 
 -- name: UpdateUserProfile :exec
 UPDATE profiles
@@ -253,8 +256,8 @@ INSERT INTO recaps(user_id, summary, tips, assigned_task, completed_task, comple
 VALUES ($1, $2, $3, $4, $5, $6, $7, 'weekly');
 
 -- name: CreateParticipation :one
-INSERT INTO participations(challenge_id, user_id, memory_id)
-VALUES ($1, $2, $3)
+INSERT INTO participations(challenge_id, user_id, memory_id, point_gain, status)
+VALUES ($1, $2, $3, $4, 'pending_upload')
 RETURNING *;
 
 -- name: GetChallengeWithDetail :one
@@ -306,15 +309,14 @@ SET challenges = challenges + 1
 WHERE user_id = $1
 RETURNING *;
 
--- name: IncreaseQuestsFieldByOne :one
+-- name: DecreaseChallengesFieldByOne :exec
 UPDATE statistics
-SET quests = quests + 1
-WHERE user_id = $1
-RETURNING *;
+SET challenges = GREATEST(challenges - 1, 0)
+WHERE user_id = $1;
 
--- name: IncreaseEventsFieldByOne :one
+-- name: IncreaseQuestsFieldByOne :one
 UPDATE statistics
-SET events = events + 1
+SET quests = quests + 1
 WHERE user_id = $1
 RETURNING *;
 
@@ -326,7 +328,7 @@ RETURNING *;
 
 -- name: CheckParticipation :one
 SELECT
-COUNT (*) FILTER (WHERE user_id = $1 AND challenge_id = $2)
+COUNT (*) FILTER (WHERE user_id = $1 AND challenge_id = $2 AND status IN ('pending_review', 'approved'))
 FROM participations;
 
 -- name: GetTodayChallenge :one
@@ -362,6 +364,7 @@ ORDER BY d.created_at DESC;
 
 -- name: GetMemoriesByChallengeID :many
 SELECT 
+    p.id AS participation_id,
     m.id AS memory_id,
     m.file_key,
     m.description,
@@ -369,25 +372,34 @@ SELECT
     u.id AS user_id,
     u.name AS user_name,
     u.username,
-    u.email
+    u.email,
+    p.bonus_points,
+    COALESCE(r.votes, 0)::int AS votes,
+    COALESCE(r.likes, 0)::int AS likes,
+    COALESCE(r.loves, 0)::int AS loves,
+    COALESCE(r.inspirings, 0)::int AS inspirings,
+    COALESCE(r.claps, 0)::int AS claps,
+    mr.reaction AS my_reaction
 FROM participations p
 JOIN memories m ON p.memory_id = m.id
 JOIN users u ON m.user_id = u.id
-WHERE p.challenge_id = $1
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.challenge_id = $2 AND p.status = 'approved'
 ORDER BY m.created_at DESC;
 
 -- name: GetTreasureByCodeId :one
 SELECT * FROM treasures
-WHERE code_id = $1 AND claimed = false;
-
--- name: DeactivateTreasure :exec
-UPDATE treasures
-SET claimed = true
-WHERE id = $1;
-
--- name: CreateClaimed :exec
-INSERT INTO claimed(user_id, treasure_id)
-VALUES ($1, $2);
+WHERE code_id = $1;
 
 -- name: GetAllClaimedTreasure :many
 SELECT 
@@ -407,6 +419,8 @@ SELECT
   q.max_contributors AS max_contributors,
   q.latitude AS latitude,
   q.longitude AS longitude,
+  q.chain_id AS chain_id,
+  q.stage_order AS stage_order,
   d.name AS name,
   d.description AS description,
   d.point_gain AS point_gain
@@ -525,11 +539,6 @@ JOIN events e ON a.event_id = e.id
 JOIN details d ON e.detail_id = d.id
 WHERE a.user_id = $1 AND a.attended = false;
 
--- name: Attend :exec
-UPDATE attendances
-SET attended = true
-WHERE id = $1;
-
 -- name: GetUserAttendanceByUserId :one
 SELECT 
     a.id AS attendance_id,
@@ -578,11 +587,6 @@ JOIN events e ON a.event_id = e.id
 JOIN details d ON e.detail_id = d.id
 WHERE a.id = $1;
 
--- name: UpdaAttendedAt :exec
-UPDATE attendances
-SET attended_at = CURRENT_TIMESTAMP
-WHERE id = $1;
-
 -- name: GetUserAttendanceById :one
 SELECT 
     a.id AS attendance_id,
@@ -628,11 +632,6 @@ JOIN events e ON a.event_id = e.id
 JOIN details d ON e.detail_id = d.id
 WHERE a.id = $1;
 
--- name: CreateAttendance :one
-INSERT INTO attendances(user_id, event_id, contact_number)
-VALUES ($1, $2, $3)
-RETURNING *;
-
 -- name: GetEventByCodeId :one
 SELECT
   e.id,
@@ -647,33 +646,16 @@ WHERE e.code_id = $1;
 SELECT 
   e.id,
   d.name AS name,
-  d.description AS description
+  d.description AS description,
+  e.starts_at,
+  e.max_attendees,
+  e.attendee_count,
+  (NOW() >= COALESCE(e.registration_closes_at, e.starts_at)) AS registration_closed,
+  (NOW() >= e.starts_at) AS started
 FROM events e
 JOIN details d ON e.detail_id = d.id
 WHERE e.id = $1;
 
--- name: GetAllEvents :many
-SELECT 
-    e.id,
-    e.detail_id,
-    e.code_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    e.cover_key,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain,
-    d.created_at AS detail_created_at,
-    d.updated_at AS detail_updated_at,
-    (e.ends_at < NOW()) AS ended
-FROM events e
-JOIN details d ON e.detail_id = d.id
-ORDER BY e.starts_at ASC;
-
 -- name: GetAllUser :many
 SELECT
   u.id AS user_id,
@@ -758,15 +740,24 @@ SELECT
     latitude,
     longitude,
     earth_distance(
-        ll_to_earth($1, $2),
+        ll_to_earth(@latitude::float8, @longitude::float8),
         ll_to_earth(latitude, longitude)
     ) AS distance_meters
 FROM quests
 WHERE finished = false
   AND earth_distance(
-        ll_to_earth($1, $2),
+        ll_to_earth(@latitude::float8, @longitude::float8),
         ll_to_earth(latitude, longitude)
-    ) <= $3
+    ) <= @radius::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = quests.chain_id
+      AND p.stage_order < quests.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = @user_id::bigint
+      )
+  )
 ORDER BY distance_meters
 LIMIT 1;
 
@@ -776,8 +767,8 @@ VALUES($1, $2, $3, $4)
 RETURNING *;
 
 -- name: CreateItems :one
-INSERT INTO items(scan_id, user_id, name, description, value)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO items(scan_id, user_id, name, description, value, material)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: CreateGreenprint :one
@@ -786,8 +777,8 @@ VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: CreateMaterials :one
-INSERT INTO materials(greenprint_id,name, description, price, quantity)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO materials(greenprint_id, name, description, price, quantity, step_id)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetMaterials :many
@@ -801,11 +792,12 @@ RETURNING *;
 
 -- name: GetSteps :many
 SELECT * FROM steps
-WHERE greenprint_id = $1;
+WHERE greenprint_id = $1
+ORDER BY id;
 
 -- name: CreateTools :one
-INSERT INTO tools(greenprint_id, name, description, price)
-VALUES($1, $2, $3, $4)
+INSERT INTO tools(greenprint_id, name, description, price, step_id, is_optional)
+VALUES($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetTools :many
@@ -813,16 +805,10 @@ SELECT * FROM tools
 WHERE greenprint_id = $1;
 
 -- name: GetParticipants :one
-SELECT 
-  COUNT (*) FILTER (WHERE challenge_id = $1) AS participants
+SELECT
+  COUNT (*) FILTER (WHERE challenge_id = $1 AND status = 'approved') AS participants
 FROM participations;
 
--- name: GetAllUserScans :many
-SELECT * FROM scans WHERE user_id = $1;
-
--- name: GetItemsByScanId :many
-SELECT * FROM items WHERE scan_id = $1;
-
 -- name: GetItemsById :one
 SELECT * FROM items WHERE id = $1;
 
@@ -860,3 +846,1405 @@ WHERE quest_id = $1 AND user_id = $2;
 UPDATE statistics
 SET tree_grown = tree_grown + $1
 WHERE user_id = $2;
+
+-- name: CreateAiUsage :exec
+INSERT INTO ai_usages(user_id, feature, provider, model, prompt_tokens, completion_tokens, total_tokens, latency_ms, cost, status, error, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: LockUserAiUsage :exec
+SELECT pg_advisory_xact_lock(hashtext('ai_usages'), @user_id::int);
+
+-- name: ReserveAiUsage :one
+WITH used AS (
+  SELECT
+    COUNT(*) FILTER (WHERE feature = @feature AND created_at >= @day_start) AS daily,
+    COUNT(*) FILTER (WHERE feature = @feature) AS monthly,
+    COUNT(*) FILTER (WHERE created_at >= @day_start) AS total_daily,
+    COUNT(*) AS total_monthly
+  FROM ai_usages
+  WHERE user_id = @user_id
+    AND status = 'success'
+    AND feature <> 'trash_vision'
+    AND created_at >= @month_start
+)
+INSERT INTO ai_usages(user_id, feature, provider, model, status, created_at)
+SELECT @user_id, @feature, @provider, @model, 'success', @created_at
+FROM used
+WHERE (@daily_limit::int = 0 OR used.daily < @daily_limit::int)
+  AND (@monthly_limit::int = 0 OR used.monthly < @monthly_limit::int)
+  AND (@total_daily_limit::int = 0 OR used.total_daily < @total_daily_limit::int)
+  AND (@total_monthly_limit::int = 0 OR used.total_monthly < @total_monthly_limit::int)
+RETURNING id;
+
+-- name: FinishAiUsage :exec
+UPDATE ai_usages
+SET prompt_tokens = $2,
+    completion_tokens = $3,
+    total_tokens = $4,
+    latency_ms = $5,
+    cost = $6,
+    status = $7,
+    error = $8
+WHERE id = $1;
+
+-- name: DeleteAiUsage :exec
+DELETE FROM ai_usages
+WHERE id = $1;
+
+-- name: CountUserAiUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE created_at >= @day_start) AS daily,
+  COUNT(*) AS monthly
+FROM ai_usages
+WHERE user_id = @user_id
+  AND feature = @feature
+  AND status = 'success'
+  AND created_at >= @month_start;
+
+-- name: CountUserTotalAiUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE created_at >= @day_start) AS daily,
+  COUNT(*) AS monthly
+FROM ai_usages
+WHERE user_id = @user_id
+  AND status = 'success'
+  AND feature <> 'trash_vision'
+  AND created_at >= @month_start;
+
+-- name: GetUserAiQuotas :many
+SELECT * FROM ai_quotas
+WHERE user_id = $1;
+
+-- name: GetUserAiUsageSummary :many
+SELECT
+  feature,
+  COUNT(*) FILTER (WHERE status = 'success' AND created_at >= @day_start) AS daily,
+  COUNT(*) FILTER (WHERE status = 'success') AS monthly,
+  COALESCE(SUM(total_tokens), 0)::bigint AS tokens,
+  COALESCE(SUM(cost), 0)::float8 AS cost
+FROM ai_usages
+WHERE user_id = @user_id
+  AND created_at >= @month_start
+GROUP BY feature
+ORDER BY feature;
+
+-- name: CreateScanImage :one
+INSERT INTO scan_images(scan_id, image_key, position)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateItemImage :exec
+INSERT INTO item_images(item_id, scan_image_id)
+VALUES ($1, $2);
+
+-- name: CreateGreenprintProject :one
+INSERT INTO greenprint_projects(user_id, greenprint_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetGreenprintProjectById :one
+SELECT * FROM greenprint_projects
+WHERE id = $1;
+
+-- name: GetUserGreenprintProjects :many
+SELECT
+  greenprint_projects.id,
+  greenprint_projects.greenprint_id,
+  greenprints.title,
+  greenprint_projects.status,
+  greenprint_projects.proof_key,
+  greenprint_projects.started_at,
+  greenprint_projects.completed_at,
+  (SELECT COUNT(*) FROM steps WHERE steps.greenprint_id = greenprint_projects.greenprint_id) AS total_steps,
+  (SELECT COUNT(*) FROM project_steps WHERE project_steps.project_id = greenprint_projects.id) AS completed_steps
+FROM greenprint_projects
+JOIN greenprints ON greenprints.id = greenprint_projects.greenprint_id
+WHERE greenprint_projects.user_id = $1
+ORDER BY greenprint_projects.started_at DESC;
+
+-- name: GetProjectSteps :many
+SELECT
+  steps.id,
+  steps.description,
+  project_steps.completed_at
+FROM greenprint_projects
+JOIN steps ON steps.greenprint_id = greenprint_projects.greenprint_id
+LEFT JOIN project_steps ON project_steps.project_id = greenprint_projects.id AND project_steps.step_id = steps.id
+WHERE greenprint_projects.id = $1
+ORDER BY steps.id;
+
+-- name: CreateProjectStep :exec
+INSERT INTO project_steps(project_id, step_id)
+VALUES ($1, $2)
+ON CONFLICT (project_id, step_id) DO NOTHING;
+
+-- name: DeleteProjectStep :exec
+DELETE FROM project_steps
+WHERE project_id = $1 AND step_id = $2;
+
+-- name: CompleteGreenprintProject :one
+UPDATE greenprint_projects
+SET status = 'completed', proof_key = $1, completed_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND status = 'in_progress'
+RETURNING *;
+
+-- name: UpdateGreenprintSearchText :exec
+UPDATE greenprints
+SET search_text = concat_ws(' ', items.name, greenprints.title, (
+  SELECT string_agg(materials.name, ' ') FROM materials WHERE materials.greenprint_id = greenprints.id
+))
+FROM items
+WHERE items.id = greenprints.item_id AND greenprints.id = $1;
+
+-- name: CopyGreenprint :one
+INSERT INTO greenprints(title, item_id, image_key, description, sustainability_score, estimated_time, is_public, source_id, search_text)
+SELECT title, @item_id::bigint, image_key, description, sustainability_score, estimated_time, false, id, search_text
+FROM greenprints
+WHERE greenprints.id = @source_id::bigint
+RETURNING *;
+
+-- name: CopySteps :exec
+INSERT INTO steps(greenprint_id, description)
+SELECT @greenprint_id::bigint, description
+FROM steps
+WHERE steps.greenprint_id = @source_id::bigint
+ORDER BY id;
+
+-- name: CopyMaterials :exec
+INSERT INTO materials(greenprint_id, name, description, price, quantity, step_id)
+SELECT @greenprint_id::bigint, materials.name, materials.description, materials.price, materials.quantity, new_steps.id
+FROM materials
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = @source_id::bigint
+) old_steps ON old_steps.id = materials.step_id
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = @greenprint_id::bigint
+) new_steps ON new_steps.position = old_steps.position
+WHERE materials.greenprint_id = @source_id::bigint
+ORDER BY materials.id;
+
+-- name: CopyTools :exec
+INSERT INTO tools(greenprint_id, name, description, price, step_id, is_optional)
+SELECT @greenprint_id::bigint, tools.name, tools.description, tools.price, new_steps.id, tools.is_optional
+FROM tools
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = @source_id::bigint
+) old_steps ON old_steps.id = tools.step_id
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = @greenprint_id::bigint
+) new_steps ON new_steps.position = old_steps.position
+WHERE tools.greenprint_id = @source_id::bigint
+ORDER BY tools.id;
+
+-- name: SearchGreenprintCatalog :many
+SELECT
+  greenprints.id,
+  greenprints.title,
+  greenprints.description,
+  greenprints.sustainability_score,
+  greenprints.estimated_time,
+  items.name AS item_name,
+  COALESCE(AVG(greenprint_ratings.rating), 0)::float8 AS rating,
+  COUNT(greenprint_ratings.id) AS rating_count,
+  (SELECT COUNT(*) FROM greenprints copies WHERE copies.source_id = greenprints.id) AS uses,
+  greenprints.created_at
+FROM greenprints
+JOIN items ON items.id = greenprints.item_id
+LEFT JOIN greenprint_ratings ON greenprint_ratings.greenprint_id = greenprints.id
+WHERE greenprints.is_public = true
+  AND greenprints.source_id IS NULL
+  AND (@query::text = '' OR to_tsvector('simple', greenprints.search_text) @@ plainto_tsquery('simple', @query::text))
+GROUP BY greenprints.id, items.name
+ORDER BY rating DESC, rating_count DESC, greenprints.created_at DESC
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: GetGreenprintRating :one
+SELECT
+  COALESCE(AVG(rating), 0)::float8 AS rating,
+  COUNT(*) AS rating_count
+FROM greenprint_ratings
+WHERE greenprint_id = $1;
+
+-- name: UpsertGreenprintRating :exec
+INSERT INTO greenprint_ratings(greenprint_id, user_id, rating, created_at, updated_at)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (greenprint_id, user_id)
+DO UPDATE SET rating = EXCLUDED.rating, updated_at = CURRENT_TIMESTAMP;
+
+-- name: HasUsedGreenprint :one
+SELECT EXISTS (
+  SELECT 1 FROM greenprints
+  JOIN items ON items.id = greenprints.item_id
+  WHERE items.user_id = @user_id::bigint
+    AND greenprints.source_id = @greenprint_id::bigint
+)
+AND NOT EXISTS (
+  SELECT 1 FROM greenprints
+  JOIN items ON items.id = greenprints.item_id
+  WHERE greenprints.id = @greenprint_id::bigint
+    AND items.user_id = @user_id::bigint
+) AS used;
+
+-- name: FindReusableGreenprint :one
+SELECT greenprints.id
+FROM greenprints
+JOIN items ON items.id = greenprints.item_id
+JOIN greenprint_ratings ON greenprint_ratings.greenprint_id = greenprints.id
+WHERE greenprints.is_public = true
+  AND greenprints.source_id IS NULL
+  AND lower(trim(items.name)) = lower(trim(@item_name::text))
+GROUP BY greenprints.id
+HAVING AVG(greenprint_ratings.rating) >= @min_rating::float8
+  AND COUNT(greenprint_ratings.id) >= @min_ratings::int
+ORDER BY AVG(greenprint_ratings.rating) DESC, COUNT(greenprint_ratings.id) DESC
+LIMIT 1;
+
+-- name: UpdateGreenprintVisibility :exec
+UPDATE greenprints
+SET is_public = $1
+WHERE id = $2;
+
+-- name: GetOwnedMaterialIds :many
+SELECT owned_materials.material_id
+FROM owned_materials
+JOIN materials ON materials.id = owned_materials.material_id
+WHERE owned_materials.user_id = $1 AND materials.greenprint_id = $2;
+
+-- name: GetOwnedToolIds :many
+SELECT owned_tools.tool_id
+FROM owned_tools
+JOIN tools ON tools.id = owned_tools.tool_id
+WHERE owned_tools.user_id = $1 AND tools.greenprint_id = $2;
+
+-- name: DeleteOwnedMaterials :exec
+DELETE FROM owned_materials
+USING materials
+WHERE materials.id = owned_materials.material_id
+  AND owned_materials.user_id = $1
+  AND materials.greenprint_id = $2;
+
+-- name: DeleteOwnedTools :exec
+DELETE FROM owned_tools
+USING tools
+WHERE tools.id = owned_tools.tool_id
+  AND owned_tools.user_id = $1
+  AND tools.greenprint_id = $2;
+
+-- name: CreateOwnedMaterial :exec
+INSERT INTO owned_materials(user_id, material_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, material_id) DO NOTHING;
+
+-- name: CreateOwnedTool :exec
+INSERT INTO owned_tools(user_id, tool_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, tool_id) DO NOTHING;
+
+-- name: GetWasteBanks :many
+SELECT
+  id,
+  name,
+  address,
+  latitude,
+  longitude,
+  phone,
+  earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(latitude, longitude)
+  )::float8 AS distance_meters
+FROM waste_banks
+WHERE is_active = true
+ORDER BY
+  CASE WHEN @has_location::bool THEN earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(latitude, longitude)
+  ) END,
+  name;
+
+-- name: GetWasteBankById :one
+SELECT * FROM waste_banks
+WHERE id = $1;
+
+-- name: GetWasteBankPrices :many
+SELECT * FROM waste_bank_prices
+WHERE waste_bank_id = $1
+ORDER BY material;
+
+-- name: GetAllWasteBankPrices :many
+SELECT * FROM waste_bank_prices
+ORDER BY waste_bank_id, material;
+
+-- name: CreateDeposit :one
+INSERT INTO deposits(user_id, waste_bank_id, total_weight, payout)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreateDepositItem :exec
+INSERT INTO deposit_items(deposit_id, item_id, material, weight, price_per_kg, subtotal)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetDepositById :one
+SELECT * FROM deposits
+WHERE id = $1;
+
+-- name: GetDepositItems :many
+SELECT
+  deposit_items.id,
+  deposit_items.item_id,
+  deposit_items.material,
+  deposit_items.weight,
+  deposit_items.price_per_kg,
+  deposit_items.subtotal,
+  items.name AS item_name
+FROM deposit_items
+LEFT JOIN items ON items.id = deposit_items.item_id
+WHERE deposit_items.deposit_id = $1
+ORDER BY deposit_items.id;
+
+-- name: GetUserDeposits :many
+SELECT
+  deposits.id,
+  deposits.waste_bank_id,
+  waste_banks.name AS waste_bank_name,
+  deposits.status,
+  deposits.total_weight,
+  deposits.payout,
+  deposits.point_gain,
+  deposits.note,
+  deposits.confirmed_at,
+  deposits.created_at
+FROM deposits
+JOIN waste_banks ON waste_banks.id = deposits.waste_bank_id
+WHERE deposits.user_id = $1
+ORDER BY deposits.created_at DESC;
+
+-- name: ConfirmDeposit :one
+UPDATE deposits
+SET status = 'confirmed', point_gain = $1, note = $2, confirmed_by = $3, confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $4 AND status = 'pending'
+RETURNING *;
+
+-- name: RejectDeposit :one
+UPDATE deposits
+SET status = 'rejected', note = $1, confirmed_by = $2, confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status = 'pending'
+RETURNING *;
+
+-- name: CountActiveItemDeposits :one
+SELECT COUNT(*) FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+WHERE deposit_items.item_id = $1 AND deposits.status <> 'rejected';
+
+-- name: GetUserDepositStatistic :one
+SELECT
+  COUNT(*) AS deposits,
+  COALESCE(SUM(total_weight), 0)::float8 AS total_weight,
+  COALESCE(SUM(payout), 0)::bigint AS total_payout,
+  COALESCE(SUM(point_gain), 0)::bigint AS total_points
+FROM deposits
+WHERE user_id = $1 AND status = 'confirmed';
+
+-- name: GetUserDepositMaterials :many
+SELECT
+  deposit_items.material,
+  SUM(deposit_items.weight)::float8 AS weight
+FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+WHERE deposits.user_id = $1 AND deposits.status = 'confirmed'
+GROUP BY deposit_items.material
+ORDER BY weight DESC;
+
+-- name: GetRegionDepositStatistics :many
+SELECT
+  regions.id,
+  regions.name,
+  COUNT(deposits.id) AS deposits,
+  COALESCE(SUM(deposits.total_weight), 0)::float8 AS total_weight
+FROM regions
+LEFT JOIN waste_banks ON waste_banks.region_id = regions.id
+LEFT JOIN deposits ON deposits.waste_bank_id = waste_banks.id AND deposits.status = 'confirmed'
+GROUP BY regions.id
+ORDER BY total_weight DESC;
+
+-- name: GetNearbyDropoffPoints :many
+SELECT
+  id,
+  name,
+  address,
+  description,
+  latitude,
+  longitude,
+  phone,
+  earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  )::float8 AS distance_meters
+FROM dropoff_points
+WHERE is_active = true
+  AND earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  ) <= @radius::float8
+  AND (@material::text = '' OR EXISTS (
+    SELECT 1 FROM dropoff_materials
+    WHERE dropoff_materials.dropoff_point_id = dropoff_points.id
+      AND dropoff_materials.material = @material::text
+  ))
+ORDER BY distance_meters
+LIMIT @page_limit::int;
+
+-- name: GetNearestDropoffPerMaterial :many
+SELECT DISTINCT ON (dropoff_materials.material)
+  dropoff_materials.material,
+  dropoff_points.id,
+  dropoff_points.name,
+  dropoff_points.address,
+  dropoff_points.description,
+  dropoff_points.latitude,
+  dropoff_points.longitude,
+  dropoff_points.phone,
+  earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  )::float8 AS distance_meters
+FROM dropoff_points
+JOIN dropoff_materials ON dropoff_materials.dropoff_point_id = dropoff_points.id
+WHERE dropoff_points.is_active = true
+  AND dropoff_materials.material = ANY(@materials::text[])
+  AND earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  ) <= @radius::float8
+ORDER BY dropoff_materials.material, distance_meters;
+
+-- name: GetDropoffPointById :one
+SELECT * FROM dropoff_points
+WHERE id = $1;
+
+-- name: GetDropoffMaterialsByPointIds :many
+SELECT * FROM dropoff_materials
+WHERE dropoff_point_id = ANY(@ids::bigint[])
+ORDER BY dropoff_point_id, material;
+
+-- name: GetDropoffHoursByPointIds :many
+SELECT * FROM dropoff_hours
+WHERE dropoff_point_id = ANY(@ids::bigint[])
+ORDER BY dropoff_point_id, day_of_week, opens_at;
+
+-- name: GetUserScans :many
+SELECT * FROM scans
+WHERE user_id = @user_id
+ORDER BY created_at DESC, id DESC
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: CountUserScans :one
+SELECT COUNT(*) FROM scans
+WHERE user_id = $1;
+
+-- name: GetUserScanById :one
+SELECT * FROM scans
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetItemsByScanIds :many
+SELECT
+  items.id,
+  items.scan_id,
+  items.name,
+  items.description,
+  items.value,
+  items.material,
+  EXISTS (
+    SELECT 1 FROM greenprints WHERE greenprints.item_id = items.id
+  ) AS has_greenprint
+FROM items
+WHERE items.scan_id = ANY(@scan_ids::bigint[])
+ORDER BY items.scan_id, items.id;
+
+-- name: GetScanImagesByScanIds :many
+SELECT * FROM scan_images
+WHERE scan_id = ANY(@scan_ids::bigint[])
+ORDER BY scan_id, position;
+
+-- name: GetItemImagesByScanIds :many
+SELECT
+  item_images.item_id,
+  scan_images.image_key
+FROM item_images
+JOIN scan_images ON scan_images.id = item_images.scan_image_id
+WHERE scan_images.scan_id = ANY(@scan_ids::bigint[])
+ORDER BY scan_images.position;
+
+-- name: GetDepositItemsByScanIds :many
+SELECT
+  deposit_items.item_id,
+  deposits.id AS deposit_id,
+  deposits.status
+FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+JOIN items ON items.id = deposit_items.item_id
+WHERE items.scan_id = ANY(@scan_ids::bigint[]) AND deposits.status <> 'rejected';
+
+-- name: GetScanObjectKeys :many
+SELECT image_key::text AS image_key FROM scans WHERE scans.id = $1
+UNION
+SELECT image_key FROM scan_images WHERE scan_images.scan_id = $1
+UNION
+SELECT greenprint_projects.proof_key FROM greenprint_projects
+JOIN greenprints ON greenprints.id = greenprint_projects.greenprint_id
+JOIN items ON items.id = greenprints.item_id
+WHERE items.scan_id = $1 AND greenprint_projects.proof_key IS NOT NULL;
+
+-- name: DeleteScan :execrows
+DELETE FROM scans
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetActivityLocation :one
+SELECT id, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius
+FROM quests
+WHERE @activity_type::text = 'quest' AND code_id = @code_id::text
+UNION ALL
+SELECT id, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius
+FROM events
+WHERE @activity_type::text = 'event' AND code_id = @code_id::text
+UNION ALL
+SELECT id, latitude, longitude, geofence_radius
+FROM treasures
+WHERE @activity_type::text = 'treasure' AND code_id = @code_id::text
+LIMIT 1;
+
+-- name: GetEarthDistance :one
+SELECT earth_distance(
+  ll_to_earth(@from_latitude::float8, @from_longitude::float8),
+  ll_to_earth(@to_latitude::float8, @to_longitude::float8)
+)::float8 AS distance_meters;
+
+-- name: HasGeofenceOverride :one
+SELECT EXISTS (
+  SELECT 1 FROM geofence_overrides
+  WHERE user_id = $1 AND activity_type = $2 AND activity_id = $3
+);
+
+-- name: CreateGeofenceOverride :exec
+INSERT INTO geofence_overrides(user_id, activity_type, activity_id, reason, created_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, activity_type, activity_id) DO UPDATE
+SET reason = EXCLUDED.reason, created_by = EXCLUDED.created_by;
+
+-- name: CreateActivityScan :exec
+INSERT INTO activity_scans(user_id, activity_type, activity_id, latitude, longitude, distance_meters, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetActivityScansByStatus :many
+SELECT * FROM activity_scans
+WHERE status = ANY(@statuses::text[])
+ORDER BY created_at DESC
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: GetCodeById :one
+SELECT * FROM codes
+WHERE id = $1;
+
+-- name: GetActivityCodeId :one
+SELECT code_id FROM quests
+WHERE @activity_type::text = 'quest' AND id = @activity_id::bigint
+UNION ALL
+SELECT code_id FROM events
+WHERE @activity_type::text = 'event' AND id = @activity_id::bigint
+UNION ALL
+SELECT code_id FROM treasures
+WHERE @activity_type::text = 'treasure' AND id = @activity_id::bigint
+LIMIT 1;
+
+-- name: UpdateCodeToken :exec
+UPDATE codes
+SET token_id = $2, rotation_seconds = $3, image_url = $4
+WHERE id = $1;
+
+-- name: ClaimActivityTokenUse :one
+INSERT INTO activity_token_uses(jti, user_id, code_id, use_number)
+SELECT @jti::text, @user_id::bigint, @code_id::text, COALESCE(MAX(use_number), 0) + 1
+FROM activity_token_uses
+WHERE jti = @jti::text AND user_id = @user_id::bigint
+HAVING COUNT(*) < @max_uses::int
+ON CONFLICT (jti, user_id, use_number) DO NOTHING
+RETURNING use_number;
+
+-- name: DeleteActivityTokenUse :exec
+DELETE FROM activity_token_uses
+WHERE jti = $1 AND user_id = $2 AND use_number = $3;
+
+-- name: GetNearbyQuests :many
+SELECT
+  q.id,
+  d.name,
+  d.description,
+  q.clue,
+  d.point_gain,
+  q.max_contributors,
+  q.chain_id,
+  q.latitude::float8 AS latitude,
+  q.longitude::float8 AS longitude,
+  (SELECT COUNT(*) FROM contributions c WHERE c.quest_id = q.id)::int AS contributor_count,
+  EXISTS (
+    SELECT 1 FROM contributions c
+    WHERE c.quest_id = q.id AND c.user_id = @user_id::bigint
+  ) AS contributed,
+  earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  )::float8 AS distance_meters
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE q.finished = false
+  AND q.latitude IS NOT NULL
+  AND q.longitude IS NOT NULL
+  AND earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  ) <= @radius::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = q.chain_id
+      AND p.stage_order < q.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = @user_id::bigint
+      )
+  )
+ORDER BY
+  CASE WHEN @sort_by::text = 'reward' THEN d.point_gain END DESC,
+  distance_meters
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: CountNearbyQuests :one
+SELECT COUNT(*) FROM quests q
+WHERE q.finished = false
+  AND q.latitude IS NOT NULL
+  AND q.longitude IS NOT NULL
+  AND earth_distance(
+    ll_to_earth(@latitude::float8, @longitude::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  ) <= @radius::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = q.chain_id
+      AND p.stage_order < q.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = @user_id::bigint
+      )
+  );
+
+-- name: CountLockedChainStages :one
+SELECT COUNT(*) FROM quests p
+WHERE p.chain_id = @chain_id::bigint
+  AND p.stage_order < @stage_order::int
+  AND NOT EXISTS (
+    SELECT 1 FROM contributions c
+    WHERE c.quest_id = p.id AND c.user_id = @user_id::bigint
+  );
+
+-- name: GetNextChainStage :one
+SELECT q.id, q.stage_order, q.clue, d.name
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE q.chain_id = @chain_id::bigint AND q.stage_order > @stage_order::int
+ORDER BY q.stage_order
+LIMIT 1;
+
+-- name: GetQuestChainById :one
+SELECT * FROM quest_chains
+WHERE id = $1;
+
+-- name: GetActiveQuestChains :many
+SELECT
+  qc.id,
+  qc.name,
+  qc.description,
+  qc.completion_point_gain,
+  (SELECT COUNT(*) FROM quests q WHERE q.chain_id = qc.id)::int AS total_stages,
+  (
+    SELECT COUNT(*) FROM quests q
+    JOIN contributions c ON c.quest_id = q.id
+    WHERE q.chain_id = qc.id AND c.user_id = @user_id::bigint
+  )::int AS completed_stages,
+  EXISTS (
+    SELECT 1 FROM quest_chain_completions qcc
+    WHERE qcc.chain_id = qc.id AND qcc.user_id = @user_id::bigint
+  ) AS completed
+FROM quest_chains qc
+WHERE qc.is_active = true
+ORDER BY qc.created_at DESC;
+
+-- name: GetChainStagesWithProgress :many
+SELECT
+  q.id,
+  q.stage_order,
+  q.clue,
+  q.location,
+  q.finished,
+  d.name,
+  d.description,
+  d.point_gain,
+  c.created_at AS contributed_at
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+LEFT JOIN contributions c ON c.quest_id = q.id AND c.user_id = @user_id::bigint
+WHERE q.chain_id = @chain_id::bigint
+ORDER BY q.stage_order;
+
+-- name: CreateQuestChainCompletion :execrows
+INSERT INTO quest_chain_completions(chain_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (chain_id, user_id) DO NOTHING;
+
+-- name: GetQuestChainLeaderboard :many
+SELECT
+  u.id,
+  u.username,
+  COUNT(c.id)::int AS completed_stages,
+  MAX(c.created_at)::timestamp AS last_contributed_at,
+  qcc.completed_at
+FROM contributions c
+JOIN quests q ON c.quest_id = q.id
+JOIN users u ON c.user_id = u.id
+LEFT JOIN quest_chain_completions qcc ON qcc.chain_id = q.chain_id AND qcc.user_id = u.id
+WHERE q.chain_id = @chain_id::bigint
+GROUP BY u.id, u.username, qcc.completed_at
+ORDER BY qcc.completed_at ASC NULLS LAST, completed_stages DESC, last_contributed_at ASC
+LIMIT @page_limit::int;
+
+-- name: GetHuntableTreasures :many
+SELECT id, name, point_gain, (max_claims - claim_count)::int AS remaining_claims, expires_at FROM treasures
+WHERE claimed = false
+  AND latitude IS NOT NULL
+  AND longitude IS NOT NULL
+  AND (starts_at IS NULL OR starts_at <= now())
+  AND (expires_at IS NULL OR expires_at > now())
+ORDER BY created_at DESC;
+
+-- name: GetHuntableTreasureById :one
+SELECT id, name, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius FROM treasures
+WHERE id = $1
+  AND claimed = false
+  AND latitude IS NOT NULL
+  AND longitude IS NOT NULL
+  AND (starts_at IS NULL OR starts_at <= now())
+  AND (expires_at IS NULL OR expires_at > now());
+
+-- name: ClaimTreasure :one
+WITH user_claims AS (
+  SELECT COUNT(*)::int AS total FROM claimed
+  WHERE treasure_id = @treasure_id::bigint AND user_id = @user_id::bigint
+), claimed_treasure AS (
+  UPDATE treasures
+  SET claim_count = claim_count + 1,
+      claimed = claim_count + 1 >= max_claims
+  WHERE id = @treasure_id::bigint
+    AND claimed = false
+    AND claim_count < max_claims
+    AND (starts_at IS NULL OR starts_at <= now())
+    AND (expires_at IS NULL OR expires_at > now())
+    AND (SELECT total FROM user_claims) < per_user_limit
+  RETURNING id, claim_count, max_claims, per_user_limit
+), inserted AS (
+  INSERT INTO claimed(user_id, treasure_id, claim_number)
+  SELECT @user_id::bigint, id, (SELECT total FROM user_claims) + 1
+  FROM claimed_treasure
+  RETURNING claim_number
+)
+SELECT
+  (claimed_treasure.max_claims - claimed_treasure.claim_count)::int AS remaining_claims,
+  (claimed_treasure.per_user_limit - inserted.claim_number)::int AS user_claims_left
+FROM claimed_treasure, inserted;
+
+-- name: GetTreasureAvailability :one
+SELECT
+  (starts_at IS NOT NULL AND starts_at > now()) AS not_started,
+  (expires_at IS NOT NULL AND expires_at <= now()) AS expired,
+  (claimed OR claim_count >= max_claims) AS sold_out,
+  (
+    SELECT COUNT(*) FROM claimed
+    WHERE claimed.treasure_id = treasures.id AND claimed.user_id = $2
+  )::int AS user_claims,
+  per_user_limit
+FROM treasures
+WHERE id = $1;
+
+-- name: RegisterForEvent :one
+WITH seat AS (
+  UPDATE events
+  SET attendee_count = attendee_count + 1
+  WHERE id = @event_id::bigint
+    AND (max_attendees IS NULL OR attendee_count < max_attendees)
+    AND (@from_waitlist::bool OR NOT EXISTS (
+      SELECT 1 FROM event_waitlists w WHERE w.event_id = events.id
+    ))
+  RETURNING id
+)
+INSERT INTO attendances(user_id, event_id, contact_number)
+SELECT @user_id::bigint, seat.id, @contact_number::varchar FROM seat
+RETURNING *;
+
+-- name: CancelAttendance :execrows
+WITH removed AS (
+  DELETE FROM attendances
+  WHERE event_id = @event_id::bigint AND user_id = @user_id::bigint AND attended = false
+  RETURNING event_id
+)
+UPDATE events
+SET attendee_count = GREATEST(attendee_count - (SELECT COUNT(*) FROM removed), 0)
+WHERE id IN (SELECT event_id FROM removed);
+
+-- name: CreateEventWaitlist :one
+INSERT INTO event_waitlists(event_id, user_id, contact_number)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetEventWaitlistPosition :one
+SELECT COUNT(*) FROM event_waitlists w
+WHERE w.event_id = @event_id
+  AND (w.created_at, w.id) <= (
+    SELECT created_at, id FROM event_waitlists
+    WHERE event_id = @event_id AND user_id = @user_id
+  );
+
+-- name: GetUserEventWaitlists :many
+SELECT * FROM event_waitlists
+WHERE user_id = $1;
+
+-- name: DeleteEventWaitlist :execrows
+DELETE FROM event_waitlists
+WHERE event_id = $1 AND user_id = $2;
+
+-- name: PopEventWaitlist :one
+DELETE FROM event_waitlists
+WHERE id = (
+  SELECT id FROM event_waitlists
+  WHERE event_id = $1
+  ORDER BY created_at ASC, id ASC
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetEventsWithPromotableWaitlist :many
+SELECT e.id FROM events e
+WHERE (e.max_attendees IS NULL OR e.attendee_count < e.max_attendees)
+  AND EXISTS (
+    SELECT 1 FROM event_waitlists w WHERE w.event_id = e.id
+  );
+
+-- name: CreateNotification :exec
+INSERT INTO notifications(user_id, type, title, body)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetUserNotifications :many
+SELECT * FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountUserNotifications :one
+SELECT
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE read_at IS NULL) AS unread
+FROM notifications
+WHERE user_id = $1;
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+WHERE id = $1 AND user_id = $2;
+
+-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: GetEventsWithPromotableWaitlist :many
+SELECT e.id FROM events e
+WHERE (e.max_attendees IS NULL OR e.attendee_count < e.max_attendees)
+  AND e.starts_at > NOW()
+  AND COALESCE(e.registration_closes_at, e.starts_at) > NOW()
+  AND EXISTS (
+    SELECT 1 FROM event_waitlists w WHERE w.event_id = e.id
+  );
+
+-- name: CheckInAttendance :execrows
+UPDATE attendances
+SET attended = true, attended_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND attended = false;
+
+-- name: GetEventCheckInWindow :one
+SELECT
+  (e.starts_at - make_interval(mins => @open_minutes::int))::timestamp AS opens_at,
+  (e.ends_at + make_interval(mins => @close_minutes::int))::timestamp AS closes_at,
+  (e.ends_at + make_interval(mins => @close_minutes::int))::timestamptz AS closes_at_tz,
+  (NOW() < e.starts_at - make_interval(mins => @open_minutes::int)) AS not_open_yet,
+  (NOW() > e.ends_at + make_interval(mins => @close_minutes::int)) AS closed
+FROM events e
+WHERE e.id = @event_id;
+
+-- name: GetAttendanceCheckIn :one
+SELECT
+  a.id AS attendance_id,
+  a.user_id,
+  a.event_id,
+  a.attended,
+  u.username,
+  d.name AS name,
+  d.description AS description,
+  d.point_gain
+FROM attendances a
+JOIN users u ON a.user_id = u.id
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.id = $1;
+
+-- name: CanCheckInEvent :one
+SELECT (
+  EXISTS (SELECT 1 FROM event_organizers o WHERE o.event_id = @event_id AND o.user_id = @user_id)
+  OR EXISTS (SELECT 1 FROM users u WHERE u.id = @user_id AND u.is_admin)
+)::bool AS can_check_in;
+
+-- name: GetCalendarFeedByUserId :one
+SELECT * FROM calendar_feeds
+WHERE user_id = $1;
+
+-- name: GetCalendarFeedByToken :one
+SELECT * FROM calendar_feeds
+WHERE token = $1;
+
+-- name: UpsertCalendarFeed :one
+INSERT INTO calendar_feeds(user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET token = EXCLUDED.token, created_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetUserCalendarEvents :many
+SELECT
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  d.updated_at
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1
+ORDER BY e.starts_at ASC;
+
+-- name: GetCalendarEventById :one
+SELECT
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  d.updated_at
+FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE e.id = $1;
+
+-- name: SearchEvents :many
+SELECT
+  e.id,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  e.cover_key,
+  d.name AS detail_name,
+  d.description AS detail_description,
+  d.point_gain,
+  d.created_at AS detail_created_at,
+  (e.ends_at < NOW()) AS ended,
+  e.max_attendees,
+  e.attendee_count,
+  COALESCE(e.registration_closes_at, e.starts_at)::timestamp AS registration_closes_at,
+  (NOW() >= COALESCE(e.registration_closes_at, e.starts_at)) AS registration_closed,
+  COALESCE(r.average, 0)::float8 AS rating_average,
+  COALESCE(r.total, 0)::int AS rating_count,
+  (CASE WHEN @has_location::bool AND e.latitude IS NOT NULL AND e.longitude IS NOT NULL THEN
+    earth_distance(
+      ll_to_earth(@latitude::float8, @longitude::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    )
+  END)::float8 AS distance_meters
+FROM events e
+JOIN details d ON e.detail_id = d.id
+LEFT JOIN LATERAL (
+  SELECT AVG(f.rating) AS average, COUNT(*) AS total
+  FROM event_feedbacks f
+  WHERE f.event_id = e.id
+) r ON true
+WHERE (
+    @status::text = ''
+    OR (@status::text = 'upcoming' AND e.starts_at > NOW())
+    OR (@status::text = 'ongoing' AND e.starts_at <= NOW() AND e.ends_at >= NOW())
+    OR (@status::text = 'past' AND e.ends_at < NOW())
+  )
+  AND (@date_from::timestamp IS NULL OR e.ends_at >= @date_from::timestamp)
+  AND (@date_to::timestamp IS NULL OR e.starts_at < @date_to::timestamp)
+  AND (
+    @search::text = ''
+    OR d.name ILIKE '%' || @search::text || '%'
+    OR d.description ILIKE '%' || @search::text || '%'
+  )
+  AND (
+    NOT @has_location::bool
+    OR (
+      e.latitude IS NOT NULL
+      AND e.longitude IS NOT NULL
+      AND earth_distance(
+      ll_to_earth(@latitude::float8, @longitude::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) <= @radius::float8
+    )
+  )
+ORDER BY
+  CASE WHEN @sort_by::text = 'distance' AND @has_location::bool THEN earth_distance(
+      ll_to_earth(@latitude::float8, @longitude::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) END,
+  CASE WHEN @sort_by::text = 'newest' THEN d.created_at END DESC,
+  CASE WHEN @sort_by::text = 'date_desc' THEN e.starts_at END DESC,
+  e.starts_at ASC,
+  e.id ASC
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: CountSearchEvents :one
+SELECT COUNT(*) FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE (
+    @status::text = ''
+    OR (@status::text = 'upcoming' AND e.starts_at > NOW())
+    OR (@status::text = 'ongoing' AND e.starts_at <= NOW() AND e.ends_at >= NOW())
+    OR (@status::text = 'past' AND e.ends_at < NOW())
+  )
+  AND (@date_from::timestamp IS NULL OR e.ends_at >= @date_from::timestamp)
+  AND (@date_to::timestamp IS NULL OR e.starts_at < @date_to::timestamp)
+  AND (
+    @search::text = ''
+    OR d.name ILIKE '%' || @search::text || '%'
+    OR d.description ILIKE '%' || @search::text || '%'
+  )
+  AND (
+    NOT @has_location::bool
+    OR (
+      e.latitude IS NOT NULL
+      AND e.longitude IS NOT NULL
+      AND earth_distance(
+      ll_to_earth(@latitude::float8, @longitude::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) <= @radius::float8
+    )
+  );
+
+-- name: CreateEventFeedback :one
+INSERT INTO event_feedbacks(event_id, user_id, rating, comment, photo_key)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: HasEventFeedback :one
+SELECT EXISTS (
+  SELECT 1 FROM event_feedbacks
+  WHERE event_id = $1 AND user_id = $2
+);
+
+-- name: GetEventFeedbackSummary :one
+SELECT
+  COUNT(*) AS total,
+  COALESCE(AVG(rating), 0)::float8 AS average,
+  COUNT(*) FILTER (WHERE rating = 1) AS one_star,
+  COUNT(*) FILTER (WHERE rating = 2) AS two_stars,
+  COUNT(*) FILTER (WHERE rating = 3) AS three_stars,
+  COUNT(*) FILTER (WHERE rating = 4) AS four_stars,
+  COUNT(*) FILTER (WHERE rating = 5) AS five_stars
+FROM event_feedbacks
+WHERE event_id = $1;
+
+-- name: GetEventFeedbacks :many
+SELECT
+  f.id,
+  f.user_id,
+  u.username,
+  f.rating,
+  f.comment,
+  f.photo_key,
+  f.created_at
+FROM event_feedbacks f
+JOIN users u ON f.user_id = u.id
+WHERE f.event_id = $1
+ORDER BY f.created_at DESC, f.id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IncreaseEventStatistics :one
+UPDATE statistics
+SET events = events + 1,
+  volunteer_minutes = volunteer_minutes + (
+    SELECT COALESCE(e.credited_minutes, (EXTRACT(EPOCH FROM (e.ends_at - e.starts_at)) / 60)::int)
+    FROM attendances a
+    JOIN events e ON a.event_id = e.id
+    WHERE a.id = @attendance_id
+  )
+WHERE user_id = @user_id
+RETURNING *;
+
+-- name: GetVolunteerEvents :many
+SELECT
+  e.id,
+  d.name,
+  e.starts_at,
+  COALESCE(e.credited_minutes, (EXTRACT(EPOCH FROM (e.ends_at - e.starts_at)) / 60)::int)::int AS credited_minutes
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = @user_id
+  AND a.attended = true
+  AND (@event_id::bigint IS NULL OR e.id = @event_id::bigint)
+  AND (@date_from::timestamp IS NULL OR e.starts_at >= @date_from::timestamp)
+  AND (@date_to::timestamp IS NULL OR e.starts_at < @date_to::timestamp)
+ORDER BY e.starts_at ASC;
+
+-- name: CreateCertificate :one
+INSERT INTO certificates(code, user_id, event_id, period_from, period_to, event_count, total_minutes, image_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetCertificateByCode :one
+SELECT
+  c.id,
+  c.code,
+  c.user_id,
+  u.name AS user_name,
+  c.event_id,
+  d.name AS event_name,
+  c.period_from,
+  c.period_to,
+  c.event_count,
+  c.total_minutes,
+  c.image_key,
+  c.issued_at
+FROM certificates c
+JOIN users u ON c.user_id = u.id
+LEFT JOIN events e ON c.event_id = e.id
+LEFT JOIN details d ON e.detail_id = d.id
+WHERE c.code = $1;
+
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs(user_id, action, subject_type, subject_id, detail, ip_address)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetEventAttendees :many
+SELECT
+  a.id,
+  a.user_id,
+  u.name,
+  u.username,
+  a.contact_number,
+  a.attended,
+  a.created_at AS registered_at,
+  a.attended_at
+FROM attendances a
+JOIN users u ON a.user_id = u.id
+WHERE a.event_id = $1
+ORDER BY a.created_at, a.id;
+
+-- name: DeletePendingUploadParticipations :many
+DELETE FROM participations
+WHERE user_id = $1 AND challenge_id = $2 AND status = 'pending_upload'
+RETURNING memory_id;
+
+-- name: GetParticipationById :one
+SELECT
+  p.id,
+  p.challenge_id,
+  p.user_id,
+  u.username,
+  p.memory_id,
+  m.file_key,
+  m.description,
+  c.day,
+  d.name AS challenge_name,
+  p.status,
+  p.point_gain,
+  p.note,
+  p.reviewed_at,
+  p.uploaded_at,
+  p.created_at
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+JOIN challenges c ON p.challenge_id = c.id
+JOIN details d ON c.detail_id = d.id
+WHERE p.id = $1;
+
+-- name: GetParticipationQueue :many
+SELECT
+  p.id,
+  p.challenge_id,
+  p.user_id,
+  u.username,
+  p.memory_id,
+  m.file_key,
+  m.description,
+  c.day,
+  d.name AS challenge_name,
+  p.status,
+  p.point_gain,
+  p.note,
+  p.reviewed_at,
+  p.uploaded_at,
+  p.created_at
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+JOIN challenges c ON p.challenge_id = c.id
+JOIN details d ON c.detail_id = d.id
+WHERE p.status = $1
+ORDER BY p.uploaded_at, p.id
+LIMIT $2 OFFSET $3;
+
+-- name: CountParticipationQueue :one
+SELECT COUNT(*) FROM participations
+WHERE status = $1;
+
+-- name: MarkParticipationUploaded :one
+UPDATE participations
+SET status = 'pending_review', uploaded_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending_upload'
+RETURNING *;
+
+-- name: ApproveParticipation :one
+UPDATE participations
+SET status = 'approved', note = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status = 'pending_review'
+RETURNING *;
+
+-- name: RejectParticipation :one
+UPDATE participations
+SET status = 'rejected', point_gain = 0, note = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status IN ('pending_upload', 'pending_review')
+RETURNING *;
+
+-- name: SetParticipationPointGain :exec
+UPDATE participations
+SET point_gain = $1
+WHERE id = $2;
+
+-- name: RevokeParticipation :one
+WITH approved AS (
+  SELECT id, point_gain FROM participations
+  WHERE id = @id AND status = 'approved'
+  FOR UPDATE
+)
+UPDATE participations p
+SET status = 'rejected', point_gain = 0, note = @note, reviewed_by = @reviewed_by, reviewed_at = CURRENT_TIMESTAMP
+FROM approved
+WHERE p.id = approved.id
+RETURNING p.user_id, approved.point_gain AS revoked_points;
+
+-- name: UpsertParticipationReaction :one
+INSERT INTO participation_reactions(participation_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (participation_id, user_id) DO UPDATE SET reaction = EXCLUDED.reaction
+RETURNING *;
+
+-- name: DeleteParticipationReaction :execrows
+DELETE FROM participation_reactions
+WHERE participation_id = $1 AND user_id = $2;
+
+-- name: GetTopParticipations :many
+SELECT
+  p.id AS participation_id,
+  m.id AS memory_id,
+  m.file_key,
+  m.description,
+  m.created_at AS memory_created_at,
+  u.id AS user_id,
+  u.username,
+  p.bonus_points,
+  COALESCE(r.votes, 0)::int AS votes,
+  COALESCE(r.likes, 0)::int AS likes,
+  COALESCE(r.loves, 0)::int AS loves,
+  COALESCE(r.inspirings, 0)::int AS inspirings,
+  COALESCE(r.claps, 0)::int AS claps,
+  mr.reaction AS my_reaction
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.challenge_id = $2 AND p.status = 'approved'
+ORDER BY votes DESC, p.uploaded_at, p.id
+LIMIT $3;
+
+-- name: GetParticipationReactions :one
+SELECT
+  COALESCE(r.votes, 0)::int AS votes,
+  COALESCE(r.likes, 0)::int AS likes,
+  COALESCE(r.loves, 0)::int AS loves,
+  COALESCE(r.inspirings, 0)::int AS inspirings,
+  COALESCE(r.claps, 0)::int AS claps,
+  mr.reaction AS my_reaction
+FROM participations p
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.id = $2;
+
+-- name: GetUnsettledChallenges :many
+SELECT
+  c.id AS challenge_id,
+  c.day,
+  d.name,
+  d.created_at,
+  (
+    SELECT COUNT(*) FROM participations p
+    WHERE p.challenge_id = c.id AND p.status = 'pending_review'
+  )::int AS pending_reviews
+FROM challenges c
+JOIN details d ON c.detail_id = d.id
+WHERE c.bonus_settled_at IS NULL AND d.created_at < $1
+ORDER BY d.created_at;
+
+-- name: SettleChallengeBonus :execrows
+UPDATE challenges
+SET bonus_settled_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND bonus_settled_at IS NULL;
+
+-- name: SetParticipationBonus :exec
+UPDATE participations
+SET bonus_points = $1
+WHERE id = $2;
@@ -8,6 +8,53 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ActivityScan struct {
+	ID             int64
+	UserID         int64
+	ActivityType   string
+	ActivityID     int64
+	Latitude       pgtype.Float8
+	Longitude      pgtype.Float8
+	DistanceMeters pgtype.Float8
+	Status         string
+	CreatedAt      pgtype.Timestamp
+}
+
+type ActivityTokenUse struct {
+	ID        int64
+	Jti       string
+	UserID    int64
+	CodeID    string
+	UsedAt    pgtype.Timestamp
+	UseNumber int32
+}
+
+type AiQuota struct {
+	ID           int64
+	UserID       int64
+	Feature      string
+	DailyLimit   pgtype.Int4
+	MonthlyLimit pgtype.Int4
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+}
+
+type AiUsage struct {
+	ID               int64
+	UserID           int64
+	Feature          string
+	Provider         string
+	Model            string
+	PromptTokens     int32
+	CompletionTokens int32
+	TotalTokens      int32
+	LatencyMs        int32
+	Cost             float64
+	Status           string
+	Error            pgtype.Text
+	CreatedAt        pgtype.Timestamp
+}
+
 type Attendance struct {
 	ID            int64
 	UserID        int64
@@ -18,6 +65,17 @@ type Attendance struct {
 	AttendedAt    pgtype.Timestamp
 }
 
+type AuditLog struct {
+	ID          int64
+	UserID      pgtype.Int8
+	Action      string
+	SubjectType string
+	SubjectID   int64
+	Detail      string
+	IpAddress   string
+	CreatedAt   pgtype.Timestamp
+}
+
 type Cache struct {
 	Key        string
 	Value      string
@@ -30,23 +88,47 @@ type CacheLock struct {
 	Expiration int32
 }
 
+type CalendarFeed struct {
+	ID        int64
+	UserID    int64
+	Token     string
+	CreatedAt pgtype.Timestamp
+}
+
+type Certificate struct {
+	ID           int64
+	Code         string
+	UserID       int64
+	EventID      pgtype.Int8
+	PeriodFrom   pgtype.Date
+	PeriodTo     pgtype.Date
+	EventCount   int32
+	TotalMinutes int32
+	ImageKey     string
+	IssuedAt     pgtype.Timestamp
+}
+
 type Challenge struct {
-	ID         int64
-	DetailID   int64
-	Day        int32
-	Difficulty string
+	ID             int64
+	DetailID       int64
+	Day            int32
+	Difficulty     string
+	BonusSettledAt pgtype.Timestamp
 }
 
 type Claimed struct {
-	ID         int64
-	UserID     int64
-	TreasureID int64
-	CreatedAt  pgtype.Timestamp
+	ID          int64
+	UserID      int64
+	TreasureID  int64
+	CreatedAt   pgtype.Timestamp
+	ClaimNumber int32
 }
 
 type Code struct {
-	ID       string
-	ImageUrl string
+	ID              string
+	ImageUrl        string
+	TokenID         pgtype.Text
+	RotationSeconds pgtype.Int4
 }
 
 type Contribution struct {
@@ -56,6 +138,30 @@ type Contribution struct {
 	CreatedAt pgtype.Timestamp
 }
 
+type Deposit struct {
+	ID          int64
+	UserID      int64
+	WasteBankID int64
+	Status      string
+	TotalWeight float64
+	Payout      int32
+	PointGain   int32
+	Note        pgtype.Text
+	ConfirmedBy pgtype.Int8
+	ConfirmedAt pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+}
+
+type DepositItem struct {
+	ID         int64
+	DepositID  int64
+	ItemID     pgtype.Int8
+	Material   string
+	Weight     float64
+	PricePerKg int32
+	Subtotal   int32
+}
+
 type Detail struct {
 	ID          int64
 	Name        string
@@ -65,17 +171,74 @@ type Detail struct {
 	UpdatedAt   pgtype.Timestamp
 }
 
+type DropoffHour struct {
+	ID             int64
+	DropoffPointID int64
+	DayOfWeek      int16
+	OpensAt        pgtype.Time
+	ClosesAt       pgtype.Time
+}
+
+type DropoffMaterial struct {
+	ID             int64
+	DropoffPointID int64
+	Material       string
+}
+
+type DropoffPoint struct {
+	ID          int64
+	Name        string
+	Address     string
+	Description pgtype.Text
+	Latitude    float64
+	Longitude   float64
+	RegionID    pgtype.Int8
+	Phone       pgtype.Text
+	IsActive    bool
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
 type Event struct {
+	ID                   int64
+	DetailID             int64
+	CodeID               string
+	Location             string
+	Latitude             float64
+	Longitude            float64
+	Contact              string
+	StartsAt             pgtype.Timestamp
+	EndsAt               pgtype.Timestamp
+	CoverKey             pgtype.Text
+	GeofenceRadius       pgtype.Int4
+	MaxAttendees         pgtype.Int4
+	AttendeeCount        int32
+	RegistrationClosesAt pgtype.Timestamp
+	CreditedMinutes      pgtype.Int4
+}
+
+type EventFeedback struct {
 	ID        int64
-	DetailID  int64
-	CodeID    string
-	Location  string
-	Latitude  float64
-	Longitude float64
-	Contact   string
-	StartsAt  pgtype.Timestamp
-	EndsAt    pgtype.Timestamp
-	CoverKey  pgtype.Text
+	EventID   int64
+	UserID    int64
+	Rating    int16
+	Comment   string
+	PhotoKey  pgtype.Text
+	CreatedAt pgtype.Timestamp
+}
+
+type EventOrganizer struct {
+	ID      int64
+	EventID int64
+	UserID  int64
+}
+
+type EventWaitlist struct {
+	ID            int64
+	EventID       int64
+	UserID        int64
+	ContactNumber string
+	CreatedAt     pgtype.Timestamp
 }
 
 type FailedJob struct {
@@ -88,6 +251,16 @@ type FailedJob struct {
 	FailedAt   pgtype.Timestamp
 }
 
+type GeofenceOverride struct {
+	ID           int64
+	UserID       int64
+	ActivityType string
+	ActivityID   int64
+	Reason       pgtype.Text
+	CreatedBy    pgtype.Int8
+	CreatedAt    pgtype.Timestamp
+}
+
 type Greenprint struct {
 	ID                  int64
 	ItemID              int64
@@ -97,6 +270,28 @@ type Greenprint struct {
 	SustainabilityScore string
 	EstimatedTime       string
 	CreatedAt           pgtype.Timestamp
+	IsPublic            bool
+	SourceID            pgtype.Int8
+	SearchText          string
+}
+
+type GreenprintProject struct {
+	ID           int64
+	UserID       int64
+	GreenprintID int64
+	Status       string
+	ProofKey     pgtype.Text
+	StartedAt    pgtype.Timestamp
+	CompletedAt  pgtype.Timestamp
+}
+
+type GreenprintRating struct {
+	ID           int64
+	GreenprintID int64
+	UserID       int64
+	Rating       int32
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
 }
 
 type Habit struct {
@@ -127,6 +322,13 @@ type Item struct {
 	Description string
 	Value       string
 	CreatedAt   pgtype.Timestamp
+	Material    pgtype.Text
+}
+
+type ItemImage struct {
+	ID          int64
+	ItemID      int64
+	ScanImageID int64
 }
 
 type Job struct {
@@ -168,14 +370,16 @@ type Material struct {
 	Price        int32
 	Quantity     int32
 	GreenprintID int64
+	StepID       pgtype.Int8
 }
 
 type Memory struct {
-	ID          int64
-	UserID      int64
-	FileKey     string
-	Description string
-	CreatedAt   pgtype.Timestamp
+	ID           int64
+	UserID       int64
+	FileKey      string
+	Description  string
+	CreatedAt    pgtype.Timestamp
+	ThumbnailKey pgtype.Text
 }
 
 type Migration struct {
@@ -184,6 +388,30 @@ type Migration struct {
 	Batch     int32
 }
 
+type Notification struct {
+	ID        int64
+	UserID    int64
+	Type      string
+	Title     string
+	Body      string
+	ReadAt    pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
+type OwnedMaterial struct {
+	ID         int64
+	UserID     int64
+	MaterialID int64
+	CreatedAt  pgtype.Timestamp
+}
+
+type OwnedTool struct {
+	ID        int64
+	UserID    int64
+	ToolID    int64
+	CreatedAt pgtype.Timestamp
+}
+
 type Packet struct {
 	ID            int64
 	UserID        int64
@@ -203,6 +431,21 @@ type Participation struct {
 	UserID      int64
 	MemoryID    int64
 	CreatedAt   pgtype.Timestamp
+	Status      string
+	PointGain   int32
+	Note        pgtype.Text
+	ReviewedBy  pgtype.Int8
+	ReviewedAt  pgtype.Timestamp
+	UploadedAt  pgtype.Timestamp
+	BonusPoints int32
+}
+
+type ParticipationReaction struct {
+	ID              int64
+	ParticipationID int64
+	UserID          int64
+	Reaction        string
+	CreatedAt       pgtype.Timestamp
 }
 
 type PasswordResetToken struct {
@@ -221,6 +464,13 @@ type Profile struct {
 	ProfileKey string
 }
 
+type ProjectStep struct {
+	ID          int64
+	ProjectID   int64
+	StepID      int64
+	CompletedAt pgtype.Timestamp
+}
+
 type Quest struct {
 	ID              int64
 	DetailID        int64
@@ -231,6 +481,26 @@ type Quest struct {
 	MaxContributors int32
 	Finished        bool
 	Clue            pgtype.Text
+	GeofenceRadius  pgtype.Int4
+	ChainID         pgtype.Int8
+	StageOrder      pgtype.Int4
+}
+
+type QuestChain struct {
+	ID                  int64
+	Name                string
+	Description         string
+	CompletionPointGain int64
+	IsActive            bool
+	CreatedAt           pgtype.Timestamp
+	UpdatedAt           pgtype.Timestamp
+}
+
+type QuestChainCompletion struct {
+	ID          int64
+	ChainID     int64
+	UserID      int64
+	CompletedAt pgtype.Timestamp
 }
 
 type Recap struct {
@@ -277,6 +547,14 @@ type Scan struct {
 	CreatedAt   pgtype.Timestamp
 }
 
+type ScanImage struct {
+	ID        int64
+	ScanID    int64
+	ImageKey  string
+	Position  int32
+	CreatedAt pgtype.Timestamp
+}
+
 type Session struct {
 	ID           string
 	UserID       pgtype.Int8
@@ -287,14 +565,15 @@ type Session struct {
 }
 
 type Statistic struct {
-	ID            int64
-	UserID        int64
-	Challenges    int32
-	Events        int32
-	Quests        int32
-	Treasures     int32
-	LongestStreak int32
-	TreeGrown     int32
+	ID               int64
+	UserID           int64
+	Challenges       int32
+	Events           int32
+	Quests           int32
+	Treasures        int32
+	LongestStreak    int32
+	TreeGrown        int32
+	VolunteerMinutes int32
 }
 
 type Step struct {
@@ -324,16 +603,26 @@ type Tool struct {
 	Description  string
 	Price        int32
 	CreatedAt    pgtype.Timestamp
+	StepID       pgtype.Int8
+	IsOptional   bool
 }
 
 type Treasure struct {
-	ID        int64
-	Name      string
-	PointGain int64
-	CodeID    string
-	Claimed   bool
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
+	ID             int64
+	Name           string
+	PointGain      int64
+	CodeID         string
+	Claimed        bool
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	Latitude       pgtype.Float8
+	Longitude      pgtype.Float8
+	GeofenceRadius pgtype.Int4
+	MaxClaims      int32
+	PerUserLimit   int32
+	ClaimCount     int32
+	StartsAt       pgtype.Timestamp
+	ExpiresAt      pgtype.Timestamp
 }
 
 type User struct {
@@ -348,3 +637,25 @@ type User struct {
 	CreatedAt       pgtype.Timestamp
 	UpdatedAt       pgtype.Timestamp
 }
+
+type WasteBank struct {
+	ID        int64
+	Name      string
+	Address   string
+	Latitude  float64
+	Longitude float64
+	RegionID  pgtype.Int8
+	Phone     pgtype.Text
+	IsActive  bool
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type WasteBankPrice struct {
+	ID          int64
+	WasteBankID int64
+	Material    string
+	PricePerKg  int32
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
@@ -35,20 +35,99 @@ func (q *Queries) AppendHistry(ctx context.Context, arg AppendHistryParams) erro
 	return err
 }
 
-const attend = `-- name: Attend :exec
+const approveParticipation = `-- name: ApproveParticipation :one
+UPDATE participations
+SET status = 'approved', note = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status = 'pending_review'
+RETURNING id, challenge_id, user_id, memory_id, created_at, status, point_gain, note, reviewed_by, reviewed_at, uploaded_at, bonus_points
+`
+
+type ApproveParticipationParams struct {
+	Note       pgtype.Text
+	ReviewedBy pgtype.Int8
+	ID         int64
+}
+
+func (q *Queries) ApproveParticipation(ctx context.Context, arg ApproveParticipationParams) (Participation, error) {
+	row := q.db.QueryRow(ctx, approveParticipation, arg.Note, arg.ReviewedBy, arg.ID)
+	var i Participation
+	err := row.Scan(
+		&i.ID,
+		&i.ChallengeID,
+		&i.UserID,
+		&i.MemoryID,
+		&i.CreatedAt,
+		&i.Status,
+		&i.PointGain,
+		&i.Note,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.UploadedAt,
+		&i.BonusPoints,
+	)
+	return i, err
+}
+
+const canCheckInEvent = `-- name: CanCheckInEvent :one
+SELECT (
+  EXISTS (SELECT 1 FROM event_organizers o WHERE o.event_id = $1 AND o.user_id = $2)
+  OR EXISTS (SELECT 1 FROM users u WHERE u.id = $2 AND u.is_admin)
+)::bool AS can_check_in
+`
+
+type CanCheckInEventParams struct {
+	EventID int64
+	UserID  int64
+}
+
+func (q *Queries) CanCheckInEvent(ctx context.Context, arg CanCheckInEventParams) (bool, error) {
+	row := q.db.QueryRow(ctx, canCheckInEvent, arg.EventID, arg.UserID)
+	var can_check_in bool
+	err := row.Scan(&can_check_in)
+	return can_check_in, err
+}
+
+const cancelAttendance = `-- name: CancelAttendance :execrows
+WITH removed AS (
+  DELETE FROM attendances
+  WHERE event_id = $1::bigint AND user_id = $2::bigint AND attended = false
+  RETURNING event_id
+)
+UPDATE events
+SET attendee_count = GREATEST(attendee_count - (SELECT COUNT(*) FROM removed), 0)
+WHERE id IN (SELECT event_id FROM removed)
+`
+
+type CancelAttendanceParams struct {
+	EventID int64
+	UserID  int64
+}
+
+func (q *Queries) CancelAttendance(ctx context.Context, arg CancelAttendanceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelAttendance, arg.EventID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const checkInAttendance = `-- name: CheckInAttendance :execrows
 UPDATE attendances
-SET attended = true
-WHERE id = $1
+SET attended = true, attended_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND attended = false
 `
 
-func (q *Queries) Attend(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, attend, id)
-	return err
+func (q *Queries) CheckInAttendance(ctx context.Context, iD int64) (int64, error) {
+	result, err := q.db.Exec(ctx, checkInAttendance, iD)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const checkParticipation = `-- name: CheckParticipation :one
 SELECT
-COUNT (*) FILTER (WHERE user_id = $1 AND challenge_id = $2)
+COUNT (*) FILTER (WHERE user_id = $1 AND challenge_id = $2 AND status IN ('pending_review', 'approved'))
 FROM participations
 `
 
@@ -64,6 +143,106 @@ func (q *Queries) CheckParticipation(ctx context.Context, arg CheckParticipation
 	return count, err
 }
 
+const claimActivityTokenUse = `-- name: ClaimActivityTokenUse :one
+INSERT INTO activity_token_uses(jti, user_id, code_id, use_number)
+SELECT $1::text, $2::bigint, $3::text, COALESCE(MAX(use_number), 0) + 1
+FROM activity_token_uses
+WHERE jti = $1::text AND user_id = $2::bigint
+HAVING COUNT(*) < $4::int
+ON CONFLICT (jti, user_id, use_number) DO NOTHING
+RETURNING use_number
+`
+
+type ClaimActivityTokenUseParams struct {
+	Jti     string
+	UserID  int64
+	CodeID  string
+	MaxUses int32
+}
+
+func (q *Queries) ClaimActivityTokenUse(ctx context.Context, arg ClaimActivityTokenUseParams) (int32, error) {
+	row := q.db.QueryRow(ctx, claimActivityTokenUse,
+		arg.Jti,
+		arg.UserID,
+		arg.CodeID,
+		arg.MaxUses,
+	)
+	var use_number int32
+	err := row.Scan(&use_number)
+	return use_number, err
+}
+
+const claimTreasure = `-- name: ClaimTreasure :one
+WITH user_claims AS (
+  SELECT COUNT(*)::int AS total FROM claimed
+  WHERE treasure_id = $1::bigint AND user_id = $2::bigint
+), claimed_treasure AS (
+  UPDATE treasures
+  SET claim_count = claim_count + 1,
+      claimed = claim_count + 1 >= max_claims
+  WHERE id = $1::bigint
+    AND claimed = false
+    AND claim_count < max_claims
+    AND (starts_at IS NULL OR starts_at <= now())
+    AND (expires_at IS NULL OR expires_at > now())
+    AND (SELECT total FROM user_claims) < per_user_limit
+  RETURNING id, claim_count, max_claims, per_user_limit
+), inserted AS (
+  INSERT INTO claimed(user_id, treasure_id, claim_number)
+  SELECT $2::bigint, id, (SELECT total FROM user_claims) + 1
+  FROM claimed_treasure
+  RETURNING claim_number
+)
+SELECT
+  (claimed_treasure.max_claims - claimed_treasure.claim_count)::int AS remaining_claims,
+  (claimed_treasure.per_user_limit - inserted.claim_number)::int AS user_claims_left
+FROM claimed_treasure, inserted
+`
+
+type ClaimTreasureParams struct {
+	TreasureID int64
+	UserID     int64
+}
+
+type ClaimTreasureRow struct {
+	RemainingClaims int32
+	UserClaimsLeft  int32
+}
+
+func (q *Queries) ClaimTreasure(ctx context.Context, arg ClaimTreasureParams) (ClaimTreasureRow, error) {
+	row := q.db.QueryRow(ctx, claimTreasure, arg.TreasureID, arg.UserID)
+	var i ClaimTreasureRow
+	err := row.Scan(&i.RemainingClaims, &i.UserClaimsLeft)
+	return i, err
+}
+
+const completeGreenprintProject = `-- name: CompleteGreenprintProject :one
+UPDATE greenprint_projects
+SET status = 'completed', proof_key = $1, completed_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND status = 'in_progress'
+RETURNING id, user_id, greenprint_id, status, proof_key, started_at, completed_at
+`
+
+type CompleteGreenprintProjectParams struct {
+	ProofKey pgtype.Text
+	ID       int64
+}
+
+func (q *Queries) CompleteGreenprintProject(ctx context.Context, arg CompleteGreenprintProjectParams) (GreenprintProject, error) {
+	row := q.db.QueryRow(ctx, completeGreenprintProject, arg.ProofKey, arg.ID)
+	var i GreenprintProject
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GreenprintID,
+		&i.Status,
+		&i.ProofKey,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
 const completePacket = `-- name: CompletePacket :exec
 UPDATE packets
 SET completed = true
@@ -106,6 +285,217 @@ func (q *Queries) CompleteTask(ctx context.Context, arg CompleteTaskParams) (Tas
 	return i, err
 }
 
+const confirmDeposit = `-- name: ConfirmDeposit :one
+UPDATE deposits
+SET status = 'confirmed', point_gain = $1, note = $2, confirmed_by = $3, confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $4 AND status = 'pending'
+RETURNING id, user_id, waste_bank_id, status, total_weight, payout, point_gain, note, confirmed_by, confirmed_at, created_at
+`
+
+type ConfirmDepositParams struct {
+	PointGain   int32
+	Note        pgtype.Text
+	ConfirmedBy pgtype.Int8
+	ID          int64
+}
+
+func (q *Queries) ConfirmDeposit(ctx context.Context, arg ConfirmDepositParams) (Deposit, error) {
+	row := q.db.QueryRow(ctx, confirmDeposit,
+		arg.PointGain,
+		arg.Note,
+		arg.ConfirmedBy,
+		arg.ID,
+	)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.WasteBankID,
+		&i.Status,
+		&i.TotalWeight,
+		&i.Payout,
+		&i.PointGain,
+		&i.Note,
+		&i.ConfirmedBy,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const copyGreenprint = `-- name: CopyGreenprint :one
+INSERT INTO greenprints(title, item_id, image_key, description, sustainability_score, estimated_time, is_public, source_id, search_text)
+SELECT title, $1::bigint, image_key, description, sustainability_score, estimated_time, false, id, search_text
+FROM greenprints
+WHERE greenprints.id = $2::bigint
+RETURNING id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at, is_public, source_id, search_text
+`
+
+type CopyGreenprintParams struct {
+	ItemID   int64
+	SourceID int64
+}
+
+func (q *Queries) CopyGreenprint(ctx context.Context, arg CopyGreenprintParams) (Greenprint, error) {
+	row := q.db.QueryRow(ctx, copyGreenprint, arg.ItemID, arg.SourceID)
+	var i Greenprint
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.ImageKey,
+		&i.Title,
+		&i.Description,
+		&i.SustainabilityScore,
+		&i.EstimatedTime,
+		&i.CreatedAt,
+		&i.IsPublic,
+		&i.SourceID,
+		&i.SearchText,
+	)
+	return i, err
+}
+
+const copyMaterials = `-- name: CopyMaterials :exec
+INSERT INTO materials(greenprint_id, name, description, price, quantity, step_id)
+SELECT $1::bigint, materials.name, materials.description, materials.price, materials.quantity, new_steps.id
+FROM materials
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = $2::bigint
+) old_steps ON old_steps.id = materials.step_id
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = $1::bigint
+) new_steps ON new_steps.position = old_steps.position
+WHERE materials.greenprint_id = $2::bigint
+ORDER BY materials.id
+`
+
+type CopyMaterialsParams struct {
+	GreenprintID int64
+	SourceID     int64
+}
+
+func (q *Queries) CopyMaterials(ctx context.Context, arg CopyMaterialsParams) error {
+	_, err := q.db.Exec(ctx, copyMaterials, arg.GreenprintID, arg.SourceID)
+	return err
+}
+
+const copySteps = `-- name: CopySteps :exec
+INSERT INTO steps(greenprint_id, description)
+SELECT $1::bigint, description
+FROM steps
+WHERE steps.greenprint_id = $2::bigint
+ORDER BY id
+`
+
+type CopyStepsParams struct {
+	GreenprintID int64
+	SourceID     int64
+}
+
+func (q *Queries) CopySteps(ctx context.Context, arg CopyStepsParams) error {
+	_, err := q.db.Exec(ctx, copySteps, arg.GreenprintID, arg.SourceID)
+	return err
+}
+
+const copyTools = `-- name: CopyTools :exec
+INSERT INTO tools(greenprint_id, name, description, price, step_id, is_optional)
+SELECT $1::bigint, tools.name, tools.description, tools.price, new_steps.id, tools.is_optional
+FROM tools
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = $2::bigint
+) old_steps ON old_steps.id = tools.step_id
+LEFT JOIN (
+  SELECT id, row_number() OVER (ORDER BY id) AS position FROM steps WHERE greenprint_id = $1::bigint
+) new_steps ON new_steps.position = old_steps.position
+WHERE tools.greenprint_id = $2::bigint
+ORDER BY tools.id
+`
+
+type CopyToolsParams struct {
+	GreenprintID int64
+	SourceID     int64
+}
+
+func (q *Queries) CopyTools(ctx context.Context, arg CopyToolsParams) error {
+	_, err := q.db.Exec(ctx, copyTools, arg.GreenprintID, arg.SourceID)
+	return err
+}
+
+const countActiveItemDeposits = `-- name: CountActiveItemDeposits :one
+SELECT COUNT(*) FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+WHERE deposit_items.item_id = $1 AND deposits.status <> 'rejected'
+`
+
+func (q *Queries) CountActiveItemDeposits(ctx context.Context, itemID pgtype.Int8) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveItemDeposits, itemID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countLockedChainStages = `-- name: CountLockedChainStages :one
+SELECT COUNT(*) FROM quests p
+WHERE p.chain_id = $1::bigint
+  AND p.stage_order < $2::int
+  AND NOT EXISTS (
+    SELECT 1 FROM contributions c
+    WHERE c.quest_id = p.id AND c.user_id = $3::bigint
+  )
+`
+
+type CountLockedChainStagesParams struct {
+	ChainID    int64
+	StageOrder int32
+	UserID     int64
+}
+
+func (q *Queries) CountLockedChainStages(ctx context.Context, arg CountLockedChainStagesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countLockedChainStages, arg.ChainID, arg.StageOrder, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countNearbyQuests = `-- name: CountNearbyQuests :one
+SELECT COUNT(*) FROM quests q
+WHERE q.finished = false
+  AND q.latitude IS NOT NULL
+  AND q.longitude IS NOT NULL
+  AND earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  ) <= $3::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = q.chain_id
+      AND p.stage_order < q.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = $4::bigint
+      )
+  )
+`
+
+type CountNearbyQuestsParams struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	UserID    int64
+}
+
+func (q *Queries) CountNearbyQuests(ctx context.Context, arg CountNearbyQuestsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countNearbyQuests,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Radius,
+		arg.UserID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPacketTasks = `-- name: CountPacketTasks :one
 SELECT
   COUNT(*) FILTER (WHERE completed = true) AS completed_task,
@@ -131,6 +521,18 @@ func (q *Queries) CountPacketTasks(ctx context.Context, arg CountPacketTasksPara
 	return i, err
 }
 
+const countParticipationQueue = `-- name: CountParticipationQueue :one
+SELECT COUNT(*) FROM participations
+WHERE status = $1
+`
+
+func (q *Queries) CountParticipationQueue(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRow(ctx, countParticipationQueue, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countQuestContributors = `-- name: CountQuestContributors :many
 SELECT
   u.id AS id,
@@ -165,6 +567,62 @@ func (q *Queries) CountQuestContributors(ctx context.Context, questID int64) ([]
 	return items, nil
 }
 
+const countSearchEvents = `-- name: CountSearchEvents :one
+SELECT COUNT(*) FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE (
+    $1::text = ''
+    OR ($1::text = 'upcoming' AND e.starts_at > NOW())
+    OR ($1::text = 'ongoing' AND e.starts_at <= NOW() AND e.ends_at >= NOW())
+    OR ($1::text = 'past' AND e.ends_at < NOW())
+  )
+  AND ($2::timestamp IS NULL OR e.ends_at >= $2::timestamp)
+  AND ($3::timestamp IS NULL OR e.starts_at < $3::timestamp)
+  AND (
+    $4::text = ''
+    OR d.name ILIKE '%' || $4::text || '%'
+    OR d.description ILIKE '%' || $4::text || '%'
+  )
+  AND (
+    NOT $5::bool
+    OR (
+      e.latitude IS NOT NULL
+      AND e.longitude IS NOT NULL
+      AND earth_distance(
+      ll_to_earth($6::float8, $7::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) <= $8::float8
+    )
+  )
+`
+
+type CountSearchEventsParams struct {
+	Status      string
+	DateFrom    pgtype.Timestamp
+	DateTo      pgtype.Timestamp
+	Search      string
+	HasLocation bool
+	Latitude    float64
+	Longitude   float64
+	Radius      float64
+}
+
+func (q *Queries) CountSearchEvents(ctx context.Context, arg CountSearchEventsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchEvents,
+		arg.Status,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.Search,
+		arg.HasLocation,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Radius,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserActivePackets = `-- name: CountUserActivePackets :one
 SELECT COUNT(*) FROM packets 
 WHERE user_id = $1 AND completed = false
@@ -177,6 +635,73 @@ func (q *Queries) CountUserActivePackets(ctx context.Context, userID int64) (int
 	return count, err
 }
 
+const countUserAiUsage = `-- name: CountUserAiUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE created_at >= $1) AS daily,
+  COUNT(*) AS monthly
+FROM ai_usages
+WHERE user_id = $2
+  AND feature = $3
+  AND status = 'success'
+  AND created_at >= $4
+`
+
+type CountUserAiUsageParams struct {
+	DayStart   pgtype.Timestamp
+	UserID     int64
+	Feature    string
+	MonthStart pgtype.Timestamp
+}
+
+type CountUserAiUsageRow struct {
+	Daily   int64
+	Monthly int64
+}
+
+func (q *Queries) CountUserAiUsage(ctx context.Context, arg CountUserAiUsageParams) (CountUserAiUsageRow, error) {
+	row := q.db.QueryRow(ctx, countUserAiUsage,
+		arg.DayStart,
+		arg.UserID,
+		arg.Feature,
+		arg.MonthStart,
+	)
+	var i CountUserAiUsageRow
+	err := row.Scan(&i.Daily, &i.Monthly)
+	return i, err
+}
+
+const countUserNotifications = `-- name: CountUserNotifications :one
+SELECT
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE read_at IS NULL) AS unread
+FROM notifications
+WHERE user_id = $1
+`
+
+type CountUserNotificationsRow struct {
+	Total  int64
+	Unread int64
+}
+
+func (q *Queries) CountUserNotifications(ctx context.Context, userID int64) (CountUserNotificationsRow, error) {
+	row := q.db.QueryRow(ctx, countUserNotifications, userID)
+	var i CountUserNotificationsRow
+	err := row.Scan(&i.Total, &i.Unread)
+	return i, err
+}
+
+const countUserScans = `-- name: CountUserScans :one
+SELECT COUNT(*) FROM scans
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserScans(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserScans, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserTask = `-- name: CountUserTask :one
 SELECT 
   COUNT (*) as assigned_task,
@@ -197,75 +722,347 @@ func (q *Queries) CountUserTask(ctx context.Context, userID int64) (CountUserTas
 	return i, err
 }
 
-const createAttendance = `-- name: CreateAttendance :one
-INSERT INTO attendances(user_id, event_id, contact_number)
-VALUES ($1, $2, $3)
-RETURNING id, user_id, event_id, attended, contact_number, created_at, attended_at
+const countUserTotalAiUsage = `-- name: CountUserTotalAiUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE created_at >= $1) AS daily,
+  COUNT(*) AS monthly
+FROM ai_usages
+WHERE user_id = $2
+  AND status = 'success'
+  AND feature <> 'trash_vision'
+  AND created_at >= $3
 `
 
-type CreateAttendanceParams struct {
-	UserID        int64
-	EventID       int64
-	ContactNumber string
+type CountUserTotalAiUsageParams struct {
+	DayStart   pgtype.Timestamp
+	UserID     int64
+	MonthStart pgtype.Timestamp
 }
 
-func (q *Queries) CreateAttendance(ctx context.Context, arg CreateAttendanceParams) (Attendance, error) {
-	row := q.db.QueryRow(ctx, createAttendance, arg.UserID, arg.EventID, arg.ContactNumber)
-	var i Attendance
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.EventID,
-		&i.Attended,
-		&i.ContactNumber,
-		&i.CreatedAt,
-		&i.AttendedAt,
-	)
+type CountUserTotalAiUsageRow struct {
+	Daily   int64
+	Monthly int64
+}
+
+func (q *Queries) CountUserTotalAiUsage(ctx context.Context, arg CountUserTotalAiUsageParams) (CountUserTotalAiUsageRow, error) {
+	row := q.db.QueryRow(ctx, countUserTotalAiUsage, arg.DayStart, arg.UserID, arg.MonthStart)
+	var i CountUserTotalAiUsageRow
+	err := row.Scan(&i.Daily, &i.Monthly)
 	return i, err
 }
 
-const createClaimed = `-- name: CreateClaimed :exec
-INSERT INTO claimed(user_id, treasure_id)
-VALUES ($1, $2)
+const createActivityScan = `-- name: CreateActivityScan :exec
+INSERT INTO activity_scans(user_id, activity_type, activity_id, latitude, longitude, distance_meters, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
-type CreateClaimedParams struct {
-	UserID     int64
-	TreasureID int64
+type CreateActivityScanParams struct {
+	UserID         int64
+	ActivityType   string
+	ActivityID     int64
+	Latitude       pgtype.Float8
+	Longitude      pgtype.Float8
+	DistanceMeters pgtype.Float8
+	Status         string
 }
 
-func (q *Queries) CreateClaimed(ctx context.Context, arg CreateClaimedParams) error {
-	_, err := q.db.Exec(ctx, createClaimed, arg.UserID, arg.TreasureID)
+func (q *Queries) CreateActivityScan(ctx context.Context, arg CreateActivityScanParams) error {
+	_, err := q.db.Exec(ctx, createActivityScan,
+		arg.UserID,
+		arg.ActivityType,
+		arg.ActivityID,
+		arg.Latitude,
+		arg.Longitude,
+		arg.DistanceMeters,
+		arg.Status,
+	)
 	return err
 }
 
-const createContributions = `-- name: CreateContributions :one
-INSERT INTO contributions(user_id, quest_id)
-VALUES ($1, $2)
-RETURNING id, quest_id, user_id, created_at
+const createAiUsage = `-- name: CreateAiUsage :exec
+INSERT INTO ai_usages(user_id, feature, provider, model, prompt_tokens, completion_tokens, total_tokens, latency_ms, cost, status, error, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 
-type CreateContributionsParams struct {
-	UserID  int64
-	QuestID int64
+type CreateAiUsageParams struct {
+	UserID           int64
+	Feature          string
+	Provider         string
+	Model            string
+	PromptTokens     int32
+	CompletionTokens int32
+	TotalTokens      int32
+	LatencyMs        int32
+	Cost             float64
+	Status           string
+	Error            pgtype.Text
+	CreatedAt        pgtype.Timestamp
 }
 
-func (q *Queries) CreateContributions(ctx context.Context, arg CreateContributionsParams) (Contribution, error) {
-	row := q.db.QueryRow(ctx, createContributions, arg.UserID, arg.QuestID)
-	var i Contribution
-	err := row.Scan(
-		&i.ID,
-		&i.QuestID,
-		&i.UserID,
-		&i.CreatedAt,
+func (q *Queries) CreateAiUsage(ctx context.Context, arg CreateAiUsageParams) error {
+	_, err := q.db.Exec(ctx, createAiUsage,
+		arg.UserID,
+		arg.Feature,
+		arg.Provider,
+		arg.Model,
+		arg.PromptTokens,
+		arg.CompletionTokens,
+		arg.TotalTokens,
+		arg.LatencyMs,
+		arg.Cost,
+		arg.Status,
+		arg.Error,
+		arg.CreatedAt,
 	)
-	return i, err
+	return err
 }
 
-const createGreenprint = `-- name: CreateGreenprint :one
-INSERT INTO greenprints(title, item_id, image_key, description, sustainability_score, estimated_time)
+const createAuditLog = `-- name: CreateAuditLog :exec
+INSERT INTO audit_logs(user_id, action, subject_type, subject_id, detail, ip_address)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at
+`
+
+type CreateAuditLogParams struct {
+	UserID      pgtype.Int8
+	Action      string
+	SubjectType string
+	SubjectID   int64
+	Detail      string
+	IpAddress   string
+}
+
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
+	_, err := q.db.Exec(ctx, createAuditLog,
+		arg.UserID,
+		arg.Action,
+		arg.SubjectType,
+		arg.SubjectID,
+		arg.Detail,
+		arg.IpAddress,
+	)
+	return err
+}
+
+const createCertificate = `-- name: CreateCertificate :one
+INSERT INTO certificates(code, user_id, event_id, period_from, period_to, event_count, total_minutes, image_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, code, user_id, event_id, period_from, period_to, event_count, total_minutes, image_key, issued_at
+`
+
+type CreateCertificateParams struct {
+	Code         string
+	UserID       int64
+	EventID      pgtype.Int8
+	PeriodFrom   pgtype.Date
+	PeriodTo     pgtype.Date
+	EventCount   int32
+	TotalMinutes int32
+	ImageKey     string
+}
+
+func (q *Queries) CreateCertificate(ctx context.Context, arg CreateCertificateParams) (Certificate, error) {
+	row := q.db.QueryRow(ctx, createCertificate,
+		arg.Code,
+		arg.UserID,
+		arg.EventID,
+		arg.PeriodFrom,
+		arg.PeriodTo,
+		arg.EventCount,
+		arg.TotalMinutes,
+		arg.ImageKey,
+	)
+	var i Certificate
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.UserID,
+		&i.EventID,
+		&i.PeriodFrom,
+		&i.PeriodTo,
+		&i.EventCount,
+		&i.TotalMinutes,
+		&i.ImageKey,
+		&i.IssuedAt,
+	)
+	return i, err
+}
+
+const createContributions = `-- name: CreateContributions :one
+INSERT INTO contributions(user_id, quest_id)
+VALUES ($1, $2)
+RETURNING id, quest_id, user_id, created_at
+`
+
+type CreateContributionsParams struct {
+	UserID  int64
+	QuestID int64
+}
+
+func (q *Queries) CreateContributions(ctx context.Context, arg CreateContributionsParams) (Contribution, error) {
+	row := q.db.QueryRow(ctx, createContributions, arg.UserID, arg.QuestID)
+	var i Contribution
+	err := row.Scan(
+		&i.ID,
+		&i.QuestID,
+		&i.UserID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDeposit = `-- name: CreateDeposit :one
+INSERT INTO deposits(user_id, waste_bank_id, total_weight, payout)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, waste_bank_id, status, total_weight, payout, point_gain, note, confirmed_by, confirmed_at, created_at
+`
+
+type CreateDepositParams struct {
+	UserID      int64
+	WasteBankID int64
+	TotalWeight float64
+	Payout      int32
+}
+
+func (q *Queries) CreateDeposit(ctx context.Context, arg CreateDepositParams) (Deposit, error) {
+	row := q.db.QueryRow(ctx, createDeposit,
+		arg.UserID,
+		arg.WasteBankID,
+		arg.TotalWeight,
+		arg.Payout,
+	)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.WasteBankID,
+		&i.Status,
+		&i.TotalWeight,
+		&i.Payout,
+		&i.PointGain,
+		&i.Note,
+		&i.ConfirmedBy,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDepositItem = `-- name: CreateDepositItem :exec
+INSERT INTO deposit_items(deposit_id, item_id, material, weight, price_per_kg, subtotal)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateDepositItemParams struct {
+	DepositID  int64
+	ItemID     pgtype.Int8
+	Material   string
+	Weight     float64
+	PricePerKg int32
+	Subtotal   int32
+}
+
+func (q *Queries) CreateDepositItem(ctx context.Context, arg CreateDepositItemParams) error {
+	_, err := q.db.Exec(ctx, createDepositItem,
+		arg.DepositID,
+		arg.ItemID,
+		arg.Material,
+		arg.Weight,
+		arg.PricePerKg,
+		arg.Subtotal,
+	)
+	return err
+}
+
+const createEventFeedback = `-- name: CreateEventFeedback :one
+INSERT INTO event_feedbacks(event_id, user_id, rating, comment, photo_key)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, event_id, user_id, rating, comment, photo_key, created_at
+`
+
+type CreateEventFeedbackParams struct {
+	EventID  int64
+	UserID   int64
+	Rating   int16
+	Comment  string
+	PhotoKey pgtype.Text
+}
+
+func (q *Queries) CreateEventFeedback(ctx context.Context, arg CreateEventFeedbackParams) (EventFeedback, error) {
+	row := q.db.QueryRow(ctx, createEventFeedback,
+		arg.EventID,
+		arg.UserID,
+		arg.Rating,
+		arg.Comment,
+		arg.PhotoKey,
+	)
+	var i EventFeedback
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.UserID,
+		&i.Rating,
+		&i.Comment,
+		&i.PhotoKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createEventWaitlist = `-- name: CreateEventWaitlist :one
+INSERT INTO event_waitlists(event_id, user_id, contact_number)
+VALUES ($1, $2, $3)
+RETURNING id, event_id, user_id, contact_number, created_at
+`
+
+type CreateEventWaitlistParams struct {
+	EventID       int64
+	UserID        int64
+	ContactNumber string
+}
+
+func (q *Queries) CreateEventWaitlist(ctx context.Context, arg CreateEventWaitlistParams) (EventWaitlist, error) {
+	row := q.db.QueryRow(ctx, createEventWaitlist, arg.EventID, arg.UserID, arg.ContactNumber)
+	var i EventWaitlist
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.UserID,
+		&i.ContactNumber,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGeofenceOverride = `-- name: CreateGeofenceOverride :exec
+INSERT INTO geofence_overrides(user_id, activity_type, activity_id, reason, created_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, activity_type, activity_id) DO UPDATE
+SET reason = EXCLUDED.reason, created_by = EXCLUDED.created_by
+`
+
+type CreateGeofenceOverrideParams struct {
+	UserID       int64
+	ActivityType string
+	ActivityID   int64
+	Reason       pgtype.Text
+	CreatedBy    pgtype.Int8
+}
+
+func (q *Queries) CreateGeofenceOverride(ctx context.Context, arg CreateGeofenceOverrideParams) error {
+	_, err := q.db.Exec(ctx, createGeofenceOverride,
+		arg.UserID,
+		arg.ActivityType,
+		arg.ActivityID,
+		arg.Reason,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const createGreenprint = `-- name: CreateGreenprint :one
+INSERT INTO greenprints(title, item_id, image_key, description, sustainability_score, estimated_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at, is_public, source_id, search_text
 `
 
 type CreateGreenprintParams struct {
@@ -296,6 +1093,35 @@ func (q *Queries) CreateGreenprint(ctx context.Context, arg CreateGreenprintPara
 		&i.SustainabilityScore,
 		&i.EstimatedTime,
 		&i.CreatedAt,
+		&i.IsPublic,
+		&i.SourceID,
+		&i.SearchText,
+	)
+	return i, err
+}
+
+const createGreenprintProject = `-- name: CreateGreenprintProject :one
+INSERT INTO greenprint_projects(user_id, greenprint_id)
+VALUES ($1, $2)
+RETURNING id, user_id, greenprint_id, status, proof_key, started_at, completed_at
+`
+
+type CreateGreenprintProjectParams struct {
+	UserID       int64
+	GreenprintID int64
+}
+
+func (q *Queries) CreateGreenprintProject(ctx context.Context, arg CreateGreenprintProjectParams) (GreenprintProject, error) {
+	row := q.db.QueryRow(ctx, createGreenprintProject, arg.UserID, arg.GreenprintID)
+	var i GreenprintProject
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GreenprintID,
+		&i.Status,
+		&i.ProofKey,
+		&i.StartedAt,
+		&i.CompletedAt,
 	)
 	return i, err
 }
@@ -329,10 +1155,25 @@ func (q *Queries) CreateHabit(ctx context.Context, arg CreateHabitParams) (int64
 	return id, err
 }
 
+const createItemImage = `-- name: CreateItemImage :exec
+INSERT INTO item_images(item_id, scan_image_id)
+VALUES ($1, $2)
+`
+
+type CreateItemImageParams struct {
+	ItemID      int64
+	ScanImageID int64
+}
+
+func (q *Queries) CreateItemImage(ctx context.Context, arg CreateItemImageParams) error {
+	_, err := q.db.Exec(ctx, createItemImage, arg.ItemID, arg.ScanImageID)
+	return err
+}
+
 const createItems = `-- name: CreateItems :one
-INSERT INTO items(scan_id, user_id, name, description, value)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, scan_id, name, description, value, created_at
+INSERT INTO items(scan_id, user_id, name, description, value, material)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, scan_id, name, description, value, created_at, material
 `
 
 type CreateItemsParams struct {
@@ -341,6 +1182,7 @@ type CreateItemsParams struct {
 	Name        string
 	Description string
 	Value       string
+	Material    pgtype.Text
 }
 
 func (q *Queries) CreateItems(ctx context.Context, arg CreateItemsParams) (Item, error) {
@@ -350,6 +1192,7 @@ func (q *Queries) CreateItems(ctx context.Context, arg CreateItemsParams) (Item,
 		arg.Name,
 		arg.Description,
 		arg.Value,
+		arg.Material,
 	)
 	var i Item
 	err := row.Scan(
@@ -360,6 +1203,7 @@ func (q *Queries) CreateItems(ctx context.Context, arg CreateItemsParams) (Item,
 		&i.Description,
 		&i.Value,
 		&i.CreatedAt,
+		&i.Material,
 	)
 	return i, err
 }
@@ -402,9 +1246,9 @@ func (q *Queries) CreateLog(ctx context.Context, arg CreateLogParams) (CreateLog
 }
 
 const createMaterials = `-- name: CreateMaterials :one
-INSERT INTO materials(greenprint_id,name, description, price, quantity)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, description, price, quantity, greenprint_id
+INSERT INTO materials(greenprint_id, name, description, price, quantity, step_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, description, price, quantity, greenprint_id, step_id
 `
 
 type CreateMaterialsParams struct {
@@ -413,6 +1257,7 @@ type CreateMaterialsParams struct {
 	Description  string
 	Price        int32
 	Quantity     int32
+	StepID       pgtype.Int8
 }
 
 func (q *Queries) CreateMaterials(ctx context.Context, arg CreateMaterialsParams) (Material, error) {
@@ -422,6 +1267,7 @@ func (q *Queries) CreateMaterials(ctx context.Context, arg CreateMaterialsParams
 		arg.Description,
 		arg.Price,
 		arg.Quantity,
+		arg.StepID,
 	)
 	var i Material
 	err := row.Scan(
@@ -431,24 +1277,31 @@ func (q *Queries) CreateMaterials(ctx context.Context, arg CreateMaterialsParams
 		&i.Price,
 		&i.Quantity,
 		&i.GreenprintID,
+		&i.StepID,
 	)
 	return i, err
 }
 
 const createMemory = `-- name: CreateMemory :one
-INSERT INTO memories(user_id, file_key, description)
-VALUES ($1, $2, $3)
+INSERT INTO memories(user_id, file_key, description, thumbnail_key)
+VALUES ($1, $2, $3, $4)
 RETURNING id
 `
 
 type CreateMemoryParams struct {
-	UserID      int64
-	FileKey     string
-	Description string
+	UserID       int64
+	FileKey      string
+	Description  string
+	ThumbnailKey pgtype.Text
 }
 
 func (q *Queries) CreateMemory(ctx context.Context, arg CreateMemoryParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createMemory, arg.UserID, arg.FileKey, arg.Description)
+	row := q.db.QueryRow(ctx, createMemory,
+		arg.UserID,
+		arg.FileKey,
+		arg.Description,
+		arg.ThumbnailKey,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -485,6 +1338,60 @@ func (q *Queries) CreateMonthlyRecap(ctx context.Context, arg CreateMonthlyRecap
 	return id, err
 }
 
+const createNotification = `-- name: CreateNotification :exec
+INSERT INTO notifications(user_id, type, title, body)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateNotificationParams struct {
+	UserID int64
+	Type   string
+	Title  string
+	Body   string
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) error {
+	_, err := q.db.Exec(ctx, createNotification,
+		arg.UserID,
+		arg.Type,
+		arg.Title,
+		arg.Body,
+	)
+	return err
+}
+
+const createOwnedMaterial = `-- name: CreateOwnedMaterial :exec
+INSERT INTO owned_materials(user_id, material_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, material_id) DO NOTHING
+`
+
+type CreateOwnedMaterialParams struct {
+	UserID     int64
+	MaterialID int64
+}
+
+func (q *Queries) CreateOwnedMaterial(ctx context.Context, arg CreateOwnedMaterialParams) error {
+	_, err := q.db.Exec(ctx, createOwnedMaterial, arg.UserID, arg.MaterialID)
+	return err
+}
+
+const createOwnedTool = `-- name: CreateOwnedTool :exec
+INSERT INTO owned_tools(user_id, tool_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, tool_id) DO NOTHING
+`
+
+type CreateOwnedToolParams struct {
+	UserID int64
+	ToolID int64
+}
+
+func (q *Queries) CreateOwnedTool(ctx context.Context, arg CreateOwnedToolParams) error {
+	_, err := q.db.Exec(ctx, createOwnedTool, arg.UserID, arg.ToolID)
+	return err
+}
+
 const createPacket = `-- name: CreatePacket :one
 INSERT INTO packets  (user_id, name, target, description, expected_task, task_per_day)
 VALUES ($1, $2, $3, $4, $5, $6)
@@ -515,19 +1422,25 @@ func (q *Queries) CreatePacket(ctx context.Context, arg CreatePacketParams) (int
 }
 
 const createParticipation = `-- name: CreateParticipation :one
-INSERT INTO participations(challenge_id, user_id, memory_id)
-VALUES ($1, $2, $3)
-RETURNING id, challenge_id, user_id, memory_id, created_at
+INSERT INTO participations(challenge_id, user_id, memory_id, point_gain, status)
+VALUES ($1, $2, $3, $4, 'pending_upload')
+RETURNING id, challenge_id, user_id, memory_id, created_at, status, point_gain, note, reviewed_by, reviewed_at, uploaded_at, bonus_points
 `
 
 type CreateParticipationParams struct {
 	ChallengeID int64
 	UserID      int64
 	MemoryID    int64
+	PointGain   int32
 }
 
 func (q *Queries) CreateParticipation(ctx context.Context, arg CreateParticipationParams) (Participation, error) {
-	row := q.db.QueryRow(ctx, createParticipation, arg.ChallengeID, arg.UserID, arg.MemoryID)
+	row := q.db.QueryRow(ctx, createParticipation,
+		arg.ChallengeID,
+		arg.UserID,
+		arg.MemoryID,
+		arg.PointGain,
+	)
 	var i Participation
 	err := row.Scan(
 		&i.ID,
@@ -535,6 +1448,13 @@ func (q *Queries) CreateParticipation(ctx context.Context, arg CreateParticipati
 		&i.UserID,
 		&i.MemoryID,
 		&i.CreatedAt,
+		&i.Status,
+		&i.PointGain,
+		&i.Note,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.UploadedAt,
+		&i.BonusPoints,
 	)
 	return i, err
 }
@@ -565,6 +1485,41 @@ func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (P
 	return i, err
 }
 
+const createProjectStep = `-- name: CreateProjectStep :exec
+INSERT INTO project_steps(project_id, step_id)
+VALUES ($1, $2)
+ON CONFLICT (project_id, step_id) DO NOTHING
+`
+
+type CreateProjectStepParams struct {
+	ProjectID int64
+	StepID    int64
+}
+
+func (q *Queries) CreateProjectStep(ctx context.Context, arg CreateProjectStepParams) error {
+	_, err := q.db.Exec(ctx, createProjectStep, arg.ProjectID, arg.StepID)
+	return err
+}
+
+const createQuestChainCompletion = `-- name: CreateQuestChainCompletion :execrows
+INSERT INTO quest_chain_completions(chain_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (chain_id, user_id) DO NOTHING
+`
+
+type CreateQuestChainCompletionParams struct {
+	ChainID int64
+	UserID  int64
+}
+
+func (q *Queries) CreateQuestChainCompletion(ctx context.Context, arg CreateQuestChainCompletionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createQuestChainCompletion, arg.ChainID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createRecapDetails = `-- name: CreateRecapDetails :exec
 INSERT INTO recap_details(monthly_recap_id, challenges, events, quests, treasures, longest_streak)
 VALUES ($1, $2, $3, $4, $5, $6)
@@ -591,6 +1546,31 @@ func (q *Queries) CreateRecapDetails(ctx context.Context, arg CreateRecapDetails
 	return err
 }
 
+const createScanImage = `-- name: CreateScanImage :one
+INSERT INTO scan_images(scan_id, image_key, position)
+VALUES ($1, $2, $3)
+RETURNING id, scan_id, image_key, position, created_at
+`
+
+type CreateScanImageParams struct {
+	ScanID   int64
+	ImageKey string
+	Position int32
+}
+
+func (q *Queries) CreateScanImage(ctx context.Context, arg CreateScanImageParams) (ScanImage, error) {
+	row := q.db.QueryRow(ctx, createScanImage, arg.ScanID, arg.ImageKey, arg.Position)
+	var i ScanImage
+	err := row.Scan(
+		&i.ID,
+		&i.ScanID,
+		&i.ImageKey,
+		&i.Position,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createScans = `-- name: CreateScans :one
 INSERT INTO scans(user_id, title, description, image_key)
 VALUES($1, $2, $3, $4)
@@ -697,9 +1677,9 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 }
 
 const createTools = `-- name: CreateTools :one
-INSERT INTO tools(greenprint_id, name, description, price)
-VALUES($1, $2, $3, $4)
-RETURNING id, greenprint_id, name, description, price, created_at
+INSERT INTO tools(greenprint_id, name, description, price, step_id, is_optional)
+VALUES($1, $2, $3, $4, $5, $6)
+RETURNING id, greenprint_id, name, description, price, created_at, step_id, is_optional
 `
 
 type CreateToolsParams struct {
@@ -707,6 +1687,8 @@ type CreateToolsParams struct {
 	Name         string
 	Description  string
 	Price        int32
+	StepID       pgtype.Int8
+	IsOptional   bool
 }
 
 func (q *Queries) CreateTools(ctx context.Context, arg CreateToolsParams) (Tool, error) {
@@ -715,6 +1697,8 @@ func (q *Queries) CreateTools(ctx context.Context, arg CreateToolsParams) (Tool,
 		arg.Name,
 		arg.Description,
 		arg.Price,
+		arg.StepID,
+		arg.IsOptional,
 	)
 	var i Tool
 	err := row.Scan(
@@ -724,6 +1708,8 @@ func (q *Queries) CreateTools(ctx context.Context, arg CreateToolsParams) (Tool,
 		&i.Description,
 		&i.Price,
 		&i.CreatedAt,
+		&i.StepID,
+		&i.IsOptional,
 	)
 	return i, err
 }
@@ -787,14 +1773,14 @@ func (q *Queries) CreateWeeklyRecap(ctx context.Context, arg CreateWeeklyRecapPa
 	return err
 }
 
-const deactivateTreasure = `-- name: DeactivateTreasure :exec
-UPDATE treasures
-SET claimed = true
-WHERE id = $1
+const decreaseChallengesFieldByOne = `-- name: DecreaseChallengesFieldByOne :exec
+UPDATE statistics
+SET challenges = GREATEST(challenges - 1, 0)
+WHERE user_id = $1
 `
 
-func (q *Queries) DeactivateTreasure(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deactivateTreasure, id)
+func (q *Queries) DecreaseChallengesFieldByOne(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, decreaseChallengesFieldByOne, userID)
 	return err
 }
 
@@ -825,44 +1811,441 @@ func (q *Queries) DecreaseUserPoints(ctx context.Context, arg DecreaseUserPoints
 	return i, err
 }
 
-const deleteMemory = `-- name: DeleteMemory :one
-DELETE FROM memories
-WHERE id = $1 AND user_id = $2
-RETURNING file_key
+const deleteActivityTokenUse = `-- name: DeleteActivityTokenUse :exec
+DELETE FROM activity_token_uses
+WHERE jti = $1 AND user_id = $2 AND use_number = $3
 `
 
-type DeleteMemoryParams struct {
-	ID     int64
-	UserID int64
+type DeleteActivityTokenUseParams struct {
+	Jti       string
+	UserID    int64
+	UseNumber int32
 }
 
-func (q *Queries) DeleteMemory(ctx context.Context, arg DeleteMemoryParams) (string, error) {
-	row := q.db.QueryRow(ctx, deleteMemory, arg.ID, arg.UserID)
-	var file_key string
-	err := row.Scan(&file_key)
-	return file_key, err
+func (q *Queries) DeleteActivityTokenUse(ctx context.Context, arg DeleteActivityTokenUseParams) error {
+	_, err := q.db.Exec(ctx, deleteActivityTokenUse, arg.Jti, arg.UserID, arg.UseNumber)
+	return err
 }
 
-const finsihQuest = `-- name: FinsihQuest :exec
-UPDATE quests
-SET finished = true
+const deleteAiUsage = `-- name: DeleteAiUsage :exec
+DELETE FROM ai_usages
 WHERE id = $1
 `
 
-func (q *Queries) FinsihQuest(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, finsihQuest, id)
+func (q *Queries) DeleteAiUsage(ctx context.Context, iD int64) error {
+	_, err := q.db.Exec(ctx, deleteAiUsage, iD)
 	return err
 }
 
-const getAllChallenges = `-- name: GetAllChallenges :many
-SELECT 
-c.id AS challenge_id,
-c.day,
-c.difficulty,
-d.id AS detail_id,
-d.name,
-d.description,
-d.point_gain,
+const deleteEventWaitlist = `-- name: DeleteEventWaitlist :execrows
+DELETE FROM event_waitlists
+WHERE event_id = $1 AND user_id = $2
+`
+
+type DeleteEventWaitlistParams struct {
+	EventID int64
+	UserID  int64
+}
+
+func (q *Queries) DeleteEventWaitlist(ctx context.Context, arg DeleteEventWaitlistParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEventWaitlist, arg.EventID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteMemory = `-- name: DeleteMemory :one
+DELETE FROM memories
+WHERE id = $1 AND user_id = $2
+RETURNING file_key, thumbnail_key
+`
+
+type DeleteMemoryParams struct {
+	ID     int64
+	UserID int64
+}
+
+type DeleteMemoryRow struct {
+	FileKey      string
+	ThumbnailKey pgtype.Text
+}
+
+func (q *Queries) DeleteMemory(ctx context.Context, arg DeleteMemoryParams) (DeleteMemoryRow, error) {
+	row := q.db.QueryRow(ctx, deleteMemory, arg.ID, arg.UserID)
+	var i DeleteMemoryRow
+	err := row.Scan(&i.FileKey, &i.ThumbnailKey)
+	return i, err
+}
+
+const deleteOwnedMaterials = `-- name: DeleteOwnedMaterials :exec
+DELETE FROM owned_materials
+USING materials
+WHERE materials.id = owned_materials.material_id
+  AND owned_materials.user_id = $1
+  AND materials.greenprint_id = $2
+`
+
+type DeleteOwnedMaterialsParams struct {
+	UserID       int64
+	GreenprintID int64
+}
+
+func (q *Queries) DeleteOwnedMaterials(ctx context.Context, arg DeleteOwnedMaterialsParams) error {
+	_, err := q.db.Exec(ctx, deleteOwnedMaterials, arg.UserID, arg.GreenprintID)
+	return err
+}
+
+const deleteOwnedTools = `-- name: DeleteOwnedTools :exec
+DELETE FROM owned_tools
+USING tools
+WHERE tools.id = owned_tools.tool_id
+  AND owned_tools.user_id = $1
+  AND tools.greenprint_id = $2
+`
+
+type DeleteOwnedToolsParams struct {
+	UserID       int64
+	GreenprintID int64
+}
+
+func (q *Queries) DeleteOwnedTools(ctx context.Context, arg DeleteOwnedToolsParams) error {
+	_, err := q.db.Exec(ctx, deleteOwnedTools, arg.UserID, arg.GreenprintID)
+	return err
+}
+
+const deleteParticipationReaction = `-- name: DeleteParticipationReaction :execrows
+DELETE FROM participation_reactions
+WHERE participation_id = $1 AND user_id = $2
+`
+
+type DeleteParticipationReactionParams struct {
+	ParticipationID int64
+	UserID          int64
+}
+
+func (q *Queries) DeleteParticipationReaction(ctx context.Context, arg DeleteParticipationReactionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteParticipationReaction, arg.ParticipationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePendingUploadParticipations = `-- name: DeletePendingUploadParticipations :many
+DELETE FROM participations
+WHERE user_id = $1 AND challenge_id = $2 AND status = 'pending_upload'
+RETURNING memory_id
+`
+
+type DeletePendingUploadParticipationsParams struct {
+	UserID      int64
+	ChallengeID int64
+}
+
+func (q *Queries) DeletePendingUploadParticipations(ctx context.Context, arg DeletePendingUploadParticipationsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, deletePendingUploadParticipations, arg.UserID, arg.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var memory_id int64
+		if err := rows.Scan(&memory_id); err != nil {
+			return nil, err
+		}
+		items = append(items, memory_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteProjectStep = `-- name: DeleteProjectStep :exec
+DELETE FROM project_steps
+WHERE project_id = $1 AND step_id = $2
+`
+
+type DeleteProjectStepParams struct {
+	ProjectID int64
+	StepID    int64
+}
+
+func (q *Queries) DeleteProjectStep(ctx context.Context, arg DeleteProjectStepParams) error {
+	_, err := q.db.Exec(ctx, deleteProjectStep, arg.ProjectID, arg.StepID)
+	return err
+}
+
+const deleteScan = `-- name: DeleteScan :execrows
+DELETE FROM scans
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteScanParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) DeleteScan(ctx context.Context, arg DeleteScanParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScan, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const findReusableGreenprint = `-- name: FindReusableGreenprint :one
+SELECT greenprints.id
+FROM greenprints
+JOIN items ON items.id = greenprints.item_id
+JOIN greenprint_ratings ON greenprint_ratings.greenprint_id = greenprints.id
+WHERE greenprints.is_public = true
+  AND greenprints.source_id IS NULL
+  AND lower(trim(items.name)) = lower(trim($1::text))
+GROUP BY greenprints.id
+HAVING AVG(greenprint_ratings.rating) >= $2::float8
+  AND COUNT(greenprint_ratings.id) >= $3::int
+ORDER BY AVG(greenprint_ratings.rating) DESC, COUNT(greenprint_ratings.id) DESC
+LIMIT 1
+`
+
+type FindReusableGreenprintParams struct {
+	ItemName   string
+	MinRating  float64
+	MinRatings int32
+}
+
+func (q *Queries) FindReusableGreenprint(ctx context.Context, arg FindReusableGreenprintParams) (int64, error) {
+	row := q.db.QueryRow(ctx, findReusableGreenprint, arg.ItemName, arg.MinRating, arg.MinRatings)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const finishAiUsage = `-- name: FinishAiUsage :exec
+UPDATE ai_usages
+SET prompt_tokens = $2,
+    completion_tokens = $3,
+    total_tokens = $4,
+    latency_ms = $5,
+    cost = $6,
+    status = $7,
+    error = $8
+WHERE id = $1
+`
+
+type FinishAiUsageParams struct {
+	ID               int64
+	PromptTokens     int32
+	CompletionTokens int32
+	TotalTokens      int32
+	LatencyMs        int32
+	Cost             float64
+	Status           string
+	Error            pgtype.Text
+}
+
+func (q *Queries) FinishAiUsage(ctx context.Context, arg FinishAiUsageParams) error {
+	_, err := q.db.Exec(ctx, finishAiUsage,
+		arg.ID,
+		arg.PromptTokens,
+		arg.CompletionTokens,
+		arg.TotalTokens,
+		arg.LatencyMs,
+		arg.Cost,
+		arg.Status,
+		arg.Error,
+	)
+	return err
+}
+
+const finsihQuest = `-- name: FinsihQuest :exec
+UPDATE quests
+SET finished = true
+WHERE id = $1
+`
+
+func (q *Queries) FinsihQuest(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, finsihQuest, id)
+	return err
+}
+
+const getActiveQuestChains = `-- name: GetActiveQuestChains :many
+SELECT
+  qc.id,
+  qc.name,
+  qc.description,
+  qc.completion_point_gain,
+  (SELECT COUNT(*) FROM quests q WHERE q.chain_id = qc.id)::int AS total_stages,
+  (
+    SELECT COUNT(*) FROM quests q
+    JOIN contributions c ON c.quest_id = q.id
+    WHERE q.chain_id = qc.id AND c.user_id = $1::bigint
+  )::int AS completed_stages,
+  EXISTS (
+    SELECT 1 FROM quest_chain_completions qcc
+    WHERE qcc.chain_id = qc.id AND qcc.user_id = $1::bigint
+  ) AS completed
+FROM quest_chains qc
+WHERE qc.is_active = true
+ORDER BY qc.created_at DESC
+`
+
+type GetActiveQuestChainsRow struct {
+	ID                  int64
+	Name                string
+	Description         string
+	CompletionPointGain int64
+	TotalStages         int32
+	CompletedStages     int32
+	Completed           bool
+}
+
+func (q *Queries) GetActiveQuestChains(ctx context.Context, userID int64) ([]GetActiveQuestChainsRow, error) {
+	rows, err := q.db.Query(ctx, getActiveQuestChains, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveQuestChainsRow
+	for rows.Next() {
+		var i GetActiveQuestChainsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CompletionPointGain,
+			&i.TotalStages,
+			&i.CompletedStages,
+			&i.Completed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActivityCodeId = `-- name: GetActivityCodeId :one
+SELECT code_id FROM quests
+WHERE $1::text = 'quest' AND id = $2::bigint
+UNION ALL
+SELECT code_id FROM events
+WHERE $1::text = 'event' AND id = $2::bigint
+UNION ALL
+SELECT code_id FROM treasures
+WHERE $1::text = 'treasure' AND id = $2::bigint
+LIMIT 1
+`
+
+type GetActivityCodeIdParams struct {
+	ActivityType string
+	ActivityID   int64
+}
+
+func (q *Queries) GetActivityCodeId(ctx context.Context, arg GetActivityCodeIdParams) (string, error) {
+	row := q.db.QueryRow(ctx, getActivityCodeId, arg.ActivityType, arg.ActivityID)
+	var code_id string
+	err := row.Scan(&code_id)
+	return code_id, err
+}
+
+const getActivityLocation = `-- name: GetActivityLocation :one
+SELECT id, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius
+FROM quests
+WHERE $1::text = 'quest' AND code_id = $2::text
+UNION ALL
+SELECT id, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius
+FROM events
+WHERE $1::text = 'event' AND code_id = $2::text
+UNION ALL
+SELECT id, latitude, longitude, geofence_radius
+FROM treasures
+WHERE $1::text = 'treasure' AND code_id = $2::text
+LIMIT 1
+`
+
+type GetActivityLocationParams struct {
+	ActivityType string
+	CodeID       string
+}
+
+type GetActivityLocationRow struct {
+	ID             int64
+	Latitude       pgtype.Float8
+	Longitude      pgtype.Float8
+	GeofenceRadius pgtype.Int4
+}
+
+func (q *Queries) GetActivityLocation(ctx context.Context, arg GetActivityLocationParams) (GetActivityLocationRow, error) {
+	row := q.db.QueryRow(ctx, getActivityLocation, arg.ActivityType, arg.CodeID)
+	var i GetActivityLocationRow
+	err := row.Scan(
+		&i.ID,
+		&i.Latitude,
+		&i.Longitude,
+		&i.GeofenceRadius,
+	)
+	return i, err
+}
+
+const getActivityScansByStatus = `-- name: GetActivityScansByStatus :many
+SELECT id, user_id, activity_type, activity_id, latitude, longitude, distance_meters, status, created_at FROM activity_scans
+WHERE status = ANY($1::text[])
+ORDER BY created_at DESC
+LIMIT $2::int OFFSET $3::int
+`
+
+type GetActivityScansByStatusParams struct {
+	Statuses   []string
+	PageLimit  int32
+	PageOffset int32
+}
+
+func (q *Queries) GetActivityScansByStatus(ctx context.Context, arg GetActivityScansByStatusParams) ([]ActivityScan, error) {
+	rows, err := q.db.Query(ctx, getActivityScansByStatus, arg.Statuses, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ActivityScan
+	for rows.Next() {
+		var i ActivityScan
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ActivityType,
+			&i.ActivityID,
+			&i.Latitude,
+			&i.Longitude,
+			&i.DistanceMeters,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllChallenges = `-- name: GetAllChallenges :many
+SELECT 
+c.id AS challenge_id,
+c.day,
+c.difficulty,
+d.id AS detail_id,
+d.name,
+d.description,
+d.point_gain,
 d.created_at,
 d.updated_at
 FROM challenges c
@@ -956,109 +2339,30 @@ func (q *Queries) GetAllClaimedTreasure(ctx context.Context, userID int64) ([]Ge
 	return items, nil
 }
 
-const getAllEvents = `-- name: GetAllEvents :many
-SELECT 
-    e.id,
-    e.detail_id,
-    e.code_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    e.cover_key,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain,
-    d.created_at AS detail_created_at,
-    d.updated_at AS detail_updated_at,
-    (e.ends_at < NOW()) AS ended
-FROM events e
-JOIN details d ON e.detail_id = d.id
-ORDER BY e.starts_at ASC
-`
-
-type GetAllEventsRow struct {
-	ID                int64
-	DetailID          int64
-	CodeID            string
-	Location          string
-	Latitude          float64
-	Longitude         float64
-	Contact           string
-	StartsAt          pgtype.Timestamp
-	EndsAt            pgtype.Timestamp
-	CoverKey          pgtype.Text
-	DetailName        string
-	DetailDescription string
-	PointGain         int64
-	DetailCreatedAt   pgtype.Timestamp
-	DetailUpdatedAt   pgtype.Timestamp
-	Ended             bool
-}
-
-func (q *Queries) GetAllEvents(ctx context.Context) ([]GetAllEventsRow, error) {
-	rows, err := q.db.Query(ctx, getAllEvents)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetAllEventsRow
-	for rows.Next() {
-		var i GetAllEventsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.DetailID,
-			&i.CodeID,
-			&i.Location,
-			&i.Latitude,
-			&i.Longitude,
-			&i.Contact,
-			&i.StartsAt,
-			&i.EndsAt,
-			&i.CoverKey,
-			&i.DetailName,
-			&i.DetailDescription,
-			&i.PointGain,
-			&i.DetailCreatedAt,
-			&i.DetailUpdatedAt,
-			&i.Ended,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getAllMonthlyRecapsWithDetails = `-- name: GetAllMonthlyRecapsWithDetails :many
-SELECT r.id            AS recap_id,
-       r.user_id,
-       r.summary,
-       r.tips,
-       r.assigned_task,
-       r.completed_task,
-       r.completion_rate,
-       r.growth_rating,
-       r.type,
-       r.created_at    AS recap_created_at,
-       d.id            AS detail_id,
-       d.challenges,
-       d.events,
-       d.quests,
-       d.treasures,
-       d.longest_streak,
-       d.created_at    AS detail_created_at
-FROM recaps r
-LEFT JOIN recap_details d 
-       ON d.monthly_recap_id = r.id
-WHERE r.user_id = $1
-  AND r.type = 'monthly'
-ORDER BY r.created_at DESC
+const getAllMonthlyRecapsWithDetails = `-- name: GetAllMonthlyRecapsWithDetails :many
+SELECT r.id            AS recap_id,
+       r.user_id,
+       r.summary,
+       r.tips,
+       r.assigned_task,
+       r.completed_task,
+       r.completion_rate,
+       r.growth_rating,
+       r.type,
+       r.created_at    AS recap_created_at,
+       d.id            AS detail_id,
+       d.challenges,
+       d.events,
+       d.quests,
+       d.treasures,
+       d.longest_streak,
+       d.created_at    AS detail_created_at
+FROM recaps r
+LEFT JOIN recap_details d 
+       ON d.monthly_recap_id = r.id
+WHERE r.user_id = $1
+  AND r.type = 'monthly'
+ORDER BY r.created_at DESC
 `
 
 type GetAllMonthlyRecapsWithDetailsRow struct {
@@ -1236,26 +2540,27 @@ func (q *Queries) GetAllUser(ctx context.Context) ([]GetAllUserRow, error) {
 	return items, nil
 }
 
-const getAllUserScans = `-- name: GetAllUserScans :many
-SELECT id, user_id, title, description, image_key, created_at FROM scans WHERE user_id = $1
+const getAllWasteBankPrices = `-- name: GetAllWasteBankPrices :many
+SELECT id, waste_bank_id, material, price_per_kg, created_at, updated_at FROM waste_bank_prices
+ORDER BY waste_bank_id, material
 `
 
-func (q *Queries) GetAllUserScans(ctx context.Context, userID int64) ([]Scan, error) {
-	rows, err := q.db.Query(ctx, getAllUserScans, userID)
+func (q *Queries) GetAllWasteBankPrices(ctx context.Context) ([]WasteBankPrice, error) {
+	rows, err := q.db.Query(ctx, getAllWasteBankPrices)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Scan
+	var items []WasteBankPrice
 	for rows.Next() {
-		var i Scan
+		var i WasteBankPrice
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.Title,
-			&i.Description,
-			&i.ImageKey,
+			&i.WasteBankID,
+			&i.Material,
+			&i.PricePerKg,
 			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1267,6 +2572,50 @@ func (q *Queries) GetAllUserScans(ctx context.Context, userID int64) ([]Scan, er
 	return items, nil
 }
 
+const getAttendanceCheckIn = `-- name: GetAttendanceCheckIn :one
+SELECT
+  a.id AS attendance_id,
+  a.user_id,
+  a.event_id,
+  a.attended,
+  u.username,
+  d.name AS name,
+  d.description AS description,
+  d.point_gain
+FROM attendances a
+JOIN users u ON a.user_id = u.id
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.id = $1
+`
+
+type GetAttendanceCheckInRow struct {
+	AttendanceID int64
+	UserID       int64
+	EventID      int64
+	Attended     bool
+	Username     string
+	Name         string
+	Description  string
+	PointGain    int64
+}
+
+func (q *Queries) GetAttendanceCheckIn(ctx context.Context, iD int64) (GetAttendanceCheckInRow, error) {
+	row := q.db.QueryRow(ctx, getAttendanceCheckIn, iD)
+	var i GetAttendanceCheckInRow
+	err := row.Scan(
+		&i.AttendanceID,
+		&i.UserID,
+		&i.EventID,
+		&i.Attended,
+		&i.Username,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+	)
+	return i, err
+}
+
 const getAttendanceDetails = `-- name: GetAttendanceDetails :one
 SELECT
     a.id AS attendance_id,
@@ -1334,6 +2683,209 @@ func (q *Queries) GetAttendanceDetails(ctx context.Context, id int64) (GetAttend
 	return i, err
 }
 
+const getCalendarEventById = `-- name: GetCalendarEventById :one
+SELECT
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  d.updated_at
+FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE e.id = $1
+`
+
+type GetCalendarEventByIdRow struct {
+	ID          int64
+	Name        string
+	Description string
+	Location    string
+	Latitude    float64
+	Longitude   float64
+	Contact     string
+	StartsAt    pgtype.Timestamp
+	EndsAt      pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) GetCalendarEventById(ctx context.Context, iD int64) (GetCalendarEventByIdRow, error) {
+	row := q.db.QueryRow(ctx, getCalendarEventById, iD)
+	var i GetCalendarEventByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Contact,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByToken = `-- name: GetCalendarFeedByToken :one
+SELECT id, user_id, token, created_at FROM calendar_feeds
+WHERE token = $1
+`
+
+func (q *Queries) GetCalendarFeedByToken(ctx context.Context, token string) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByToken, token)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Token,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByUserId = `-- name: GetCalendarFeedByUserId :one
+SELECT id, user_id, token, created_at FROM calendar_feeds
+WHERE user_id = $1
+`
+
+func (q *Queries) GetCalendarFeedByUserId(ctx context.Context, userID int64) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByUserId, userID)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Token,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCertificateByCode = `-- name: GetCertificateByCode :one
+SELECT
+  c.id,
+  c.code,
+  c.user_id,
+  u.name AS user_name,
+  c.event_id,
+  d.name AS event_name,
+  c.period_from,
+  c.period_to,
+  c.event_count,
+  c.total_minutes,
+  c.image_key,
+  c.issued_at
+FROM certificates c
+JOIN users u ON c.user_id = u.id
+LEFT JOIN events e ON c.event_id = e.id
+LEFT JOIN details d ON e.detail_id = d.id
+WHERE c.code = $1
+`
+
+type GetCertificateByCodeRow struct {
+	ID           int64
+	Code         string
+	UserID       int64
+	UserName     string
+	EventID      pgtype.Int8
+	EventName    pgtype.Text
+	PeriodFrom   pgtype.Date
+	PeriodTo     pgtype.Date
+	EventCount   int32
+	TotalMinutes int32
+	ImageKey     string
+	IssuedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetCertificateByCode(ctx context.Context, code string) (GetCertificateByCodeRow, error) {
+	row := q.db.QueryRow(ctx, getCertificateByCode, code)
+	var i GetCertificateByCodeRow
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.UserID,
+		&i.UserName,
+		&i.EventID,
+		&i.EventName,
+		&i.PeriodFrom,
+		&i.PeriodTo,
+		&i.EventCount,
+		&i.TotalMinutes,
+		&i.ImageKey,
+		&i.IssuedAt,
+	)
+	return i, err
+}
+
+const getChainStagesWithProgress = `-- name: GetChainStagesWithProgress :many
+SELECT
+  q.id,
+  q.stage_order,
+  q.clue,
+  q.location,
+  q.finished,
+  d.name,
+  d.description,
+  d.point_gain,
+  c.created_at AS contributed_at
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+LEFT JOIN contributions c ON c.quest_id = q.id AND c.user_id = $1::bigint
+WHERE q.chain_id = $2::bigint
+ORDER BY q.stage_order
+`
+
+type GetChainStagesWithProgressParams struct {
+	UserID  int64
+	ChainID int64
+}
+
+type GetChainStagesWithProgressRow struct {
+	ID            int64
+	StageOrder    pgtype.Int4
+	Clue          pgtype.Text
+	Location      string
+	Finished      bool
+	Name          string
+	Description   string
+	PointGain     int64
+	ContributedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetChainStagesWithProgress(ctx context.Context, arg GetChainStagesWithProgressParams) ([]GetChainStagesWithProgressRow, error) {
+	rows, err := q.db.Query(ctx, getChainStagesWithProgress, arg.UserID, arg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetChainStagesWithProgressRow
+	for rows.Next() {
+		var i GetChainStagesWithProgressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StageOrder,
+			&i.Clue,
+			&i.Location,
+			&i.Finished,
+			&i.Name,
+			&i.Description,
+			&i.PointGain,
+			&i.ContributedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getChallengeWithDetail = `-- name: GetChallengeWithDetail :one
 SELECT 
     c.id AS challenge_id,
@@ -1425,6 +2977,23 @@ func (q *Queries) GetChallengeWithDetailById(ctx context.Context, id int64) (Get
 	return i, err
 }
 
+const getCodeById = `-- name: GetCodeById :one
+SELECT id, image_url, token_id, rotation_seconds FROM codes
+WHERE id = $1
+`
+
+func (q *Queries) GetCodeById(ctx context.Context, iD string) (Code, error) {
+	row := q.db.QueryRow(ctx, getCodeById, iD)
+	var i Code
+	err := row.Scan(
+		&i.ID,
+		&i.ImageUrl,
+		&i.TokenID,
+		&i.RotationSeconds,
+	)
+	return i, err
+}
+
 const getContribution = `-- name: GetContribution :one
 SELECT
   COUNT(*) AS is_exist
@@ -1499,141 +3068,72 @@ func (q *Queries) GetContributionDetails(ctx context.Context, id int64) (GetCont
 	return i, err
 }
 
-const getEventByCodeId = `-- name: GetEventByCodeId :one
-SELECT
-  e.id,
-  d.name AS name,
-  d.description AS description,
-  d.point_gain AS point_gain
-FROM events e
-JOIN details d ON e.detail_id = d.id
-WHERE e.code_id = $1
+const getDepositById = `-- name: GetDepositById :one
+SELECT id, user_id, waste_bank_id, status, total_weight, payout, point_gain, note, confirmed_by, confirmed_at, created_at FROM deposits
+WHERE id = $1
 `
 
-type GetEventByCodeIdRow struct {
-	ID          int64
-	Name        string
-	Description string
-	PointGain   int64
-}
-
-func (q *Queries) GetEventByCodeId(ctx context.Context, codeID string) (GetEventByCodeIdRow, error) {
-	row := q.db.QueryRow(ctx, getEventByCodeId, codeID)
-	var i GetEventByCodeIdRow
+func (q *Queries) GetDepositById(ctx context.Context, id int64) (Deposit, error) {
+	row := q.db.QueryRow(ctx, getDepositById, id)
+	var i Deposit
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Description,
+		&i.UserID,
+		&i.WasteBankID,
+		&i.Status,
+		&i.TotalWeight,
+		&i.Payout,
 		&i.PointGain,
+		&i.Note,
+		&i.ConfirmedBy,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getEventById = `-- name: GetEventById :one
-SELECT 
-  e.id,
-  d.name AS name,
-  d.description AS description
-FROM events e
-JOIN details d ON e.detail_id = d.id
-WHERE e.id = $1
+const getDepositItems = `-- name: GetDepositItems :many
+SELECT
+  deposit_items.id,
+  deposit_items.item_id,
+  deposit_items.material,
+  deposit_items.weight,
+  deposit_items.price_per_kg,
+  deposit_items.subtotal,
+  items.name AS item_name
+FROM deposit_items
+LEFT JOIN items ON items.id = deposit_items.item_id
+WHERE deposit_items.deposit_id = $1
+ORDER BY deposit_items.id
 `
 
-type GetEventByIdRow struct {
-	ID          int64
-	Name        string
-	Description string
-}
-
-func (q *Queries) GetEventById(ctx context.Context, id int64) (GetEventByIdRow, error) {
-	row := q.db.QueryRow(ctx, getEventById, id)
-	var i GetEventByIdRow
-	err := row.Scan(&i.ID, &i.Name, &i.Description)
-	return i, err
+type GetDepositItemsRow struct {
+	ID         int64
+	ItemID     pgtype.Int8
+	Material   string
+	Weight     float64
+	PricePerKg int32
+	Subtotal   int32
+	ItemName   pgtype.Text
 }
 
-const getGreenprints = `-- name: GetGreenprints :one
-SELECT id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at FROM greenprints
-WHERE item_id = $1
-`
-
-func (q *Queries) GetGreenprints(ctx context.Context, itemID int64) (Greenprint, error) {
-	row := q.db.QueryRow(ctx, getGreenprints, itemID)
-	var i Greenprint
-	err := row.Scan(
-		&i.ID,
-		&i.ItemID,
-		&i.ImageKey,
-		&i.Title,
-		&i.Description,
-		&i.SustainabilityScore,
-		&i.EstimatedTime,
-		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const getGreenprintsById = `-- name: GetGreenprintsById :one
-SELECT id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at FROM greenprints
-WHERE id = $1
-`
-
-func (q *Queries) GetGreenprintsById(ctx context.Context, id int64) (Greenprint, error) {
-	row := q.db.QueryRow(ctx, getGreenprintsById, id)
-	var i Greenprint
-	err := row.Scan(
-		&i.ID,
-		&i.ItemID,
-		&i.ImageKey,
-		&i.Title,
-		&i.Description,
-		&i.SustainabilityScore,
-		&i.EstimatedTime,
-		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const getItemsById = `-- name: GetItemsById :one
-SELECT id, user_id, scan_id, name, description, value, created_at FROM items WHERE id = $1
-`
-
-func (q *Queries) GetItemsById(ctx context.Context, id int64) (Item, error) {
-	row := q.db.QueryRow(ctx, getItemsById, id)
-	var i Item
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.ScanID,
-		&i.Name,
-		&i.Description,
-		&i.Value,
-		&i.CreatedAt,
-	)
-	return i, err
-}
-
-const getItemsByScanId = `-- name: GetItemsByScanId :many
-SELECT id, user_id, scan_id, name, description, value, created_at FROM items WHERE scan_id = $1
-`
-
-func (q *Queries) GetItemsByScanId(ctx context.Context, scanID int64) ([]Item, error) {
-	rows, err := q.db.Query(ctx, getItemsByScanId, scanID)
+func (q *Queries) GetDepositItems(ctx context.Context, depositID int64) ([]GetDepositItemsRow, error) {
+	rows, err := q.db.Query(ctx, getDepositItems, depositID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Item
+	var items []GetDepositItemsRow
 	for rows.Next() {
-		var i Item
+		var i GetDepositItemsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.ScanID,
-			&i.Name,
-			&i.Description,
-			&i.Value,
-			&i.CreatedAt,
+			&i.ItemID,
+			&i.Material,
+			&i.Weight,
+			&i.PricePerKg,
+			&i.Subtotal,
+			&i.ItemName,
 		); err != nil {
 			return nil, err
 		}
@@ -1645,31 +3145,33 @@ func (q *Queries) GetItemsByScanId(ctx context.Context, scanID int64) ([]Item, e
 	return items, nil
 }
 
-const getLastMonthUserHistories = `-- name: GetLastMonthUserHistories :many
-SELECT id, user_id, name, type, category, amount, created_at FROM histories
-WHERE user_id = $1
-AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
-ORDER BY created_at DESC
+const getDepositItemsByScanIds = `-- name: GetDepositItemsByScanIds :many
+SELECT
+  deposit_items.item_id,
+  deposits.id AS deposit_id,
+  deposits.status
+FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+JOIN items ON items.id = deposit_items.item_id
+WHERE items.scan_id = ANY($1::bigint[]) AND deposits.status <> 'rejected'
 `
 
-func (q *Queries) GetLastMonthUserHistories(ctx context.Context, userID int64) ([]History, error) {
-	rows, err := q.db.Query(ctx, getLastMonthUserHistories, userID)
+type GetDepositItemsByScanIdsRow struct {
+	ItemID    pgtype.Int8
+	DepositID int64
+	Status    string
+}
+
+func (q *Queries) GetDepositItemsByScanIds(ctx context.Context, scanIds []int64) ([]GetDepositItemsByScanIdsRow, error) {
+	rows, err := q.db.Query(ctx, getDepositItemsByScanIds, scanIds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []History
+	var items []GetDepositItemsByScanIdsRow
 	for rows.Next() {
-		var i History
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Name,
-			&i.Type,
-			&i.Category,
-			&i.Amount,
-			&i.CreatedAt,
-		); err != nil {
+		var i GetDepositItemsByScanIdsRow
+		if err := rows.Scan(&i.ItemID, &i.DepositID, &i.Status); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1680,29 +3182,27 @@ func (q *Queries) GetLastMonthUserHistories(ctx context.Context, userID int64) (
 	return items, nil
 }
 
-const getLastMonthUserLogs = `-- name: GetLastMonthUserLogs :many
-SELECT id, user_id, text, is_system, is_private, created_at FROM logs
-WHERE user_id =  $1
-AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
-ORDER BY created_at DESC
+const getDropoffHoursByPointIds = `-- name: GetDropoffHoursByPointIds :many
+SELECT id, dropoff_point_id, day_of_week, opens_at, closes_at FROM dropoff_hours
+WHERE dropoff_point_id = ANY($1::bigint[])
+ORDER BY dropoff_point_id, day_of_week, opens_at
 `
 
-func (q *Queries) GetLastMonthUserLogs(ctx context.Context, userID int64) ([]Log, error) {
-	rows, err := q.db.Query(ctx, getLastMonthUserLogs, userID)
+func (q *Queries) GetDropoffHoursByPointIds(ctx context.Context, ids []int64) ([]DropoffHour, error) {
+	rows, err := q.db.Query(ctx, getDropoffHoursByPointIds, ids)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Log
+	var items []DropoffHour
 	for rows.Next() {
-		var i Log
+		var i DropoffHour
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.Text,
-			&i.IsSystem,
-			&i.IsPrivate,
-			&i.CreatedAt,
+			&i.DropoffPointID,
+			&i.DayOfWeek,
+			&i.OpensAt,
+			&i.ClosesAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1714,34 +3214,22 @@ func (q *Queries) GetLastMonthUserLogs(ctx context.Context, userID int64) ([]Log
 	return items, nil
 }
 
-const getLastWeekTasks = `-- name: GetLastWeekTasks :many
-SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at
-FROM tasks
-WHERE created_at >= NOW() - INTERVAL '1 weeks' AND user_id = $1
-ORDER BY created_at DESC
+const getDropoffMaterialsByPointIds = `-- name: GetDropoffMaterialsByPointIds :many
+SELECT id, dropoff_point_id, material FROM dropoff_materials
+WHERE dropoff_point_id = ANY($1::bigint[])
+ORDER BY dropoff_point_id, material
 `
 
-func (q *Queries) GetLastWeekTasks(ctx context.Context, userID int64) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getLastWeekTasks, userID)
+func (q *Queries) GetDropoffMaterialsByPointIds(ctx context.Context, ids []int64) ([]DropoffMaterial, error) {
+	rows, err := q.db.Query(ctx, getDropoffMaterialsByPointIds, ids)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []DropoffMaterial
 	for rows.Next() {
-		var i Task
-		if err := rows.Scan(
-			&i.ID,
-			&i.HabitID,
-			&i.UserID,
-			&i.PacketID,
-			&i.Name,
-			&i.Description,
-			&i.Difficulty,
-			&i.Completed,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var i DropoffMaterial
+		if err := rows.Scan(&i.ID, &i.DropoffPointID, &i.Material); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1752,101 +3240,101 @@ func (q *Queries) GetLastWeekTasks(ctx context.Context, userID int64) ([]Task, e
 	return items, nil
 }
 
-const getLatestMonhtlyRecap = `-- name: GetLatestMonhtlyRecap :one
-SELECT 
-  id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at,
-  (date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)) 
-           AS is_this_month
-FROM recaps
-WHERE user_id = $1 AND type = 'monthly'
-ORDER BY created_at DESC
-LIMIT 1
+const getDropoffPointById = `-- name: GetDropoffPointById :one
+SELECT id, name, address, description, latitude, longitude, region_id, phone, is_active, created_at, updated_at FROM dropoff_points
+WHERE id = $1
 `
 
-type GetLatestMonhtlyRecapRow struct {
-	ID             int64
-	UserID         int64
-	Summary        string
-	Tips           string
-	AssignedTask   int32
-	CompletedTask  int32
-	CompletionRate string
-	GrowthRating   string
-	Type           string
-	CreatedAt      pgtype.Timestamp
-	IsThisMonth    bool
-}
-
-func (q *Queries) GetLatestMonhtlyRecap(ctx context.Context, userID int64) (GetLatestMonhtlyRecapRow, error) {
-	row := q.db.QueryRow(ctx, getLatestMonhtlyRecap, userID)
-	var i GetLatestMonhtlyRecapRow
+func (q *Queries) GetDropoffPointById(ctx context.Context, id int64) (DropoffPoint, error) {
+	row := q.db.QueryRow(ctx, getDropoffPointById, id)
+	var i DropoffPoint
 	err := row.Scan(
 		&i.ID,
-		&i.UserID,
-		&i.Summary,
-		&i.Tips,
-		&i.AssignedTask,
-		&i.CompletedTask,
-		&i.CompletionRate,
-		&i.GrowthRating,
-		&i.Type,
+		&i.Name,
+		&i.Address,
+		&i.Description,
+		&i.Latitude,
+		&i.Longitude,
+		&i.RegionID,
+		&i.Phone,
+		&i.IsActive,
 		&i.CreatedAt,
-		&i.IsThisMonth,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getLatestRecap = `-- name: GetLatestRecap :one
-SELECT id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at
-FROM recaps
-WHERE user_id = $1
-  AND type = 'weekly'
-ORDER BY created_at DESC
-LIMIT 1
+const getEarthDistance = `-- name: GetEarthDistance :one
+SELECT earth_distance(
+  ll_to_earth($1::float8, $2::float8),
+  ll_to_earth($3::float8, $4::float8)
+)::float8 AS distance_meters
 `
 
-func (q *Queries) GetLatestRecap(ctx context.Context, userID int64) (Recap, error) {
-	row := q.db.QueryRow(ctx, getLatestRecap, userID)
-	var i Recap
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Summary,
-		&i.Tips,
-		&i.AssignedTask,
-		&i.CompletedTask,
-		&i.CompletionRate,
-		&i.GrowthRating,
-		&i.Type,
-		&i.CreatedAt,
+type GetEarthDistanceParams struct {
+	FromLatitude  float64
+	FromLongitude float64
+	ToLatitude    float64
+	ToLongitude   float64
+}
+
+func (q *Queries) GetEarthDistance(ctx context.Context, arg GetEarthDistanceParams) (float64, error) {
+	row := q.db.QueryRow(ctx, getEarthDistance,
+		arg.FromLatitude,
+		arg.FromLongitude,
+		arg.ToLatitude,
+		arg.ToLongitude,
 	)
-	return i, err
+	var distance_meters float64
+	err := row.Scan(&distance_meters)
+	return distance_meters, err
 }
 
-const getLockedHabits = `-- name: GetLockedHabits :many
-SELECT 
-  id, packet_id, name, description, difficulty, locked, weight
-FROM habits
-WHERE packet_id = $1 AND locked = true
+const getEventAttendees = `-- name: GetEventAttendees :many
+SELECT
+  a.id,
+  a.user_id,
+  u.name,
+  u.username,
+  a.contact_number,
+  a.attended,
+  a.created_at AS registered_at,
+  a.attended_at
+FROM attendances a
+JOIN users u ON a.user_id = u.id
+WHERE a.event_id = $1
+ORDER BY a.created_at, a.id
 `
 
-func (q *Queries) GetLockedHabits(ctx context.Context, packetID int64) ([]Habit, error) {
-	rows, err := q.db.Query(ctx, getLockedHabits, packetID)
+type GetEventAttendeesRow struct {
+	ID            int64
+	UserID        int64
+	Name          string
+	Username      string
+	ContactNumber string
+	Attended      bool
+	RegisteredAt  pgtype.Timestamp
+	AttendedAt    pgtype.Timestamp
+}
+
+func (q *Queries) GetEventAttendees(ctx context.Context, eventID int64) ([]GetEventAttendeesRow, error) {
+	rows, err := q.db.Query(ctx, getEventAttendees, eventID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Habit
+	var items []GetEventAttendeesRow
 	for rows.Next() {
-		var i Habit
+		var i GetEventAttendeesRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.PacketID,
+			&i.UserID,
 			&i.Name,
-			&i.Description,
-			&i.Difficulty,
-			&i.Locked,
-			&i.Weight,
+			&i.Username,
+			&i.ContactNumber,
+			&i.Attended,
+			&i.RegisteredAt,
+			&i.AttendedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1858,39 +3346,203 @@ func (q *Queries) GetLockedHabits(ctx context.Context, packetID int64) ([]Habit,
 	return items, nil
 }
 
-const getLogs = `-- name: GetLogs :many
-SELECT text, created_at, is_system, is_private
-FROM logs
-WHERE user_id = $1 AND is_private = $2
-ORDER BY created_at DESC
+const getEventByCodeId = `-- name: GetEventByCodeId :one
+SELECT
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  d.point_gain AS point_gain
+FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE e.code_id = $1
 `
 
-type GetLogsParams struct {
-	UserID    int64
-	IsPrivate bool
+type GetEventByCodeIdRow struct {
+	ID          int64
+	Name        string
+	Description string
+	PointGain   int64
 }
 
-type GetLogsRow struct {
-	Text      string
-	CreatedAt pgtype.Timestamp
-	IsSystem  bool
-	IsPrivate bool
+func (q *Queries) GetEventByCodeId(ctx context.Context, codeID string) (GetEventByCodeIdRow, error) {
+	row := q.db.QueryRow(ctx, getEventByCodeId, codeID)
+	var i GetEventByCodeIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+	)
+	return i, err
 }
 
-func (q *Queries) GetLogs(ctx context.Context, arg GetLogsParams) ([]GetLogsRow, error) {
-	rows, err := q.db.Query(ctx, getLogs, arg.UserID, arg.IsPrivate)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetLogsRow
-	for rows.Next() {
-		var i GetLogsRow
-		if err := rows.Scan(
-			&i.Text,
-			&i.CreatedAt,
-			&i.IsSystem,
-			&i.IsPrivate,
+const getEventById = `-- name: GetEventById :one
+SELECT 
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  e.starts_at,
+  e.max_attendees,
+  e.attendee_count,
+  (NOW() >= COALESCE(e.registration_closes_at, e.starts_at)) AS registration_closed,
+  (NOW() >= e.starts_at) AS started
+FROM events e
+JOIN details d ON e.detail_id = d.id
+WHERE e.id = $1
+`
+
+type GetEventByIdRow struct {
+	ID                 int64
+	Name               string
+	Description        string
+	StartsAt           pgtype.Timestamp
+	MaxAttendees       pgtype.Int4
+	AttendeeCount      int32
+	RegistrationClosed bool
+	Started            bool
+}
+
+func (q *Queries) GetEventById(ctx context.Context, iD int64) (GetEventByIdRow, error) {
+	row := q.db.QueryRow(ctx, getEventById, iD)
+	var i GetEventByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.StartsAt,
+		&i.MaxAttendees,
+		&i.AttendeeCount,
+		&i.RegistrationClosed,
+		&i.Started,
+	)
+	return i, err
+}
+
+const getEventCheckInWindow = `-- name: GetEventCheckInWindow :one
+SELECT
+  (e.starts_at - make_interval(mins => $1::int))::timestamp AS opens_at,
+  (e.ends_at + make_interval(mins => $2::int))::timestamp AS closes_at,
+  (e.ends_at + make_interval(mins => $2::int))::timestamptz AS closes_at_tz,
+  (NOW() < e.starts_at - make_interval(mins => $1::int)) AS not_open_yet,
+  (NOW() > e.ends_at + make_interval(mins => $2::int)) AS closed
+FROM events e
+WHERE e.id = $3
+`
+
+type GetEventCheckInWindowParams struct {
+	OpenMinutes  int32
+	CloseMinutes int32
+	EventID      int64
+}
+
+type GetEventCheckInWindowRow struct {
+	OpensAt    pgtype.Timestamp
+	ClosesAt   pgtype.Timestamp
+	ClosesAtTz pgtype.Timestamptz
+	NotOpenYet bool
+	Closed     bool
+}
+
+func (q *Queries) GetEventCheckInWindow(ctx context.Context, arg GetEventCheckInWindowParams) (GetEventCheckInWindowRow, error) {
+	row := q.db.QueryRow(ctx, getEventCheckInWindow, arg.OpenMinutes, arg.CloseMinutes, arg.EventID)
+	var i GetEventCheckInWindowRow
+	err := row.Scan(
+		&i.OpensAt,
+		&i.ClosesAt,
+		&i.ClosesAtTz,
+		&i.NotOpenYet,
+		&i.Closed,
+	)
+	return i, err
+}
+
+const getEventFeedbackSummary = `-- name: GetEventFeedbackSummary :one
+SELECT
+  COUNT(*) AS total,
+  COALESCE(AVG(rating), 0)::float8 AS average,
+  COUNT(*) FILTER (WHERE rating = 1) AS one_star,
+  COUNT(*) FILTER (WHERE rating = 2) AS two_stars,
+  COUNT(*) FILTER (WHERE rating = 3) AS three_stars,
+  COUNT(*) FILTER (WHERE rating = 4) AS four_stars,
+  COUNT(*) FILTER (WHERE rating = 5) AS five_stars
+FROM event_feedbacks
+WHERE event_id = $1
+`
+
+type GetEventFeedbackSummaryRow struct {
+	Total      int64
+	Average    float64
+	OneStar    int64
+	TwoStars   int64
+	ThreeStars int64
+	FourStars  int64
+	FiveStars  int64
+}
+
+func (q *Queries) GetEventFeedbackSummary(ctx context.Context, eventID int64) (GetEventFeedbackSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getEventFeedbackSummary, eventID)
+	var i GetEventFeedbackSummaryRow
+	err := row.Scan(
+		&i.Total,
+		&i.Average,
+		&i.OneStar,
+		&i.TwoStars,
+		&i.ThreeStars,
+		&i.FourStars,
+		&i.FiveStars,
+	)
+	return i, err
+}
+
+const getEventFeedbacks = `-- name: GetEventFeedbacks :many
+SELECT
+  f.id,
+  f.user_id,
+  u.username,
+  f.rating,
+  f.comment,
+  f.photo_key,
+  f.created_at
+FROM event_feedbacks f
+JOIN users u ON f.user_id = u.id
+WHERE f.event_id = $1
+ORDER BY f.created_at DESC, f.id DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetEventFeedbacksParams struct {
+	EventID int64
+	Limit   int32
+	Offset  int32
+}
+
+type GetEventFeedbacksRow struct {
+	ID        int64
+	UserID    int64
+	Username  string
+	Rating    int16
+	Comment   string
+	PhotoKey  pgtype.Text
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetEventFeedbacks(ctx context.Context, arg GetEventFeedbacksParams) ([]GetEventFeedbacksRow, error) {
+	rows, err := q.db.Query(ctx, getEventFeedbacks, arg.EventID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEventFeedbacksRow
+	for rows.Next() {
+		var i GetEventFeedbacksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Username,
+			&i.Rating,
+			&i.Comment,
+			&i.PhotoKey,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1902,31 +3554,50 @@ func (q *Queries) GetLogs(ctx context.Context, arg GetLogsParams) ([]GetLogsRow,
 	return items, nil
 }
 
-const getMaterials = `-- name: GetMaterials :many
-SELECT id, name, description, price, quantity, greenprint_id FROM materials
-WHERE greenprint_id = $1
+const getEventWaitlistPosition = `-- name: GetEventWaitlistPosition :one
+SELECT COUNT(*) FROM event_waitlists w
+WHERE w.event_id = $1
+  AND (w.created_at, w.id) <= (
+    SELECT created_at, id FROM event_waitlists
+    WHERE event_id = $1 AND user_id = $2
+  )
 `
 
-func (q *Queries) GetMaterials(ctx context.Context, greenprintID int64) ([]Material, error) {
-	rows, err := q.db.Query(ctx, getMaterials, greenprintID)
+type GetEventWaitlistPositionParams struct {
+	EventID int64
+	UserID  int64
+}
+
+func (q *Queries) GetEventWaitlistPosition(ctx context.Context, arg GetEventWaitlistPositionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getEventWaitlistPosition, arg.EventID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getEventsWithPromotableWaitlist = `-- name: GetEventsWithPromotableWaitlist :many
+SELECT e.id FROM events e
+WHERE (e.max_attendees IS NULL OR e.attendee_count < e.max_attendees)
+  AND e.starts_at > NOW()
+  AND COALESCE(e.registration_closes_at, e.starts_at) > NOW()
+  AND EXISTS (
+    SELECT 1 FROM event_waitlists w WHERE w.event_id = e.id
+  )
+`
+
+func (q *Queries) GetEventsWithPromotableWaitlist(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getEventsWithPromotableWaitlist)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Material
+	var items []int64
 	for rows.Next() {
-		var i Material
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.Price,
-			&i.Quantity,
-			&i.GreenprintID,
-		); err != nil {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -1934,52 +3605,158 @@ func (q *Queries) GetMaterials(ctx context.Context, greenprintID int64) ([]Mater
 	return items, nil
 }
 
-const getMemoriesByChallengeID = `-- name: GetMemoriesByChallengeID :many
-SELECT 
-    m.id AS memory_id,
-    m.file_key,
-    m.description,
-    m.created_at AS memory_created_at,
-    u.id AS user_id,
-    u.name AS user_name,
-    u.username,
-    u.email
-FROM participations p
-JOIN memories m ON p.memory_id = m.id
-JOIN users u ON m.user_id = u.id
-WHERE p.challenge_id = $1
-ORDER BY m.created_at DESC
+const getGreenprintProjectById = `-- name: GetGreenprintProjectById :one
+SELECT id, user_id, greenprint_id, status, proof_key, started_at, completed_at FROM greenprint_projects
+WHERE id = $1
 `
 
-type GetMemoriesByChallengeIDRow struct {
-	MemoryID        int64
-	FileKey         string
-	Description     string
-	MemoryCreatedAt pgtype.Timestamp
-	UserID          int64
-	UserName        string
-	Username        string
-	Email           string
+func (q *Queries) GetGreenprintProjectById(ctx context.Context, id int64) (GreenprintProject, error) {
+	row := q.db.QueryRow(ctx, getGreenprintProjectById, id)
+	var i GreenprintProject
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.GreenprintID,
+		&i.Status,
+		&i.ProofKey,
+		&i.StartedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getGreenprintRating = `-- name: GetGreenprintRating :one
+SELECT
+  COALESCE(AVG(rating), 0)::float8 AS rating,
+  COUNT(*) AS rating_count
+FROM greenprint_ratings
+WHERE greenprint_id = $1
+`
+
+type GetGreenprintRatingRow struct {
+	Rating      float64
+	RatingCount int64
+}
+
+func (q *Queries) GetGreenprintRating(ctx context.Context, greenprintID int64) (GetGreenprintRatingRow, error) {
+	row := q.db.QueryRow(ctx, getGreenprintRating, greenprintID)
+	var i GetGreenprintRatingRow
+	err := row.Scan(&i.Rating, &i.RatingCount)
+	return i, err
 }
 
-func (q *Queries) GetMemoriesByChallengeID(ctx context.Context, challengeID int64) ([]GetMemoriesByChallengeIDRow, error) {
-	rows, err := q.db.Query(ctx, getMemoriesByChallengeID, challengeID)
+const getGreenprints = `-- name: GetGreenprints :one
+SELECT id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at, is_public, source_id, search_text FROM greenprints
+WHERE item_id = $1
+`
+
+func (q *Queries) GetGreenprints(ctx context.Context, itemID int64) (Greenprint, error) {
+	row := q.db.QueryRow(ctx, getGreenprints, itemID)
+	var i Greenprint
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.ImageKey,
+		&i.Title,
+		&i.Description,
+		&i.SustainabilityScore,
+		&i.EstimatedTime,
+		&i.CreatedAt,
+		&i.IsPublic,
+		&i.SourceID,
+		&i.SearchText,
+	)
+	return i, err
+}
+
+const getGreenprintsById = `-- name: GetGreenprintsById :one
+SELECT id, item_id, image_key, title, description, sustainability_score, estimated_time, created_at, is_public, source_id, search_text FROM greenprints
+WHERE id = $1
+`
+
+func (q *Queries) GetGreenprintsById(ctx context.Context, id int64) (Greenprint, error) {
+	row := q.db.QueryRow(ctx, getGreenprintsById, id)
+	var i Greenprint
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.ImageKey,
+		&i.Title,
+		&i.Description,
+		&i.SustainabilityScore,
+		&i.EstimatedTime,
+		&i.CreatedAt,
+		&i.IsPublic,
+		&i.SourceID,
+		&i.SearchText,
+	)
+	return i, err
+}
+
+const getHuntableTreasureById = `-- name: GetHuntableTreasureById :one
+SELECT id, name, latitude::float8 AS latitude, longitude::float8 AS longitude, geofence_radius FROM treasures
+WHERE id = $1
+  AND claimed = false
+  AND latitude IS NOT NULL
+  AND longitude IS NOT NULL
+  AND (starts_at IS NULL OR starts_at <= now())
+  AND (expires_at IS NULL OR expires_at > now())
+`
+
+type GetHuntableTreasureByIdRow struct {
+	ID             int64
+	Name           string
+	Latitude       float64
+	Longitude      float64
+	GeofenceRadius pgtype.Int4
+}
+
+func (q *Queries) GetHuntableTreasureById(ctx context.Context, iD int64) (GetHuntableTreasureByIdRow, error) {
+	row := q.db.QueryRow(ctx, getHuntableTreasureById, iD)
+	var i GetHuntableTreasureByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Latitude,
+		&i.Longitude,
+		&i.GeofenceRadius,
+	)
+	return i, err
+}
+
+const getHuntableTreasures = `-- name: GetHuntableTreasures :many
+SELECT id, name, point_gain, (max_claims - claim_count)::int AS remaining_claims, expires_at FROM treasures
+WHERE claimed = false
+  AND latitude IS NOT NULL
+  AND longitude IS NOT NULL
+  AND (starts_at IS NULL OR starts_at <= now())
+  AND (expires_at IS NULL OR expires_at > now())
+ORDER BY created_at DESC
+`
+
+type GetHuntableTreasuresRow struct {
+	ID              int64
+	Name            string
+	PointGain       int64
+	RemainingClaims int32
+	ExpiresAt       pgtype.Timestamp
+}
+
+func (q *Queries) GetHuntableTreasures(ctx context.Context) ([]GetHuntableTreasuresRow, error) {
+	rows, err := q.db.Query(ctx, getHuntableTreasures)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMemoriesByChallengeIDRow
+	var items []GetHuntableTreasuresRow
 	for rows.Next() {
-		var i GetMemoriesByChallengeIDRow
+		var i GetHuntableTreasuresRow
 		if err := rows.Scan(
-			&i.MemoryID,
-			&i.FileKey,
-			&i.Description,
-			&i.MemoryCreatedAt,
-			&i.UserID,
-			&i.UserName,
-			&i.Username,
-			&i.Email,
+			&i.ID,
+			&i.Name,
+			&i.PointGain,
+			&i.RemainingClaims,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1991,70 +3768,31 @@ func (q *Queries) GetMemoriesByChallengeID(ctx context.Context, challengeID int6
 	return items, nil
 }
 
-const getMemoryWithParticipation = `-- name: GetMemoryWithParticipation :many
-SELECT 
-    m.id AS memory_id,
-    m.file_key,
-    m.description AS memory_description,
-    m.created_at AS memory_created_at,
-    u.id AS user_id,
-    u.username AS user_name,
-    CASE 
-        WHEN p.id IS NOT NULL THEN TRUE 
-        ELSE FALSE 
-    END AS is_participation,
-    p.challenge_id,
-    c.day,
-    c.difficulty,
-    d.name AS challenge_name,
-    d.point_gain
-FROM memories m
-JOIN users u ON m.user_id = u.id
-LEFT JOIN participations p ON m.id = p.memory_id
-LEFT JOIN challenges c ON p.challenge_id = c.id
-LEFT JOIN details d ON c.detail_id = d.id
-WHERE m.user_id = $1
-ORDER BY m.created_at DESC
+const getItemImagesByScanIds = `-- name: GetItemImagesByScanIds :many
+SELECT
+  item_images.item_id,
+  scan_images.image_key
+FROM item_images
+JOIN scan_images ON scan_images.id = item_images.scan_image_id
+WHERE scan_images.scan_id = ANY($1::bigint[])
+ORDER BY scan_images.position
 `
 
-type GetMemoryWithParticipationRow struct {
-	MemoryID          int64
-	FileKey           string
-	MemoryDescription string
-	MemoryCreatedAt   pgtype.Timestamp
-	UserID            int64
-	UserName          string
-	IsParticipation   bool
-	ChallengeID       pgtype.Int8
-	Day               pgtype.Int4
-	Difficulty        pgtype.Text
-	ChallengeName     pgtype.Text
-	PointGain         pgtype.Int8
+type GetItemImagesByScanIdsRow struct {
+	ItemID   int64
+	ImageKey string
 }
 
-func (q *Queries) GetMemoryWithParticipation(ctx context.Context, userID int64) ([]GetMemoryWithParticipationRow, error) {
-	rows, err := q.db.Query(ctx, getMemoryWithParticipation, userID)
+func (q *Queries) GetItemImagesByScanIds(ctx context.Context, scanIds []int64) ([]GetItemImagesByScanIdsRow, error) {
+	rows, err := q.db.Query(ctx, getItemImagesByScanIds, scanIds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMemoryWithParticipationRow
+	var items []GetItemImagesByScanIdsRow
 	for rows.Next() {
-		var i GetMemoryWithParticipationRow
-		if err := rows.Scan(
-			&i.MemoryID,
-			&i.FileKey,
-			&i.MemoryDescription,
-			&i.MemoryCreatedAt,
-			&i.UserID,
-			&i.UserName,
-			&i.IsParticipation,
-			&i.ChallengeID,
-			&i.Day,
-			&i.Difficulty,
-			&i.ChallengeName,
-			&i.PointGain,
-		); err != nil {
+		var i GetItemImagesByScanIdsRow
+		if err := rows.Scan(&i.ItemID, &i.ImageKey); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2065,134 +3803,69 @@ func (q *Queries) GetMemoryWithParticipation(ctx context.Context, userID int64)
 	return items, nil
 }
 
-const getNearestQuestWithinRadius = `-- name: GetNearestQuestWithinRadius :one
-SELECT 
-    id,
-    clue,
-    location,
-    latitude,
-    longitude,
-    earth_distance(
-        ll_to_earth($1, $2),
-        ll_to_earth(latitude, longitude)
-    ) AS distance_meters
-FROM quests
-WHERE finished = false
-  AND earth_distance(
-        ll_to_earth($1, $2),
-        ll_to_earth(latitude, longitude)
-    ) <= $3
-ORDER BY distance_meters
-LIMIT 1
+const getItemsById = `-- name: GetItemsById :one
+SELECT id, user_id, scan_id, name, description, value, created_at, material FROM items WHERE id = $1
 `
 
-type GetNearestQuestWithinRadiusParams struct {
-	LlToEarth   float64
-	LlToEarth_2 float64
-	Latitude    float64
-}
-
-type GetNearestQuestWithinRadiusRow struct {
-	ID             int64
-	Clue           pgtype.Text
-	Location       string
-	Latitude       float64
-	Longitude      float64
-	DistanceMeters float64
-}
-
-func (q *Queries) GetNearestQuestWithinRadius(ctx context.Context, arg GetNearestQuestWithinRadiusParams) (GetNearestQuestWithinRadiusRow, error) {
-	row := q.db.QueryRow(ctx, getNearestQuestWithinRadius, arg.LlToEarth, arg.LlToEarth_2, arg.Latitude)
-	var i GetNearestQuestWithinRadiusRow
+func (q *Queries) GetItemsById(ctx context.Context, id int64) (Item, error) {
+	row := q.db.QueryRow(ctx, getItemsById, id)
+	var i Item
 	err := row.Scan(
 		&i.ID,
-		&i.Clue,
-		&i.Location,
-		&i.Latitude,
-		&i.Longitude,
-		&i.DistanceMeters,
-	)
-	return i, err
-}
-
-const getPacketDetail = `-- name: GetPacketDetail :one
-SELECT 
-    p.id AS packet_id,
-    p.user_id,
-    u.name AS user_name,
-    u.username,
-    p.name AS packet_name,
-    p.target,
-    p.description,
-    p.completed_task,
-    p.expected_task,
-    p.task_per_day,
-    p.completed,
-    p.created_at
-FROM packets p
-JOIN users u ON u.id = p.user_id
-WHERE p.id = $1
-`
-
-type GetPacketDetailRow struct {
-	PacketID      int64
-	UserID        int64
-	UserName      string
-	Username      string
-	PacketName    string
-	Target        string
-	Description   string
-	CompletedTask int32
-	ExpectedTask  int32
-	TaskPerDay    int32
-	Completed     bool
-	CreatedAt     pgtype.Timestamp
-}
-
-func (q *Queries) GetPacketDetail(ctx context.Context, id int64) (GetPacketDetailRow, error) {
-	row := q.db.QueryRow(ctx, getPacketDetail, id)
-	var i GetPacketDetailRow
-	err := row.Scan(
-		&i.PacketID,
 		&i.UserID,
-		&i.UserName,
-		&i.Username,
-		&i.PacketName,
-		&i.Target,
+		&i.ScanID,
+		&i.Name,
 		&i.Description,
-		&i.CompletedTask,
-		&i.ExpectedTask,
-		&i.TaskPerDay,
-		&i.Completed,
+		&i.Value,
 		&i.CreatedAt,
+		&i.Material,
 	)
 	return i, err
 }
 
-const getPacketHabits = `-- name: GetPacketHabits :many
-SELECT 
-  id, packet_id, name, description, difficulty, locked, weight
-FROM habits
-WHERE packet_id = $1
+const getItemsByScanIds = `-- name: GetItemsByScanIds :many
+SELECT
+  items.id,
+  items.scan_id,
+  items.name,
+  items.description,
+  items.value,
+  items.material,
+  EXISTS (
+    SELECT 1 FROM greenprints WHERE greenprints.item_id = items.id
+  ) AS has_greenprint
+FROM items
+WHERE items.scan_id = ANY($1::bigint[])
+ORDER BY items.scan_id, items.id
 `
 
-func (q *Queries) GetPacketHabits(ctx context.Context, packetID int64) ([]Habit, error) {
-	rows, err := q.db.Query(ctx, getPacketHabits, packetID)
+type GetItemsByScanIdsRow struct {
+	ID            int64
+	ScanID        int64
+	Name          string
+	Description   string
+	Value         string
+	Material      pgtype.Text
+	HasGreenprint bool
+}
+
+func (q *Queries) GetItemsByScanIds(ctx context.Context, scanIds []int64) ([]GetItemsByScanIdsRow, error) {
+	rows, err := q.db.Query(ctx, getItemsByScanIds, scanIds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Habit
+	var items []GetItemsByScanIdsRow
 	for rows.Next() {
-		var i Habit
+		var i GetItemsByScanIdsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.PacketID,
+			&i.ScanID,
 			&i.Name,
 			&i.Description,
-			&i.Difficulty,
-			&i.Locked,
-			&i.Weight,
+			&i.Value,
+			&i.Material,
+			&i.HasGreenprint,
 		); err != nil {
 			return nil, err
 		}
@@ -2204,28 +3877,30 @@ func (q *Queries) GetPacketHabits(ctx context.Context, packetID int64) ([]Habit,
 	return items, nil
 }
 
-const getPacketUnlockedHabits = `-- name: GetPacketUnlockedHabits :many
-SELECT id, packet_id, name, description, difficulty, locked, weight FROM habits
-WHERE packet_id = $1 AND locked = false
+const getLastMonthUserHistories = `-- name: GetLastMonthUserHistories :many
+SELECT id, user_id, name, type, category, amount, created_at FROM histories
+WHERE user_id = $1
+AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
+ORDER BY created_at DESC
 `
 
-func (q *Queries) GetPacketUnlockedHabits(ctx context.Context, packetID int64) ([]Habit, error) {
-	rows, err := q.db.Query(ctx, getPacketUnlockedHabits, packetID)
+func (q *Queries) GetLastMonthUserHistories(ctx context.Context, userID int64) ([]History, error) {
+	rows, err := q.db.Query(ctx, getLastMonthUserHistories, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Habit
+	var items []History
 	for rows.Next() {
-		var i Habit
+		var i History
 		if err := rows.Scan(
 			&i.ID,
-			&i.PacketID,
+			&i.UserID,
 			&i.Name,
-			&i.Description,
-			&i.Difficulty,
-			&i.Locked,
-			&i.Weight,
+			&i.Type,
+			&i.Category,
+			&i.Amount,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -2237,114 +3912,28 @@ func (q *Queries) GetPacketUnlockedHabits(ctx context.Context, packetID int64) (
 	return items, nil
 }
 
-const getParticipants = `-- name: GetParticipants :one
-SELECT 
-  COUNT (*) FILTER (WHERE challenge_id = $1) AS participants
-FROM participations
-`
-
-func (q *Queries) GetParticipants(ctx context.Context, challengeID int64) (int64, error) {
-	row := q.db.QueryRow(ctx, getParticipants, challengeID)
-	var participants int64
-	err := row.Scan(&participants)
-	return participants, err
-}
-
-const getParticipationByMemoryId = `-- name: GetParticipationByMemoryId :one
-SELECT COUNT(*) as participations FROM participations WHERE memory_id = $1
-`
-
-func (q *Queries) GetParticipationByMemoryId(ctx context.Context, memoryID int64) (int64, error) {
-	row := q.db.QueryRow(ctx, getParticipationByMemoryId, memoryID)
-	var participations int64
-	err := row.Scan(&participations)
-	return participations, err
-}
-
-const getQuestByCodeId = `-- name: GetQuestByCodeId :one
-SELECT 
-  q.id AS id,
-  q.location AS location,
-  q.max_contributors AS max_contributors,
-  q.latitude AS latitude,
-  q.longitude AS longitude,
-  d.name AS name,
-  d.description AS description,
-  d.point_gain AS point_gain,
-  q.finished
-FROM quests q
-JOIN details d ON q.detail_id = d.id
-WHERE q.code_id = $1
-`
-
-type GetQuestByCodeIdRow struct {
-	ID              int64
-	Location        string
-	MaxContributors int32
-	Latitude        float64
-	Longitude       float64
-	Name            string
-	Description     string
-	PointGain       int64
-	Finished        bool
-}
-
-func (q *Queries) GetQuestByCodeId(ctx context.Context, codeID string) (GetQuestByCodeIdRow, error) {
-	row := q.db.QueryRow(ctx, getQuestByCodeId, codeID)
-	var i GetQuestByCodeIdRow
-	err := row.Scan(
-		&i.ID,
-		&i.Location,
-		&i.MaxContributors,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Name,
-		&i.Description,
-		&i.PointGain,
-		&i.Finished,
-	)
-	return i, err
-}
-
-const getRegionById = `-- name: GetRegionById :one
-SELECT id, name, location, latitude, longitude, tree_amount, created_at, updated_at FROM regions
-WHERE id = $1
-`
-
-func (q *Queries) GetRegionById(ctx context.Context, id int64) (Region, error) {
-	row := q.db.QueryRow(ctx, getRegionById, id)
-	var i Region
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Location,
-		&i.Latitude,
-		&i.Longitude,
-		&i.TreeAmount,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const getSteps = `-- name: GetSteps :many
-SELECT id, greenprint_id, description, created_at FROM steps
-WHERE greenprint_id = $1
+const getLastMonthUserLogs = `-- name: GetLastMonthUserLogs :many
+SELECT id, user_id, text, is_system, is_private, created_at FROM logs
+WHERE user_id =  $1
+AND date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)
+ORDER BY created_at DESC
 `
 
-func (q *Queries) GetSteps(ctx context.Context, greenprintID int64) ([]Step, error) {
-	rows, err := q.db.Query(ctx, getSteps, greenprintID)
+func (q *Queries) GetLastMonthUserLogs(ctx context.Context, userID int64) ([]Log, error) {
+	rows, err := q.db.Query(ctx, getLastMonthUserLogs, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Step
+	var items []Log
 	for rows.Next() {
-		var i Step
+		var i Log
 		if err := rows.Scan(
 			&i.ID,
-			&i.GreenprintID,
-			&i.Description,
+			&i.UserID,
+			&i.Text,
+			&i.IsSystem,
+			&i.IsPrivate,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -2357,103 +3946,183 @@ func (q *Queries) GetSteps(ctx context.Context, greenprintID int64) ([]Step, err
 	return items, nil
 }
 
-const getTaskById = `-- name: GetTaskById :one
-SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at FROM tasks
-WHERE id = $1
+const getLastWeekTasks = `-- name: GetLastWeekTasks :many
+SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at
+FROM tasks
+WHERE created_at >= NOW() - INTERVAL '1 weeks' AND user_id = $1
+ORDER BY created_at DESC
 `
 
-func (q *Queries) GetTaskById(ctx context.Context, id int64) (Task, error) {
-	row := q.db.QueryRow(ctx, getTaskById, id)
-	var i Task
+func (q *Queries) GetLastWeekTasks(ctx context.Context, userID int64) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getLastWeekTasks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.HabitID,
+			&i.UserID,
+			&i.PacketID,
+			&i.Name,
+			&i.Description,
+			&i.Difficulty,
+			&i.Completed,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestMonhtlyRecap = `-- name: GetLatestMonhtlyRecap :one
+SELECT 
+  id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at,
+  (date_trunc('month', created_at) = date_trunc('month', CURRENT_DATE)) 
+           AS is_this_month
+FROM recaps
+WHERE user_id = $1 AND type = 'monthly'
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetLatestMonhtlyRecapRow struct {
+	ID             int64
+	UserID         int64
+	Summary        string
+	Tips           string
+	AssignedTask   int32
+	CompletedTask  int32
+	CompletionRate string
+	GrowthRating   string
+	Type           string
+	CreatedAt      pgtype.Timestamp
+	IsThisMonth    bool
+}
+
+func (q *Queries) GetLatestMonhtlyRecap(ctx context.Context, userID int64) (GetLatestMonhtlyRecapRow, error) {
+	row := q.db.QueryRow(ctx, getLatestMonhtlyRecap, userID)
+	var i GetLatestMonhtlyRecapRow
 	err := row.Scan(
 		&i.ID,
-		&i.HabitID,
 		&i.UserID,
-		&i.PacketID,
-		&i.Name,
-		&i.Description,
-		&i.Difficulty,
-		&i.Completed,
+		&i.Summary,
+		&i.Tips,
+		&i.AssignedTask,
+		&i.CompletedTask,
+		&i.CompletionRate,
+		&i.GrowthRating,
+		&i.Type,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.IsThisMonth,
 	)
 	return i, err
 }
 
-const getTodayChallenge = `-- name: GetTodayChallenge :one
-SELECT 
-    c.id AS challenge_id,
-    c.day,
-    c.difficulty,
-    d.id AS detail_id,
-    d.name,
-    d.description,
-    d.point_gain,
-    d.created_at,
-    d.updated_at
-FROM challenges c
-JOIN details d ON c.detail_id = d.id
-ORDER BY d.created_at DESC
+const getLatestRecap = `-- name: GetLatestRecap :one
+SELECT id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at
+FROM recaps
+WHERE user_id = $1
+  AND type = 'weekly'
+ORDER BY created_at DESC
 LIMIT 1
 `
 
-type GetTodayChallengeRow struct {
-	ChallengeID int64
-	Day         int32
-	Difficulty  string
-	DetailID    int64
-	Name        string
-	Description string
-	PointGain   int64
-	CreatedAt   pgtype.Timestamp
-	UpdatedAt   pgtype.Timestamp
-}
-
-func (q *Queries) GetTodayChallenge(ctx context.Context) (GetTodayChallengeRow, error) {
-	row := q.db.QueryRow(ctx, getTodayChallenge)
-	var i GetTodayChallengeRow
+func (q *Queries) GetLatestRecap(ctx context.Context, userID int64) (Recap, error) {
+	row := q.db.QueryRow(ctx, getLatestRecap, userID)
+	var i Recap
 	err := row.Scan(
-		&i.ChallengeID,
-		&i.Day,
-		&i.Difficulty,
-		&i.DetailID,
-		&i.Name,
-		&i.Description,
-		&i.PointGain,
+		&i.ID,
+		&i.UserID,
+		&i.Summary,
+		&i.Tips,
+		&i.AssignedTask,
+		&i.CompletedTask,
+		&i.CompletionRate,
+		&i.GrowthRating,
+		&i.Type,
 		&i.CreatedAt,
-		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getTodayTasks = `-- name: GetTodayTasks :many
-SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at
-FROM tasks
-WHERE user_id = $1
-  AND DATE(created_at) = CURRENT_DATE
-ORDER BY id
+const getLockedHabits = `-- name: GetLockedHabits :many
+SELECT 
+  id, packet_id, name, description, difficulty, locked, weight
+FROM habits
+WHERE packet_id = $1 AND locked = true
 `
 
-func (q *Queries) GetTodayTasks(ctx context.Context, userID int64) ([]Task, error) {
-	rows, err := q.db.Query(ctx, getTodayTasks, userID)
+func (q *Queries) GetLockedHabits(ctx context.Context, packetID int64) ([]Habit, error) {
+	rows, err := q.db.Query(ctx, getLockedHabits, packetID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Task
+	var items []Habit
 	for rows.Next() {
-		var i Task
+		var i Habit
 		if err := rows.Scan(
 			&i.ID,
-			&i.HabitID,
-			&i.UserID,
 			&i.PacketID,
 			&i.Name,
 			&i.Description,
 			&i.Difficulty,
-			&i.Completed,
+			&i.Locked,
+			&i.Weight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLogs = `-- name: GetLogs :many
+SELECT text, created_at, is_system, is_private
+FROM logs
+WHERE user_id = $1 AND is_private = $2
+ORDER BY created_at DESC
+`
+
+type GetLogsParams struct {
+	UserID    int64
+	IsPrivate bool
+}
+
+type GetLogsRow struct {
+	Text      string
+	CreatedAt pgtype.Timestamp
+	IsSystem  bool
+	IsPrivate bool
+}
+
+func (q *Queries) GetLogs(ctx context.Context, arg GetLogsParams) ([]GetLogsRow, error) {
+	rows, err := q.db.Query(ctx, getLogs, arg.UserID, arg.IsPrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLogsRow
+	for rows.Next() {
+		var i GetLogsRow
+		if err := rows.Scan(
+			&i.Text,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.IsSystem,
+			&i.IsPrivate,
 		); err != nil {
 			return nil, err
 		}
@@ -2465,27 +4134,28 @@ func (q *Queries) GetTodayTasks(ctx context.Context, userID int64) ([]Task, erro
 	return items, nil
 }
 
-const getTools = `-- name: GetTools :many
-SELECT id, greenprint_id, name, description, price, created_at FROM tools
+const getMaterials = `-- name: GetMaterials :many
+SELECT id, name, description, price, quantity, greenprint_id, step_id FROM materials
 WHERE greenprint_id = $1
 `
 
-func (q *Queries) GetTools(ctx context.Context, greenprintID int64) ([]Tool, error) {
-	rows, err := q.db.Query(ctx, getTools, greenprintID)
+func (q *Queries) GetMaterials(ctx context.Context, greenprintID int64) ([]Material, error) {
+	rows, err := q.db.Query(ctx, getMaterials, greenprintID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Tool
+	var items []Material
 	for rows.Next() {
-		var i Tool
+		var i Material
 		if err := rows.Scan(
 			&i.ID,
-			&i.GreenprintID,
 			&i.Name,
 			&i.Description,
 			&i.Price,
-			&i.CreatedAt,
+			&i.Quantity,
+			&i.GreenprintID,
+			&i.StepID,
 		); err != nil {
 			return nil, err
 		}
@@ -2497,573 +4167,3698 @@ func (q *Queries) GetTools(ctx context.Context, greenprintID int64) ([]Tool, err
 	return items, nil
 }
 
-const getTreasureByCodeId = `-- name: GetTreasureByCodeId :one
-SELECT id, name, point_gain, code_id, claimed, created_at, updated_at FROM treasures
-WHERE code_id = $1 AND claimed = false
+const getMemoriesByChallengeID = `-- name: GetMemoriesByChallengeID :many
+SELECT 
+    p.id AS participation_id,
+    m.id AS memory_id,
+    m.file_key,
+    m.description,
+    m.created_at AS memory_created_at,
+    u.id AS user_id,
+    u.name AS user_name,
+    u.username,
+    u.email,
+    p.bonus_points,
+    COALESCE(r.votes, 0)::int AS votes,
+    COALESCE(r.likes, 0)::int AS likes,
+    COALESCE(r.loves, 0)::int AS loves,
+    COALESCE(r.inspirings, 0)::int AS inspirings,
+    COALESCE(r.claps, 0)::int AS claps,
+    mr.reaction AS my_reaction
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON m.user_id = u.id
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.challenge_id = $2 AND p.status = 'approved'
+ORDER BY m.created_at DESC
 `
 
-func (q *Queries) GetTreasureByCodeId(ctx context.Context, codeID string) (Treasure, error) {
-	row := q.db.QueryRow(ctx, getTreasureByCodeId, codeID)
-	var i Treasure
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.PointGain,
-		&i.CodeID,
-		&i.Claimed,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type GetMemoriesByChallengeIDParams struct {
+	UserID      int64
+	ChallengeID int64
 }
 
-const getUncompletedQuestByCodeId = `-- name: GetUncompletedQuestByCodeId :one
-SELECT 
-  q.id AS id,
-  q.location AS location,
-  q.max_contributors AS max_contributors,
-  q.latitude AS latitude,
-  q.longitude AS longitude,
-  d.name AS name,
-  d.description AS description,
-  d.point_gain AS point_gain
-FROM quests q
-JOIN details d ON q.detail_id = d.id
-WHERE code_id = $1 AND finished = false
+type GetMemoriesByChallengeIDRow struct {
+	ParticipationID int64
+	MemoryID        int64
+	FileKey         string
+	Description     string
+	MemoryCreatedAt pgtype.Timestamp
+	UserID          int64
+	UserName        string
+	Username        string
+	Email           string
+	BonusPoints     int32
+	Votes           int32
+	Likes           int32
+	Loves           int32
+	Inspirings      int32
+	Claps           int32
+	MyReaction      pgtype.Text
+}
+
+func (q *Queries) GetMemoriesByChallengeID(ctx context.Context, arg GetMemoriesByChallengeIDParams) ([]GetMemoriesByChallengeIDRow, error) {
+	rows, err := q.db.Query(ctx, getMemoriesByChallengeID, arg.UserID, arg.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMemoriesByChallengeIDRow
+	for rows.Next() {
+		var i GetMemoriesByChallengeIDRow
+		if err := rows.Scan(
+			&i.ParticipationID,
+			&i.MemoryID,
+			&i.FileKey,
+			&i.Description,
+			&i.MemoryCreatedAt,
+			&i.UserID,
+			&i.UserName,
+			&i.Username,
+			&i.Email,
+			&i.BonusPoints,
+			&i.Votes,
+			&i.Likes,
+			&i.Loves,
+			&i.Inspirings,
+			&i.Claps,
+			&i.MyReaction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMemoryWithParticipation = `-- name: GetMemoryWithParticipation :many
+SELECT 
+    m.id AS memory_id,
+    m.file_key,
+    m.thumbnail_key,
+    m.description AS memory_description,
+    m.created_at AS memory_created_at,
+    u.id AS user_id,
+    u.username AS user_name,
+    CASE 
+        WHEN p.id IS NOT NULL THEN TRUE 
+        ELSE FALSE 
+    END AS is_participation,
+    p.challenge_id,
+    p.status AS participation_status,
+    c.day,
+    c.difficulty,
+    d.name AS challenge_name,
+    d.point_gain
+FROM memories m
+JOIN users u ON m.user_id = u.id
+LEFT JOIN participations p ON m.id = p.memory_id
+LEFT JOIN challenges c ON p.challenge_id = c.id
+LEFT JOIN details d ON c.detail_id = d.id
+WHERE m.user_id = $1
+ORDER BY m.created_at DESC
+`
+
+type GetMemoryWithParticipationRow struct {
+	MemoryID            int64
+	FileKey             string
+	ThumbnailKey        pgtype.Text
+	MemoryDescription   string
+	MemoryCreatedAt     pgtype.Timestamp
+	UserID              int64
+	UserName            string
+	IsParticipation     bool
+	ChallengeID         pgtype.Int8
+	ParticipationStatus pgtype.Text
+	Day                 pgtype.Int4
+	Difficulty          pgtype.Text
+	ChallengeName       pgtype.Text
+	PointGain           pgtype.Int8
+}
+
+func (q *Queries) GetMemoryWithParticipation(ctx context.Context, userID int64) ([]GetMemoryWithParticipationRow, error) {
+	rows, err := q.db.Query(ctx, getMemoryWithParticipation, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMemoryWithParticipationRow
+	for rows.Next() {
+		var i GetMemoryWithParticipationRow
+		if err := rows.Scan(
+			&i.MemoryID,
+			&i.FileKey,
+			&i.ThumbnailKey,
+			&i.MemoryDescription,
+			&i.MemoryCreatedAt,
+			&i.UserID,
+			&i.UserName,
+			&i.IsParticipation,
+			&i.ChallengeID,
+			&i.ParticipationStatus,
+			&i.Day,
+			&i.Difficulty,
+			&i.ChallengeName,
+			&i.PointGain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNearbyDropoffPoints = `-- name: GetNearbyDropoffPoints :many
+SELECT
+  id,
+  name,
+  address,
+  description,
+  latitude,
+  longitude,
+  phone,
+  earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  )::float8 AS distance_meters
+FROM dropoff_points
+WHERE is_active = true
+  AND earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  ) <= $3::float8
+  AND ($4::text = '' OR EXISTS (
+    SELECT 1 FROM dropoff_materials
+    WHERE dropoff_materials.dropoff_point_id = dropoff_points.id
+      AND dropoff_materials.material = $4::text
+  ))
+ORDER BY distance_meters
+LIMIT $5::int
+`
+
+type GetNearbyDropoffPointsParams struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	Material  string
+	PageLimit int32
+}
+
+type GetNearbyDropoffPointsRow struct {
+	ID             int64
+	Name           string
+	Address        string
+	Description    pgtype.Text
+	Latitude       float64
+	Longitude      float64
+	Phone          pgtype.Text
+	DistanceMeters float64
+}
+
+func (q *Queries) GetNearbyDropoffPoints(ctx context.Context, arg GetNearbyDropoffPointsParams) ([]GetNearbyDropoffPointsRow, error) {
+	rows, err := q.db.Query(ctx, getNearbyDropoffPoints,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Radius,
+		arg.Material,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNearbyDropoffPointsRow
+	for rows.Next() {
+		var i GetNearbyDropoffPointsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.Description,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Phone,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNearbyQuests = `-- name: GetNearbyQuests :many
+SELECT
+  q.id,
+  d.name,
+  d.description,
+  q.clue,
+  d.point_gain,
+  q.max_contributors,
+  q.chain_id,
+  q.latitude::float8 AS latitude,
+  q.longitude::float8 AS longitude,
+  (SELECT COUNT(*) FROM contributions c WHERE c.quest_id = q.id)::int AS contributor_count,
+  EXISTS (
+    SELECT 1 FROM contributions c
+    WHERE c.quest_id = q.id AND c.user_id = $1::bigint
+  ) AS contributed,
+  earth_distance(
+    ll_to_earth($2::float8, $3::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  )::float8 AS distance_meters
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE q.finished = false
+  AND q.latitude IS NOT NULL
+  AND q.longitude IS NOT NULL
+  AND earth_distance(
+    ll_to_earth($2::float8, $3::float8),
+    ll_to_earth(q.latitude, q.longitude)
+  ) <= $4::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = q.chain_id
+      AND p.stage_order < q.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = $1::bigint
+      )
+  )
+ORDER BY
+  CASE WHEN $5::text = 'reward' THEN d.point_gain END DESC,
+  distance_meters
+LIMIT $6::int OFFSET $7::int
+`
+
+type GetNearbyQuestsParams struct {
+	UserID     int64
+	Latitude   float64
+	Longitude  float64
+	Radius     float64
+	SortBy     string
+	PageLimit  int32
+	PageOffset int32
+}
+
+type GetNearbyQuestsRow struct {
+	ID               int64
+	Name             string
+	Description      string
+	Clue             pgtype.Text
+	PointGain        int64
+	MaxContributors  int32
+	ChainID          pgtype.Int8
+	Latitude         float64
+	Longitude        float64
+	ContributorCount int32
+	Contributed      bool
+	DistanceMeters   float64
+}
+
+func (q *Queries) GetNearbyQuests(ctx context.Context, arg GetNearbyQuestsParams) ([]GetNearbyQuestsRow, error) {
+	rows, err := q.db.Query(ctx, getNearbyQuests,
+		arg.UserID,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Radius,
+		arg.SortBy,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNearbyQuestsRow
+	for rows.Next() {
+		var i GetNearbyQuestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Clue,
+			&i.PointGain,
+			&i.MaxContributors,
+			&i.ChainID,
+			&i.Latitude,
+			&i.Longitude,
+			&i.ContributorCount,
+			&i.Contributed,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNearestDropoffPerMaterial = `-- name: GetNearestDropoffPerMaterial :many
+SELECT DISTINCT ON (dropoff_materials.material)
+  dropoff_materials.material,
+  dropoff_points.id,
+  dropoff_points.name,
+  dropoff_points.address,
+  dropoff_points.description,
+  dropoff_points.latitude,
+  dropoff_points.longitude,
+  dropoff_points.phone,
+  earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  )::float8 AS distance_meters
+FROM dropoff_points
+JOIN dropoff_materials ON dropoff_materials.dropoff_point_id = dropoff_points.id
+WHERE dropoff_points.is_active = true
+  AND dropoff_materials.material = ANY($3::text[])
+  AND earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(dropoff_points.latitude, dropoff_points.longitude)
+  ) <= $4::float8
+ORDER BY dropoff_materials.material, distance_meters
+`
+
+type GetNearestDropoffPerMaterialParams struct {
+	Latitude  float64
+	Longitude float64
+	Materials []string
+	Radius    float64
+}
+
+type GetNearestDropoffPerMaterialRow struct {
+	Material       string
+	ID             int64
+	Name           string
+	Address        string
+	Description    pgtype.Text
+	Latitude       float64
+	Longitude      float64
+	Phone          pgtype.Text
+	DistanceMeters float64
+}
+
+func (q *Queries) GetNearestDropoffPerMaterial(ctx context.Context, arg GetNearestDropoffPerMaterialParams) ([]GetNearestDropoffPerMaterialRow, error) {
+	rows, err := q.db.Query(ctx, getNearestDropoffPerMaterial,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Materials,
+		arg.Radius,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNearestDropoffPerMaterialRow
+	for rows.Next() {
+		var i GetNearestDropoffPerMaterialRow
+		if err := rows.Scan(
+			&i.Material,
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.Description,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Phone,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNearestQuestWithinRadius = `-- name: GetNearestQuestWithinRadius :one
+SELECT 
+    id,
+    clue,
+    location,
+    latitude,
+    longitude,
+    earth_distance(
+        ll_to_earth($1::float8, $2::float8),
+        ll_to_earth(latitude, longitude)
+    ) AS distance_meters
+FROM quests
+WHERE finished = false
+  AND earth_distance(
+        ll_to_earth($1::float8, $2::float8),
+        ll_to_earth(latitude, longitude)
+    ) <= $3::float8
+  AND NOT EXISTS (
+    SELECT 1 FROM quests p
+    WHERE p.chain_id = quests.chain_id
+      AND p.stage_order < quests.stage_order
+      AND NOT EXISTS (
+        SELECT 1 FROM contributions c
+        WHERE c.quest_id = p.id AND c.user_id = $4::bigint
+      )
+  )
+ORDER BY distance_meters
+LIMIT 1
+`
+
+type GetNearestQuestWithinRadiusParams struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	UserID    int64
+}
+
+type GetNearestQuestWithinRadiusRow struct {
+	ID             int64
+	Clue           pgtype.Text
+	Location       string
+	Latitude       float64
+	Longitude      float64
+	DistanceMeters float64
+}
+
+func (q *Queries) GetNearestQuestWithinRadius(ctx context.Context, arg GetNearestQuestWithinRadiusParams) (GetNearestQuestWithinRadiusRow, error) {
+	row := q.db.QueryRow(ctx, getNearestQuestWithinRadius,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Radius,
+		arg.UserID,
+	)
+	var i GetNearestQuestWithinRadiusRow
+	err := row.Scan(
+		&i.ID,
+		&i.Clue,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.DistanceMeters,
+	)
+	return i, err
+}
+
+const getNextChainStage = `-- name: GetNextChainStage :one
+SELECT q.id, q.stage_order, q.clue, d.name
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE q.chain_id = $1::bigint AND q.stage_order > $2::int
+ORDER BY q.stage_order
+LIMIT 1
+`
+
+type GetNextChainStageParams struct {
+	ChainID    int64
+	StageOrder int32
+}
+
+type GetNextChainStageRow struct {
+	ID         int64
+	StageOrder pgtype.Int4
+	Clue       pgtype.Text
+	Name       string
+}
+
+func (q *Queries) GetNextChainStage(ctx context.Context, arg GetNextChainStageParams) (GetNextChainStageRow, error) {
+	row := q.db.QueryRow(ctx, getNextChainStage, arg.ChainID, arg.StageOrder)
+	var i GetNextChainStageRow
+	err := row.Scan(
+		&i.ID,
+		&i.StageOrder,
+		&i.Clue,
+		&i.Name,
+	)
+	return i, err
+}
+
+const getOwnedMaterialIds = `-- name: GetOwnedMaterialIds :many
+SELECT owned_materials.material_id
+FROM owned_materials
+JOIN materials ON materials.id = owned_materials.material_id
+WHERE owned_materials.user_id = $1 AND materials.greenprint_id = $2
+`
+
+type GetOwnedMaterialIdsParams struct {
+	UserID       int64
+	GreenprintID int64
+}
+
+func (q *Queries) GetOwnedMaterialIds(ctx context.Context, arg GetOwnedMaterialIdsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getOwnedMaterialIds, arg.UserID, arg.GreenprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var material_id int64
+		if err := rows.Scan(&material_id); err != nil {
+			return nil, err
+		}
+		items = append(items, material_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOwnedToolIds = `-- name: GetOwnedToolIds :many
+SELECT owned_tools.tool_id
+FROM owned_tools
+JOIN tools ON tools.id = owned_tools.tool_id
+WHERE owned_tools.user_id = $1 AND tools.greenprint_id = $2
+`
+
+type GetOwnedToolIdsParams struct {
+	UserID       int64
+	GreenprintID int64
+}
+
+func (q *Queries) GetOwnedToolIds(ctx context.Context, arg GetOwnedToolIdsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getOwnedToolIds, arg.UserID, arg.GreenprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var tool_id int64
+		if err := rows.Scan(&tool_id); err != nil {
+			return nil, err
+		}
+		items = append(items, tool_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPacketDetail = `-- name: GetPacketDetail :one
+SELECT 
+    p.id AS packet_id,
+    p.user_id,
+    u.name AS user_name,
+    u.username,
+    p.name AS packet_name,
+    p.target,
+    p.description,
+    p.completed_task,
+    p.expected_task,
+    p.task_per_day,
+    p.completed,
+    p.created_at
+FROM packets p
+JOIN users u ON u.id = p.user_id
+WHERE p.id = $1
+`
+
+type GetPacketDetailRow struct {
+	PacketID      int64
+	UserID        int64
+	UserName      string
+	Username      string
+	PacketName    string
+	Target        string
+	Description   string
+	CompletedTask int32
+	ExpectedTask  int32
+	TaskPerDay    int32
+	Completed     bool
+	CreatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetPacketDetail(ctx context.Context, id int64) (GetPacketDetailRow, error) {
+	row := q.db.QueryRow(ctx, getPacketDetail, id)
+	var i GetPacketDetailRow
+	err := row.Scan(
+		&i.PacketID,
+		&i.UserID,
+		&i.UserName,
+		&i.Username,
+		&i.PacketName,
+		&i.Target,
+		&i.Description,
+		&i.CompletedTask,
+		&i.ExpectedTask,
+		&i.TaskPerDay,
+		&i.Completed,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPacketHabits = `-- name: GetPacketHabits :many
+SELECT 
+  id, packet_id, name, description, difficulty, locked, weight
+FROM habits
+WHERE packet_id = $1
+`
+
+func (q *Queries) GetPacketHabits(ctx context.Context, packetID int64) ([]Habit, error) {
+	rows, err := q.db.Query(ctx, getPacketHabits, packetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Habit
+	for rows.Next() {
+		var i Habit
+		if err := rows.Scan(
+			&i.ID,
+			&i.PacketID,
+			&i.Name,
+			&i.Description,
+			&i.Difficulty,
+			&i.Locked,
+			&i.Weight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPacketUnlockedHabits = `-- name: GetPacketUnlockedHabits :many
+SELECT id, packet_id, name, description, difficulty, locked, weight FROM habits
+WHERE packet_id = $1 AND locked = false
+`
+
+func (q *Queries) GetPacketUnlockedHabits(ctx context.Context, packetID int64) ([]Habit, error) {
+	rows, err := q.db.Query(ctx, getPacketUnlockedHabits, packetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Habit
+	for rows.Next() {
+		var i Habit
+		if err := rows.Scan(
+			&i.ID,
+			&i.PacketID,
+			&i.Name,
+			&i.Description,
+			&i.Difficulty,
+			&i.Locked,
+			&i.Weight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipants = `-- name: GetParticipants :one
+SELECT
+  COUNT (*) FILTER (WHERE challenge_id = $1 AND status = 'approved') AS participants
+FROM participations
+`
+
+func (q *Queries) GetParticipants(ctx context.Context, challengeID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getParticipants, challengeID)
+	var participants int64
+	err := row.Scan(&participants)
+	return participants, err
+}
+
+const getParticipationById = `-- name: GetParticipationById :one
+SELECT
+  p.id,
+  p.challenge_id,
+  p.user_id,
+  u.username,
+  p.memory_id,
+  m.file_key,
+  m.description,
+  c.day,
+  d.name AS challenge_name,
+  p.status,
+  p.point_gain,
+  p.note,
+  p.reviewed_at,
+  p.uploaded_at,
+  p.created_at
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+JOIN challenges c ON p.challenge_id = c.id
+JOIN details d ON c.detail_id = d.id
+WHERE p.id = $1
+`
+
+type GetParticipationByIdRow struct {
+	ID            int64
+	ChallengeID   int64
+	UserID        int64
+	Username      string
+	MemoryID      int64
+	FileKey       string
+	Description   string
+	Day           int32
+	ChallengeName string
+	Status        string
+	PointGain     int32
+	Note          pgtype.Text
+	ReviewedAt    pgtype.Timestamp
+	UploadedAt    pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetParticipationById(ctx context.Context, iD int64) (GetParticipationByIdRow, error) {
+	row := q.db.QueryRow(ctx, getParticipationById, iD)
+	var i GetParticipationByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.ChallengeID,
+		&i.UserID,
+		&i.Username,
+		&i.MemoryID,
+		&i.FileKey,
+		&i.Description,
+		&i.Day,
+		&i.ChallengeName,
+		&i.Status,
+		&i.PointGain,
+		&i.Note,
+		&i.ReviewedAt,
+		&i.UploadedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getParticipationByMemoryId = `-- name: GetParticipationByMemoryId :one
+SELECT COUNT(*) as participations FROM participations WHERE memory_id = $1
+`
+
+func (q *Queries) GetParticipationByMemoryId(ctx context.Context, memoryID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getParticipationByMemoryId, memoryID)
+	var participations int64
+	err := row.Scan(&participations)
+	return participations, err
+}
+
+const getParticipationQueue = `-- name: GetParticipationQueue :many
+SELECT
+  p.id,
+  p.challenge_id,
+  p.user_id,
+  u.username,
+  p.memory_id,
+  m.file_key,
+  m.description,
+  c.day,
+  d.name AS challenge_name,
+  p.status,
+  p.point_gain,
+  p.note,
+  p.reviewed_at,
+  p.uploaded_at,
+  p.created_at
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+JOIN challenges c ON p.challenge_id = c.id
+JOIN details d ON c.detail_id = d.id
+WHERE p.status = $1
+ORDER BY p.uploaded_at, p.id
+LIMIT $2 OFFSET $3
+`
+
+type GetParticipationQueueParams struct {
+	Status string
+	Limit  int32
+	Offset int32
+}
+
+type GetParticipationQueueRow struct {
+	ID            int64
+	ChallengeID   int64
+	UserID        int64
+	Username      string
+	MemoryID      int64
+	FileKey       string
+	Description   string
+	Day           int32
+	ChallengeName string
+	Status        string
+	PointGain     int32
+	Note          pgtype.Text
+	ReviewedAt    pgtype.Timestamp
+	UploadedAt    pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetParticipationQueue(ctx context.Context, arg GetParticipationQueueParams) ([]GetParticipationQueueRow, error) {
+	rows, err := q.db.Query(ctx, getParticipationQueue, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetParticipationQueueRow
+	for rows.Next() {
+		var i GetParticipationQueueRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChallengeID,
+			&i.UserID,
+			&i.Username,
+			&i.MemoryID,
+			&i.FileKey,
+			&i.Description,
+			&i.Day,
+			&i.ChallengeName,
+			&i.Status,
+			&i.PointGain,
+			&i.Note,
+			&i.ReviewedAt,
+			&i.UploadedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipationReactions = `-- name: GetParticipationReactions :one
+SELECT
+  COALESCE(r.votes, 0)::int AS votes,
+  COALESCE(r.likes, 0)::int AS likes,
+  COALESCE(r.loves, 0)::int AS loves,
+  COALESCE(r.inspirings, 0)::int AS inspirings,
+  COALESCE(r.claps, 0)::int AS claps,
+  mr.reaction AS my_reaction
+FROM participations p
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.id = $2
+`
+
+type GetParticipationReactionsParams struct {
+	UserID          int64
+	ParticipationID int64
+}
+
+type GetParticipationReactionsRow struct {
+	Votes      int32
+	Likes      int32
+	Loves      int32
+	Inspirings int32
+	Claps      int32
+	MyReaction pgtype.Text
+}
+
+func (q *Queries) GetParticipationReactions(ctx context.Context, arg GetParticipationReactionsParams) (GetParticipationReactionsRow, error) {
+	row := q.db.QueryRow(ctx, getParticipationReactions, arg.UserID, arg.ParticipationID)
+	var i GetParticipationReactionsRow
+	err := row.Scan(
+		&i.Votes,
+		&i.Likes,
+		&i.Loves,
+		&i.Inspirings,
+		&i.Claps,
+		&i.MyReaction,
+	)
+	return i, err
+}
+
+const getProjectSteps = `-- name: GetProjectSteps :many
+SELECT
+  steps.id,
+  steps.description,
+  project_steps.completed_at
+FROM greenprint_projects
+JOIN steps ON steps.greenprint_id = greenprint_projects.greenprint_id
+LEFT JOIN project_steps ON project_steps.project_id = greenprint_projects.id AND project_steps.step_id = steps.id
+WHERE greenprint_projects.id = $1
+ORDER BY steps.id
+`
+
+type GetProjectStepsRow struct {
+	ID          int64
+	Description string
+	CompletedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetProjectSteps(ctx context.Context, id int64) ([]GetProjectStepsRow, error) {
+	rows, err := q.db.Query(ctx, getProjectSteps, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProjectStepsRow
+	for rows.Next() {
+		var i GetProjectStepsRow
+		if err := rows.Scan(&i.ID, &i.Description, &i.CompletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuestByCodeId = `-- name: GetQuestByCodeId :one
+SELECT 
+  q.id AS id,
+  q.location AS location,
+  q.max_contributors AS max_contributors,
+  q.latitude AS latitude,
+  q.longitude AS longitude,
+  d.name AS name,
+  d.description AS description,
+  d.point_gain AS point_gain,
+  q.finished
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE q.code_id = $1
+`
+
+type GetQuestByCodeIdRow struct {
+	ID              int64
+	Location        string
+	MaxContributors int32
+	Latitude        float64
+	Longitude       float64
+	Name            string
+	Description     string
+	PointGain       int64
+	Finished        bool
+}
+
+func (q *Queries) GetQuestByCodeId(ctx context.Context, codeID string) (GetQuestByCodeIdRow, error) {
+	row := q.db.QueryRow(ctx, getQuestByCodeId, codeID)
+	var i GetQuestByCodeIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Location,
+		&i.MaxContributors,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+		&i.Finished,
+	)
+	return i, err
+}
+
+const getQuestChainById = `-- name: GetQuestChainById :one
+SELECT id, name, description, completion_point_gain, is_active, created_at, updated_at FROM quest_chains
+WHERE id = $1
+`
+
+func (q *Queries) GetQuestChainById(ctx context.Context, iD int64) (QuestChain, error) {
+	row := q.db.QueryRow(ctx, getQuestChainById, iD)
+	var i QuestChain
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CompletionPointGain,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getQuestChainLeaderboard = `-- name: GetQuestChainLeaderboard :many
+SELECT
+  u.id,
+  u.username,
+  COUNT(c.id)::int AS completed_stages,
+  MAX(c.created_at)::timestamp AS last_contributed_at,
+  qcc.completed_at
+FROM contributions c
+JOIN quests q ON c.quest_id = q.id
+JOIN users u ON c.user_id = u.id
+LEFT JOIN quest_chain_completions qcc ON qcc.chain_id = q.chain_id AND qcc.user_id = u.id
+WHERE q.chain_id = $1::bigint
+GROUP BY u.id, u.username, qcc.completed_at
+ORDER BY qcc.completed_at ASC NULLS LAST, completed_stages DESC, last_contributed_at ASC
+LIMIT $2::int
+`
+
+type GetQuestChainLeaderboardParams struct {
+	ChainID   int64
+	PageLimit int32
+}
+
+type GetQuestChainLeaderboardRow struct {
+	ID                int64
+	Username          string
+	CompletedStages   int32
+	LastContributedAt pgtype.Timestamp
+	CompletedAt       pgtype.Timestamp
+}
+
+func (q *Queries) GetQuestChainLeaderboard(ctx context.Context, arg GetQuestChainLeaderboardParams) ([]GetQuestChainLeaderboardRow, error) {
+	rows, err := q.db.Query(ctx, getQuestChainLeaderboard, arg.ChainID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetQuestChainLeaderboardRow
+	for rows.Next() {
+		var i GetQuestChainLeaderboardRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.CompletedStages,
+			&i.LastContributedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRegionById = `-- name: GetRegionById :one
+SELECT id, name, location, latitude, longitude, tree_amount, created_at, updated_at FROM regions
+WHERE id = $1
+`
+
+func (q *Queries) GetRegionById(ctx context.Context, id int64) (Region, error) {
+	row := q.db.QueryRow(ctx, getRegionById, id)
+	var i Region
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.TreeAmount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getRegionDepositStatistics = `-- name: GetRegionDepositStatistics :many
+SELECT
+  regions.id,
+  regions.name,
+  COUNT(deposits.id) AS deposits,
+  COALESCE(SUM(deposits.total_weight), 0)::float8 AS total_weight
+FROM regions
+LEFT JOIN waste_banks ON waste_banks.region_id = regions.id
+LEFT JOIN deposits ON deposits.waste_bank_id = waste_banks.id AND deposits.status = 'confirmed'
+GROUP BY regions.id
+ORDER BY total_weight DESC
+`
+
+type GetRegionDepositStatisticsRow struct {
+	ID          int64
+	Name        string
+	Deposits    int64
+	TotalWeight float64
+}
+
+func (q *Queries) GetRegionDepositStatistics(ctx context.Context) ([]GetRegionDepositStatisticsRow, error) {
+	rows, err := q.db.Query(ctx, getRegionDepositStatistics)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRegionDepositStatisticsRow
+	for rows.Next() {
+		var i GetRegionDepositStatisticsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Deposits,
+			&i.TotalWeight,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getScanImagesByScanIds = `-- name: GetScanImagesByScanIds :many
+SELECT id, scan_id, image_key, position, created_at FROM scan_images
+WHERE scan_id = ANY($1::bigint[])
+ORDER BY scan_id, position
+`
+
+func (q *Queries) GetScanImagesByScanIds(ctx context.Context, scanIds []int64) ([]ScanImage, error) {
+	rows, err := q.db.Query(ctx, getScanImagesByScanIds, scanIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScanImage
+	for rows.Next() {
+		var i ScanImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScanID,
+			&i.ImageKey,
+			&i.Position,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getScanObjectKeys = `-- name: GetScanObjectKeys :many
+SELECT image_key::text AS image_key FROM scans WHERE scans.id = $1
+UNION
+SELECT image_key FROM scan_images WHERE scan_images.scan_id = $1
+UNION
+SELECT greenprint_projects.proof_key FROM greenprint_projects
+JOIN greenprints ON greenprints.id = greenprint_projects.greenprint_id
+JOIN items ON items.id = greenprints.item_id
+WHERE items.scan_id = $1 AND greenprint_projects.proof_key IS NOT NULL
+`
+
+func (q *Queries) GetScanObjectKeys(ctx context.Context, id int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getScanObjectKeys, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var image_key string
+		if err := rows.Scan(&image_key); err != nil {
+			return nil, err
+		}
+		items = append(items, image_key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSteps = `-- name: GetSteps :many
+SELECT id, greenprint_id, description, created_at FROM steps
+WHERE greenprint_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetSteps(ctx context.Context, greenprintID int64) ([]Step, error) {
+	rows, err := q.db.Query(ctx, getSteps, greenprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Step
+	for rows.Next() {
+		var i Step
+		if err := rows.Scan(
+			&i.ID,
+			&i.GreenprintID,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTaskById = `-- name: GetTaskById :one
+SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at FROM tasks
+WHERE id = $1
+`
+
+func (q *Queries) GetTaskById(ctx context.Context, id int64) (Task, error) {
+	row := q.db.QueryRow(ctx, getTaskById, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.HabitID,
+		&i.UserID,
+		&i.PacketID,
+		&i.Name,
+		&i.Description,
+		&i.Difficulty,
+		&i.Completed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTodayChallenge = `-- name: GetTodayChallenge :one
+SELECT 
+    c.id AS challenge_id,
+    c.day,
+    c.difficulty,
+    d.id AS detail_id,
+    d.name,
+    d.description,
+    d.point_gain,
+    d.created_at,
+    d.updated_at
+FROM challenges c
+JOIN details d ON c.detail_id = d.id
+ORDER BY d.created_at DESC
+LIMIT 1
+`
+
+type GetTodayChallengeRow struct {
+	ChallengeID int64
+	Day         int32
+	Difficulty  string
+	DetailID    int64
+	Name        string
+	Description string
+	PointGain   int64
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) GetTodayChallenge(ctx context.Context) (GetTodayChallengeRow, error) {
+	row := q.db.QueryRow(ctx, getTodayChallenge)
+	var i GetTodayChallengeRow
+	err := row.Scan(
+		&i.ChallengeID,
+		&i.Day,
+		&i.Difficulty,
+		&i.DetailID,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTodayTasks = `-- name: GetTodayTasks :many
+SELECT id, habit_id, user_id, packet_id, name, description, difficulty, completed, created_at, updated_at
+FROM tasks
+WHERE user_id = $1
+  AND DATE(created_at) = CURRENT_DATE
+ORDER BY id
+`
+
+func (q *Queries) GetTodayTasks(ctx context.Context, userID int64) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTodayTasks, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.HabitID,
+			&i.UserID,
+			&i.PacketID,
+			&i.Name,
+			&i.Description,
+			&i.Difficulty,
+			&i.Completed,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTools = `-- name: GetTools :many
+SELECT id, greenprint_id, name, description, price, created_at, step_id, is_optional FROM tools
+WHERE greenprint_id = $1
+`
+
+func (q *Queries) GetTools(ctx context.Context, greenprintID int64) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, getTools, greenprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tool
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.GreenprintID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.CreatedAt,
+			&i.StepID,
+			&i.IsOptional,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopParticipations = `-- name: GetTopParticipations :many
+SELECT
+  p.id AS participation_id,
+  m.id AS memory_id,
+  m.file_key,
+  m.description,
+  m.created_at AS memory_created_at,
+  u.id AS user_id,
+  u.username,
+  p.bonus_points,
+  COALESCE(r.votes, 0)::int AS votes,
+  COALESCE(r.likes, 0)::int AS likes,
+  COALESCE(r.loves, 0)::int AS loves,
+  COALESCE(r.inspirings, 0)::int AS inspirings,
+  COALESCE(r.claps, 0)::int AS claps,
+  mr.reaction AS my_reaction
+FROM participations p
+JOIN memories m ON p.memory_id = m.id
+JOIN users u ON p.user_id = u.id
+LEFT JOIN LATERAL (
+  SELECT
+    COUNT(*) AS votes,
+    COUNT(*) FILTER (WHERE reaction = 'like') AS likes,
+    COUNT(*) FILTER (WHERE reaction = 'love') AS loves,
+    COUNT(*) FILTER (WHERE reaction = 'inspiring') AS inspirings,
+    COUNT(*) FILTER (WHERE reaction = 'clap') AS claps
+  FROM participation_reactions pr
+  WHERE pr.participation_id = p.id
+) r ON true
+LEFT JOIN participation_reactions mr ON mr.participation_id = p.id AND mr.user_id = $1
+WHERE p.challenge_id = $2 AND p.status = 'approved'
+ORDER BY votes DESC, p.uploaded_at, p.id
+LIMIT $3
+`
+
+type GetTopParticipationsParams struct {
+	UserID      int64
+	ChallengeID int64
+	Limit       int32
+}
+
+type GetTopParticipationsRow struct {
+	ParticipationID int64
+	MemoryID        int64
+	FileKey         string
+	Description     string
+	MemoryCreatedAt pgtype.Timestamp
+	UserID          int64
+	Username        string
+	BonusPoints     int32
+	Votes           int32
+	Likes           int32
+	Loves           int32
+	Inspirings      int32
+	Claps           int32
+	MyReaction      pgtype.Text
+}
+
+func (q *Queries) GetTopParticipations(ctx context.Context, arg GetTopParticipationsParams) ([]GetTopParticipationsRow, error) {
+	rows, err := q.db.Query(ctx, getTopParticipations, arg.UserID, arg.ChallengeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopParticipationsRow
+	for rows.Next() {
+		var i GetTopParticipationsRow
+		if err := rows.Scan(
+			&i.ParticipationID,
+			&i.MemoryID,
+			&i.FileKey,
+			&i.Description,
+			&i.MemoryCreatedAt,
+			&i.UserID,
+			&i.Username,
+			&i.BonusPoints,
+			&i.Votes,
+			&i.Likes,
+			&i.Loves,
+			&i.Inspirings,
+			&i.Claps,
+			&i.MyReaction,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTreasureAvailability = `-- name: GetTreasureAvailability :one
+SELECT
+  (starts_at IS NOT NULL AND starts_at > now()) AS not_started,
+  (expires_at IS NOT NULL AND expires_at <= now()) AS expired,
+  (claimed OR claim_count >= max_claims) AS sold_out,
+  (
+    SELECT COUNT(*) FROM claimed
+    WHERE claimed.treasure_id = treasures.id AND claimed.user_id = $2
+  )::int AS user_claims,
+  per_user_limit
+FROM treasures
+WHERE id = $1
+`
+
+type GetTreasureAvailabilityParams struct {
+	ID     int64
+	UserID int64
+}
+
+type GetTreasureAvailabilityRow struct {
+	NotStarted   bool
+	Expired      bool
+	SoldOut      bool
+	UserClaims   int32
+	PerUserLimit int32
+}
+
+func (q *Queries) GetTreasureAvailability(ctx context.Context, arg GetTreasureAvailabilityParams) (GetTreasureAvailabilityRow, error) {
+	row := q.db.QueryRow(ctx, getTreasureAvailability, arg.ID, arg.UserID)
+	var i GetTreasureAvailabilityRow
+	err := row.Scan(
+		&i.NotStarted,
+		&i.Expired,
+		&i.SoldOut,
+		&i.UserClaims,
+		&i.PerUserLimit,
+	)
+	return i, err
+}
+
+const getTreasureByCodeId = `-- name: GetTreasureByCodeId :one
+SELECT id, name, point_gain, code_id, claimed, created_at, updated_at, latitude, longitude, geofence_radius, max_claims, per_user_limit, claim_count, starts_at, expires_at FROM treasures
+WHERE code_id = $1
+`
+
+func (q *Queries) GetTreasureByCodeId(ctx context.Context, codeID string) (Treasure, error) {
+	row := q.db.QueryRow(ctx, getTreasureByCodeId, codeID)
+	var i Treasure
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PointGain,
+		&i.CodeID,
+		&i.Claimed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.GeofenceRadius,
+		&i.MaxClaims,
+		&i.PerUserLimit,
+		&i.ClaimCount,
+		&i.StartsAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getUncompletedQuestByCodeId = `-- name: GetUncompletedQuestByCodeId :one
+SELECT 
+  q.id AS id,
+  q.location AS location,
+  q.max_contributors AS max_contributors,
+  q.latitude AS latitude,
+  q.longitude AS longitude,
+  q.chain_id AS chain_id,
+  q.stage_order AS stage_order,
+  d.name AS name,
+  d.description AS description,
+  d.point_gain AS point_gain
+FROM quests q
+JOIN details d ON q.detail_id = d.id
+WHERE code_id = $1 AND finished = false
+`
+
+type GetUncompletedQuestByCodeIdRow struct {
+	ID              int64
+	Location        string
+	MaxContributors int32
+	Latitude        float64
+	Longitude       float64
+	ChainID         pgtype.Int8
+	StageOrder      pgtype.Int4
+	Name            string
+	Description     string
+	PointGain       int64
+}
+
+func (q *Queries) GetUncompletedQuestByCodeId(ctx context.Context, codeID string) (GetUncompletedQuestByCodeIdRow, error) {
+	row := q.db.QueryRow(ctx, getUncompletedQuestByCodeId, codeID)
+	var i GetUncompletedQuestByCodeIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Location,
+		&i.MaxContributors,
+		&i.Latitude,
+		&i.Longitude,
+		&i.ChainID,
+		&i.StageOrder,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+	)
+	return i, err
+}
+
+const getUnsettledChallenges = `-- name: GetUnsettledChallenges :many
+SELECT
+  c.id AS challenge_id,
+  c.day,
+  d.name,
+  d.created_at,
+  (
+    SELECT COUNT(*) FROM participations p
+    WHERE p.challenge_id = c.id AND p.status = 'pending_review'
+  )::int AS pending_reviews
+FROM challenges c
+JOIN details d ON c.detail_id = d.id
+WHERE c.bonus_settled_at IS NULL AND d.created_at < $1
+ORDER BY d.created_at
+`
+
+type GetUnsettledChallengesRow struct {
+	ChallengeID    int64
+	Day            int32
+	Name           string
+	CreatedAt      pgtype.Timestamp
+	PendingReviews int32
+}
+
+func (q *Queries) GetUnsettledChallenges(ctx context.Context, dayStart pgtype.Timestamp) ([]GetUnsettledChallengesRow, error) {
+	rows, err := q.db.Query(ctx, getUnsettledChallenges, dayStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnsettledChallengesRow
+	for rows.Next() {
+		var i GetUnsettledChallengesRow
+		if err := rows.Scan(
+			&i.ChallengeID,
+			&i.Day,
+			&i.Name,
+			&i.CreatedAt,
+			&i.PendingReviews,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserActivePackets = `-- name: GetUserActivePackets :one
+SELECT id, user_id, name, target, description, completed_task, expected_task, task_per_day, completed, created_at FROM packets
+WHERE user_id = $1 AND completed = false
+`
+
+func (q *Queries) GetUserActivePackets(ctx context.Context, userID int64) (Packet, error) {
+	row := q.db.QueryRow(ctx, getUserActivePackets, userID)
+	var i Packet
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Target,
+		&i.Description,
+		&i.CompletedTask,
+		&i.ExpectedTask,
+		&i.TaskPerDay,
+		&i.Completed,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserAiQuotas = `-- name: GetUserAiQuotas :many
+SELECT id, user_id, feature, daily_limit, monthly_limit, created_at, updated_at FROM ai_quotas
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserAiQuotas(ctx context.Context, userID int64) ([]AiQuota, error) {
+	rows, err := q.db.Query(ctx, getUserAiQuotas, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AiQuota
+	for rows.Next() {
+		var i AiQuota
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Feature,
+			&i.DailyLimit,
+			&i.MonthlyLimit,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserAiUsageSummary = `-- name: GetUserAiUsageSummary :many
+SELECT
+  feature,
+  COUNT(*) FILTER (WHERE status = 'success' AND created_at >= $1) AS daily,
+  COUNT(*) FILTER (WHERE status = 'success') AS monthly,
+  COALESCE(SUM(total_tokens), 0)::bigint AS tokens,
+  COALESCE(SUM(cost), 0)::float8 AS cost
+FROM ai_usages
+WHERE user_id = $2
+  AND created_at >= $3
+GROUP BY feature
+ORDER BY feature
+`
+
+type GetUserAiUsageSummaryParams struct {
+	DayStart   pgtype.Timestamp
+	UserID     int64
+	MonthStart pgtype.Timestamp
+}
+
+type GetUserAiUsageSummaryRow struct {
+	Feature string
+	Daily   int64
+	Monthly int64
+	Tokens  int64
+	Cost    float64
+}
+
+func (q *Queries) GetUserAiUsageSummary(ctx context.Context, arg GetUserAiUsageSummaryParams) ([]GetUserAiUsageSummaryRow, error) {
+	rows, err := q.db.Query(ctx, getUserAiUsageSummary, arg.DayStart, arg.UserID, arg.MonthStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserAiUsageSummaryRow
+	for rows.Next() {
+		var i GetUserAiUsageSummaryRow
+		if err := rows.Scan(
+			&i.Feature,
+			&i.Daily,
+			&i.Monthly,
+			&i.Tokens,
+			&i.Cost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserAttendance = `-- name: GetUserAttendance :one
+SELECT 
+    a.id AS attendance_id,
+    a.created_at AS attended_at,
+    a.contact_number,
+    e.id AS event_id,
+    e.location,
+    e.latitude,
+    e.longitude,
+    e.contact,
+    e.starts_at,
+    e.ends_at,
+    a.attended,
+    e.cover_key,
+    d.id AS detail_id,
+    d.name AS detail_name,
+    d.description AS detail_description,
+    d.point_gain,
+    a.created_at
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.id = $1
+`
+
+type GetUserAttendanceRow struct {
+	AttendanceID      int64
+	AttendedAt        pgtype.Timestamp
+	ContactNumber     string
+	EventID           int64
+	Location          string
+	Latitude          float64
+	Longitude         float64
+	Contact           string
+	StartsAt          pgtype.Timestamp
+	EndsAt            pgtype.Timestamp
+	Attended          bool
+	CoverKey          pgtype.Text
+	DetailID          int64
+	DetailName        string
+	DetailDescription string
+	PointGain         int64
+	CreatedAt         pgtype.Timestamp
+}
+
+func (q *Queries) GetUserAttendance(ctx context.Context, id int64) (GetUserAttendanceRow, error) {
+	row := q.db.QueryRow(ctx, getUserAttendance, id)
+	var i GetUserAttendanceRow
+	err := row.Scan(
+		&i.AttendanceID,
+		&i.AttendedAt,
+		&i.ContactNumber,
+		&i.EventID,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Contact,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Attended,
+		&i.CoverKey,
+		&i.DetailID,
+		&i.DetailName,
+		&i.DetailDescription,
+		&i.PointGain,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserAttendanceById = `-- name: GetUserAttendanceById :one
+SELECT 
+    a.id AS attendance_id,
+    a.created_at AS attended_at,
+    a.contact_number,
+    e.id AS event_id,
+    e.location,
+    e.latitude,
+    e.longitude,
+    e.contact,
+    e.starts_at,
+    e.ends_at,
+    a.attended,
+    e.cover_key,
+    d.name AS name,
+    d.description AS description,
+    d.point_gain
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.id = $1
+`
+
+type GetUserAttendanceByIdRow struct {
+	AttendanceID  int64
+	AttendedAt    pgtype.Timestamp
+	ContactNumber string
+	EventID       int64
+	Location      string
+	Latitude      float64
+	Longitude     float64
+	Contact       string
+	StartsAt      pgtype.Timestamp
+	EndsAt        pgtype.Timestamp
+	Attended      bool
+	CoverKey      pgtype.Text
+	Name          string
+	Description   string
+	PointGain     int64
+}
+
+func (q *Queries) GetUserAttendanceById(ctx context.Context, id int64) (GetUserAttendanceByIdRow, error) {
+	row := q.db.QueryRow(ctx, getUserAttendanceById, id)
+	var i GetUserAttendanceByIdRow
+	err := row.Scan(
+		&i.AttendanceID,
+		&i.AttendedAt,
+		&i.ContactNumber,
+		&i.EventID,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Contact,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Attended,
+		&i.CoverKey,
+		&i.Name,
+		&i.Description,
+		&i.PointGain,
+	)
+	return i, err
+}
+
+const getUserAttendanceByUserId = `-- name: GetUserAttendanceByUserId :one
+SELECT 
+    a.id AS attendance_id,
+    a.created_at AS attended_at,
+    a.contact_number,
+    e.id AS event_id,
+    e.location,
+    e.latitude,
+    e.longitude,
+    e.contact,
+    e.starts_at,
+    e.ends_at,
+    a.attended,
+    e.cover_key,
+    d.id AS detail_id,
+    d.name AS detail_name,
+    d.description AS detail_description,
+    d.point_gain,
+    a.created_at
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1 AND e.id = $2
+`
+
+type GetUserAttendanceByUserIdParams struct {
+	UserID int64
+	ID     int64
+}
+
+type GetUserAttendanceByUserIdRow struct {
+	AttendanceID      int64
+	AttendedAt        pgtype.Timestamp
+	ContactNumber     string
+	EventID           int64
+	Location          string
+	Latitude          float64
+	Longitude         float64
+	Contact           string
+	StartsAt          pgtype.Timestamp
+	EndsAt            pgtype.Timestamp
+	Attended          bool
+	CoverKey          pgtype.Text
+	DetailID          int64
+	DetailName        string
+	DetailDescription string
+	PointGain         int64
+	CreatedAt         pgtype.Timestamp
+}
+
+func (q *Queries) GetUserAttendanceByUserId(ctx context.Context, arg GetUserAttendanceByUserIdParams) (GetUserAttendanceByUserIdRow, error) {
+	row := q.db.QueryRow(ctx, getUserAttendanceByUserId, arg.UserID, arg.ID)
+	var i GetUserAttendanceByUserIdRow
+	err := row.Scan(
+		&i.AttendanceID,
+		&i.AttendedAt,
+		&i.ContactNumber,
+		&i.EventID,
+		&i.Location,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Contact,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Attended,
+		&i.CoverKey,
+		&i.DetailID,
+		&i.DetailName,
+		&i.DetailDescription,
+		&i.PointGain,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserAttendances = `-- name: GetUserAttendances :many
+SELECT 
+    a.id AS attendance_id,
+    a.created_at AS attended_at,
+    a.contact_number,
+    e.id AS event_id,
+    e.location,
+    e.latitude,
+    e.longitude,
+    e.contact,
+    e.starts_at,
+    e.ends_at,
+    a.attended,
+    e.cover_key,
+    d.id AS detail_id,
+    d.name AS detail_name,
+    d.description AS detail_description,
+    d.point_gain
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1
+ORDER BY a.created_at DESC
+`
+
+type GetUserAttendancesRow struct {
+	AttendanceID      int64
+	AttendedAt        pgtype.Timestamp
+	ContactNumber     string
+	EventID           int64
+	Location          string
+	Latitude          float64
+	Longitude         float64
+	Contact           string
+	StartsAt          pgtype.Timestamp
+	EndsAt            pgtype.Timestamp
+	Attended          bool
+	CoverKey          pgtype.Text
+	DetailID          int64
+	DetailName        string
+	DetailDescription string
+	PointGain         int64
+}
+
+func (q *Queries) GetUserAttendances(ctx context.Context, userID int64) ([]GetUserAttendancesRow, error) {
+	rows, err := q.db.Query(ctx, getUserAttendances, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserAttendancesRow
+	for rows.Next() {
+		var i GetUserAttendancesRow
+		if err := rows.Scan(
+			&i.AttendanceID,
+			&i.AttendedAt,
+			&i.ContactNumber,
+			&i.EventID,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Contact,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Attended,
+			&i.CoverKey,
+			&i.DetailID,
+			&i.DetailName,
+			&i.DetailDescription,
+			&i.PointGain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, username, email, password, is_admin 
+FROM users
+WHERE email = $1
+`
+
+type GetUserByEmailRow struct {
+	ID       int64
+	Username string
+	Email    string
+	Password string
+	IsAdmin  bool
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i GetUserByEmailRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.IsAdmin,
+	)
+	return i, err
+}
+
+const getUserById = `-- name: GetUserById :one
+SELECT id, username, email, password, is_admin
+FROM users
+WHERE id = $1
+`
+
+type GetUserByIdRow struct {
+	ID       int64
+	Username string
+	Email    string
+	Password string
+	IsAdmin  bool
+}
+
+func (q *Queries) GetUserById(ctx context.Context, id int64) (GetUserByIdRow, error) {
+	row := q.db.QueryRow(ctx, getUserById, id)
+	var i GetUserByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.IsAdmin,
+	)
+	return i, err
+}
+
+const getUserCalendarEvents = `-- name: GetUserCalendarEvents :many
+SELECT
+  e.id,
+  d.name AS name,
+  d.description AS description,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  d.updated_at
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1
+ORDER BY e.starts_at ASC
+`
+
+type GetUserCalendarEventsRow struct {
+	ID          int64
+	Name        string
+	Description string
+	Location    string
+	Latitude    float64
+	Longitude   float64
+	Contact     string
+	StartsAt    pgtype.Timestamp
+	EndsAt      pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+func (q *Queries) GetUserCalendarEvents(ctx context.Context, userID int64) ([]GetUserCalendarEventsRow, error) {
+	rows, err := q.db.Query(ctx, getUserCalendarEvents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserCalendarEventsRow
+	for rows.Next() {
+		var i GetUserCalendarEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Contact,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserContributions = `-- name: GetUserContributions :many
+SELECT 
+    c.id               AS contribution_id,
+    d.name As name,
+    d.description AS description,
+    q.latitude,
+    q.longitude,
+    d.point_gain AS point_gain
+FROM contributions c
+JOIN quests q ON c.quest_id = q.id
+JOIN details d ON q.detail_id = d.id
+WHERE c.user_id = $1
+ORDER BY c.created_at DESC
+`
+
+type GetUserContributionsRow struct {
+	ContributionID int64
+	Name           string
+	Description    string
+	Latitude       float64
+	Longitude      float64
+	PointGain      int64
+}
+
+func (q *Queries) GetUserContributions(ctx context.Context, userID int64) ([]GetUserContributionsRow, error) {
+	rows, err := q.db.Query(ctx, getUserContributions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserContributionsRow
+	for rows.Next() {
+		var i GetUserContributionsRow
+		if err := rows.Scan(
+			&i.ContributionID,
+			&i.Name,
+			&i.Description,
+			&i.Latitude,
+			&i.Longitude,
+			&i.PointGain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserDepositMaterials = `-- name: GetUserDepositMaterials :many
+SELECT
+  deposit_items.material,
+  SUM(deposit_items.weight)::float8 AS weight
+FROM deposit_items
+JOIN deposits ON deposits.id = deposit_items.deposit_id
+WHERE deposits.user_id = $1 AND deposits.status = 'confirmed'
+GROUP BY deposit_items.material
+ORDER BY weight DESC
+`
+
+type GetUserDepositMaterialsRow struct {
+	Material string
+	Weight   float64
+}
+
+func (q *Queries) GetUserDepositMaterials(ctx context.Context, userID int64) ([]GetUserDepositMaterialsRow, error) {
+	rows, err := q.db.Query(ctx, getUserDepositMaterials, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserDepositMaterialsRow
+	for rows.Next() {
+		var i GetUserDepositMaterialsRow
+		if err := rows.Scan(&i.Material, &i.Weight); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserDepositStatistic = `-- name: GetUserDepositStatistic :one
+SELECT
+  COUNT(*) AS deposits,
+  COALESCE(SUM(total_weight), 0)::float8 AS total_weight,
+  COALESCE(SUM(payout), 0)::bigint AS total_payout,
+  COALESCE(SUM(point_gain), 0)::bigint AS total_points
+FROM deposits
+WHERE user_id = $1 AND status = 'confirmed'
+`
+
+type GetUserDepositStatisticRow struct {
+	Deposits    int64
+	TotalWeight float64
+	TotalPayout int64
+	TotalPoints int64
+}
+
+func (q *Queries) GetUserDepositStatistic(ctx context.Context, userID int64) (GetUserDepositStatisticRow, error) {
+	row := q.db.QueryRow(ctx, getUserDepositStatistic, userID)
+	var i GetUserDepositStatisticRow
+	err := row.Scan(
+		&i.Deposits,
+		&i.TotalWeight,
+		&i.TotalPayout,
+		&i.TotalPoints,
+	)
+	return i, err
+}
+
+const getUserDeposits = `-- name: GetUserDeposits :many
+SELECT
+  deposits.id,
+  deposits.waste_bank_id,
+  waste_banks.name AS waste_bank_name,
+  deposits.status,
+  deposits.total_weight,
+  deposits.payout,
+  deposits.point_gain,
+  deposits.note,
+  deposits.confirmed_at,
+  deposits.created_at
+FROM deposits
+JOIN waste_banks ON waste_banks.id = deposits.waste_bank_id
+WHERE deposits.user_id = $1
+ORDER BY deposits.created_at DESC
+`
+
+type GetUserDepositsRow struct {
+	ID            int64
+	WasteBankID   int64
+	WasteBankName string
+	Status        string
+	TotalWeight   float64
+	Payout        int32
+	PointGain     int32
+	Note          pgtype.Text
+	ConfirmedAt   pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+}
+
+func (q *Queries) GetUserDeposits(ctx context.Context, userID int64) ([]GetUserDepositsRow, error) {
+	rows, err := q.db.Query(ctx, getUserDeposits, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserDepositsRow
+	for rows.Next() {
+		var i GetUserDepositsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WasteBankID,
+			&i.WasteBankName,
+			&i.Status,
+			&i.TotalWeight,
+			&i.Payout,
+			&i.PointGain,
+			&i.Note,
+			&i.ConfirmedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserEventWaitlists = `-- name: GetUserEventWaitlists :many
+SELECT id, event_id, user_id, contact_number, created_at FROM event_waitlists
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserEventWaitlists(ctx context.Context, userID int64) ([]EventWaitlist, error) {
+	rows, err := q.db.Query(ctx, getUserEventWaitlists, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EventWaitlist
+	for rows.Next() {
+		var i EventWaitlist
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserID,
+			&i.ContactNumber,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserGreenprintProjects = `-- name: GetUserGreenprintProjects :many
+SELECT
+  greenprint_projects.id,
+  greenprint_projects.greenprint_id,
+  greenprints.title,
+  greenprint_projects.status,
+  greenprint_projects.proof_key,
+  greenprint_projects.started_at,
+  greenprint_projects.completed_at,
+  (SELECT COUNT(*) FROM steps WHERE steps.greenprint_id = greenprint_projects.greenprint_id) AS total_steps,
+  (SELECT COUNT(*) FROM project_steps WHERE project_steps.project_id = greenprint_projects.id) AS completed_steps
+FROM greenprint_projects
+JOIN greenprints ON greenprints.id = greenprint_projects.greenprint_id
+WHERE greenprint_projects.user_id = $1
+ORDER BY greenprint_projects.started_at DESC
+`
+
+type GetUserGreenprintProjectsRow struct {
+	ID             int64
+	GreenprintID   int64
+	Title          string
+	Status         string
+	ProofKey       pgtype.Text
+	StartedAt      pgtype.Timestamp
+	CompletedAt    pgtype.Timestamp
+	TotalSteps     int64
+	CompletedSteps int64
+}
+
+func (q *Queries) GetUserGreenprintProjects(ctx context.Context, userID int64) ([]GetUserGreenprintProjectsRow, error) {
+	rows, err := q.db.Query(ctx, getUserGreenprintProjects, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserGreenprintProjectsRow
+	for rows.Next() {
+		var i GetUserGreenprintProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GreenprintID,
+			&i.Title,
+			&i.Status,
+			&i.ProofKey,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.TotalSteps,
+			&i.CompletedSteps,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserHistories = `-- name: GetUserHistories :many
+SELECT id, user_id, name, type, category, amount, created_at FROM histories
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetUserHistories(ctx context.Context, userID int64) ([]History, error) {
+	rows, err := q.db.Query(ctx, getUserHistories, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Type,
+			&i.Category,
+			&i.Amount,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNotifications = `-- name: GetUserNotifications :many
+SELECT id, user_id, type, title, body, read_at, created_at FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetUserNotificationsParams struct {
+	UserID int64
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) GetUserNotifications(ctx context.Context, arg GetUserNotificationsParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, getUserNotifications, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Title,
+			&i.Body,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserPendingAttendances = `-- name: GetUserPendingAttendances :many
+SELECT 
+    a.id AS attendance_id,
+    e.id AS event_id,
+    a.created_at AS registered_at,
+    a.contact_number,
+    e.location,
+    e.latitude,
+    e.longitude,
+    e.contact,
+    e.starts_at,
+    e.ends_at,
+    e.cover_key,
+    d.name AS detail_name,
+    d.description AS detail_description,
+    d.point_gain
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1 AND a.attended = false
+`
+
+type GetUserPendingAttendancesRow struct {
+	AttendanceID      int64
+	EventID           int64
+	RegisteredAt      pgtype.Timestamp
+	ContactNumber     string
+	Location          string
+	Latitude          float64
+	Longitude         float64
+	Contact           string
+	StartsAt          pgtype.Timestamp
+	EndsAt            pgtype.Timestamp
+	CoverKey          pgtype.Text
+	DetailName        string
+	DetailDescription string
+	PointGain         int64
+}
+
+func (q *Queries) GetUserPendingAttendances(ctx context.Context, userID int64) ([]GetUserPendingAttendancesRow, error) {
+	rows, err := q.db.Query(ctx, getUserPendingAttendances, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserPendingAttendancesRow
+	for rows.Next() {
+		var i GetUserPendingAttendancesRow
+		if err := rows.Scan(
+			&i.AttendanceID,
+			&i.EventID,
+			&i.RegisteredAt,
+			&i.ContactNumber,
+			&i.Location,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Contact,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CoverKey,
+			&i.DetailName,
+			&i.DetailDescription,
+			&i.PointGain,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserProfile = `-- name: GetUserProfile :one
+SELECT p.id, p.user_id, p.current_exp, p.exp_needed, p.level, p.points, p.profile_key
+FROM profiles p
+JOIN users u ON p.user_id = u.id
+WHERE p.user_id = $1
+`
+
+func (q *Queries) GetUserProfile(ctx context.Context, userID int64) (Profile, error) {
+	row := q.db.QueryRow(ctx, getUserProfile, userID)
+	var i Profile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CurrentExp,
+		&i.ExpNeeded,
+		&i.Level,
+		&i.Points,
+		&i.ProfileKey,
+	)
+	return i, err
+}
+
+const getUserProfileStatistic = `-- name: GetUserProfileStatistic :one
+SELECT 
+    u.id AS user_id,
+    u.name,
+    u.username,
+    u.email,
+    p.current_exp,
+    p.exp_needed,
+    p.level,
+    p.points,
+    p.profile_key,
+    s.challenges,
+    s.events,
+    s.quests,
+    s.treasures,
+    s.longest_streak,
+    s.tree_grown,
+    s.volunteer_minutes
+FROM users u
+JOIN profiles p ON u.id = p.user_id
+JOIN statistics s ON u.id = s.user_id
+WHERE u.id = $1
+`
+
+type GetUserProfileStatisticRow struct {
+	UserID           int64
+	Name             string
+	Username         string
+	Email            string
+	CurrentExp       int64
+	ExpNeeded        int64
+	Level            int32
+	Points           int64
+	ProfileKey       string
+	Challenges       int32
+	Events           int32
+	Quests           int32
+	Treasures        int32
+	LongestStreak    int32
+	TreeGrown        int32
+	VolunteerMinutes int32
+}
+
+func (q *Queries) GetUserProfileStatistic(ctx context.Context, id int64) (GetUserProfileStatisticRow, error) {
+	row := q.db.QueryRow(ctx, getUserProfileStatistic, id)
+	var i GetUserProfileStatisticRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Name,
+		&i.Username,
+		&i.Email,
+		&i.CurrentExp,
+		&i.ExpNeeded,
+		&i.Level,
+		&i.Points,
+		&i.ProfileKey,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
+	)
+	return i, err
+}
+
+const getUserScanById = `-- name: GetUserScanById :one
+SELECT id, user_id, title, description, image_key, created_at FROM scans
+WHERE id = $1 AND user_id = $2
+`
+
+type GetUserScanByIdParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) GetUserScanById(ctx context.Context, arg GetUserScanByIdParams) (Scan, error) {
+	row := q.db.QueryRow(ctx, getUserScanById, arg.ID, arg.UserID)
+	var i Scan
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.ImageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserScans = `-- name: GetUserScans :many
+SELECT id, user_id, title, description, image_key, created_at FROM scans
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2::int OFFSET $3::int
+`
+
+type GetUserScansParams struct {
+	UserID     int64
+	PageLimit  int32
+	PageOffset int32
+}
+
+func (q *Queries) GetUserScans(ctx context.Context, arg GetUserScansParams) ([]Scan, error) {
+	rows, err := q.db.Query(ctx, getUserScans, arg.UserID, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Scan
+	for rows.Next() {
+		var i Scan
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.ImageKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserStatistic = `-- name: GetUserStatistic :one
+SELECT id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown, volunteer_minutes FROM statistics WHERE user_id = $1
+`
+
+func (q *Queries) GetUserStatistic(ctx context.Context, userID int64) (Statistic, error) {
+	row := q.db.QueryRow(ctx, getUserStatistic, userID)
+	var i Statistic
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
+	)
+	return i, err
+}
+
+const getVolunteerEvents = `-- name: GetVolunteerEvents :many
+SELECT
+  e.id,
+  d.name,
+  e.starts_at,
+  COALESCE(e.credited_minutes, (EXTRACT(EPOCH FROM (e.ends_at - e.starts_at)) / 60)::int)::int AS credited_minutes
+FROM attendances a
+JOIN events e ON a.event_id = e.id
+JOIN details d ON e.detail_id = d.id
+WHERE a.user_id = $1
+  AND a.attended = true
+  AND ($2::bigint IS NULL OR e.id = $2::bigint)
+  AND ($3::timestamp IS NULL OR e.starts_at >= $3::timestamp)
+  AND ($4::timestamp IS NULL OR e.starts_at < $4::timestamp)
+ORDER BY e.starts_at ASC
+`
+
+type GetVolunteerEventsParams struct {
+	UserID   int64
+	EventID  pgtype.Int8
+	DateFrom pgtype.Timestamp
+	DateTo   pgtype.Timestamp
+}
+
+type GetVolunteerEventsRow struct {
+	ID              int64
+	Name            string
+	StartsAt        pgtype.Timestamp
+	CreditedMinutes int32
+}
+
+func (q *Queries) GetVolunteerEvents(ctx context.Context, arg GetVolunteerEventsParams) ([]GetVolunteerEventsRow, error) {
+	rows, err := q.db.Query(ctx, getVolunteerEvents,
+		arg.UserID,
+		arg.EventID,
+		arg.DateFrom,
+		arg.DateTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVolunteerEventsRow
+	for rows.Next() {
+		var i GetVolunteerEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartsAt,
+			&i.CreditedMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWasteBankById = `-- name: GetWasteBankById :one
+SELECT id, name, address, latitude, longitude, region_id, phone, is_active, created_at, updated_at FROM waste_banks
+WHERE id = $1
+`
+
+func (q *Queries) GetWasteBankById(ctx context.Context, id int64) (WasteBank, error) {
+	row := q.db.QueryRow(ctx, getWasteBankById, id)
+	var i WasteBank
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.Latitude,
+		&i.Longitude,
+		&i.RegionID,
+		&i.Phone,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWasteBankPrices = `-- name: GetWasteBankPrices :many
+SELECT id, waste_bank_id, material, price_per_kg, created_at, updated_at FROM waste_bank_prices
+WHERE waste_bank_id = $1
+ORDER BY material
+`
+
+func (q *Queries) GetWasteBankPrices(ctx context.Context, wasteBankID int64) ([]WasteBankPrice, error) {
+	rows, err := q.db.Query(ctx, getWasteBankPrices, wasteBankID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WasteBankPrice
+	for rows.Next() {
+		var i WasteBankPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.WasteBankID,
+			&i.Material,
+			&i.PricePerKg,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWasteBanks = `-- name: GetWasteBanks :many
+SELECT
+  id,
+  name,
+  address,
+  latitude,
+  longitude,
+  phone,
+  earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(latitude, longitude)
+  )::float8 AS distance_meters
+FROM waste_banks
+WHERE is_active = true
+ORDER BY
+  CASE WHEN $3::bool THEN earth_distance(
+    ll_to_earth($1::float8, $2::float8),
+    ll_to_earth(latitude, longitude)
+  ) END,
+  name
+`
+
+type GetWasteBanksParams struct {
+	Latitude    float64
+	Longitude   float64
+	HasLocation bool
+}
+
+type GetWasteBanksRow struct {
+	ID             int64
+	Name           string
+	Address        string
+	Latitude       float64
+	Longitude      float64
+	Phone          pgtype.Text
+	DistanceMeters float64
+}
+
+func (q *Queries) GetWasteBanks(ctx context.Context, arg GetWasteBanksParams) ([]GetWasteBanksRow, error) {
+	rows, err := q.db.Query(ctx, getWasteBanks, arg.Latitude, arg.Longitude, arg.HasLocation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWasteBanksRow
+	for rows.Next() {
+		var i GetWasteBanksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Phone,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWeeklyRecaps = `-- name: GetWeeklyRecaps :many
+SELECT id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at FROM recaps
+WHERE user_id = $1 AND type = 'weekly'
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetWeeklyRecaps(ctx context.Context, userID int64) ([]Recap, error) {
+	rows, err := q.db.Query(ctx, getWeeklyRecaps, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recap
+	for rows.Next() {
+		var i Recap
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Summary,
+			&i.Tips,
+			&i.AssignedTask,
+			&i.CompletedTask,
+			&i.CompletionRate,
+			&i.GrowthRating,
+			&i.Type,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const hasEventFeedback = `-- name: HasEventFeedback :one
+SELECT EXISTS (
+  SELECT 1 FROM event_feedbacks
+  WHERE event_id = $1 AND user_id = $2
+)
+`
+
+type HasEventFeedbackParams struct {
+	EventID int64
+	UserID  int64
+}
+
+func (q *Queries) HasEventFeedback(ctx context.Context, arg HasEventFeedbackParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasEventFeedback, arg.EventID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hasGeofenceOverride = `-- name: HasGeofenceOverride :one
+SELECT EXISTS (
+  SELECT 1 FROM geofence_overrides
+  WHERE user_id = $1 AND activity_type = $2 AND activity_id = $3
+)
+`
+
+type HasGeofenceOverrideParams struct {
+	UserID       int64
+	ActivityType string
+	ActivityID   int64
+}
+
+func (q *Queries) HasGeofenceOverride(ctx context.Context, arg HasGeofenceOverrideParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasGeofenceOverride, arg.UserID, arg.ActivityType, arg.ActivityID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const hasUsedGreenprint = `-- name: HasUsedGreenprint :one
+SELECT EXISTS (
+  SELECT 1 FROM greenprints
+  JOIN items ON items.id = greenprints.item_id
+  WHERE items.user_id = $1::bigint
+    AND greenprints.source_id = $2::bigint
+)
+AND NOT EXISTS (
+  SELECT 1 FROM greenprints
+  JOIN items ON items.id = greenprints.item_id
+  WHERE greenprints.id = $2::bigint
+    AND items.user_id = $1::bigint
+) AS used
 `
 
-type GetUncompletedQuestByCodeIdRow struct {
-	ID              int64
-	Location        string
-	MaxContributors int32
-	Latitude        float64
-	Longitude       float64
-	Name            string
-	Description     string
-	PointGain       int64
+type HasUsedGreenprintParams struct {
+	UserID       int64
+	GreenprintID int64
 }
 
-func (q *Queries) GetUncompletedQuestByCodeId(ctx context.Context, codeID string) (GetUncompletedQuestByCodeIdRow, error) {
-	row := q.db.QueryRow(ctx, getUncompletedQuestByCodeId, codeID)
-	var i GetUncompletedQuestByCodeIdRow
+func (q *Queries) HasUsedGreenprint(ctx context.Context, arg HasUsedGreenprintParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasUsedGreenprint, arg.UserID, arg.GreenprintID)
+	var used bool
+	err := row.Scan(&used)
+	return used, err
+}
+
+const increaseChallengesFieldByOne = `-- name: IncreaseChallengesFieldByOne :one
+UPDATE statistics
+SET challenges = challenges + 1
+WHERE user_id = $1
+RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown, volunteer_minutes
+`
+
+func (q *Queries) IncreaseChallengesFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
+	row := q.db.QueryRow(ctx, increaseChallengesFieldByOne, userID)
+	var i Statistic
 	err := row.Scan(
 		&i.ID,
-		&i.Location,
-		&i.MaxContributors,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Name,
-		&i.Description,
-		&i.PointGain,
+		&i.UserID,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
 	)
 	return i, err
 }
 
-const getUserActivePackets = `-- name: GetUserActivePackets :one
-SELECT id, user_id, name, target, description, completed_task, expected_task, task_per_day, completed, created_at FROM packets
-WHERE user_id = $1 AND completed = false
+const increaseEventStatistics = `-- name: IncreaseEventStatistics :one
+UPDATE statistics
+SET events = events + 1,
+  volunteer_minutes = volunteer_minutes + (
+    SELECT COALESCE(e.credited_minutes, (EXTRACT(EPOCH FROM (e.ends_at - e.starts_at)) / 60)::int)
+    FROM attendances a
+    JOIN events e ON a.event_id = e.id
+    WHERE a.id = $1
+  )
+WHERE user_id = $2
+RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown, volunteer_minutes
 `
 
-func (q *Queries) GetUserActivePackets(ctx context.Context, userID int64) (Packet, error) {
-	row := q.db.QueryRow(ctx, getUserActivePackets, userID)
-	var i Packet
+type IncreaseEventStatisticsParams struct {
+	AttendanceID int64
+	UserID       int64
+}
+
+func (q *Queries) IncreaseEventStatistics(ctx context.Context, arg IncreaseEventStatisticsParams) (Statistic, error) {
+	row := q.db.QueryRow(ctx, increaseEventStatistics, arg.AttendanceID, arg.UserID)
+	var i Statistic
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Name,
-		&i.Target,
-		&i.Description,
-		&i.CompletedTask,
-		&i.ExpectedTask,
-		&i.TaskPerDay,
-		&i.Completed,
-		&i.CreatedAt,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
 	)
 	return i, err
 }
 
-const getUserAttendance = `-- name: GetUserAttendance :one
-SELECT 
-    a.id AS attendance_id,
-    a.created_at AS attended_at,
-    a.contact_number,
-    e.id AS event_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    a.attended,
-    e.cover_key,
-    d.id AS detail_id,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain,
-    a.created_at
-FROM attendances a
-JOIN events e ON a.event_id = e.id
-JOIN details d ON e.detail_id = d.id
-WHERE a.id = $1
+const increaseExp = `-- name: IncreaseExp :one
+UPDATE profiles 
+SET current_exp = current_exp + $1::int
+WHERE user_id = $2::int
+RETURNING current_exp, exp_needed, level
 `
 
-type GetUserAttendanceRow struct {
-	AttendanceID      int64
-	AttendedAt        pgtype.Timestamp
-	ContactNumber     string
-	EventID           int64
-	Location          string
-	Latitude          float64
-	Longitude         float64
-	Contact           string
-	StartsAt          pgtype.Timestamp
-	EndsAt            pgtype.Timestamp
-	Attended          bool
-	CoverKey          pgtype.Text
-	DetailID          int64
-	DetailName        string
-	DetailDescription string
-	PointGain         int64
-	CreatedAt         pgtype.Timestamp
+type IncreaseExpParams struct {
+	ExpGain int32
+	UserID  int32
 }
 
-func (q *Queries) GetUserAttendance(ctx context.Context, id int64) (GetUserAttendanceRow, error) {
-	row := q.db.QueryRow(ctx, getUserAttendance, id)
-	var i GetUserAttendanceRow
+type IncreaseExpRow struct {
+	CurrentExp int64
+	ExpNeeded  int64
+	Level      int32
+}
+
+func (q *Queries) IncreaseExp(ctx context.Context, arg IncreaseExpParams) (IncreaseExpRow, error) {
+	row := q.db.QueryRow(ctx, increaseExp, arg.ExpGain, arg.UserID)
+	var i IncreaseExpRow
+	err := row.Scan(&i.CurrentExp, &i.ExpNeeded, &i.Level)
+	return i, err
+}
+
+const increasePacketCompletedTask = `-- name: IncreasePacketCompletedTask :exec
+UPDATE packets
+SET completed_task = completed_task + 1
+WHERE id = $1
+`
+
+func (q *Queries) IncreasePacketCompletedTask(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, increasePacketCompletedTask, id)
+	return err
+}
+
+const increaseQuestsFieldByOne = `-- name: IncreaseQuestsFieldByOne :one
+UPDATE statistics
+SET quests = quests + 1
+WHERE user_id = $1
+RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown, volunteer_minutes
+`
+
+func (q *Queries) IncreaseQuestsFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
+	row := q.db.QueryRow(ctx, increaseQuestsFieldByOne, userID)
+	var i Statistic
 	err := row.Scan(
-		&i.AttendanceID,
-		&i.AttendedAt,
-		&i.ContactNumber,
-		&i.EventID,
-		&i.Location,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Contact,
-		&i.StartsAt,
-		&i.EndsAt,
-		&i.Attended,
-		&i.CoverKey,
-		&i.DetailID,
-		&i.DetailName,
-		&i.DetailDescription,
-		&i.PointGain,
-		&i.CreatedAt,
+		&i.ID,
+		&i.UserID,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
+	)
+	return i, err
+}
+
+const increaseRegionTreeAmount = `-- name: IncreaseRegionTreeAmount :exec
+UPDATE regions
+SET tree_amount = tree_amount + $1
+WHERE id = $2
+`
+
+type IncreaseRegionTreeAmountParams struct {
+	TreeAmount int32
+	ID         int64
+}
+
+func (q *Queries) IncreaseRegionTreeAmount(ctx context.Context, arg IncreaseRegionTreeAmountParams) error {
+	_, err := q.db.Exec(ctx, increaseRegionTreeAmount, arg.TreeAmount, arg.ID)
+	return err
+}
+
+const increaseTreasuresFieldByOne = `-- name: IncreaseTreasuresFieldByOne :one
+UPDATE statistics
+SET treasures = treasures + 1
+WHERE user_id = $1
+RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown, volunteer_minutes
+`
+
+func (q *Queries) IncreaseTreasuresFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
+	row := q.db.QueryRow(ctx, increaseTreasuresFieldByOne, userID)
+	var i Statistic
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Challenges,
+		&i.Events,
+		&i.Quests,
+		&i.Treasures,
+		&i.LongestStreak,
+		&i.TreeGrown,
+		&i.VolunteerMinutes,
+	)
+	return i, err
+}
+
+const increaseUserPoints = `-- name: IncreaseUserPoints :one
+UPDATE profiles
+SET points = points + $1
+WHERE user_id = $2
+RETURNING id, user_id, current_exp, exp_needed, level, points, profile_key
+`
+
+type IncreaseUserPointsParams struct {
+	Points int64
+	UserID int64
+}
+
+func (q *Queries) IncreaseUserPoints(ctx context.Context, arg IncreaseUserPointsParams) (Profile, error) {
+	row := q.db.QueryRow(ctx, increaseUserPoints, arg.Points, arg.UserID)
+	var i Profile
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CurrentExp,
+		&i.ExpNeeded,
+		&i.Level,
+		&i.Points,
+		&i.ProfileKey,
 	)
 	return i, err
 }
 
-const getUserAttendanceById = `-- name: GetUserAttendanceById :one
-SELECT 
-    a.id AS attendance_id,
-    a.created_at AS attended_at,
-    a.contact_number,
-    e.id AS event_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    a.attended,
-    e.cover_key,
-    d.name AS name,
-    d.description AS description,
-    d.point_gain
-FROM attendances a
-JOIN events e ON a.event_id = e.id
-JOIN details d ON e.detail_id = d.id
-WHERE a.id = $1
+const increaseUserTreeGrownm = `-- name: IncreaseUserTreeGrownm :exec
+UPDATE statistics
+SET tree_grown = tree_grown + $1
+WHERE user_id = $2
+`
+
+type IncreaseUserTreeGrownmParams struct {
+	TreeGrown int32
+	UserID    int64
+}
+
+func (q *Queries) IncreaseUserTreeGrownm(ctx context.Context, arg IncreaseUserTreeGrownmParams) error {
+	_, err := q.db.Exec(ctx, increaseUserTreeGrownm, arg.TreeGrown, arg.UserID)
+	return err
+}
+
+const lockUserAiUsage = `-- name: LockUserAiUsage :exec
+SELECT pg_advisory_xact_lock(hashtext('ai_usages'), $1::int)
 `
 
-type GetUserAttendanceByIdRow struct {
-	AttendanceID  int64
-	AttendedAt    pgtype.Timestamp
-	ContactNumber string
-	EventID       int64
-	Location      string
-	Latitude      float64
-	Longitude     float64
-	Contact       string
-	StartsAt      pgtype.Timestamp
-	EndsAt        pgtype.Timestamp
-	Attended      bool
-	CoverKey      pgtype.Text
-	Name          string
-	Description   string
-	PointGain     int64
+func (q *Queries) LockUserAiUsage(ctx context.Context, userID int32) error {
+	_, err := q.db.Exec(ctx, lockUserAiUsage, userID)
+	return err
 }
 
-func (q *Queries) GetUserAttendanceById(ctx context.Context, id int64) (GetUserAttendanceByIdRow, error) {
-	row := q.db.QueryRow(ctx, getUserAttendanceById, id)
-	var i GetUserAttendanceByIdRow
-	err := row.Scan(
-		&i.AttendanceID,
-		&i.AttendedAt,
-		&i.ContactNumber,
-		&i.EventID,
-		&i.Location,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Contact,
-		&i.StartsAt,
-		&i.EndsAt,
-		&i.Attended,
-		&i.CoverKey,
-		&i.Name,
-		&i.Description,
-		&i.PointGain,
-	)
-	return i, err
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND read_at IS NULL
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, markAllNotificationsRead, userID)
+	return err
 }
 
-const getUserAttendanceByUserId = `-- name: GetUserAttendanceByUserId :one
-SELECT 
-    a.id AS attendance_id,
-    a.created_at AS attended_at,
-    a.contact_number,
-    e.id AS event_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    a.attended,
-    e.cover_key,
-    d.id AS detail_id,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain,
-    a.created_at
-FROM attendances a
-JOIN events e ON a.event_id = e.id
-JOIN details d ON e.detail_id = d.id
-WHERE a.user_id = $1 AND e.id = $2
+const markNotificationRead = `-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+WHERE id = $1 AND user_id = $2
 `
 
-type GetUserAttendanceByUserIdParams struct {
-	UserID int64
+type MarkNotificationReadParams struct {
 	ID     int64
+	UserID int64
 }
 
-type GetUserAttendanceByUserIdRow struct {
-	AttendanceID      int64
-	AttendedAt        pgtype.Timestamp
-	ContactNumber     string
-	EventID           int64
-	Location          string
-	Latitude          float64
-	Longitude         float64
-	Contact           string
-	StartsAt          pgtype.Timestamp
-	EndsAt            pgtype.Timestamp
-	Attended          bool
-	CoverKey          pgtype.Text
-	DetailID          int64
-	DetailName        string
-	DetailDescription string
-	PointGain         int64
-	CreatedAt         pgtype.Timestamp
+func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markNotificationRead, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) GetUserAttendanceByUserId(ctx context.Context, arg GetUserAttendanceByUserIdParams) (GetUserAttendanceByUserIdRow, error) {
-	row := q.db.QueryRow(ctx, getUserAttendanceByUserId, arg.UserID, arg.ID)
-	var i GetUserAttendanceByUserIdRow
+const markParticipationUploaded = `-- name: MarkParticipationUploaded :one
+UPDATE participations
+SET status = 'pending_review', uploaded_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending_upload'
+RETURNING id, challenge_id, user_id, memory_id, created_at, status, point_gain, note, reviewed_by, reviewed_at, uploaded_at, bonus_points
+`
+
+func (q *Queries) MarkParticipationUploaded(ctx context.Context, iD int64) (Participation, error) {
+	row := q.db.QueryRow(ctx, markParticipationUploaded, iD)
+	var i Participation
 	err := row.Scan(
-		&i.AttendanceID,
-		&i.AttendedAt,
-		&i.ContactNumber,
-		&i.EventID,
-		&i.Location,
-		&i.Latitude,
-		&i.Longitude,
-		&i.Contact,
-		&i.StartsAt,
-		&i.EndsAt,
-		&i.Attended,
-		&i.CoverKey,
-		&i.DetailID,
-		&i.DetailName,
-		&i.DetailDescription,
+		&i.ID,
+		&i.ChallengeID,
+		&i.UserID,
+		&i.MemoryID,
+		&i.CreatedAt,
+		&i.Status,
 		&i.PointGain,
+		&i.Note,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.UploadedAt,
+		&i.BonusPoints,
+	)
+	return i, err
+}
+
+const popEventWaitlist = `-- name: PopEventWaitlist :one
+DELETE FROM event_waitlists
+WHERE id = (
+  SELECT id FROM event_waitlists
+  WHERE event_id = $1
+  ORDER BY created_at ASC, id ASC
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_id, user_id, contact_number, created_at
+`
+
+func (q *Queries) PopEventWaitlist(ctx context.Context, eventID int64) (EventWaitlist, error) {
+	row := q.db.QueryRow(ctx, popEventWaitlist, eventID)
+	var i EventWaitlist
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.UserID,
+		&i.ContactNumber,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getUserAttendances = `-- name: GetUserAttendances :many
-SELECT 
-    a.id AS attendance_id,
-    a.created_at AS attended_at,
-    a.contact_number,
-    e.id AS event_id,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    a.attended,
-    e.cover_key,
-    d.id AS detail_id,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain
-FROM attendances a
-JOIN events e ON a.event_id = e.id
-JOIN details d ON e.detail_id = d.id
-WHERE a.user_id = $1
-ORDER BY a.created_at DESC
+const registerForEvent = `-- name: RegisterForEvent :one
+WITH seat AS (
+  UPDATE events
+  SET attendee_count = attendee_count + 1
+  WHERE id = $1::bigint
+    AND (max_attendees IS NULL OR attendee_count < max_attendees)
+    AND ($2::bool OR NOT EXISTS (
+      SELECT 1 FROM event_waitlists w WHERE w.event_id = events.id
+    ))
+  RETURNING id
+)
+INSERT INTO attendances(user_id, event_id, contact_number)
+SELECT $3::bigint, seat.id, $4::varchar FROM seat
+RETURNING id, user_id, event_id, attended, contact_number, created_at, attended_at
 `
 
-type GetUserAttendancesRow struct {
-	AttendanceID      int64
-	AttendedAt        pgtype.Timestamp
-	ContactNumber     string
-	EventID           int64
-	Location          string
-	Latitude          float64
-	Longitude         float64
-	Contact           string
-	StartsAt          pgtype.Timestamp
-	EndsAt            pgtype.Timestamp
-	Attended          bool
-	CoverKey          pgtype.Text
-	DetailID          int64
-	DetailName        string
-	DetailDescription string
-	PointGain         int64
+type RegisterForEventParams struct {
+	EventID       int64
+	FromWaitlist  bool
+	UserID        int64
+	ContactNumber string
 }
 
-func (q *Queries) GetUserAttendances(ctx context.Context, userID int64) ([]GetUserAttendancesRow, error) {
-	rows, err := q.db.Query(ctx, getUserAttendances, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetUserAttendancesRow
-	for rows.Next() {
-		var i GetUserAttendancesRow
-		if err := rows.Scan(
-			&i.AttendanceID,
-			&i.AttendedAt,
-			&i.ContactNumber,
-			&i.EventID,
-			&i.Location,
-			&i.Latitude,
-			&i.Longitude,
-			&i.Contact,
-			&i.StartsAt,
-			&i.EndsAt,
-			&i.Attended,
-			&i.CoverKey,
-			&i.DetailID,
-			&i.DetailName,
-			&i.DetailDescription,
-			&i.PointGain,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) RegisterForEvent(ctx context.Context, arg RegisterForEventParams) (Attendance, error) {
+	row := q.db.QueryRow(ctx, registerForEvent,
+		arg.EventID,
+		arg.FromWaitlist,
+		arg.UserID,
+		arg.ContactNumber,
+	)
+	var i Attendance
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EventID,
+		&i.Attended,
+		&i.ContactNumber,
+		&i.CreatedAt,
+		&i.AttendedAt,
+	)
+	return i, err
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, password, is_admin 
-FROM users
-WHERE email = $1
+const rejectDeposit = `-- name: RejectDeposit :one
+UPDATE deposits
+SET status = 'rejected', note = $1, confirmed_by = $2, confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status = 'pending'
+RETURNING id, user_id, waste_bank_id, status, total_weight, payout, point_gain, note, confirmed_by, confirmed_at, created_at
 `
 
-type GetUserByEmailRow struct {
-	ID       int64
-	Username string
-	Email    string
-	Password string
-	IsAdmin  bool
+type RejectDepositParams struct {
+	Note        pgtype.Text
+	ConfirmedBy pgtype.Int8
+	ID          int64
 }
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
-	var i GetUserByEmailRow
+func (q *Queries) RejectDeposit(ctx context.Context, arg RejectDepositParams) (Deposit, error) {
+	row := q.db.QueryRow(ctx, rejectDeposit, arg.Note, arg.ConfirmedBy, arg.ID)
+	var i Deposit
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.Password,
-		&i.IsAdmin,
+		&i.UserID,
+		&i.WasteBankID,
+		&i.Status,
+		&i.TotalWeight,
+		&i.Payout,
+		&i.PointGain,
+		&i.Note,
+		&i.ConfirmedBy,
+		&i.ConfirmedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getUserById = `-- name: GetUserById :one
-SELECT id, username, email, password, is_admin
-FROM users
-WHERE id = $1
+const rejectParticipation = `-- name: RejectParticipation :one
+UPDATE participations
+SET status = 'rejected', point_gain = 0, note = $1, reviewed_by = $2, reviewed_at = CURRENT_TIMESTAMP
+WHERE id = $3 AND status IN ('pending_upload', 'pending_review')
+RETURNING id, challenge_id, user_id, memory_id, created_at, status, point_gain, note, reviewed_by, reviewed_at, uploaded_at, bonus_points
 `
 
-type GetUserByIdRow struct {
-	ID       int64
-	Username string
-	Email    string
-	Password string
-	IsAdmin  bool
+type RejectParticipationParams struct {
+	Note       pgtype.Text
+	ReviewedBy pgtype.Int8
+	ID         int64
 }
 
-func (q *Queries) GetUserById(ctx context.Context, id int64) (GetUserByIdRow, error) {
-	row := q.db.QueryRow(ctx, getUserById, id)
-	var i GetUserByIdRow
+func (q *Queries) RejectParticipation(ctx context.Context, arg RejectParticipationParams) (Participation, error) {
+	row := q.db.QueryRow(ctx, rejectParticipation, arg.Note, arg.ReviewedBy, arg.ID)
+	var i Participation
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.Password,
-		&i.IsAdmin,
+		&i.ChallengeID,
+		&i.UserID,
+		&i.MemoryID,
+		&i.CreatedAt,
+		&i.Status,
+		&i.PointGain,
+		&i.Note,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.UploadedAt,
+		&i.BonusPoints,
 	)
 	return i, err
 }
 
-const getUserContributions = `-- name: GetUserContributions :many
-SELECT 
-    c.id               AS contribution_id,
-    d.name As name,
-    d.description AS description,
-    q.latitude,
-    q.longitude,
-    d.point_gain AS point_gain
-FROM contributions c
-JOIN quests q ON c.quest_id = q.id
-JOIN details d ON q.detail_id = d.id
-WHERE c.user_id = $1
-ORDER BY c.created_at DESC
+const reserveAiUsage = `-- name: ReserveAiUsage :one
+WITH used AS (
+  SELECT
+    COUNT(*) FILTER (WHERE feature = $1 AND created_at >= $2) AS daily,
+    COUNT(*) FILTER (WHERE feature = $1) AS monthly,
+    COUNT(*) FILTER (WHERE created_at >= $2) AS total_daily,
+    COUNT(*) AS total_monthly
+  FROM ai_usages
+  WHERE user_id = $3
+    AND status = 'success'
+    AND feature <> 'trash_vision'
+    AND created_at >= $4
+)
+INSERT INTO ai_usages(user_id, feature, provider, model, status, created_at)
+SELECT $3, $1, $5, $6, 'success', $7
+FROM used
+WHERE ($8::int = 0 OR used.daily < $8::int)
+  AND ($9::int = 0 OR used.monthly < $9::int)
+  AND ($10::int = 0 OR used.total_daily < $10::int)
+  AND ($11::int = 0 OR used.total_monthly < $11::int)
+RETURNING id
 `
 
-type GetUserContributionsRow struct {
-	ContributionID int64
-	Name           string
-	Description    string
-	Latitude       float64
-	Longitude      float64
-	PointGain      int64
+type ReserveAiUsageParams struct {
+	Feature           string
+	DayStart          pgtype.Timestamp
+	UserID            int64
+	MonthStart        pgtype.Timestamp
+	Provider          string
+	Model             string
+	CreatedAt         pgtype.Timestamp
+	DailyLimit        int32
+	MonthlyLimit      int32
+	TotalDailyLimit   int32
+	TotalMonthlyLimit int32
 }
 
-func (q *Queries) GetUserContributions(ctx context.Context, userID int64) ([]GetUserContributionsRow, error) {
-	rows, err := q.db.Query(ctx, getUserContributions, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetUserContributionsRow
-	for rows.Next() {
-		var i GetUserContributionsRow
-		if err := rows.Scan(
-			&i.ContributionID,
-			&i.Name,
-			&i.Description,
-			&i.Latitude,
-			&i.Longitude,
-			&i.PointGain,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) ReserveAiUsage(ctx context.Context, arg ReserveAiUsageParams) (int64, error) {
+	row := q.db.QueryRow(ctx, reserveAiUsage,
+		arg.Feature,
+		arg.DayStart,
+		arg.UserID,
+		arg.MonthStart,
+		arg.Provider,
+		arg.Model,
+		arg.CreatedAt,
+		arg.DailyLimit,
+		arg.MonthlyLimit,
+		arg.TotalDailyLimit,
+		arg.TotalMonthlyLimit,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
 }
 
-const getUserHistories = `-- name: GetUserHistories :many
-SELECT id, user_id, name, type, category, amount, created_at FROM histories
-WHERE user_id = $1
-ORDER BY created_at DESC
+const revokeParticipation = `-- name: RevokeParticipation :one
+WITH approved AS (
+  SELECT id, point_gain FROM participations
+  WHERE id = $1 AND status = 'approved'
+  FOR UPDATE
+)
+UPDATE participations p
+SET status = 'rejected', point_gain = 0, note = $2, reviewed_by = $3, reviewed_at = CURRENT_TIMESTAMP
+FROM approved
+WHERE p.id = approved.id
+RETURNING p.user_id, approved.point_gain AS revoked_points
 `
 
-func (q *Queries) GetUserHistories(ctx context.Context, userID int64) ([]History, error) {
-	rows, err := q.db.Query(ctx, getUserHistories, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []History
-	for rows.Next() {
-		var i History
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Name,
-			&i.Type,
-			&i.Category,
-			&i.Amount,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type RevokeParticipationParams struct {
+	ID         int64
+	Note       pgtype.Text
+	ReviewedBy pgtype.Int8
 }
 
-const getUserPendingAttendances = `-- name: GetUserPendingAttendances :many
-SELECT 
-    a.id AS attendance_id,
-    e.id AS event_id,
-    a.created_at AS registered_at,
-    a.contact_number,
-    e.location,
-    e.latitude,
-    e.longitude,
-    e.contact,
-    e.starts_at,
-    e.ends_at,
-    e.cover_key,
-    d.name AS detail_name,
-    d.description AS detail_description,
-    d.point_gain
-FROM attendances a
-JOIN events e ON a.event_id = e.id
-JOIN details d ON e.detail_id = d.id
-WHERE a.user_id = $1 AND a.attended = false
-`
+type RevokeParticipationRow struct {
+	UserID        int64
+	RevokedPoints int32
+}
 
-type GetUserPendingAttendancesRow struct {
-	AttendanceID      int64
-	EventID           int64
-	RegisteredAt      pgtype.Timestamp
-	ContactNumber     string
-	Location          string
-	Latitude          float64
-	Longitude         float64
-	Contact           string
-	StartsAt          pgtype.Timestamp
-	EndsAt            pgtype.Timestamp
-	CoverKey          pgtype.Text
-	DetailName        string
-	DetailDescription string
-	PointGain         int64
+func (q *Queries) RevokeParticipation(ctx context.Context, arg RevokeParticipationParams) (RevokeParticipationRow, error) {
+	row := q.db.QueryRow(ctx, revokeParticipation, arg.ID, arg.Note, arg.ReviewedBy)
+	var i RevokeParticipationRow
+	err := row.Scan(&i.UserID, &i.RevokedPoints)
+	return i, err
 }
 
-func (q *Queries) GetUserPendingAttendances(ctx context.Context, userID int64) ([]GetUserPendingAttendancesRow, error) {
-	rows, err := q.db.Query(ctx, getUserPendingAttendances, userID)
+const searchEvents = `-- name: SearchEvents :many
+SELECT
+  e.id,
+  e.location,
+  e.latitude,
+  e.longitude,
+  e.contact,
+  e.starts_at,
+  e.ends_at,
+  e.cover_key,
+  d.name AS detail_name,
+  d.description AS detail_description,
+  d.point_gain,
+  d.created_at AS detail_created_at,
+  (e.ends_at < NOW()) AS ended,
+  e.max_attendees,
+  e.attendee_count,
+  COALESCE(e.registration_closes_at, e.starts_at)::timestamp AS registration_closes_at,
+  (NOW() >= COALESCE(e.registration_closes_at, e.starts_at)) AS registration_closed,
+  COALESCE(r.average, 0)::float8 AS rating_average,
+  COALESCE(r.total, 0)::int AS rating_count,
+  (CASE WHEN $1::bool AND e.latitude IS NOT NULL AND e.longitude IS NOT NULL THEN
+    earth_distance(
+      ll_to_earth($2::float8, $3::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    )
+  END)::float8 AS distance_meters
+FROM events e
+JOIN details d ON e.detail_id = d.id
+LEFT JOIN LATERAL (
+  SELECT AVG(f.rating) AS average, COUNT(*) AS total
+  FROM event_feedbacks f
+  WHERE f.event_id = e.id
+) r ON true
+WHERE (
+    $4::text = ''
+    OR ($4::text = 'upcoming' AND e.starts_at > NOW())
+    OR ($4::text = 'ongoing' AND e.starts_at <= NOW() AND e.ends_at >= NOW())
+    OR ($4::text = 'past' AND e.ends_at < NOW())
+  )
+  AND ($5::timestamp IS NULL OR e.ends_at >= $5::timestamp)
+  AND ($6::timestamp IS NULL OR e.starts_at < $6::timestamp)
+  AND (
+    $7::text = ''
+    OR d.name ILIKE '%' || $7::text || '%'
+    OR d.description ILIKE '%' || $7::text || '%'
+  )
+  AND (
+    NOT $1::bool
+    OR (
+      e.latitude IS NOT NULL
+      AND e.longitude IS NOT NULL
+      AND earth_distance(
+      ll_to_earth($2::float8, $3::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) <= $8::float8
+    )
+  )
+ORDER BY
+  CASE WHEN $9::text = 'distance' AND $1::bool THEN earth_distance(
+      ll_to_earth($2::float8, $3::float8),
+      ll_to_earth(e.latitude, e.longitude)
+    ) END,
+  CASE WHEN $9::text = 'newest' THEN d.created_at END DESC,
+  CASE WHEN $9::text = 'date_desc' THEN e.starts_at END DESC,
+  e.starts_at ASC,
+  e.id ASC
+LIMIT $10::int OFFSET $11::int
+`
+
+type SearchEventsParams struct {
+	HasLocation bool
+	Latitude    float64
+	Longitude   float64
+	Status      string
+	DateFrom    pgtype.Timestamp
+	DateTo      pgtype.Timestamp
+	Search      string
+	Radius      float64
+	SortBy      string
+	PageLimit   int32
+	PageOffset  int32
+}
+
+type SearchEventsRow struct {
+	ID                   int64
+	Location             string
+	Latitude             float64
+	Longitude            float64
+	Contact              string
+	StartsAt             pgtype.Timestamp
+	EndsAt               pgtype.Timestamp
+	CoverKey             pgtype.Text
+	DetailName           string
+	DetailDescription    string
+	PointGain            int64
+	DetailCreatedAt      pgtype.Timestamp
+	Ended                bool
+	MaxAttendees         pgtype.Int4
+	AttendeeCount        int32
+	RegistrationClosesAt pgtype.Timestamp
+	RegistrationClosed   bool
+	RatingAverage        float64
+	RatingCount          int32
+	DistanceMeters       pgtype.Float8
+}
+
+func (q *Queries) SearchEvents(ctx context.Context, arg SearchEventsParams) ([]SearchEventsRow, error) {
+	rows, err := q.db.Query(ctx, searchEvents,
+		arg.HasLocation,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Status,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.Search,
+		arg.Radius,
+		arg.SortBy,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetUserPendingAttendancesRow
+	var items []SearchEventsRow
 	for rows.Next() {
-		var i GetUserPendingAttendancesRow
+		var i SearchEventsRow
 		if err := rows.Scan(
-			&i.AttendanceID,
-			&i.EventID,
-			&i.RegisteredAt,
-			&i.ContactNumber,
+			&i.ID,
 			&i.Location,
 			&i.Latitude,
 			&i.Longitude,
@@ -3074,148 +7869,87 @@ func (q *Queries) GetUserPendingAttendances(ctx context.Context, userID int64) (
 			&i.DetailName,
 			&i.DetailDescription,
 			&i.PointGain,
+			&i.DetailCreatedAt,
+			&i.Ended,
+			&i.MaxAttendees,
+			&i.AttendeeCount,
+			&i.RegistrationClosesAt,
+			&i.RegistrationClosed,
+			&i.RatingAverage,
+			&i.RatingCount,
+			&i.DistanceMeters,
 		); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getUserProfile = `-- name: GetUserProfile :one
-SELECT p.id, p.user_id, p.current_exp, p.exp_needed, p.level, p.points, p.profile_key
-FROM profiles p
-JOIN users u ON p.user_id = u.id
-WHERE p.user_id = $1
-`
-
-func (q *Queries) GetUserProfile(ctx context.Context, userID int64) (Profile, error) {
-	row := q.db.QueryRow(ctx, getUserProfile, userID)
-	var i Profile
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.CurrentExp,
-		&i.ExpNeeded,
-		&i.Level,
-		&i.Points,
-		&i.ProfileKey,
-	)
-	return i, err
-}
-
-const getUserProfileStatistic = `-- name: GetUserProfileStatistic :one
-SELECT 
-    u.id AS user_id,
-    u.name,
-    u.username,
-    u.email,
-    p.current_exp,
-    p.exp_needed,
-    p.level,
-    p.points,
-    p.profile_key,
-    s.challenges,
-    s.events,
-    s.quests,
-    s.treasures,
-    s.longest_streak,
-    s.tree_grown
-FROM users u
-JOIN profiles p ON u.id = p.user_id
-JOIN statistics s ON u.id = s.user_id
-WHERE u.id = $1
-`
-
-type GetUserProfileStatisticRow struct {
-	UserID        int64
-	Name          string
-	Username      string
-	Email         string
-	CurrentExp    int64
-	ExpNeeded     int64
-	Level         int32
-	Points        int64
-	ProfileKey    string
-	Challenges    int32
-	Events        int32
-	Quests        int32
-	Treasures     int32
-	LongestStreak int32
-	TreeGrown     int32
-}
-
-func (q *Queries) GetUserProfileStatistic(ctx context.Context, id int64) (GetUserProfileStatisticRow, error) {
-	row := q.db.QueryRow(ctx, getUserProfileStatistic, id)
-	var i GetUserProfileStatisticRow
-	err := row.Scan(
-		&i.UserID,
-		&i.Name,
-		&i.Username,
-		&i.Email,
-		&i.CurrentExp,
-		&i.ExpNeeded,
-		&i.Level,
-		&i.Points,
-		&i.ProfileKey,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getUserStatistic = `-- name: GetUserStatistic :one
-SELECT id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown FROM statistics WHERE user_id = $1
+const searchGreenprintCatalog = `-- name: SearchGreenprintCatalog :many
+SELECT
+  greenprints.id,
+  greenprints.title,
+  greenprints.description,
+  greenprints.sustainability_score,
+  greenprints.estimated_time,
+  items.name AS item_name,
+  COALESCE(AVG(greenprint_ratings.rating), 0)::float8 AS rating,
+  COUNT(greenprint_ratings.id) AS rating_count,
+  (SELECT COUNT(*) FROM greenprints copies WHERE copies.source_id = greenprints.id) AS uses,
+  greenprints.created_at
+FROM greenprints
+JOIN items ON items.id = greenprints.item_id
+LEFT JOIN greenprint_ratings ON greenprint_ratings.greenprint_id = greenprints.id
+WHERE greenprints.is_public = true
+  AND greenprints.source_id IS NULL
+  AND ($1::text = '' OR to_tsvector('simple', greenprints.search_text) @@ plainto_tsquery('simple', $1::text))
+GROUP BY greenprints.id, items.name
+ORDER BY rating DESC, rating_count DESC, greenprints.created_at DESC
+LIMIT $2::int OFFSET $3::int
 `
 
-func (q *Queries) GetUserStatistic(ctx context.Context, userID int64) (Statistic, error) {
-	row := q.db.QueryRow(ctx, getUserStatistic, userID)
-	var i Statistic
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
+type SearchGreenprintCatalogParams struct {
+	Query      string
+	PageLimit  int32
+	PageOffset int32
 }
 
-const getWeeklyRecaps = `-- name: GetWeeklyRecaps :many
-SELECT id, user_id, summary, tips, assigned_task, completed_task, completion_rate, growth_rating, type, created_at FROM recaps
-WHERE user_id = $1 AND type = 'weekly'
-ORDER BY created_at DESC
-`
+type SearchGreenprintCatalogRow struct {
+	ID                  int64
+	Title               string
+	Description         string
+	SustainabilityScore string
+	EstimatedTime       string
+	ItemName            string
+	Rating              float64
+	RatingCount         int64
+	Uses                int64
+	CreatedAt           pgtype.Timestamp
+}
 
-func (q *Queries) GetWeeklyRecaps(ctx context.Context, userID int64) ([]Recap, error) {
-	rows, err := q.db.Query(ctx, getWeeklyRecaps, userID)
+func (q *Queries) SearchGreenprintCatalog(ctx context.Context, arg SearchGreenprintCatalogParams) ([]SearchGreenprintCatalogRow, error) {
+	rows, err := q.db.Query(ctx, searchGreenprintCatalog, arg.Query, arg.PageLimit, arg.PageOffset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Recap
+	var items []SearchGreenprintCatalogRow
 	for rows.Next() {
-		var i Recap
+		var i SearchGreenprintCatalogRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.Summary,
-			&i.Tips,
-			&i.AssignedTask,
-			&i.CompletedTask,
-			&i.CompletionRate,
-			&i.GrowthRating,
-			&i.Type,
+			&i.Title,
+			&i.Description,
+			&i.SustainabilityScore,
+			&i.EstimatedTime,
+			&i.ItemName,
+			&i.Rating,
+			&i.RatingCount,
+			&i.Uses,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -3228,212 +7962,113 @@ func (q *Queries) GetWeeklyRecaps(ctx context.Context, userID int64) ([]Recap, e
 	return items, nil
 }
 
-const increaseChallengesFieldByOne = `-- name: IncreaseChallengesFieldByOne :one
-UPDATE statistics
-SET challenges = challenges + 1
-WHERE user_id = $1
-RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown
+const setParticipationBonus = `-- name: SetParticipationBonus :exec
+UPDATE participations
+SET bonus_points = $1
+WHERE id = $2
 `
 
-func (q *Queries) IncreaseChallengesFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
-	row := q.db.QueryRow(ctx, increaseChallengesFieldByOne, userID)
-	var i Statistic
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
+type SetParticipationBonusParams struct {
+	BonusPoints int32
+	ID          int64
 }
 
-const increaseEventsFieldByOne = `-- name: IncreaseEventsFieldByOne :one
-UPDATE statistics
-SET events = events + 1
-WHERE user_id = $1
-RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown
-`
-
-func (q *Queries) IncreaseEventsFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
-	row := q.db.QueryRow(ctx, increaseEventsFieldByOne, userID)
-	var i Statistic
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
+func (q *Queries) SetParticipationBonus(ctx context.Context, arg SetParticipationBonusParams) error {
+	_, err := q.db.Exec(ctx, setParticipationBonus, arg.BonusPoints, arg.ID)
+	return err
 }
 
-const increaseExp = `-- name: IncreaseExp :one
-UPDATE profiles 
-SET current_exp = current_exp + $1::int
-WHERE user_id = $2::int
-RETURNING current_exp, exp_needed, level
+const setParticipationPointGain = `-- name: SetParticipationPointGain :exec
+UPDATE participations
+SET point_gain = $1
+WHERE id = $2
 `
 
-type IncreaseExpParams struct {
-	ExpGain int32
-	UserID  int32
-}
-
-type IncreaseExpRow struct {
-	CurrentExp int64
-	ExpNeeded  int64
-	Level      int32
-}
-
-func (q *Queries) IncreaseExp(ctx context.Context, arg IncreaseExpParams) (IncreaseExpRow, error) {
-	row := q.db.QueryRow(ctx, increaseExp, arg.ExpGain, arg.UserID)
-	var i IncreaseExpRow
-	err := row.Scan(&i.CurrentExp, &i.ExpNeeded, &i.Level)
-	return i, err
+type SetParticipationPointGainParams struct {
+	PointGain int32
+	ID        int64
 }
 
-const increasePacketCompletedTask = `-- name: IncreasePacketCompletedTask :exec
-UPDATE packets
-SET completed_task = completed_task + 1
-WHERE id = $1
-`
-
-func (q *Queries) IncreasePacketCompletedTask(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, increasePacketCompletedTask, id)
+func (q *Queries) SetParticipationPointGain(ctx context.Context, arg SetParticipationPointGainParams) error {
+	_, err := q.db.Exec(ctx, setParticipationPointGain, arg.PointGain, arg.ID)
 	return err
 }
 
-const increaseQuestsFieldByOne = `-- name: IncreaseQuestsFieldByOne :one
-UPDATE statistics
-SET quests = quests + 1
-WHERE user_id = $1
-RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown
+const settleChallengeBonus = `-- name: SettleChallengeBonus :execrows
+UPDATE challenges
+SET bonus_settled_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND bonus_settled_at IS NULL
 `
 
-func (q *Queries) IncreaseQuestsFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
-	row := q.db.QueryRow(ctx, increaseQuestsFieldByOne, userID)
-	var i Statistic
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
+func (q *Queries) SettleChallengeBonus(ctx context.Context, iD int64) (int64, error) {
+	result, err := q.db.Exec(ctx, settleChallengeBonus, iD)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const increaseRegionTreeAmount = `-- name: IncreaseRegionTreeAmount :exec
-UPDATE regions
-SET tree_amount = tree_amount + $1
-WHERE id = $2
+const unlockHabit = `-- name: UnlockHabit :exec
+UPDATE habits
+SET locked = false
+WHERE id = $1
 `
 
-type IncreaseRegionTreeAmountParams struct {
-	TreeAmount int32
-	ID         int64
-}
-
-func (q *Queries) IncreaseRegionTreeAmount(ctx context.Context, arg IncreaseRegionTreeAmountParams) error {
-	_, err := q.db.Exec(ctx, increaseRegionTreeAmount, arg.TreeAmount, arg.ID)
+func (q *Queries) UnlockHabit(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, unlockHabit, id)
 	return err
 }
 
-const increaseTreasuresFieldByOne = `-- name: IncreaseTreasuresFieldByOne :one
-UPDATE statistics
-SET treasures = treasures + 1
-WHERE user_id = $1
-RETURNING id, user_id, challenges, events, quests, treasures, longest_streak, tree_grown
-`
-
-func (q *Queries) IncreaseTreasuresFieldByOne(ctx context.Context, userID int64) (Statistic, error) {
-	row := q.db.QueryRow(ctx, increaseTreasuresFieldByOne, userID)
-	var i Statistic
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Challenges,
-		&i.Events,
-		&i.Quests,
-		&i.Treasures,
-		&i.LongestStreak,
-		&i.TreeGrown,
-	)
-	return i, err
-}
-
-const increaseUserPoints = `-- name: IncreaseUserPoints :one
-UPDATE profiles
-SET points = points + $1
-WHERE user_id = $2
-RETURNING id, user_id, current_exp, exp_needed, level, points, profile_key
+const updateCodeToken = `-- name: UpdateCodeToken :exec
+UPDATE codes
+SET token_id = $2, rotation_seconds = $3, image_url = $4
+WHERE id = $1
 `
 
-type IncreaseUserPointsParams struct {
-	Points int64
-	UserID int64
+type UpdateCodeTokenParams struct {
+	ID              string
+	TokenID         pgtype.Text
+	RotationSeconds pgtype.Int4
+	ImageUrl        string
 }
 
-func (q *Queries) IncreaseUserPoints(ctx context.Context, arg IncreaseUserPointsParams) (Profile, error) {
-	row := q.db.QueryRow(ctx, increaseUserPoints, arg.Points, arg.UserID)
-	var i Profile
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.CurrentExp,
-		&i.ExpNeeded,
-		&i.Level,
-		&i.Points,
-		&i.ProfileKey,
+func (q *Queries) UpdateCodeToken(ctx context.Context, arg UpdateCodeTokenParams) error {
+	_, err := q.db.Exec(ctx, updateCodeToken,
+		arg.ID,
+		arg.TokenID,
+		arg.RotationSeconds,
+		arg.ImageUrl,
 	)
-	return i, err
+	return err
 }
 
-const increaseUserTreeGrownm = `-- name: IncreaseUserTreeGrownm :exec
-UPDATE statistics
-SET tree_grown = tree_grown + $1
-WHERE user_id = $2
+const updateGreenprintSearchText = `-- name: UpdateGreenprintSearchText :exec
+UPDATE greenprints
+SET search_text = concat_ws(' ', items.name, greenprints.title, (
+  SELECT string_agg(materials.name, ' ') FROM materials WHERE materials.greenprint_id = greenprints.id
+))
+FROM items
+WHERE items.id = greenprints.item_id AND greenprints.id = $1
 `
 
-type IncreaseUserTreeGrownmParams struct {
-	TreeGrown int32
-	UserID    int64
-}
-
-func (q *Queries) IncreaseUserTreeGrownm(ctx context.Context, arg IncreaseUserTreeGrownmParams) error {
-	_, err := q.db.Exec(ctx, increaseUserTreeGrownm, arg.TreeGrown, arg.UserID)
+func (q *Queries) UpdateGreenprintSearchText(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, updateGreenprintSearchText, id)
 	return err
 }
 
-const unlockHabit = `-- name: UnlockHabit :exec
-UPDATE habits
-SET locked = false
-WHERE id = $1
+const updateGreenprintVisibility = `-- name: UpdateGreenprintVisibility :exec
+UPDATE greenprints
+SET is_public = $1
+WHERE id = $2
 `
 
-func (q *Queries) UnlockHabit(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, unlockHabit, id)
-	return err
+type UpdateGreenprintVisibilityParams struct {
+	IsPublic bool
+	ID       int64
 }
 
-const updaAttendedAt = `-- name: UpdaAttendedAt :exec
-UPDATE attendances
-SET attended_at = CURRENT_TIMESTAMP
-WHERE id = $1
-`
-
-func (q *Queries) UpdaAttendedAt(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, updaAttendedAt, id)
+func (q *Queries) UpdateGreenprintVisibility(ctx context.Context, arg UpdateGreenprintVisibilityParams) error {
+	_, err := q.db.Exec(ctx, updateGreenprintVisibility, arg.IsPublic, arg.ID)
 	return err
 }
 
@@ -3486,3 +8121,72 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 	_, err := q.db.Exec(ctx, updateUserProfile, arg.ProfileKey, arg.UserID)
 	return err
 }
+
+const upsertCalendarFeed = `-- name: UpsertCalendarFeed :one
+INSERT INTO calendar_feeds(user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET token = EXCLUDED.token, created_at = CURRENT_TIMESTAMP
+RETURNING id, user_id, token, created_at
+`
+
+type UpsertCalendarFeedParams struct {
+	UserID int64
+	Token  string
+}
+
+func (q *Queries) UpsertCalendarFeed(ctx context.Context, arg UpsertCalendarFeedParams) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, upsertCalendarFeed, arg.UserID, arg.Token)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Token,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertGreenprintRating = `-- name: UpsertGreenprintRating :exec
+INSERT INTO greenprint_ratings(greenprint_id, user_id, rating, created_at, updated_at)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (greenprint_id, user_id)
+DO UPDATE SET rating = EXCLUDED.rating, updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertGreenprintRatingParams struct {
+	GreenprintID int64
+	UserID       int64
+	Rating       int32
+}
+
+func (q *Queries) UpsertGreenprintRating(ctx context.Context, arg UpsertGreenprintRatingParams) error {
+	_, err := q.db.Exec(ctx, upsertGreenprintRating, arg.GreenprintID, arg.UserID, arg.Rating)
+	return err
+}
+
+const upsertParticipationReaction = `-- name: UpsertParticipationReaction :one
+INSERT INTO participation_reactions(participation_id, user_id, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (participation_id, user_id) DO UPDATE SET reaction = EXCLUDED.reaction
+RETURNING id, participation_id, user_id, reaction, created_at
+`
+
+type UpsertParticipationReactionParams struct {
+	ParticipationID int64
+	UserID          int64
+	Reaction        string
+}
+
+func (q *Queries) UpsertParticipationReaction(ctx context.Context, arg UpsertParticipationReactionParams) (ParticipationReaction, error) {
+	row := q.db.QueryRow(ctx, upsertParticipationReaction, arg.ParticipationID, arg.UserID, arg.Reaction)
+	var i ParticipationReaction
+	err := row.Scan(
+		&i.ID,
+		&i.ParticipationID,
+		&i.UserID,
+		&i.Reaction,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Tx runs fn inside a single transaction. It commits when fn returns nil and
+// rolls back otherwise.
+func (q *Queries) Tx(ctx context.Context, fn func(*Queries) error) error {
+	db, ok := q.db.(txBeginner)
+	if !ok {
+		return fmt.Errorf("repositories: %T cannot begin a transaction", q.db)
+	}
+
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		return fn(q.WithTx(tx))
+	})
+}
@@ -51,25 +51,29 @@ SET default_tablespace = '';
 SET default_table_access_method = heap;
 
 --
--- Name: attendances; Type: TABLE; Schema: public; Owner: -
+-- Name: activity_scans; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.attendances (
+CREATE TABLE public.activity_scans (
     id bigint NOT NULL,
     user_id bigint NOT NULL,
-    event_id bigint NOT NULL,
-    attended boolean DEFAULT false NOT NULL,
-    contact_number character varying(255) NOT NULL,
+    activity_type character varying(255) NOT NULL,
+    activity_id bigint NOT NULL,
+    latitude double precision,
+    longitude double precision,
+    distance_meters double precision,
+    status character varying(255) NOT NULL,
     created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    attended_at timestamp(0) without time zone
+    CONSTRAINT activity_scans_activity_type_check CHECK (((activity_type)::text = ANY ((ARRAY['quest'::character varying, 'event'::character varying, 'treasure'::character varying])::text[]))),
+    CONSTRAINT activity_scans_status_check CHECK (((status)::text = ANY ((ARRAY['accepted'::character varying, 'flagged'::character varying, 'rejected'::character varying, 'overridden'::character varying])::text[])))
 );
 
 
 --
--- Name: attendances_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: activity_scans_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.attendances_id_seq
+CREATE SEQUENCE public.activity_scans_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -78,52 +82,31 @@ CREATE SEQUENCE public.attendances_id_seq
 
 
 --
--- Name: attendances_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
---
-
-ALTER SEQUENCE public.attendances_id_seq OWNED BY public.attendances.id;
-
-
---
--- Name: cache; Type: TABLE; Schema: public; Owner: -
---
-
-CREATE TABLE public.cache (
-    key character varying(255) NOT NULL,
-    value text NOT NULL,
-    expiration integer NOT NULL
-);
-
-
---
--- Name: cache_locks; Type: TABLE; Schema: public; Owner: -
+-- Name: activity_scans_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-CREATE TABLE public.cache_locks (
-    key character varying(255) NOT NULL,
-    owner character varying(255) NOT NULL,
-    expiration integer NOT NULL
-);
+ALTER SEQUENCE public.activity_scans_id_seq OWNED BY public.activity_scans.id;
 
 
 --
--- Name: challenges; Type: TABLE; Schema: public; Owner: -
+-- Name: activity_token_uses; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.challenges (
+CREATE TABLE public.activity_token_uses (
     id bigint NOT NULL,
-    detail_id bigint NOT NULL,
-    day integer NOT NULL,
-    difficulty character varying(255) NOT NULL,
-    CONSTRAINT challenges_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['easy'::character varying, 'normal'::character varying, 'hard'::character varying])::text[])))
+    jti character varying(255) NOT NULL,
+    user_id bigint NOT NULL,
+    code_id character varying(255) NOT NULL,
+    used_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    use_number integer DEFAULT 1 NOT NULL
 );
 
 
 --
--- Name: challenges_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: activity_token_uses_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.challenges_id_seq
+CREATE SEQUENCE public.activity_token_uses_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -132,29 +115,32 @@ CREATE SEQUENCE public.challenges_id_seq
 
 
 --
--- Name: challenges_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: activity_token_uses_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.challenges_id_seq OWNED BY public.challenges.id;
+ALTER SEQUENCE public.activity_token_uses_id_seq OWNED BY public.activity_token_uses.id;
 
 
 --
--- Name: claimed; Type: TABLE; Schema: public; Owner: -
+-- Name: ai_quotas; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.claimed (
+CREATE TABLE public.ai_quotas (
     id bigint NOT NULL,
     user_id bigint NOT NULL,
-    treasure_id bigint NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    feature character varying(255) NOT NULL,
+    daily_limit integer,
+    monthly_limit integer,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
 );
 
 
 --
--- Name: claimed_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: ai_quotas_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.claimed_id_seq
+CREATE SEQUENCE public.ai_quotas_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -163,39 +149,39 @@ CREATE SEQUENCE public.claimed_id_seq
 
 
 --
--- Name: claimed_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
---
-
-ALTER SEQUENCE public.claimed_id_seq OWNED BY public.claimed.id;
-
-
---
--- Name: codes; Type: TABLE; Schema: public; Owner: -
+-- Name: ai_quotas_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-CREATE TABLE public.codes (
-    id character varying(255) NOT NULL,
-    image_url character varying(255) NOT NULL
-);
+ALTER SEQUENCE public.ai_quotas_id_seq OWNED BY public.ai_quotas.id;
 
 
 --
--- Name: contributions; Type: TABLE; Schema: public; Owner: -
+-- Name: ai_usages; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.contributions (
+CREATE TABLE public.ai_usages (
     id bigint NOT NULL,
-    quest_id bigint NOT NULL,
     user_id bigint NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    feature character varying(255) NOT NULL,
+    provider character varying(255) NOT NULL,
+    model character varying(255) NOT NULL,
+    prompt_tokens integer DEFAULT 0 NOT NULL,
+    completion_tokens integer DEFAULT 0 NOT NULL,
+    total_tokens integer DEFAULT 0 NOT NULL,
+    latency_ms integer DEFAULT 0 NOT NULL,
+    cost double precision DEFAULT '0'::double precision NOT NULL,
+    status character varying(255) NOT NULL,
+    error text,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT ai_usages_status_check CHECK (((status)::text = ANY ((ARRAY['success'::character varying, 'failed'::character varying])::text[])))
 );
 
 
 --
--- Name: contributions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: ai_usages_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.contributions_id_seq
+CREATE SEQUENCE public.ai_usages_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -204,31 +190,32 @@ CREATE SEQUENCE public.contributions_id_seq
 
 
 --
--- Name: contributions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: ai_usages_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.contributions_id_seq OWNED BY public.contributions.id;
+ALTER SEQUENCE public.ai_usages_id_seq OWNED BY public.ai_usages.id;
 
 
 --
--- Name: details; Type: TABLE; Schema: public; Owner: -
+-- Name: attendances; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.details (
+CREATE TABLE public.attendances (
     id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    description text NOT NULL,
-    point_gain bigint NOT NULL,
-    created_at timestamp(0) without time zone,
-    updated_at timestamp(0) without time zone
+    user_id bigint NOT NULL,
+    event_id bigint NOT NULL,
+    attended boolean DEFAULT false NOT NULL,
+    contact_number character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    attended_at timestamp(0) without time zone
 );
 
 
 --
--- Name: details_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: attendances_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.details_id_seq
+CREATE SEQUENCE public.attendances_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -237,35 +224,33 @@ CREATE SEQUENCE public.details_id_seq
 
 
 --
--- Name: details_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: attendances_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.details_id_seq OWNED BY public.details.id;
+ALTER SEQUENCE public.attendances_id_seq OWNED BY public.attendances.id;
 
 
 --
--- Name: events; Type: TABLE; Schema: public; Owner: -
+-- Name: audit_logs; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.events (
+CREATE TABLE public.audit_logs (
     id bigint NOT NULL,
-    detail_id bigint NOT NULL,
-    code_id character varying(255) NOT NULL,
-    location text NOT NULL,
-    latitude numeric(10,7),
-    longitude numeric(10,7),
-    contact character varying(255) NOT NULL,
-    starts_at timestamp(0) without time zone NOT NULL,
-    ends_at timestamp(0) without time zone NOT NULL,
-    cover_key character varying(255)
+    user_id bigint,
+    action character varying(255) NOT NULL,
+    subject_type character varying(255) NOT NULL,
+    subject_id bigint NOT NULL,
+    detail text DEFAULT ''::text NOT NULL,
+    ip_address character varying(45) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: audit_logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.events_id_seq
+CREATE SEQUENCE public.audit_logs_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -274,67 +259,51 @@ CREATE SEQUENCE public.events_id_seq
 
 
 --
--- Name: events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: audit_logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.events_id_seq OWNED BY public.events.id;
+ALTER SEQUENCE public.audit_logs_id_seq OWNED BY public.audit_logs.id;
 
 
 --
--- Name: failed_jobs; Type: TABLE; Schema: public; Owner: -
+-- Name: cache; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.failed_jobs (
-    id bigint NOT NULL,
-    uuid character varying(255) NOT NULL,
-    connection text NOT NULL,
-    queue text NOT NULL,
-    payload text NOT NULL,
-    exception text NOT NULL,
-    failed_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+CREATE TABLE public.cache (
+    key character varying(255) NOT NULL,
+    value text NOT NULL,
+    expiration integer NOT NULL
 );
 
 
 --
--- Name: failed_jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
---
-
-CREATE SEQUENCE public.failed_jobs_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
-
-
---
--- Name: failed_jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: cache_locks; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.failed_jobs_id_seq OWNED BY public.failed_jobs.id;
+CREATE TABLE public.cache_locks (
+    key character varying(255) NOT NULL,
+    owner character varying(255) NOT NULL,
+    expiration integer NOT NULL
+);
 
 
 --
--- Name: greenprints; Type: TABLE; Schema: public; Owner: -
+-- Name: calendar_feeds; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.greenprints (
+CREATE TABLE public.calendar_feeds (
     id bigint NOT NULL,
-    item_id bigint NOT NULL,
-    image_key character varying(255) NOT NULL,
-    title character varying(255) NOT NULL,
-    description text NOT NULL,
-    sustainability_score character varying(255) NOT NULL,
-    estimated_time character varying(255) NOT NULL,
+    user_id bigint NOT NULL,
+    token character varying(64) NOT NULL,
     created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: greenprints_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: calendar_feeds_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.greenprints_id_seq
+CREATE SEQUENCE public.calendar_feeds_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -343,33 +312,35 @@ CREATE SEQUENCE public.greenprints_id_seq
 
 
 --
--- Name: greenprints_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: calendar_feeds_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.greenprints_id_seq OWNED BY public.greenprints.id;
+ALTER SEQUENCE public.calendar_feeds_id_seq OWNED BY public.calendar_feeds.id;
 
 
 --
--- Name: habits; Type: TABLE; Schema: public; Owner: -
+-- Name: certificates; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.habits (
+CREATE TABLE public.certificates (
     id bigint NOT NULL,
-    packet_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    description text NOT NULL,
-    difficulty character varying(255) NOT NULL,
-    locked boolean NOT NULL,
-    weight integer NOT NULL,
-    CONSTRAINT habits_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['hard'::character varying, 'normal'::character varying, 'easy'::character varying])::text[])))
+    code character varying(20) NOT NULL,
+    user_id bigint NOT NULL,
+    event_id bigint,
+    period_from date,
+    period_to date,
+    event_count integer NOT NULL,
+    total_minutes integer NOT NULL,
+    image_key character varying(255) NOT NULL,
+    issued_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: habits_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: certificates_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.habits_id_seq
+CREATE SEQUENCE public.certificates_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -378,33 +349,31 @@ CREATE SEQUENCE public.habits_id_seq
 
 
 --
--- Name: habits_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: certificates_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.habits_id_seq OWNED BY public.habits.id;
+ALTER SEQUENCE public.certificates_id_seq OWNED BY public.certificates.id;
 
 
 --
--- Name: histories; Type: TABLE; Schema: public; Owner: -
+-- Name: challenges; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.histories (
+CREATE TABLE public.challenges (
     id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    type character varying(255) NOT NULL,
-    category character varying(255) NOT NULL,
-    amount integer NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    CONSTRAINT histories_type_check CHECK (((type)::text = ANY ((ARRAY['input'::character varying, 'output'::character varying])::text[])))
+    detail_id bigint NOT NULL,
+    day integer NOT NULL,
+    difficulty character varying(255) NOT NULL,
+    bonus_settled_at timestamp(0) without time zone,
+    CONSTRAINT challenges_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['easy'::character varying, 'normal'::character varying, 'hard'::character varying])::text[])))
 );
 
 
 --
--- Name: histories_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: challenges_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.histories_id_seq
+CREATE SEQUENCE public.challenges_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -413,33 +382,30 @@ CREATE SEQUENCE public.histories_id_seq
 
 
 --
--- Name: histories_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: challenges_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.histories_id_seq OWNED BY public.histories.id;
+ALTER SEQUENCE public.challenges_id_seq OWNED BY public.challenges.id;
 
 
 --
--- Name: items; Type: TABLE; Schema: public; Owner: -
+-- Name: claimed; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.items (
+CREATE TABLE public.claimed (
     id bigint NOT NULL,
     user_id bigint NOT NULL,
-    scan_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    description text NOT NULL,
-    value character varying(255) NOT NULL,
+    treasure_id bigint NOT NULL,
     created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    CONSTRAINT items_value_check CHECK (((value)::text = ANY ((ARRAY['high'::character varying, 'mid'::character varying, 'low'::character varying])::text[])))
+    claim_number integer DEFAULT 1 NOT NULL
 );
 
 
 --
--- Name: items_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: claimed_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.items_id_seq
+CREATE SEQUENCE public.claimed_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -448,50 +414,41 @@ CREATE SEQUENCE public.items_id_seq
 
 
 --
--- Name: items_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: claimed_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.items_id_seq OWNED BY public.items.id;
+ALTER SEQUENCE public.claimed_id_seq OWNED BY public.claimed.id;
 
 
 --
--- Name: job_batches; Type: TABLE; Schema: public; Owner: -
+-- Name: codes; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.job_batches (
+CREATE TABLE public.codes (
     id character varying(255) NOT NULL,
-    name character varying(255) NOT NULL,
-    total_jobs integer NOT NULL,
-    pending_jobs integer NOT NULL,
-    failed_jobs integer NOT NULL,
-    failed_job_ids text NOT NULL,
-    options text,
-    cancelled_at integer,
-    created_at integer NOT NULL,
-    finished_at integer
+    image_url character varying(255) NOT NULL,
+    token_id character varying(255),
+    rotation_seconds integer
 );
 
 
 --
--- Name: jobs; Type: TABLE; Schema: public; Owner: -
+-- Name: contributions; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.jobs (
+CREATE TABLE public.contributions (
     id bigint NOT NULL,
-    queue character varying(255) NOT NULL,
-    payload text NOT NULL,
-    attempts smallint NOT NULL,
-    reserved_at integer,
-    available_at integer NOT NULL,
-    created_at integer NOT NULL
+    quest_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: contributions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.jobs_id_seq
+CREATE SEQUENCE public.contributions_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -500,31 +457,32 @@ CREATE SEQUENCE public.jobs_id_seq
 
 
 --
--- Name: jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: contributions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.jobs_id_seq OWNED BY public.jobs.id;
+ALTER SEQUENCE public.contributions_id_seq OWNED BY public.contributions.id;
 
 
 --
--- Name: logs; Type: TABLE; Schema: public; Owner: -
+-- Name: deposit_items; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.logs (
+CREATE TABLE public.deposit_items (
     id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    text text NOT NULL,
-    is_system boolean DEFAULT false NOT NULL,
-    is_private boolean NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    deposit_id bigint NOT NULL,
+    item_id bigint,
+    material character varying(255) NOT NULL,
+    weight double precision NOT NULL,
+    price_per_kg integer NOT NULL,
+    subtotal integer NOT NULL
 );
 
 
 --
--- Name: logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: deposit_items_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.logs_id_seq
+CREATE SEQUENCE public.deposit_items_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -533,31 +491,37 @@ CREATE SEQUENCE public.logs_id_seq
 
 
 --
--- Name: logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: deposit_items_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.logs_id_seq OWNED BY public.logs.id;
+ALTER SEQUENCE public.deposit_items_id_seq OWNED BY public.deposit_items.id;
 
 
 --
--- Name: materials; Type: TABLE; Schema: public; Owner: -
+-- Name: deposits; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.materials (
+CREATE TABLE public.deposits (
     id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    description text NOT NULL,
-    price integer NOT NULL,
-    quantity integer NOT NULL,
-    greenprint_id bigint NOT NULL
+    user_id bigint NOT NULL,
+    waste_bank_id bigint NOT NULL,
+    status character varying(255) DEFAULT 'pending'::character varying NOT NULL,
+    total_weight double precision DEFAULT '0'::double precision NOT NULL,
+    payout integer DEFAULT 0 NOT NULL,
+    point_gain integer DEFAULT 0 NOT NULL,
+    note text,
+    confirmed_by bigint,
+    confirmed_at timestamp(0) without time zone,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT deposits_status_check CHECK (((status)::text = ANY ((ARRAY['pending'::character varying, 'confirmed'::character varying, 'rejected'::character varying])::text[])))
 );
 
 
 --
--- Name: materials_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: deposits_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.materials_id_seq
+CREATE SEQUENCE public.deposits_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -566,30 +530,31 @@ CREATE SEQUENCE public.materials_id_seq
 
 
 --
--- Name: materials_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: deposits_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.materials_id_seq OWNED BY public.materials.id;
+ALTER SEQUENCE public.deposits_id_seq OWNED BY public.deposits.id;
 
 
 --
--- Name: memories; Type: TABLE; Schema: public; Owner: -
+-- Name: details; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.memories (
+CREATE TABLE public.details (
     id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    file_key character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
     description text NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    point_gain bigint NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
 );
 
 
 --
--- Name: memories_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: details_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.memories_id_seq
+CREATE SEQUENCE public.details_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -598,29 +563,30 @@ CREATE SEQUENCE public.memories_id_seq
 
 
 --
--- Name: memories_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: details_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.memories_id_seq OWNED BY public.memories.id;
+ALTER SEQUENCE public.details_id_seq OWNED BY public.details.id;
 
 
 --
--- Name: migrations; Type: TABLE; Schema: public; Owner: -
+-- Name: dropoff_hours; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.migrations (
-    id integer NOT NULL,
-    migration character varying(255) NOT NULL,
-    batch integer NOT NULL
+CREATE TABLE public.dropoff_hours (
+    id bigint NOT NULL,
+    dropoff_point_id bigint NOT NULL,
+    day_of_week smallint NOT NULL,
+    opens_at time(0) without time zone NOT NULL,
+    closes_at time(0) without time zone NOT NULL
 );
 
 
 --
--- Name: migrations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: dropoff_hours_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.migrations_id_seq
-    AS integer
+CREATE SEQUENCE public.dropoff_hours_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -629,35 +595,28 @@ CREATE SEQUENCE public.migrations_id_seq
 
 
 --
--- Name: migrations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: dropoff_hours_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.migrations_id_seq OWNED BY public.migrations.id;
+ALTER SEQUENCE public.dropoff_hours_id_seq OWNED BY public.dropoff_hours.id;
 
 
 --
--- Name: packets; Type: TABLE; Schema: public; Owner: -
+-- Name: dropoff_materials; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.packets (
+CREATE TABLE public.dropoff_materials (
     id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    target character varying(255) NOT NULL,
-    description text NOT NULL,
-    completed_task integer DEFAULT 0 NOT NULL,
-    expected_task integer NOT NULL,
-    task_per_day integer NOT NULL,
-    completed boolean DEFAULT false NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    dropoff_point_id bigint NOT NULL,
+    material character varying(255) NOT NULL
 );
 
 
 --
--- Name: packets_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: dropoff_materials_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.packets_id_seq
+CREATE SEQUENCE public.dropoff_materials_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -666,30 +625,36 @@ CREATE SEQUENCE public.packets_id_seq
 
 
 --
--- Name: packets_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: dropoff_materials_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.packets_id_seq OWNED BY public.packets.id;
+ALTER SEQUENCE public.dropoff_materials_id_seq OWNED BY public.dropoff_materials.id;
 
 
 --
--- Name: participations; Type: TABLE; Schema: public; Owner: -
+-- Name: dropoff_points; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.participations (
+CREATE TABLE public.dropoff_points (
     id bigint NOT NULL,
-    challenge_id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    memory_id bigint NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    name character varying(255) NOT NULL,
+    address text NOT NULL,
+    description text,
+    latitude double precision NOT NULL,
+    longitude double precision NOT NULL,
+    region_id bigint,
+    phone character varying(255),
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
 );
 
 
 --
--- Name: participations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: dropoff_points_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.participations_id_seq
+CREATE SEQUENCE public.dropoff_points_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -698,43 +663,33 @@ CREATE SEQUENCE public.participations_id_seq
 
 
 --
--- Name: participations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
---
-
-ALTER SEQUENCE public.participations_id_seq OWNED BY public.participations.id;
-
-
---
--- Name: password_reset_tokens; Type: TABLE; Schema: public; Owner: -
+-- Name: dropoff_points_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-CREATE TABLE public.password_reset_tokens (
-    email character varying(255) NOT NULL,
-    token character varying(255) NOT NULL,
-    created_at timestamp(0) without time zone
-);
+ALTER SEQUENCE public.dropoff_points_id_seq OWNED BY public.dropoff_points.id;
 
 
 --
--- Name: profiles; Type: TABLE; Schema: public; Owner: -
+-- Name: event_feedbacks; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.profiles (
+CREATE TABLE public.event_feedbacks (
     id bigint NOT NULL,
+    event_id bigint NOT NULL,
     user_id bigint NOT NULL,
-    current_exp bigint DEFAULT '0'::bigint NOT NULL,
-    exp_needed bigint DEFAULT '100'::bigint NOT NULL,
-    level integer DEFAULT 1 NOT NULL,
-    points bigint DEFAULT '0'::bigint NOT NULL,
-    profile_key character varying(255) DEFAULT 'profiles/Portrait_Placeholder.png'::character varying NOT NULL
+    rating smallint NOT NULL,
+    comment text DEFAULT ''::text NOT NULL,
+    photo_key character varying(255),
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT event_feedbacks_rating_check CHECK (((rating >= 1) AND (rating <= 5)))
 );
 
 
 --
--- Name: profiles_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: event_feedbacks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.profiles_id_seq
+CREATE SEQUENCE public.event_feedbacks_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -743,34 +698,28 @@ CREATE SEQUENCE public.profiles_id_seq
 
 
 --
--- Name: profiles_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: event_feedbacks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.profiles_id_seq OWNED BY public.profiles.id;
+ALTER SEQUENCE public.event_feedbacks_id_seq OWNED BY public.event_feedbacks.id;
 
 
 --
--- Name: quests; Type: TABLE; Schema: public; Owner: -
+-- Name: event_organizers; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.quests (
+CREATE TABLE public.event_organizers (
     id bigint NOT NULL,
-    detail_id bigint NOT NULL,
-    code_id character varying(255) NOT NULL,
-    location text NOT NULL,
-    latitude numeric(10,7),
-    longitude numeric(10,7),
-    max_contributors integer NOT NULL,
-    finished boolean DEFAULT false NOT NULL,
-    clue text
+    event_id bigint NOT NULL,
+    user_id bigint NOT NULL
 );
 
 
 --
--- Name: quests_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: event_organizers_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.quests_id_seq
+CREATE SEQUENCE public.event_organizers_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -779,33 +728,30 @@ CREATE SEQUENCE public.quests_id_seq
 
 
 --
--- Name: quests_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: event_organizers_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.quests_id_seq OWNED BY public.quests.id;
+ALTER SEQUENCE public.event_organizers_id_seq OWNED BY public.event_organizers.id;
 
 
 --
--- Name: recap_details; Type: TABLE; Schema: public; Owner: -
+-- Name: event_waitlists; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.recap_details (
+CREATE TABLE public.event_waitlists (
     id bigint NOT NULL,
-    monthly_recap_id bigint NOT NULL,
-    challenges integer DEFAULT 0 NOT NULL,
-    events integer DEFAULT 0 NOT NULL,
-    quests integer DEFAULT 0 NOT NULL,
-    treasures integer DEFAULT 0 NOT NULL,
-    longest_streak integer DEFAULT 0 NOT NULL,
+    event_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    contact_number character varying(255) NOT NULL,
     created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: recap_details_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: event_waitlists_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.recap_details_id_seq
+CREATE SEQUENCE public.event_waitlists_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -814,36 +760,40 @@ CREATE SEQUENCE public.recap_details_id_seq
 
 
 --
--- Name: recap_details_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: event_waitlists_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.recap_details_id_seq OWNED BY public.recap_details.id;
+ALTER SEQUENCE public.event_waitlists_id_seq OWNED BY public.event_waitlists.id;
 
 
 --
--- Name: recaps; Type: TABLE; Schema: public; Owner: -
+-- Name: events; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.recaps (
+CREATE TABLE public.events (
     id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    summary text NOT NULL,
-    tips text NOT NULL,
-    assigned_task integer NOT NULL,
-    completed_task integer NOT NULL,
-    completion_rate character varying(255) NOT NULL,
-    growth_rating character varying(255) NOT NULL,
-    type character varying(255) NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    CONSTRAINT recaps_type_check CHECK (((type)::text = ANY ((ARRAY['weekly'::character varying, 'monthly'::character varying])::text[])))
+    detail_id bigint NOT NULL,
+    code_id character varying(255) NOT NULL,
+    location text NOT NULL,
+    latitude numeric(10,7),
+    longitude numeric(10,7),
+    contact character varying(255) NOT NULL,
+    starts_at timestamp(0) without time zone NOT NULL,
+    ends_at timestamp(0) without time zone NOT NULL,
+    cover_key character varying(255),
+    geofence_radius integer,
+    max_attendees integer,
+    attendee_count integer DEFAULT 0 NOT NULL,
+    registration_closes_at timestamp(0) without time zone,
+    credited_minutes integer
 );
 
 
 --
--- Name: recaps_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.recaps_id_seq
+CREATE SEQUENCE public.events_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -852,33 +802,32 @@ CREATE SEQUENCE public.recaps_id_seq
 
 
 --
--- Name: recaps_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.recaps_id_seq OWNED BY public.recaps.id;
+ALTER SEQUENCE public.events_id_seq OWNED BY public.events.id;
 
 
 --
--- Name: regions; Type: TABLE; Schema: public; Owner: -
+-- Name: failed_jobs; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.regions (
+CREATE TABLE public.failed_jobs (
     id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    location text NOT NULL,
-    latitude double precision NOT NULL,
-    longitude double precision NOT NULL,
-    tree_amount integer DEFAULT 0 NOT NULL,
-    created_at timestamp(0) without time zone,
-    updated_at timestamp(0) without time zone
+    uuid character varying(255) NOT NULL,
+    connection text NOT NULL,
+    queue text NOT NULL,
+    payload text NOT NULL,
+    exception text NOT NULL,
+    failed_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
 
 --
--- Name: regions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: failed_jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.regions_id_seq
+CREATE SEQUENCE public.failed_jobs_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -887,31 +836,33 @@ CREATE SEQUENCE public.regions_id_seq
 
 
 --
--- Name: regions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: failed_jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.regions_id_seq OWNED BY public.regions.id;
+ALTER SEQUENCE public.failed_jobs_id_seq OWNED BY public.failed_jobs.id;
 
 
 --
--- Name: scans; Type: TABLE; Schema: public; Owner: -
+-- Name: geofence_overrides; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.scans (
+CREATE TABLE public.geofence_overrides (
     id bigint NOT NULL,
     user_id bigint NOT NULL,
-    title character varying(255) NOT NULL,
-    description text NOT NULL,
-    image_key character varying(255) NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    activity_type character varying(255) NOT NULL,
+    activity_id bigint NOT NULL,
+    reason text,
+    created_by bigint,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT geofence_overrides_activity_type_check CHECK (((activity_type)::text = ANY ((ARRAY['quest'::character varying, 'event'::character varying, 'treasure'::character varying])::text[])))
 );
 
 
 --
--- Name: scans_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: geofence_overrides_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.scans_id_seq
+CREATE SEQUENCE public.geofence_overrides_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -920,47 +871,33 @@ CREATE SEQUENCE public.scans_id_seq
 
 
 --
--- Name: scans_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
---
-
-ALTER SEQUENCE public.scans_id_seq OWNED BY public.scans.id;
-
-
---
--- Name: sessions; Type: TABLE; Schema: public; Owner: -
+-- Name: geofence_overrides_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-CREATE TABLE public.sessions (
-    id character varying(255) NOT NULL,
-    user_id bigint,
-    ip_address character varying(45),
-    user_agent text,
-    payload text NOT NULL,
-    last_activity integer NOT NULL
-);
+ALTER SEQUENCE public.geofence_overrides_id_seq OWNED BY public.geofence_overrides.id;
 
 
 --
--- Name: statistics; Type: TABLE; Schema: public; Owner: -
+-- Name: greenprint_projects; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.statistics (
+CREATE TABLE public.greenprint_projects (
     id bigint NOT NULL,
     user_id bigint NOT NULL,
-    challenges integer DEFAULT 0 NOT NULL,
-    events integer DEFAULT 0 NOT NULL,
-    quests integer DEFAULT 0 NOT NULL,
-    treasures integer DEFAULT 0 NOT NULL,
-    longest_streak integer DEFAULT 0 NOT NULL,
-    tree_grown integer DEFAULT 0 NOT NULL
+    greenprint_id bigint NOT NULL,
+    status character varying(255) DEFAULT 'in_progress'::character varying NOT NULL,
+    proof_key character varying(255),
+    started_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at timestamp(0) without time zone,
+    CONSTRAINT greenprint_projects_status_check CHECK (((status)::text = ANY ((ARRAY['in_progress'::character varying, 'completed'::character varying])::text[])))
 );
 
 
 --
--- Name: statistics_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: greenprint_projects_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.statistics_id_seq
+CREATE SEQUENCE public.greenprint_projects_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -969,29 +906,32 @@ CREATE SEQUENCE public.statistics_id_seq
 
 
 --
--- Name: statistics_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: greenprint_projects_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.statistics_id_seq OWNED BY public.statistics.id;
+ALTER SEQUENCE public.greenprint_projects_id_seq OWNED BY public.greenprint_projects.id;
 
 
 --
--- Name: steps; Type: TABLE; Schema: public; Owner: -
+-- Name: greenprint_ratings; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.steps (
+CREATE TABLE public.greenprint_ratings (
     id bigint NOT NULL,
     greenprint_id bigint NOT NULL,
-    description text NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+    user_id bigint NOT NULL,
+    rating integer NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone,
+    CONSTRAINT greenprint_ratings_rating_check CHECK (((rating >= 1) AND (rating <= 5)))
 );
 
 
 --
--- Name: steps_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: greenprint_ratings_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.steps_id_seq
+CREATE SEQUENCE public.greenprint_ratings_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -1000,36 +940,36 @@ CREATE SEQUENCE public.steps_id_seq
 
 
 --
--- Name: steps_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: greenprint_ratings_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.steps_id_seq OWNED BY public.steps.id;
+ALTER SEQUENCE public.greenprint_ratings_id_seq OWNED BY public.greenprint_ratings.id;
 
 
 --
--- Name: tasks; Type: TABLE; Schema: public; Owner: -
+-- Name: greenprints; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.tasks (
+CREATE TABLE public.greenprints (
     id bigint NOT NULL,
-    habit_id bigint NOT NULL,
-    user_id bigint NOT NULL,
-    packet_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
+    item_id bigint NOT NULL,
+    image_key character varying(255) NOT NULL,
+    title character varying(255) NOT NULL,
     description text NOT NULL,
-    difficulty character varying(255) NOT NULL,
-    completed boolean DEFAULT false NOT NULL,
+    sustainability_score character varying(255) NOT NULL,
+    estimated_time character varying(255) NOT NULL,
     created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    updated_at timestamp(0) without time zone,
-    CONSTRAINT tasks_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['hard'::character varying, 'normal'::character varying, 'easy'::character varying])::text[])))
+    is_public boolean DEFAULT false NOT NULL,
+    source_id bigint,
+    search_text text DEFAULT ''::text NOT NULL
 );
 
 
 --
--- Name: tasks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: greenprints_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.tasks_id_seq
+CREATE SEQUENCE public.greenprints_id_seq
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
@@ -1038,737 +978,2734 @@ CREATE SEQUENCE public.tasks_id_seq
 
 
 --
--- Name: tasks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: greenprints_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.tasks_id_seq OWNED BY public.tasks.id;
+ALTER SEQUENCE public.greenprints_id_seq OWNED BY public.greenprints.id;
+
+
+--
+-- Name: habits; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.habits (
+    id bigint NOT NULL,
+    packet_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    difficulty character varying(255) NOT NULL,
+    locked boolean NOT NULL,
+    weight integer NOT NULL,
+    CONSTRAINT habits_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['hard'::character varying, 'normal'::character varying, 'easy'::character varying])::text[])))
+);
+
+
+--
+-- Name: habits_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.habits_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: habits_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.habits_id_seq OWNED BY public.habits.id;
+
+
+--
+-- Name: histories; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.histories (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    type character varying(255) NOT NULL,
+    category character varying(255) NOT NULL,
+    amount integer NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT histories_type_check CHECK (((type)::text = ANY ((ARRAY['input'::character varying, 'output'::character varying])::text[])))
+);
+
+
+--
+-- Name: histories_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.histories_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: histories_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.histories_id_seq OWNED BY public.histories.id;
+
+
+--
+-- Name: item_images; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.item_images (
+    id bigint NOT NULL,
+    item_id bigint NOT NULL,
+    scan_image_id bigint NOT NULL
+);
+
+
+--
+-- Name: item_images_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.item_images_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: item_images_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.item_images_id_seq OWNED BY public.item_images.id;
+
+
+--
+-- Name: items; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.items (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    scan_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    value character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    material character varying(255),
+    CONSTRAINT items_value_check CHECK (((value)::text = ANY ((ARRAY['high'::character varying, 'mid'::character varying, 'low'::character varying])::text[])))
+);
+
+
+--
+-- Name: items_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.items_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: items_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.items_id_seq OWNED BY public.items.id;
+
+
+--
+-- Name: job_batches; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.job_batches (
+    id character varying(255) NOT NULL,
+    name character varying(255) NOT NULL,
+    total_jobs integer NOT NULL,
+    pending_jobs integer NOT NULL,
+    failed_jobs integer NOT NULL,
+    failed_job_ids text NOT NULL,
+    options text,
+    cancelled_at integer,
+    created_at integer NOT NULL,
+    finished_at integer
+);
+
+
+--
+-- Name: jobs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.jobs (
+    id bigint NOT NULL,
+    queue character varying(255) NOT NULL,
+    payload text NOT NULL,
+    attempts smallint NOT NULL,
+    reserved_at integer,
+    available_at integer NOT NULL,
+    created_at integer NOT NULL
+);
+
+
+--
+-- Name: jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.jobs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.jobs_id_seq OWNED BY public.jobs.id;
+
+
+--
+-- Name: logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.logs (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    text text NOT NULL,
+    is_system boolean DEFAULT false NOT NULL,
+    is_private boolean NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.logs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.logs_id_seq OWNED BY public.logs.id;
+
+
+--
+-- Name: materials; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.materials (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    price integer NOT NULL,
+    quantity integer NOT NULL,
+    greenprint_id bigint NOT NULL,
+    step_id bigint
+);
+
+
+--
+-- Name: materials_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.materials_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: materials_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.materials_id_seq OWNED BY public.materials.id;
+
+
+--
+-- Name: memories; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.memories (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    file_key character varying(255) NOT NULL,
+    description text NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    thumbnail_key character varying(255)
+);
+
+
+--
+-- Name: memories_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.memories_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: memories_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.memories_id_seq OWNED BY public.memories.id;
+
+
+--
+-- Name: migrations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.migrations (
+    id integer NOT NULL,
+    migration character varying(255) NOT NULL,
+    batch integer NOT NULL
+);
+
+
+--
+-- Name: migrations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.migrations_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: migrations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.migrations_id_seq OWNED BY public.migrations.id;
+
+
+--
+-- Name: notifications; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.notifications (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    type character varying(255) NOT NULL,
+    title character varying(255) NOT NULL,
+    body text NOT NULL,
+    read_at timestamp(0) without time zone,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: notifications_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.notifications_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: notifications_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.notifications_id_seq OWNED BY public.notifications.id;
+
+
+--
+-- Name: owned_materials; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.owned_materials (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    material_id bigint NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: owned_materials_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.owned_materials_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: owned_materials_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.owned_materials_id_seq OWNED BY public.owned_materials.id;
+
+
+--
+-- Name: owned_tools; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.owned_tools (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    tool_id bigint NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: owned_tools_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.owned_tools_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: owned_tools_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.owned_tools_id_seq OWNED BY public.owned_tools.id;
+
+
+--
+-- Name: packets; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.packets (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    target character varying(255) NOT NULL,
+    description text NOT NULL,
+    completed_task integer DEFAULT 0 NOT NULL,
+    expected_task integer NOT NULL,
+    task_per_day integer NOT NULL,
+    completed boolean DEFAULT false NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: packets_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.packets_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: packets_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.packets_id_seq OWNED BY public.packets.id;
+
+
+--
+-- Name: participation_reactions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.participation_reactions (
+    id bigint NOT NULL,
+    participation_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    reaction character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT participation_reactions_reaction_check CHECK (((reaction)::text = ANY ((ARRAY['like'::character varying, 'love'::character varying, 'inspiring'::character varying, 'clap'::character varying])::text[])))
+);
+
+
+--
+-- Name: participation_reactions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.participation_reactions_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: participation_reactions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.participation_reactions_id_seq OWNED BY public.participation_reactions.id;
+
+
+--
+-- Name: participations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.participations (
+    id bigint NOT NULL,
+    challenge_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    memory_id bigint NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    status character varying(255) DEFAULT 'approved'::character varying NOT NULL,
+    point_gain integer DEFAULT 0 NOT NULL,
+    note text,
+    reviewed_by bigint,
+    reviewed_at timestamp(0) without time zone,
+    uploaded_at timestamp(0) without time zone,
+    bonus_points integer DEFAULT 0 NOT NULL,
+    CONSTRAINT participations_status_check CHECK (((status)::text = ANY ((ARRAY['pending_upload'::character varying, 'pending_review'::character varying, 'approved'::character varying, 'rejected'::character varying])::text[])))
+);
+
+
+--
+-- Name: participations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.participations_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: participations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.participations_id_seq OWNED BY public.participations.id;
+
+
+--
+-- Name: password_reset_tokens; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.password_reset_tokens (
+    email character varying(255) NOT NULL,
+    token character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: profiles; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.profiles (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    current_exp bigint DEFAULT '0'::bigint NOT NULL,
+    exp_needed bigint DEFAULT '100'::bigint NOT NULL,
+    level integer DEFAULT 1 NOT NULL,
+    points bigint DEFAULT '0'::bigint NOT NULL,
+    profile_key character varying(255) DEFAULT 'profiles/Portrait_Placeholder.png'::character varying NOT NULL
+);
+
+
+--
+-- Name: profiles_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.profiles_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: profiles_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.profiles_id_seq OWNED BY public.profiles.id;
+
+
+--
+-- Name: project_steps; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.project_steps (
+    id bigint NOT NULL,
+    project_id bigint NOT NULL,
+    step_id bigint NOT NULL,
+    completed_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: project_steps_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.project_steps_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: project_steps_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.project_steps_id_seq OWNED BY public.project_steps.id;
+
+
+--
+-- Name: quest_chain_completions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.quest_chain_completions (
+    id bigint NOT NULL,
+    chain_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    completed_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: quest_chain_completions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.quest_chain_completions_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: quest_chain_completions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.quest_chain_completions_id_seq OWNED BY public.quest_chain_completions.id;
+
+
+--
+-- Name: quest_chains; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.quest_chains (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    completion_point_gain bigint DEFAULT 0 NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: quest_chains_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.quest_chains_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: quest_chains_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.quest_chains_id_seq OWNED BY public.quest_chains.id;
+
+
+--
+-- Name: quests; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.quests (
+    id bigint NOT NULL,
+    detail_id bigint NOT NULL,
+    code_id character varying(255) NOT NULL,
+    location text NOT NULL,
+    latitude numeric(10,7),
+    longitude numeric(10,7),
+    max_contributors integer NOT NULL,
+    finished boolean DEFAULT false NOT NULL,
+    clue text,
+    geofence_radius integer,
+    chain_id bigint,
+    stage_order integer
+);
+
+
+--
+-- Name: quests_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.quests_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: quests_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.quests_id_seq OWNED BY public.quests.id;
+
+
+--
+-- Name: recap_details; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.recap_details (
+    id bigint NOT NULL,
+    monthly_recap_id bigint NOT NULL,
+    challenges integer DEFAULT 0 NOT NULL,
+    events integer DEFAULT 0 NOT NULL,
+    quests integer DEFAULT 0 NOT NULL,
+    treasures integer DEFAULT 0 NOT NULL,
+    longest_streak integer DEFAULT 0 NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: recap_details_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.recap_details_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: recap_details_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.recap_details_id_seq OWNED BY public.recap_details.id;
+
+
+--
+-- Name: recaps; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.recaps (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    summary text NOT NULL,
+    tips text NOT NULL,
+    assigned_task integer NOT NULL,
+    completed_task integer NOT NULL,
+    completion_rate character varying(255) NOT NULL,
+    growth_rating character varying(255) NOT NULL,
+    type character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT recaps_type_check CHECK (((type)::text = ANY ((ARRAY['weekly'::character varying, 'monthly'::character varying])::text[])))
+);
+
+
+--
+-- Name: recaps_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.recaps_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: recaps_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.recaps_id_seq OWNED BY public.recaps.id;
+
+
+--
+-- Name: regions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.regions (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    location text NOT NULL,
+    latitude double precision NOT NULL,
+    longitude double precision NOT NULL,
+    tree_amount integer DEFAULT 0 NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: regions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.regions_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: regions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.regions_id_seq OWNED BY public.regions.id;
+
+
+--
+-- Name: scan_images; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.scan_images (
+    id bigint NOT NULL,
+    scan_id bigint NOT NULL,
+    image_key character varying(255) NOT NULL,
+    position integer DEFAULT 0 NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: scan_images_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.scan_images_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: scan_images_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.scan_images_id_seq OWNED BY public.scan_images.id;
+
+
+--
+-- Name: scans; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.scans (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    title character varying(255) NOT NULL,
+    description text NOT NULL,
+    image_key character varying(255) NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: scans_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.scans_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: scans_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.scans_id_seq OWNED BY public.scans.id;
+
+
+--
+-- Name: sessions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.sessions (
+    id character varying(255) NOT NULL,
+    user_id bigint,
+    ip_address character varying(45),
+    user_agent text,
+    payload text NOT NULL,
+    last_activity integer NOT NULL
+);
+
+
+--
+-- Name: statistics; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.statistics (
+    id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    challenges integer DEFAULT 0 NOT NULL,
+    events integer DEFAULT 0 NOT NULL,
+    quests integer DEFAULT 0 NOT NULL,
+    treasures integer DEFAULT 0 NOT NULL,
+    longest_streak integer DEFAULT 0 NOT NULL,
+    tree_grown integer DEFAULT 0 NOT NULL,
+    volunteer_minutes integer DEFAULT 0 NOT NULL
+);
+
+
+--
+-- Name: statistics_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.statistics_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: statistics_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.statistics_id_seq OWNED BY public.statistics.id;
+
+
+--
+-- Name: steps; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.steps (
+    id bigint NOT NULL,
+    greenprint_id bigint NOT NULL,
+    description text NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: steps_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.steps_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: steps_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.steps_id_seq OWNED BY public.steps.id;
+
+
+--
+-- Name: tasks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.tasks (
+    id bigint NOT NULL,
+    habit_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    packet_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    difficulty character varying(255) NOT NULL,
+    completed boolean DEFAULT false NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp(0) without time zone,
+    CONSTRAINT tasks_difficulty_check CHECK (((difficulty)::text = ANY ((ARRAY['hard'::character varying, 'normal'::character varying, 'easy'::character varying])::text[])))
+);
+
+
+--
+-- Name: tasks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.tasks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: tasks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.tasks_id_seq OWNED BY public.tasks.id;
 
 
 --
 -- Name: tools; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.tools (
-    id bigint NOT NULL,
-    greenprint_id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    description text NOT NULL,
-    price integer NOT NULL,
-    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
-);
+CREATE TABLE public.tools (
+    id bigint NOT NULL,
+    greenprint_id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    description text NOT NULL,
+    price integer NOT NULL,
+    created_at timestamp(0) without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    step_id bigint,
+    is_optional boolean DEFAULT false NOT NULL
+);
+
+
+--
+-- Name: tools_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.tools_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: tools_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.tools_id_seq OWNED BY public.tools.id;
+
+
+--
+-- Name: treasures; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.treasures (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    point_gain bigint NOT NULL,
+    code_id character varying(255) NOT NULL,
+    claimed boolean DEFAULT false NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone,
+    latitude double precision,
+    longitude double precision,
+    geofence_radius integer,
+    max_claims integer DEFAULT 1 NOT NULL,
+    per_user_limit integer DEFAULT 1 NOT NULL,
+    claim_count integer DEFAULT 0 NOT NULL,
+    starts_at timestamp(0) without time zone,
+    expires_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: treasures_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.treasures_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: treasures_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.treasures_id_seq OWNED BY public.treasures.id;
+
+
+--
+-- Name: users; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    username character varying(255) NOT NULL,
+    email character varying(255) NOT NULL,
+    email_verified_at timestamp(0) without time zone,
+    password character varying(255) NOT NULL,
+    is_admin boolean DEFAULT false NOT NULL,
+    remember_token character varying(100),
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.users_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+
+
+--
+-- Name: waste_bank_prices; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.waste_bank_prices (
+    id bigint NOT NULL,
+    waste_bank_id bigint NOT NULL,
+    material character varying(255) NOT NULL,
+    price_per_kg integer NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: waste_bank_prices_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.waste_bank_prices_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: waste_bank_prices_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.waste_bank_prices_id_seq OWNED BY public.waste_bank_prices.id;
+
+
+--
+-- Name: waste_banks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.waste_banks (
+    id bigint NOT NULL,
+    name character varying(255) NOT NULL,
+    address text NOT NULL,
+    latitude double precision NOT NULL,
+    longitude double precision NOT NULL,
+    region_id bigint,
+    phone character varying(255),
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp(0) without time zone,
+    updated_at timestamp(0) without time zone
+);
+
+
+--
+-- Name: waste_banks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.waste_banks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: waste_banks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.waste_banks_id_seq OWNED BY public.waste_banks.id;
+
+
+--
+-- Name: activity_scans id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.activity_scans ALTER COLUMN id SET DEFAULT nextval('public.activity_scans_id_seq'::regclass);
+
+
+--
+-- Name: activity_token_uses id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.activity_token_uses ALTER COLUMN id SET DEFAULT nextval('public.activity_token_uses_id_seq'::regclass);
+
+
+--
+-- Name: ai_quotas id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ai_quotas ALTER COLUMN id SET DEFAULT nextval('public.ai_quotas_id_seq'::regclass);
+
+
+--
+-- Name: ai_usages id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ai_usages ALTER COLUMN id SET DEFAULT nextval('public.ai_usages_id_seq'::regclass);
+
+
+--
+-- Name: attendances id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attendances ALTER COLUMN id SET DEFAULT nextval('public.attendances_id_seq'::regclass);
+
+
+--
+-- Name: audit_logs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_logs ALTER COLUMN id SET DEFAULT nextval('public.audit_logs_id_seq'::regclass);
+
+
+--
+-- Name: calendar_feeds id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.calendar_feeds ALTER COLUMN id SET DEFAULT nextval('public.calendar_feeds_id_seq'::regclass);
+
+
+--
+-- Name: certificates id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.certificates ALTER COLUMN id SET DEFAULT nextval('public.certificates_id_seq'::regclass);
+
+
+--
+-- Name: challenges id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.challenges ALTER COLUMN id SET DEFAULT nextval('public.challenges_id_seq'::regclass);
+
+
+--
+-- Name: claimed id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.claimed ALTER COLUMN id SET DEFAULT nextval('public.claimed_id_seq'::regclass);
+
+
+--
+-- Name: contributions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.contributions ALTER COLUMN id SET DEFAULT nextval('public.contributions_id_seq'::regclass);
+
+
+--
+-- Name: deposit_items id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.deposit_items ALTER COLUMN id SET DEFAULT nextval('public.deposit_items_id_seq'::regclass);
+
+
+--
+-- Name: deposits id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.deposits ALTER COLUMN id SET DEFAULT nextval('public.deposits_id_seq'::regclass);
+
+
+--
+-- Name: details id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.details ALTER COLUMN id SET DEFAULT nextval('public.details_id_seq'::regclass);
+
+
+--
+-- Name: dropoff_hours id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_hours ALTER COLUMN id SET DEFAULT nextval('public.dropoff_hours_id_seq'::regclass);
+
+
+--
+-- Name: dropoff_materials id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_materials ALTER COLUMN id SET DEFAULT nextval('public.dropoff_materials_id_seq'::regclass);
+
+
+--
+-- Name: dropoff_points id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_points ALTER COLUMN id SET DEFAULT nextval('public.dropoff_points_id_seq'::regclass);
+
+
+--
+-- Name: event_feedbacks id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_feedbacks ALTER COLUMN id SET DEFAULT nextval('public.event_feedbacks_id_seq'::regclass);
+
+
+--
+-- Name: event_organizers id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_organizers ALTER COLUMN id SET DEFAULT nextval('public.event_organizers_id_seq'::regclass);
+
+
+--
+-- Name: event_waitlists id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_waitlists ALTER COLUMN id SET DEFAULT nextval('public.event_waitlists_id_seq'::regclass);
+
+
+--
+-- Name: events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.events ALTER COLUMN id SET DEFAULT nextval('public.events_id_seq'::regclass);
+
+
+--
+-- Name: failed_jobs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.failed_jobs ALTER COLUMN id SET DEFAULT nextval('public.failed_jobs_id_seq'::regclass);
+
+
+--
+-- Name: geofence_overrides id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.geofence_overrides ALTER COLUMN id SET DEFAULT nextval('public.geofence_overrides_id_seq'::regclass);
+
+
+--
+-- Name: greenprint_projects id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_projects ALTER COLUMN id SET DEFAULT nextval('public.greenprint_projects_id_seq'::regclass);
+
+
+--
+-- Name: greenprint_ratings id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_ratings ALTER COLUMN id SET DEFAULT nextval('public.greenprint_ratings_id_seq'::regclass);
+
+
+--
+-- Name: greenprints id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprints ALTER COLUMN id SET DEFAULT nextval('public.greenprints_id_seq'::regclass);
+
+
+--
+-- Name: habits id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.habits ALTER COLUMN id SET DEFAULT nextval('public.habits_id_seq'::regclass);
+
+
+--
+-- Name: histories id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.histories ALTER COLUMN id SET DEFAULT nextval('public.histories_id_seq'::regclass);
+
+
+--
+-- Name: item_images id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.item_images ALTER COLUMN id SET DEFAULT nextval('public.item_images_id_seq'::regclass);
+
+
+--
+-- Name: items id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.items ALTER COLUMN id SET DEFAULT nextval('public.items_id_seq'::regclass);
+
+
+--
+-- Name: jobs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.jobs ALTER COLUMN id SET DEFAULT nextval('public.jobs_id_seq'::regclass);
+
+
+--
+-- Name: logs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.logs ALTER COLUMN id SET DEFAULT nextval('public.logs_id_seq'::regclass);
+
+
+--
+-- Name: materials id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.materials ALTER COLUMN id SET DEFAULT nextval('public.materials_id_seq'::regclass);
+
+
+--
+-- Name: memories id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.memories ALTER COLUMN id SET DEFAULT nextval('public.memories_id_seq'::regclass);
+
+
+--
+-- Name: migrations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.migrations ALTER COLUMN id SET DEFAULT nextval('public.migrations_id_seq'::regclass);
+
+
+--
+-- Name: notifications id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.notifications ALTER COLUMN id SET DEFAULT nextval('public.notifications_id_seq'::regclass);
+
+
+--
+-- Name: owned_materials id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_materials ALTER COLUMN id SET DEFAULT nextval('public.owned_materials_id_seq'::regclass);
+
+
+--
+-- Name: owned_tools id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_tools ALTER COLUMN id SET DEFAULT nextval('public.owned_tools_id_seq'::regclass);
+
+
+--
+-- Name: packets id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.packets ALTER COLUMN id SET DEFAULT nextval('public.packets_id_seq'::regclass);
+
+
+--
+-- Name: participation_reactions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.participation_reactions ALTER COLUMN id SET DEFAULT nextval('public.participation_reactions_id_seq'::regclass);
+
+
+--
+-- Name: participations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.participations ALTER COLUMN id SET DEFAULT nextval('public.participations_id_seq'::regclass);
+
+
+--
+-- Name: profiles id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.profiles ALTER COLUMN id SET DEFAULT nextval('public.profiles_id_seq'::regclass);
+
+
+--
+-- Name: project_steps id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.project_steps ALTER COLUMN id SET DEFAULT nextval('public.project_steps_id_seq'::regclass);
+
+
+--
+-- Name: quest_chain_completions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quest_chain_completions ALTER COLUMN id SET DEFAULT nextval('public.quest_chain_completions_id_seq'::regclass);
+
+
+--
+-- Name: quest_chains id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quest_chains ALTER COLUMN id SET DEFAULT nextval('public.quest_chains_id_seq'::regclass);
+
+
+--
+-- Name: quests id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quests ALTER COLUMN id SET DEFAULT nextval('public.quests_id_seq'::regclass);
+
+
+--
+-- Name: recap_details id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.recap_details ALTER COLUMN id SET DEFAULT nextval('public.recap_details_id_seq'::regclass);
+
+
+--
+-- Name: recaps id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.recaps ALTER COLUMN id SET DEFAULT nextval('public.recaps_id_seq'::regclass);
+
+
+--
+-- Name: regions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.regions ALTER COLUMN id SET DEFAULT nextval('public.regions_id_seq'::regclass);
+
+
+--
+-- Name: scan_images id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.scan_images ALTER COLUMN id SET DEFAULT nextval('public.scan_images_id_seq'::regclass);
+
+
+--
+-- Name: scans id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.scans ALTER COLUMN id SET DEFAULT nextval('public.scans_id_seq'::regclass);
+
+
+--
+-- Name: statistics id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.statistics ALTER COLUMN id SET DEFAULT nextval('public.statistics_id_seq'::regclass);
+
+
+--
+-- Name: steps id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.steps ALTER COLUMN id SET DEFAULT nextval('public.steps_id_seq'::regclass);
+
+
+--
+-- Name: tasks id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tasks ALTER COLUMN id SET DEFAULT nextval('public.tasks_id_seq'::regclass);
+
+
+--
+-- Name: tools id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tools ALTER COLUMN id SET DEFAULT nextval('public.tools_id_seq'::regclass);
+
+
+--
+-- Name: treasures id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.treasures ALTER COLUMN id SET DEFAULT nextval('public.treasures_id_seq'::regclass);
+
+
+--
+-- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+
+
+--
+-- Name: waste_bank_prices id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.waste_bank_prices ALTER COLUMN id SET DEFAULT nextval('public.waste_bank_prices_id_seq'::regclass);
+
+
+--
+-- Name: waste_banks id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.waste_banks ALTER COLUMN id SET DEFAULT nextval('public.waste_banks_id_seq'::regclass);
+
+
+--
+-- Name: activity_scans activity_scans_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.activity_scans
+    ADD CONSTRAINT activity_scans_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: activity_token_uses activity_token_uses_jti_user_id_use_number_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.activity_token_uses
+    ADD CONSTRAINT activity_token_uses_jti_user_id_use_number_unique UNIQUE (jti, user_id, use_number);
+
+
+--
+-- Name: activity_token_uses activity_token_uses_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.activity_token_uses
+    ADD CONSTRAINT activity_token_uses_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: ai_quotas ai_quotas_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ai_quotas
+    ADD CONSTRAINT ai_quotas_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: ai_quotas ai_quotas_user_id_feature_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ai_quotas
+    ADD CONSTRAINT ai_quotas_user_id_feature_unique UNIQUE (user_id, feature);
+
+
+--
+-- Name: ai_usages ai_usages_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ai_usages
+    ADD CONSTRAINT ai_usages_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: attendances attendances_event_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attendances
+    ADD CONSTRAINT attendances_event_id_user_id_unique UNIQUE (event_id, user_id);
+
+
+--
+-- Name: attendances attendances_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attendances
+    ADD CONSTRAINT attendances_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: audit_logs audit_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_logs
+    ADD CONSTRAINT audit_logs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: cache_locks cache_locks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.cache_locks
+    ADD CONSTRAINT cache_locks_pkey PRIMARY KEY (key);
+
+
+--
+-- Name: cache cache_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.cache
+    ADD CONSTRAINT cache_pkey PRIMARY KEY (key);
+
+
+--
+-- Name: calendar_feeds calendar_feeds_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.calendar_feeds
+    ADD CONSTRAINT calendar_feeds_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: calendar_feeds calendar_feeds_token_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.calendar_feeds
+    ADD CONSTRAINT calendar_feeds_token_unique UNIQUE (token);
+
+
+--
+-- Name: calendar_feeds calendar_feeds_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.calendar_feeds
+    ADD CONSTRAINT calendar_feeds_user_id_unique UNIQUE (user_id);
+
+
+--
+-- Name: certificates certificates_code_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.certificates
+    ADD CONSTRAINT certificates_code_unique UNIQUE (code);
+
+
+--
+-- Name: certificates certificates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.certificates
+    ADD CONSTRAINT certificates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: challenges challenges_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.challenges
+    ADD CONSTRAINT challenges_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: claimed claimed_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.claimed
+    ADD CONSTRAINT claimed_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: claimed claimed_treasure_id_user_id_claim_number_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.claimed
+    ADD CONSTRAINT claimed_treasure_id_user_id_claim_number_unique UNIQUE (treasure_id, user_id, claim_number);
+
+
+--
+-- Name: codes codes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.codes
+    ADD CONSTRAINT codes_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: contributions contributions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.contributions
+    ADD CONSTRAINT contributions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: deposit_items deposit_items_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.deposit_items
+    ADD CONSTRAINT deposit_items_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: deposits deposits_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.deposits
+    ADD CONSTRAINT deposits_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: details details_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.details
+    ADD CONSTRAINT details_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: dropoff_hours dropoff_hours_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_hours
+    ADD CONSTRAINT dropoff_hours_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: dropoff_materials dropoff_materials_dropoff_point_id_material_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_materials
+    ADD CONSTRAINT dropoff_materials_dropoff_point_id_material_unique UNIQUE (dropoff_point_id, material);
+
+
+--
+-- Name: dropoff_materials dropoff_materials_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_materials
+    ADD CONSTRAINT dropoff_materials_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: dropoff_points dropoff_points_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.dropoff_points
+    ADD CONSTRAINT dropoff_points_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: event_feedbacks event_feedbacks_event_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_feedbacks
+    ADD CONSTRAINT event_feedbacks_event_id_user_id_unique UNIQUE (event_id, user_id);
+
+
+--
+-- Name: event_feedbacks event_feedbacks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_feedbacks
+    ADD CONSTRAINT event_feedbacks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: event_organizers event_organizers_event_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_organizers
+    ADD CONSTRAINT event_organizers_event_id_user_id_unique UNIQUE (event_id, user_id);
+
+
+--
+-- Name: event_organizers event_organizers_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_organizers
+    ADD CONSTRAINT event_organizers_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: event_waitlists event_waitlists_event_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_waitlists
+    ADD CONSTRAINT event_waitlists_event_id_user_id_unique UNIQUE (event_id, user_id);
+
+
+--
+-- Name: event_waitlists event_waitlists_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_waitlists
+    ADD CONSTRAINT event_waitlists_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: events events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: failed_jobs failed_jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.failed_jobs
+    ADD CONSTRAINT failed_jobs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: failed_jobs failed_jobs_uuid_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.failed_jobs
+    ADD CONSTRAINT failed_jobs_uuid_unique UNIQUE (uuid);
+
+
+--
+-- Name: geofence_overrides geofence_overrides_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.geofence_overrides
+    ADD CONSTRAINT geofence_overrides_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: geofence_overrides geofence_overrides_user_id_activity_type_activity_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.geofence_overrides
+    ADD CONSTRAINT geofence_overrides_user_id_activity_type_activity_id_unique UNIQUE (user_id, activity_type, activity_id);
+
+
+--
+-- Name: greenprint_projects greenprint_projects_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_projects
+    ADD CONSTRAINT greenprint_projects_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: greenprint_projects greenprint_projects_user_id_greenprint_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_projects
+    ADD CONSTRAINT greenprint_projects_user_id_greenprint_id_unique UNIQUE (user_id, greenprint_id);
+
+
+--
+-- Name: greenprint_ratings greenprint_ratings_greenprint_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_ratings
+    ADD CONSTRAINT greenprint_ratings_greenprint_id_user_id_unique UNIQUE (greenprint_id, user_id);
+
+
+--
+-- Name: greenprint_ratings greenprint_ratings_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprint_ratings
+    ADD CONSTRAINT greenprint_ratings_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: greenprints greenprints_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.greenprints
+    ADD CONSTRAINT greenprints_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: habits habits_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.habits
+    ADD CONSTRAINT habits_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: histories histories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.histories
+    ADD CONSTRAINT histories_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: item_images item_images_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.item_images
+    ADD CONSTRAINT item_images_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: items items_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.items
+    ADD CONSTRAINT items_pkey PRIMARY KEY (id);
 
 
 --
--- Name: tools_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: job_batches job_batches_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.tools_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+ALTER TABLE ONLY public.job_batches
+    ADD CONSTRAINT job_batches_pkey PRIMARY KEY (id);
 
 
 --
--- Name: tools_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: jobs jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.tools_id_seq OWNED BY public.tools.id;
+ALTER TABLE ONLY public.jobs
+    ADD CONSTRAINT jobs_pkey PRIMARY KEY (id);
 
 
 --
--- Name: treasures; Type: TABLE; Schema: public; Owner: -
+-- Name: logs logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE TABLE public.treasures (
-    id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    point_gain bigint NOT NULL,
-    code_id character varying(255) NOT NULL,
-    claimed boolean DEFAULT false NOT NULL,
-    created_at timestamp(0) without time zone,
-    updated_at timestamp(0) without time zone
-);
+ALTER TABLE ONLY public.logs
+    ADD CONSTRAINT logs_pkey PRIMARY KEY (id);
 
 
 --
--- Name: treasures_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: materials materials_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.treasures_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+ALTER TABLE ONLY public.materials
+    ADD CONSTRAINT materials_pkey PRIMARY KEY (id);
 
 
 --
--- Name: treasures_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: memories memories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.treasures_id_seq OWNED BY public.treasures.id;
+ALTER TABLE ONLY public.memories
+    ADD CONSTRAINT memories_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users; Type: TABLE; Schema: public; Owner: -
+-- Name: migrations migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE TABLE public.users (
-    id bigint NOT NULL,
-    name character varying(255) NOT NULL,
-    username character varying(255) NOT NULL,
-    email character varying(255) NOT NULL,
-    email_verified_at timestamp(0) without time zone,
-    password character varying(255) NOT NULL,
-    is_admin boolean DEFAULT false NOT NULL,
-    remember_token character varying(100),
-    created_at timestamp(0) without time zone,
-    updated_at timestamp(0) without time zone
-);
+ALTER TABLE ONLY public.migrations
+    ADD CONSTRAINT migrations_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: notifications notifications_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.users_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+ALTER TABLE ONLY public.notifications
+    ADD CONSTRAINT notifications_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: owned_materials owned_materials_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER TABLE ONLY public.owned_materials
+    ADD CONSTRAINT owned_materials_pkey PRIMARY KEY (id);
 
 
 --
--- Name: attendances id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: owned_materials owned_materials_user_id_material_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.attendances ALTER COLUMN id SET DEFAULT nextval('public.attendances_id_seq'::regclass);
+ALTER TABLE ONLY public.owned_materials
+    ADD CONSTRAINT owned_materials_user_id_material_id_unique UNIQUE (user_id, material_id);
 
 
 --
--- Name: challenges id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: owned_tools owned_tools_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.challenges ALTER COLUMN id SET DEFAULT nextval('public.challenges_id_seq'::regclass);
+ALTER TABLE ONLY public.owned_tools
+    ADD CONSTRAINT owned_tools_pkey PRIMARY KEY (id);
 
 
 --
--- Name: claimed id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: owned_tools owned_tools_user_id_tool_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.claimed ALTER COLUMN id SET DEFAULT nextval('public.claimed_id_seq'::regclass);
+ALTER TABLE ONLY public.owned_tools
+    ADD CONSTRAINT owned_tools_user_id_tool_id_unique UNIQUE (user_id, tool_id);
 
 
 --
--- Name: contributions id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: packets packets_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.contributions ALTER COLUMN id SET DEFAULT nextval('public.contributions_id_seq'::regclass);
+ALTER TABLE ONLY public.packets
+    ADD CONSTRAINT packets_pkey PRIMARY KEY (id);
 
 
 --
--- Name: details id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: participation_reactions participation_reactions_participation_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.details ALTER COLUMN id SET DEFAULT nextval('public.details_id_seq'::regclass);
+ALTER TABLE ONLY public.participation_reactions
+    ADD CONSTRAINT participation_reactions_participation_id_user_id_unique UNIQUE (participation_id, user_id);
 
 
 --
--- Name: events id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: participation_reactions participation_reactions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events ALTER COLUMN id SET DEFAULT nextval('public.events_id_seq'::regclass);
+ALTER TABLE ONLY public.participation_reactions
+    ADD CONSTRAINT participation_reactions_pkey PRIMARY KEY (id);
 
 
 --
--- Name: failed_jobs id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: participations participations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.failed_jobs ALTER COLUMN id SET DEFAULT nextval('public.failed_jobs_id_seq'::regclass);
+ALTER TABLE ONLY public.participations
+    ADD CONSTRAINT participations_pkey PRIMARY KEY (id);
 
 
 --
--- Name: greenprints id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: password_reset_tokens password_reset_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.greenprints ALTER COLUMN id SET DEFAULT nextval('public.greenprints_id_seq'::regclass);
+ALTER TABLE ONLY public.password_reset_tokens
+    ADD CONSTRAINT password_reset_tokens_pkey PRIMARY KEY (email);
 
 
 --
--- Name: habits id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: profiles profiles_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.habits ALTER COLUMN id SET DEFAULT nextval('public.habits_id_seq'::regclass);
+ALTER TABLE ONLY public.profiles
+    ADD CONSTRAINT profiles_pkey PRIMARY KEY (id);
 
 
 --
--- Name: histories id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: project_steps project_steps_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.histories ALTER COLUMN id SET DEFAULT nextval('public.histories_id_seq'::regclass);
+ALTER TABLE ONLY public.project_steps
+    ADD CONSTRAINT project_steps_pkey PRIMARY KEY (id);
 
 
 --
--- Name: items id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: project_steps project_steps_project_id_step_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.items ALTER COLUMN id SET DEFAULT nextval('public.items_id_seq'::regclass);
+ALTER TABLE ONLY public.project_steps
+    ADD CONSTRAINT project_steps_project_id_step_id_unique UNIQUE (project_id, step_id);
 
 
 --
--- Name: jobs id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: quest_chain_completions quest_chain_completions_chain_id_user_id_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.jobs ALTER COLUMN id SET DEFAULT nextval('public.jobs_id_seq'::regclass);
+ALTER TABLE ONLY public.quest_chain_completions
+    ADD CONSTRAINT quest_chain_completions_chain_id_user_id_unique UNIQUE (chain_id, user_id);
 
 
 --
--- Name: logs id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: quest_chain_completions quest_chain_completions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.logs ALTER COLUMN id SET DEFAULT nextval('public.logs_id_seq'::regclass);
+ALTER TABLE ONLY public.quest_chain_completions
+    ADD CONSTRAINT quest_chain_completions_pkey PRIMARY KEY (id);
 
 
 --
--- Name: materials id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: quest_chains quest_chains_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.materials ALTER COLUMN id SET DEFAULT nextval('public.materials_id_seq'::regclass);
+ALTER TABLE ONLY public.quest_chains
+    ADD CONSTRAINT quest_chains_pkey PRIMARY KEY (id);
 
 
 --
--- Name: memories id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: quests quests_chain_id_stage_order_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.memories ALTER COLUMN id SET DEFAULT nextval('public.memories_id_seq'::regclass);
+ALTER TABLE ONLY public.quests
+    ADD CONSTRAINT quests_chain_id_stage_order_unique UNIQUE (chain_id, stage_order);
 
 
 --
--- Name: migrations id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: quests quests_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.migrations ALTER COLUMN id SET DEFAULT nextval('public.migrations_id_seq'::regclass);
+ALTER TABLE ONLY public.quests
+    ADD CONSTRAINT quests_pkey PRIMARY KEY (id);
 
 
 --
--- Name: packets id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: recap_details recap_details_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.packets ALTER COLUMN id SET DEFAULT nextval('public.packets_id_seq'::regclass);
+ALTER TABLE ONLY public.recap_details
+    ADD CONSTRAINT recap_details_pkey PRIMARY KEY (id);
 
 
 --
--- Name: participations id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: recaps recaps_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.participations ALTER COLUMN id SET DEFAULT nextval('public.participations_id_seq'::regclass);
+ALTER TABLE ONLY public.recaps
+    ADD CONSTRAINT recaps_pkey PRIMARY KEY (id);
 
 
 --
--- Name: profiles id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: regions regions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.profiles ALTER COLUMN id SET DEFAULT nextval('public.profiles_id_seq'::regclass);
+ALTER TABLE ONLY public.regions
+    ADD CONSTRAINT regions_pkey PRIMARY KEY (id);
 
 
 --
--- Name: quests id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: scan_images scan_images_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.quests ALTER COLUMN id SET DEFAULT nextval('public.quests_id_seq'::regclass);
+ALTER TABLE ONLY public.scan_images
+    ADD CONSTRAINT scan_images_pkey PRIMARY KEY (id);
 
 
 --
--- Name: recap_details id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: scans scans_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.recap_details ALTER COLUMN id SET DEFAULT nextval('public.recap_details_id_seq'::regclass);
+ALTER TABLE ONLY public.scans
+    ADD CONSTRAINT scans_pkey PRIMARY KEY (id);
 
 
 --
--- Name: recaps id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: sessions sessions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.recaps ALTER COLUMN id SET DEFAULT nextval('public.recaps_id_seq'::regclass);
+ALTER TABLE ONLY public.sessions
+    ADD CONSTRAINT sessions_pkey PRIMARY KEY (id);
 
 
 --
--- Name: regions id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: statistics statistics_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.regions ALTER COLUMN id SET DEFAULT nextval('public.regions_id_seq'::regclass);
+ALTER TABLE ONLY public.statistics
+    ADD CONSTRAINT statistics_pkey PRIMARY KEY (id);
 
 
 --
--- Name: scans id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: steps steps_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.scans ALTER COLUMN id SET DEFAULT nextval('public.scans_id_seq'::regclass);
+ALTER TABLE ONLY public.steps
+    ADD CONSTRAINT steps_pkey PRIMARY KEY (id);
 
 
 --
--- Name: statistics id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: tasks tasks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.statistics ALTER COLUMN id SET DEFAULT nextval('public.statistics_id_seq'::regclass);
+ALTER TABLE ONLY public.tasks
+    ADD CONSTRAINT tasks_pkey PRIMARY KEY (id);
 
 
 --
--- Name: steps id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: tools tools_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.steps ALTER COLUMN id SET DEFAULT nextval('public.steps_id_seq'::regclass);
+ALTER TABLE ONLY public.tools
+    ADD CONSTRAINT tools_pkey PRIMARY KEY (id);
 
 
 --
--- Name: tasks id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: treasures treasures_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.tasks ALTER COLUMN id SET DEFAULT nextval('public.tasks_id_seq'::regclass);
+ALTER TABLE ONLY public.treasures
+    ADD CONSTRAINT treasures_pkey PRIMARY KEY (id);
 
 
 --
--- Name: tools id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: users users_email_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.tools ALTER COLUMN id SET DEFAULT nextval('public.tools_id_seq'::regclass);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_email_unique UNIQUE (email);
 
 
 --
--- Name: treasures id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.treasures ALTER COLUMN id SET DEFAULT nextval('public.treasures_id_seq'::regclass);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: users users_username_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_username_unique UNIQUE (username);
 
 
 --
--- Name: attendances attendances_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: waste_bank_prices waste_bank_prices_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.attendances
-    ADD CONSTRAINT attendances_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.waste_bank_prices
+    ADD CONSTRAINT waste_bank_prices_pkey PRIMARY KEY (id);
 
 
 --
--- Name: cache_locks cache_locks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: waste_bank_prices waste_bank_prices_waste_bank_id_material_unique; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.cache_locks
-    ADD CONSTRAINT cache_locks_pkey PRIMARY KEY (key);
+ALTER TABLE ONLY public.waste_bank_prices
+    ADD CONSTRAINT waste_bank_prices_waste_bank_id_material_unique UNIQUE (waste_bank_id, material);
 
 
 --
--- Name: cache cache_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: waste_banks waste_banks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.cache
-    ADD CONSTRAINT cache_pkey PRIMARY KEY (key);
+ALTER TABLE ONLY public.waste_banks
+    ADD CONSTRAINT waste_banks_pkey PRIMARY KEY (id);
 
 
 --
--- Name: challenges challenges_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: activity_scans_activity_type_activity_id_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.challenges
-    ADD CONSTRAINT challenges_pkey PRIMARY KEY (id);
+CREATE INDEX activity_scans_activity_type_activity_id_index ON public.activity_scans USING btree (activity_type, activity_id);
 
 
 --
--- Name: claimed claimed_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: activity_scans_status_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.claimed
-    ADD CONSTRAINT claimed_pkey PRIMARY KEY (id);
+CREATE INDEX activity_scans_status_index ON public.activity_scans USING btree (status);
 
 
 --
--- Name: codes codes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: ai_usages_user_id_feature_created_at_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.codes
-    ADD CONSTRAINT codes_pkey PRIMARY KEY (id);
+CREATE INDEX ai_usages_user_id_feature_created_at_index ON public.ai_usages USING btree (user_id, feature, created_at);
 
 
 --
--- Name: contributions contributions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: audit_logs_subject_type_subject_id_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.contributions
-    ADD CONSTRAINT contributions_pkey PRIMARY KEY (id);
+CREATE INDEX audit_logs_subject_type_subject_id_index ON public.audit_logs USING btree (subject_type, subject_id);
 
 
 --
--- Name: details details_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: audit_logs_user_id_created_at_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.details
-    ADD CONSTRAINT details_pkey PRIMARY KEY (id);
+CREATE INDEX audit_logs_user_id_created_at_index ON public.audit_logs USING btree (user_id, created_at);
 
 
 --
--- Name: events events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: certificates_user_id_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+CREATE INDEX certificates_user_id_index ON public.certificates USING btree (user_id);
 
 
 --
--- Name: failed_jobs failed_jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposits_user_id_status_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.failed_jobs
-    ADD CONSTRAINT failed_jobs_pkey PRIMARY KEY (id);
+CREATE INDEX deposits_user_id_status_index ON public.deposits USING btree (user_id, status);
 
 
 --
--- Name: failed_jobs failed_jobs_uuid_unique; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: dropoff_materials_material_index; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX dropoff_materials_material_index ON public.dropoff_materials USING btree (material);
+
+
+--
+-- Name: greenprints_search_text_index; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX greenprints_search_text_index ON public.greenprints USING gin (to_tsvector('simple'::regconfig, search_text));
+
+
+--
+-- Name: jobs_queue_index; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX jobs_queue_index ON public.jobs USING btree (queue);
+
+
+--
+-- Name: notifications_user_id_created_at_index; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX notifications_user_id_created_at_index ON public.notifications USING btree (user_id, created_at);
+
+
+--
+-- Name: participations_status_created_at_index; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX participations_status_created_at_index ON public.participations USING btree (status, created_at);
+
+
+--
+-- Name: sessions_last_activity_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.failed_jobs
-    ADD CONSTRAINT failed_jobs_uuid_unique UNIQUE (uuid);
+CREATE INDEX sessions_last_activity_index ON public.sessions USING btree (last_activity);
 
 
 --
--- Name: greenprints greenprints_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: sessions_user_id_index; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.greenprints
-    ADD CONSTRAINT greenprints_pkey PRIMARY KEY (id);
+CREATE INDEX sessions_user_id_index ON public.sessions USING btree (user_id);
 
 
 --
--- Name: habits habits_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: activity_scans activity_scans_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.habits
-    ADD CONSTRAINT habits_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.activity_scans
+    ADD CONSTRAINT activity_scans_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: histories histories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: activity_token_uses activity_token_uses_code_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.histories
-    ADD CONSTRAINT histories_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.activity_token_uses
+    ADD CONSTRAINT activity_token_uses_code_id_foreign FOREIGN KEY (code_id) REFERENCES public.codes(id) ON DELETE CASCADE;
 
 
 --
--- Name: items items_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: activity_token_uses activity_token_uses_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.items
-    ADD CONSTRAINT items_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.activity_token_uses
+    ADD CONSTRAINT activity_token_uses_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: job_batches job_batches_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: ai_quotas ai_quotas_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.job_batches
-    ADD CONSTRAINT job_batches_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.ai_quotas
+    ADD CONSTRAINT ai_quotas_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: jobs jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: ai_usages ai_usages_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.jobs
-    ADD CONSTRAINT jobs_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.ai_usages
+    ADD CONSTRAINT ai_usages_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: logs logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: attendances attendances_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.logs
-    ADD CONSTRAINT logs_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.attendances
+    ADD CONSTRAINT attendances_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id);
 
 
 --
--- Name: materials materials_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: attendances attendances_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.materials
-    ADD CONSTRAINT materials_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.attendances
+    ADD CONSTRAINT attendances_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: memories memories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: audit_logs audit_logs_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.memories
-    ADD CONSTRAINT memories_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.audit_logs
+    ADD CONSTRAINT audit_logs_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE SET NULL;
 
 
 --
--- Name: migrations migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: calendar_feeds calendar_feeds_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.migrations
-    ADD CONSTRAINT migrations_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.calendar_feeds
+    ADD CONSTRAINT calendar_feeds_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
 --
--- Name: packets packets_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: certificates certificates_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.packets
-    ADD CONSTRAINT packets_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.certificates
+    ADD CONSTRAINT certificates_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: participations participations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: certificates certificates_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.participations
-    ADD CONSTRAINT participations_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.certificates
+    ADD CONSTRAINT certificates_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
 --
--- Name: password_reset_tokens password_reset_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: challenges challenges_detail_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.password_reset_tokens
-    ADD CONSTRAINT password_reset_tokens_pkey PRIMARY KEY (email);
+ALTER TABLE ONLY public.challenges
+    ADD CONSTRAINT challenges_detail_id_foreign FOREIGN KEY (detail_id) REFERENCES public.details(id);
 
 
 --
--- Name: profiles profiles_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: claimed claimed_treasure_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.profiles
-    ADD CONSTRAINT profiles_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.claimed
+    ADD CONSTRAINT claimed_treasure_id_foreign FOREIGN KEY (treasure_id) REFERENCES public.treasures(id);
 
 
 --
--- Name: quests quests_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: claimed claimed_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.quests
-    ADD CONSTRAINT quests_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.claimed
+    ADD CONSTRAINT claimed_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: recap_details recap_details_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: contributions contributions_quest_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.recap_details
-    ADD CONSTRAINT recap_details_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.contributions
+    ADD CONSTRAINT contributions_quest_id_foreign FOREIGN KEY (quest_id) REFERENCES public.quests(id);
 
 
 --
--- Name: recaps recaps_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: contributions contributions_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.recaps
-    ADD CONSTRAINT recaps_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.contributions
+    ADD CONSTRAINT contributions_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: regions regions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposit_items deposit_items_deposit_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.regions
-    ADD CONSTRAINT regions_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.deposit_items
+    ADD CONSTRAINT deposit_items_deposit_id_foreign FOREIGN KEY (deposit_id) REFERENCES public.deposits(id);
 
 
 --
--- Name: scans scans_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposit_items deposit_items_item_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.scans
-    ADD CONSTRAINT scans_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.deposit_items
+    ADD CONSTRAINT deposit_items_item_id_foreign FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE SET NULL;
 
 
 --
--- Name: sessions sessions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposits deposits_confirmed_by_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.sessions
-    ADD CONSTRAINT sessions_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.deposits
+    ADD CONSTRAINT deposits_confirmed_by_foreign FOREIGN KEY (confirmed_by) REFERENCES public.users(id);
 
 
 --
--- Name: statistics statistics_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposits deposits_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.statistics
-    ADD CONSTRAINT statistics_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.deposits
+    ADD CONSTRAINT deposits_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: steps steps_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: deposits deposits_waste_bank_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.steps
-    ADD CONSTRAINT steps_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.deposits
+    ADD CONSTRAINT deposits_waste_bank_id_foreign FOREIGN KEY (waste_bank_id) REFERENCES public.waste_banks(id);
 
 
 --
--- Name: tasks tasks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: dropoff_hours dropoff_hours_dropoff_point_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.tasks
-    ADD CONSTRAINT tasks_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.dropoff_hours
+    ADD CONSTRAINT dropoff_hours_dropoff_point_id_foreign FOREIGN KEY (dropoff_point_id) REFERENCES public.dropoff_points(id) ON DELETE CASCADE;
 
 
 --
--- Name: tools tools_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: dropoff_materials dropoff_materials_dropoff_point_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.tools
-    ADD CONSTRAINT tools_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.dropoff_materials
+    ADD CONSTRAINT dropoff_materials_dropoff_point_id_foreign FOREIGN KEY (dropoff_point_id) REFERENCES public.dropoff_points(id) ON DELETE CASCADE;
 
 
 --
--- Name: treasures treasures_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: dropoff_points dropoff_points_region_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.treasures
-    ADD CONSTRAINT treasures_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.dropoff_points
+    ADD CONSTRAINT dropoff_points_region_id_foreign FOREIGN KEY (region_id) REFERENCES public.regions(id);
 
 
 --
--- Name: users users_email_unique; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: event_feedbacks event_feedbacks_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_email_unique UNIQUE (email);
+ALTER TABLE ONLY public.event_feedbacks
+    ADD CONSTRAINT event_feedbacks_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE;
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: event_feedbacks event_feedbacks_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.event_feedbacks
+    ADD CONSTRAINT event_feedbacks_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
 --
--- Name: users users_username_unique; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: event_organizers event_organizers_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_username_unique UNIQUE (username);
+ALTER TABLE ONLY public.event_organizers
+    ADD CONSTRAINT event_organizers_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE;
 
 
 --
--- Name: jobs_queue_index; Type: INDEX; Schema: public; Owner: -
+-- Name: event_organizers event_organizers_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX jobs_queue_index ON public.jobs USING btree (queue);
+ALTER TABLE ONLY public.event_organizers
+    ADD CONSTRAINT event_organizers_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
 --
--- Name: sessions_last_activity_index; Type: INDEX; Schema: public; Owner: -
+-- Name: event_waitlists event_waitlists_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX sessions_last_activity_index ON public.sessions USING btree (last_activity);
+ALTER TABLE ONLY public.event_waitlists
+    ADD CONSTRAINT event_waitlists_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE CASCADE;
 
 
 --
--- Name: sessions_user_id_index; Type: INDEX; Schema: public; Owner: -
+-- Name: event_waitlists event_waitlists_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX sessions_user_id_index ON public.sessions USING btree (user_id);
+ALTER TABLE ONLY public.event_waitlists
+    ADD CONSTRAINT event_waitlists_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
 --
--- Name: attendances attendances_event_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: events events_code_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.attendances
-    ADD CONSTRAINT attendances_event_id_foreign FOREIGN KEY (event_id) REFERENCES public.events(id);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_code_id_foreign FOREIGN KEY (code_id) REFERENCES public.codes(id);
 
 
 --
--- Name: attendances attendances_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: events events_detail_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.attendances
-    ADD CONSTRAINT attendances_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_detail_id_foreign FOREIGN KEY (detail_id) REFERENCES public.details(id);
 
 
 --
--- Name: challenges challenges_detail_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: geofence_overrides geofence_overrides_created_by_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.challenges
-    ADD CONSTRAINT challenges_detail_id_foreign FOREIGN KEY (detail_id) REFERENCES public.details(id);
+ALTER TABLE ONLY public.geofence_overrides
+    ADD CONSTRAINT geofence_overrides_created_by_foreign FOREIGN KEY (created_by) REFERENCES public.users(id);
 
 
 --
--- Name: claimed claimed_treasure_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: geofence_overrides geofence_overrides_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.claimed
-    ADD CONSTRAINT claimed_treasure_id_foreign FOREIGN KEY (treasure_id) REFERENCES public.treasures(id);
+ALTER TABLE ONLY public.geofence_overrides
+    ADD CONSTRAINT geofence_overrides_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: claimed claimed_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprint_projects greenprint_projects_greenprint_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.claimed
-    ADD CONSTRAINT claimed_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+ALTER TABLE ONLY public.greenprint_projects
+    ADD CONSTRAINT greenprint_projects_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id) ON DELETE CASCADE;
 
 
 --
--- Name: contributions contributions_quest_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprint_projects greenprint_projects_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.contributions
-    ADD CONSTRAINT contributions_quest_id_foreign FOREIGN KEY (quest_id) REFERENCES public.quests(id);
+ALTER TABLE ONLY public.greenprint_projects
+    ADD CONSTRAINT greenprint_projects_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: contributions contributions_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprint_ratings greenprint_ratings_greenprint_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.contributions
-    ADD CONSTRAINT contributions_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+ALTER TABLE ONLY public.greenprint_ratings
+    ADD CONSTRAINT greenprint_ratings_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id) ON DELETE CASCADE;
 
 
 --
--- Name: events events_code_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprint_ratings greenprint_ratings_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT events_code_id_foreign FOREIGN KEY (code_id) REFERENCES public.codes(id);
+ALTER TABLE ONLY public.greenprint_ratings
+    ADD CONSTRAINT greenprint_ratings_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
 --
--- Name: events events_detail_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprints greenprints_item_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT events_detail_id_foreign FOREIGN KEY (detail_id) REFERENCES public.details(id);
+ALTER TABLE ONLY public.greenprints
+    ADD CONSTRAINT greenprints_item_id_foreign FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
 
 
 --
--- Name: greenprints greenprints_item_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: greenprints greenprints_source_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
 ALTER TABLE ONLY public.greenprints
-    ADD CONSTRAINT greenprints_item_id_foreign FOREIGN KEY (item_id) REFERENCES public.items(id);
+    ADD CONSTRAINT greenprints_source_id_foreign FOREIGN KEY (source_id) REFERENCES public.greenprints(id) ON DELETE SET NULL;
 
 
 --
@@ -1787,12 +3724,28 @@ ALTER TABLE ONLY public.histories
     ADD CONSTRAINT histories_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: item_images item_images_item_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.item_images
+    ADD CONSTRAINT item_images_item_id_foreign FOREIGN KEY (item_id) REFERENCES public.items(id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_images item_images_scan_image_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.item_images
+    ADD CONSTRAINT item_images_scan_image_id_foreign FOREIGN KEY (scan_image_id) REFERENCES public.scan_images(id) ON DELETE CASCADE;
+
+
 --
 -- Name: items items_scan_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
 ALTER TABLE ONLY public.items
-    ADD CONSTRAINT items_scan_id_foreign FOREIGN KEY (scan_id) REFERENCES public.scans(id);
+    ADD CONSTRAINT items_scan_id_foreign FOREIGN KEY (scan_id) REFERENCES public.scans(id) ON DELETE CASCADE;
 
 
 --
@@ -1816,7 +3769,15 @@ ALTER TABLE ONLY public.logs
 --
 
 ALTER TABLE ONLY public.materials
-    ADD CONSTRAINT materials_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id);
+    ADD CONSTRAINT materials_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id) ON DELETE CASCADE;
+
+
+--
+-- Name: materials materials_step_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.materials
+    ADD CONSTRAINT materials_step_id_foreign FOREIGN KEY (step_id) REFERENCES public.steps(id) ON DELETE CASCADE;
 
 
 --
@@ -1827,6 +3788,46 @@ ALTER TABLE ONLY public.memories
     ADD CONSTRAINT memories_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: notifications notifications_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.notifications
+    ADD CONSTRAINT notifications_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: owned_materials owned_materials_material_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_materials
+    ADD CONSTRAINT owned_materials_material_id_foreign FOREIGN KEY (material_id) REFERENCES public.materials(id) ON DELETE CASCADE;
+
+
+--
+-- Name: owned_materials owned_materials_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_materials
+    ADD CONSTRAINT owned_materials_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+
+--
+-- Name: owned_tools owned_tools_tool_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_tools
+    ADD CONSTRAINT owned_tools_tool_id_foreign FOREIGN KEY (tool_id) REFERENCES public.tools(id) ON DELETE CASCADE;
+
+
+--
+-- Name: owned_tools owned_tools_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.owned_tools
+    ADD CONSTRAINT owned_tools_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+
 --
 -- Name: packets packets_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1835,6 +3836,22 @@ ALTER TABLE ONLY public.packets
     ADD CONSTRAINT packets_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: participation_reactions participation_reactions_participation_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.participation_reactions
+    ADD CONSTRAINT participation_reactions_participation_id_foreign FOREIGN KEY (participation_id) REFERENCES public.participations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: participation_reactions participation_reactions_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.participation_reactions
+    ADD CONSTRAINT participation_reactions_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- Name: participations participations_challenge_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1851,6 +3868,14 @@ ALTER TABLE ONLY public.participations
     ADD CONSTRAINT participations_memory_id_foreign FOREIGN KEY (memory_id) REFERENCES public.memories(id);
 
 
+--
+-- Name: participations participations_reviewed_by_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.participations
+    ADD CONSTRAINT participations_reviewed_by_foreign FOREIGN KEY (reviewed_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
 --
 -- Name: participations participations_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1867,6 +3892,46 @@ ALTER TABLE ONLY public.profiles
     ADD CONSTRAINT profiles_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: project_steps project_steps_project_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.project_steps
+    ADD CONSTRAINT project_steps_project_id_foreign FOREIGN KEY (project_id) REFERENCES public.greenprint_projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: project_steps project_steps_step_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.project_steps
+    ADD CONSTRAINT project_steps_step_id_foreign FOREIGN KEY (step_id) REFERENCES public.steps(id) ON DELETE CASCADE;
+
+
+--
+-- Name: quest_chain_completions quest_chain_completions_chain_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quest_chain_completions
+    ADD CONSTRAINT quest_chain_completions_chain_id_foreign FOREIGN KEY (chain_id) REFERENCES public.quest_chains(id) ON DELETE CASCADE;
+
+
+--
+-- Name: quest_chain_completions quest_chain_completions_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quest_chain_completions
+    ADD CONSTRAINT quest_chain_completions_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
+
+
+--
+-- Name: quests quests_chain_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.quests
+    ADD CONSTRAINT quests_chain_id_foreign FOREIGN KEY (chain_id) REFERENCES public.quest_chains(id) ON DELETE SET NULL;
+
+
 --
 -- Name: quests quests_code_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1899,6 +3964,14 @@ ALTER TABLE ONLY public.recaps
     ADD CONSTRAINT recaps_user_id_foreign FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: scan_images scan_images_scan_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.scan_images
+    ADD CONSTRAINT scan_images_scan_id_foreign FOREIGN KEY (scan_id) REFERENCES public.scans(id) ON DELETE CASCADE;
+
+
 --
 -- Name: scans scans_user_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1920,7 +3993,7 @@ ALTER TABLE ONLY public.statistics
 --
 
 ALTER TABLE ONLY public.steps
-    ADD CONSTRAINT steps_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id);
+    ADD CONSTRAINT steps_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id) ON DELETE CASCADE;
 
 
 --
@@ -1952,7 +4025,15 @@ ALTER TABLE ONLY public.tasks
 --
 
 ALTER TABLE ONLY public.tools
-    ADD CONSTRAINT tools_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id);
+    ADD CONSTRAINT tools_greenprint_id_foreign FOREIGN KEY (greenprint_id) REFERENCES public.greenprints(id) ON DELETE CASCADE;
+
+
+--
+-- Name: tools tools_step_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tools
+    ADD CONSTRAINT tools_step_id_foreign FOREIGN KEY (step_id) REFERENCES public.steps(id) ON DELETE CASCADE;
 
 
 --
@@ -1963,6 +4044,22 @@ ALTER TABLE ONLY public.treasures
     ADD CONSTRAINT treasures_code_id_foreign FOREIGN KEY (code_id) REFERENCES public.codes(id);
 
 
+--
+-- Name: waste_bank_prices waste_bank_prices_waste_bank_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.waste_bank_prices
+    ADD CONSTRAINT waste_bank_prices_waste_bank_id_foreign FOREIGN KEY (waste_bank_id) REFERENCES public.waste_banks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: waste_banks waste_banks_region_id_foreign; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.waste_banks
+    ADD CONSTRAINT waste_banks_region_id_foreign FOREIGN KEY (region_id) REFERENCES public.regions(id);
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -1991,7 +4088,7 @@ SET row_security = off;
 -- Name: migrations_id_seq; Type: SEQUENCE SET; Schema: public; Owner: -
 --
 
-SELECT pg_catalog.setval('public.migrations_id_seq', 30, true);
+SELECT pg_catalog.setval('public.migrations_id_seq', 51, true);
 
 
 --
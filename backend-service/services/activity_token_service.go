@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/skip2/go-qrcode"
+)
+
+type ActivityTokenService struct {
+	Repository *repositories.Queries
+	*MediaService
+}
+
+func NewActivityTokenService(
+	rp *repositories.Queries,
+	ms *MediaService,
+) *ActivityTokenService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("ACTIVITY_TOKEN_TTL_DAYS", 365)
+	cnf.SetDefault("ACTIVITY_TOKEN_GRACE_SECONDS", 10)
+	cnf.SetDefault("ACTIVITY_QR_SIZE", 512)
+
+	return &ActivityTokenService{
+		Repository:   rp,
+		MediaService: ms,
+	}
+}
+
+// Issue mints a new token for the QR code of an activity and revokes the
+// tokens issued before it. Static tokens get a QR image uploaded to S3 and
+// stored in codes.image_url. With rotationSeconds set the code switches to
+// short lived tokens that are fetched through Current instead.
+func (s *ActivityTokenService) Issue(ctx context.Context, activityType string, activityId int64, rotationSeconds int) (models.ResponseActivityToken, error) {
+	var res models.ResponseActivityToken
+
+	codeId, err := s.Repository.GetActivityCodeId(ctx, repositories.GetActivityCodeIdParams{
+		ActivityType: activityType,
+		ActivityID:   activityId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return res, fiber.NewError(fiber.StatusNotFound, "Aktivitas tidak ditemukan")
+		}
+		slog.Error("Failed to get activity code", "err", err)
+		return res, err
+	}
+
+	if rotationSeconds > 0 {
+		err = s.Repository.UpdateCodeToken(ctx, repositories.UpdateCodeTokenParams{
+			ID:              codeId,
+			RotationSeconds: pgtype.Int4{Int32: int32(rotationSeconds), Valid: true},
+		})
+		if err != nil {
+			slog.Error("Failed to update code", "err", err)
+			return res, err
+		}
+
+		return s.rotatingToken(activityType, codeId, rotationSeconds)
+	}
+
+	cnf := helpers.NewConfig()
+	jti := uuid.NewString()
+	expiry := time.Now().AddDate(0, 0, cnf.GetInt("ACTIVITY_TOKEN_TTL_DAYS"))
+
+	token, err := helpers.GenerateActivityToken(activityType, codeId, jti, expiry)
+	if err != nil {
+		slog.Error("Failed to sign activity token", "err", err)
+		return res, err
+	}
+
+	image, err := qrcode.Encode(token, qrcode.Medium, cnf.GetInt("ACTIVITY_QR_SIZE"))
+	if err != nil {
+		slog.Error("Failed to encode QR code", "err", err)
+		return res, err
+	}
+
+	key := fmt.Sprintf("qr/%s/%s.png", codeId, jti)
+	err = s.MediaService.UploadFile(ctx, key, "image/png", image)
+	if err != nil {
+		return res, err
+	}
+	imageUrl := cnf.GetString("AWS_URL") + key
+
+	err = s.Repository.UpdateCodeToken(ctx, repositories.UpdateCodeTokenParams{
+		ID:       codeId,
+		TokenID:  pgtype.Text{String: jti, Valid: true},
+		ImageUrl: imageUrl,
+	})
+	if err != nil {
+		slog.Error("Failed to update code", "err", err)
+		return res, err
+	}
+
+	res = models.ResponseActivityToken{
+		CodeID:    codeId,
+		Token:     token,
+		ImageUrl:  imageUrl,
+		ExpiresAt: expiry.Format("2006-01-02 15:04"),
+	}
+
+	return res, nil
+}
+
+// Current returns the token of the running window of a rotating code, for
+// screens that display the QR code at the activity.
+func (s *ActivityTokenService) Current(ctx context.Context, activityType string, activityId int64) (models.ResponseActivityToken, error) {
+	var res models.ResponseActivityToken
+
+	codeId, err := s.Repository.GetActivityCodeId(ctx, repositories.GetActivityCodeIdParams{
+		ActivityType: activityType,
+		ActivityID:   activityId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return res, fiber.NewError(fiber.StatusNotFound, "Aktivitas tidak ditemukan")
+		}
+		slog.Error("Failed to get activity code", "err", err)
+		return res, err
+	}
+
+	code, err := s.Repository.GetCodeById(ctx, codeId)
+	if err != nil {
+		slog.Error("Failed to get code", "err", err)
+		return res, err
+	}
+
+	if !code.RotationSeconds.Valid {
+		return res, fiber.NewError(fiber.StatusBadRequest, "QR code aktivitas ini tidak berotasi")
+	}
+
+	return s.rotatingToken(activityType, codeId, int(code.RotationSeconds.Int32))
+}
+
+// rotatingToken signs the token of the current window. Every screen showing
+// the code gets the same token, the jti is the code id and the window index.
+func (s *ActivityTokenService) rotatingToken(activityType string, codeId string, rotationSeconds int) (models.ResponseActivityToken, error) {
+	var res models.ResponseActivityToken
+	cnf := helpers.NewConfig()
+
+	window := time.Now().Unix() / int64(rotationSeconds)
+	windowEnd := time.Unix((window+1)*int64(rotationSeconds), 0)
+	expiry := windowEnd.Add(time.Duration(cnf.GetInt("ACTIVITY_TOKEN_GRACE_SECONDS")) * time.Second)
+
+	token, err := helpers.GenerateActivityToken(activityType, codeId, fmt.Sprintf("%s:%d", codeId, window), expiry)
+	if err != nil {
+		slog.Error("Failed to sign activity token", "err", err)
+		return res, err
+	}
+
+	image, err := qrcode.Encode(token, qrcode.Medium, cnf.GetInt("ACTIVITY_QR_SIZE"))
+	if err != nil {
+		slog.Error("Failed to encode QR code", "err", err)
+		return res, err
+	}
+
+	res = models.ResponseActivityToken{
+		CodeID:          codeId,
+		Token:           token,
+		QRCode:          "data:image/png;base64," + base64.StdEncoding.EncodeToString(image),
+		RotationSeconds: rotationSeconds,
+		ExpiresAt:       windowEnd.Format("2006-01-02 15:04:05"),
+	}
+
+	return res, nil
+}
+
+// Claim enforces the rules of the code behind a scanned token and reserves
+// one use of the token for the user. Once a code rotates only its rotating
+// tokens are accepted, a static code only accepts its latest issued token.
+// A user can use a token once, or up to the per user limit of a treasure so
+// multi claim treasures work with a static QR code. Codes that never had a
+// token issued here keep accepting their original token. The reservation is a
+// single insert keyed on the use number, so concurrent scans of the same token
+// can't both get through. It returns the use number to release, 0 when
+// nothing was reserved.
+func (s *ActivityTokenService) Claim(ctx context.Context, userId int64, claims *helpers.ActivityClaims) (int32, error) {
+	code, err := s.Repository.GetCodeById(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the activity handler reports the missing activity itself
+			return 0, nil
+		}
+		slog.Error("Failed to get code", "err", err)
+		return 0, err
+	}
+
+	if !tokenAccepted(code, claims.ID) {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "QR code sudah tidak berlaku")
+	}
+
+	if claims.ID == "" {
+		return 0, nil
+	}
+
+	maxUses := int32(1)
+	if claims.Type == "treasure" {
+		treasure, err := s.Repository.GetTreasureByCodeId(ctx, code.ID)
+		if err == nil {
+			maxUses = max(treasure.PerUserLimit, 1)
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Error("Failed to get treasure", "err", err)
+			return 0, err
+		}
+	}
+
+	useNumber, err := s.Repository.ClaimActivityTokenUse(ctx, repositories.ClaimActivityTokenUseParams{
+		Jti:     claims.ID,
+		UserID:  userId,
+		CodeID:  claims.Subject,
+		MaxUses: maxUses,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fiber.NewError(fiber.StatusBadRequest, "QR code ini sudah Anda gunakan")
+		}
+		slog.Error("Failed to record token use", "err", err)
+		return 0, err
+	}
+
+	return useNumber, nil
+}
+
+// tokenAccepted reports whether a code still takes the token with this jti.
+func tokenAccepted(code repositories.Code, jti string) bool {
+	switch {
+	case code.RotationSeconds.Valid:
+		return strings.HasPrefix(jti, code.ID+":")
+	case code.TokenID.Valid:
+		return jti == code.TokenID.String
+	}
+	return true
+}
+
+// Release gives a claimed use of a token back when the scan didn't go
+// through, so the user can try again.
+func (s *ActivityTokenService) Release(ctx context.Context, userId int64, claims *helpers.ActivityClaims, useNumber int32) error {
+	if useNumber == 0 {
+		return nil
+	}
+
+	err := s.Repository.DeleteActivityTokenUse(ctx, repositories.DeleteActivityTokenUseParams{
+		Jti:       claims.ID,
+		UserID:    userId,
+		UseNumber: useNumber,
+	})
+	if err != nil {
+		slog.Error("Failed to release token use", "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/repositories"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// tokenDB fakes the queries Claim and Release run: one code, the per user
+// limit of its treasure and the token uses recorded against it.
+type tokenDB struct {
+	code         repositories.Code
+	perUserLimit int32
+	uses         map[string][]int32
+}
+
+func (db *tokenDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if !strings.Contains(sql, "name: DeleteActivityTokenUse") {
+		return pgconn.CommandTag{}, errors.New("unexpected exec: " + sql)
+	}
+
+	key := fmt.Sprint(args[0], "/", args[1])
+	db.uses[key] = slices.DeleteFunc(db.uses[key], func(n int32) bool { return n == args[2].(int32) })
+	return pgconn.NewCommandTag("DELETE 1"), nil
+}
+
+func (db *tokenDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query: " + sql)
+}
+
+func (db *tokenDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	switch {
+	case strings.Contains(sql, "name: GetCodeById"):
+		return rowFunc(func(dest ...any) error {
+			*dest[0].(*string) = db.code.ID
+			*dest[1].(*string) = db.code.ImageUrl
+			*dest[2].(*pgtype.Text) = db.code.TokenID
+			*dest[3].(*pgtype.Int4) = db.code.RotationSeconds
+			return nil
+		})
+	case strings.Contains(sql, "name: GetTreasureByCodeId"):
+		return rowFunc(func(dest ...any) error {
+			if db.perUserLimit == 0 {
+				return pgx.ErrNoRows
+			}
+			*dest[11].(*int32) = db.perUserLimit
+			return nil
+		})
+	case strings.Contains(sql, "name: ClaimActivityTokenUse"):
+		return rowFunc(func(dest ...any) error {
+			key := fmt.Sprint(args[0], "/", args[1])
+			if int32(len(db.uses[key])) >= args[3].(int32) {
+				return pgx.ErrNoRows
+			}
+			next := int32(1)
+			if len(db.uses[key]) > 0 {
+				next = slices.Max(db.uses[key]) + 1
+			}
+			db.uses[key] = append(db.uses[key], next)
+			*dest[0].(*int32) = next
+			return nil
+		})
+	}
+	return rowFunc(func(dest ...any) error { return errors.New("unexpected query row: " + sql) })
+}
+
+type rowFunc func(dest ...any) error
+
+func (f rowFunc) Scan(dest ...any) error {
+	return f(dest...)
+}
+
+func TestTokenAccepted(t *testing.T) {
+	legacy := repositories.Code{ID: "code-1"}
+	static := repositories.Code{ID: "code-1", TokenID: pgtype.Text{String: "jti-2", Valid: true}}
+	rotating := repositories.Code{ID: "code-1", TokenID: static.TokenID, RotationSeconds: pgtype.Int4{Int32: 30, Valid: true}}
+
+	tests := []struct {
+		name string
+		code repositories.Code
+		jti  string
+		want bool
+	}{
+		{name: "legacy code takes its original token", code: legacy, jti: "", want: true},
+		{name: "static code takes the latest token", code: static, jti: "jti-2", want: true},
+		{name: "static code drops a revoked token", code: static, jti: "jti-1", want: false},
+		{name: "static code drops a token without jti", code: static, jti: "", want: false},
+		{name: "rotating code takes a window token", code: rotating, jti: "code-1:58000", want: true},
+		{name: "rotating code drops the static token", code: rotating, jti: "jti-2", want: false},
+		{name: "rotating code drops another code's window", code: rotating, jti: "code-10:58000", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenAccepted(tt.code, tt.jti); got != tt.want {
+				t.Errorf("tokenAccepted(%q) = %v, want %v", tt.jti, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimReplay(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokenType    string
+		perUserLimit int32
+		uses         int
+	}{
+		{name: "quest token", tokenType: "quest", uses: 1},
+		{name: "single claim treasure", tokenType: "treasure", perUserLimit: 1, uses: 1},
+		{name: "multi claim treasure", tokenType: "treasure", perUserLimit: 3, uses: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := &tokenDB{
+				code:         repositories.Code{ID: "code-1", TokenID: pgtype.Text{String: "jti-1", Valid: true}},
+				perUserLimit: tt.perUserLimit,
+				uses:         map[string][]int32{},
+			}
+			s := &ActivityTokenService{Repository: repositories.New(db)}
+			claims := &helpers.ActivityClaims{Type: tt.tokenType}
+			claims.Subject, claims.ID = "code-1", "jti-1"
+
+			var last int32
+			for i := 1; i <= tt.uses; i++ {
+				useNumber, err := s.Claim(ctx, 1, claims)
+				if err != nil {
+					t.Fatalf("claim %d = %v, want nil", i, err)
+				}
+				last = useNumber
+			}
+
+			var fiberErr *fiber.Error
+			if _, err := s.Claim(ctx, 1, claims); !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+				t.Fatalf("replayed claim = %v, want bad request", err)
+			}
+
+			if _, err := s.Claim(ctx, 2, claims); err != nil {
+				t.Fatalf("claim by another user = %v, want nil", err)
+			}
+
+			if err := s.Release(ctx, 1, claims, last); err != nil {
+				t.Fatalf("release = %v, want nil", err)
+			}
+			if _, err := s.Claim(ctx, 1, claims); err != nil {
+				t.Fatalf("claim after release = %v, want nil", err)
+			}
+		})
+	}
+}
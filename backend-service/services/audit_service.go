@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	AuditEventAttendeesExport = "event.attendees.export"
+)
+
+type AuditService struct {
+	Repository *repositories.Queries
+}
+
+func NewAuditService(
+	rp *repositories.Queries,
+) *AuditService {
+	return &AuditService{
+		Repository: rp,
+	}
+}
+
+// RecordAudit keeps a trail of who accessed personal data. Callers should
+// refuse the access when it can't be recorded.
+func (s *AuditService) RecordAudit(ctx context.Context, userId int64, action string, subjectType string, subjectId int64, ip string, detail string) error {
+	err := s.Repository.CreateAuditLog(ctx, repositories.CreateAuditLogParams{
+		UserID:      pgtype.Int8{Int64: userId, Valid: true},
+		Action:      action,
+		SubjectType: subjectType,
+		SubjectID:   subjectId,
+		Detail:      detail,
+		IpAddress:   ip,
+	})
+	if err != nil {
+		slog.Error("Failed to create audit log", "err", err)
+		return err
+	}
+
+	return nil
+}
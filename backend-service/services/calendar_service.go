@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CalendarService struct {
+	Repository *repositories.Queries
+}
+
+func NewCalendarService(
+	rp *repositories.Queries,
+) *CalendarService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("CALENDAR_TIMEZONE", "Asia/Jakarta")
+
+	return &CalendarService{
+		Repository: rp,
+	}
+}
+
+// FeedToken returns the secret token of the user's calendar feed, creating it
+// on first use. Resetting swaps it for a new one so the old URL stops working.
+func (s *CalendarService) FeedToken(ctx context.Context, userId int64, reset bool) (string, error) {
+	if !reset {
+		feed, err := s.Repository.GetCalendarFeedByUserId(ctx, userId)
+		if err == nil {
+			return feed.Token, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Error("Failed to get calendar feed", "err", err)
+			return "", err
+		}
+	}
+
+	raw := make([]byte, 24)
+	_, err := rand.Read(raw)
+	if err != nil {
+		slog.Error("Failed to generate calendar token", "err", err)
+		return "", err
+	}
+
+	feed, err := s.Repository.UpsertCalendarFeed(ctx, repositories.UpsertCalendarFeedParams{
+		UserID: userId,
+		Token:  hex.EncodeToString(raw),
+	})
+	if err != nil {
+		slog.Error("Failed to save calendar feed", "err", err)
+		return "", err
+	}
+
+	return feed.Token, nil
+}
+
+// UserFeed renders every event the owner of the token registered for.
+func (s *CalendarService) UserFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.Repository.GetCalendarFeedByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Kalender tidak ditemukan")
+		}
+		slog.Error("Failed to get calendar feed", "err", err)
+		return nil, err
+	}
+
+	rows, err := s.Repository.GetUserCalendarEvents(ctx, feed.UserID)
+	if err != nil {
+		slog.Error("Failed to get calendar events", "err", err)
+		return nil, err
+	}
+
+	events := []helpers.ICalEvent{}
+	for _, row := range rows {
+		events = append(events, s.toICalEvent(repositories.GetCalendarEventByIdRow(row)))
+	}
+
+	return helpers.BuildICalendar("Event Raksana", events), nil
+}
+
+// EventFile renders a single event, for adding it to a calendar by hand.
+func (s *CalendarService) EventFile(ctx context.Context, eventId int64) ([]byte, error) {
+	row, err := s.Repository.GetCalendarEventById(ctx, eventId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Event does not exists")
+		}
+		slog.Error("Failed to get calendar event", "err", err)
+		return nil, err
+	}
+
+	return helpers.BuildICalendar(row.Name, []helpers.ICalEvent{s.toICalEvent(row)}), nil
+}
+
+// toICalEvent keeps the UID stable per event, so calendar apps update the
+// entry in place when the event is moved or renamed.
+func (s *CalendarService) toICalEvent(row repositories.GetCalendarEventByIdRow) helpers.ICalEvent {
+	description := row.Description
+	if row.Contact != "" {
+		description += "\n\nKontak: " + row.Contact
+	}
+
+	var lastModified time.Time
+	if row.UpdatedAt.Valid {
+		lastModified = s.localTime(row.UpdatedAt)
+	}
+
+	return helpers.ICalEvent{
+		UID:          fmt.Sprintf("event-%d@raksana", row.ID),
+		Summary:      row.Name,
+		Description:  description,
+		Location:     row.Location,
+		Latitude:     row.Latitude,
+		Longitude:    row.Longitude,
+		Start:        s.localTime(row.StartsAt),
+		End:          s.localTime(row.EndsAt),
+		LastModified: lastModified,
+	}
+}
+
+// localTime reads the wall clock time stored in the database as a time in
+// CALENDAR_TIMEZONE.
+func (s *CalendarService) localTime(ts pgtype.Timestamp) time.Time {
+	loc, err := time.LoadLocation(helpers.NewConfig().GetString("CALENDAR_TIMEZONE"))
+	if err != nil {
+		slog.Error("Failed to load calendar timezone", "err", err)
+		loc = time.UTC
+	}
+
+	t := ts.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
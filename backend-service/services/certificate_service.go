@@ -0,0 +1,441 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const certificateCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var monthNames = []string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+var (
+	certificateGreen = color.RGBA{R: 46, G: 125, B: 50, A: 255}
+	certificateInk   = color.RGBA{R: 33, G: 33, B: 33, A: 255}
+	certificateMuted = color.RGBA{R: 97, G: 97, B: 97, A: 255}
+)
+
+var (
+	fontsOnce   sync.Once
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+	fontsErr    error
+)
+
+type CertificateService struct {
+	Redis      *redis.Client
+	Repository *repositories.Queries
+	*MediaService
+}
+
+func NewCertificateService(
+	r *redis.Client,
+	rp *repositories.Queries,
+	ms *MediaService,
+) *CertificateService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("CERTIFICATE_MAX_RANGE_DAYS", 366)
+	cnf.SetDefault("CERTIFICATE_COOLDOWN_SECONDS", 60)
+	cnf.SetDefault("CERTIFICATE_DAILY_LIMIT", 10)
+
+	return &CertificateService{
+		Redis:        r,
+		Repository:   rp,
+		MediaService: ms,
+	}
+}
+
+// Issue renders a certificate of the volunteer hours of the user, either for
+// one attended event or for every event attended between from and to. The
+// printed code carries an HMAC of what the certificate states, so a copy
+// with edited numbers fails verification.
+func (s *CertificateService) Issue(ctx context.Context, userId int64, req *models.PostCertificate, verifyBaseUrl string) (models.ResponseCertificate, error) {
+	var res models.ResponseCertificate
+
+	var eventId pgtype.Int8
+	var dateFrom, dateTo pgtype.Timestamp
+	var periodFrom, periodTo pgtype.Date
+
+	switch {
+	case req.EventID != 0 && (req.From != "" || req.To != ""):
+		return res, fiber.NewError(fiber.StatusBadRequest, "Pilih satu event atau rentang tanggal, tidak keduanya")
+	case req.EventID != 0:
+		eventId = pgtype.Int8{Int64: req.EventID, Valid: true}
+	case req.From != "" && req.To != "":
+		from, _ := time.Parse("2006-01-02", req.From)
+		to, _ := time.Parse("2006-01-02", req.To)
+		if to.Before(from) {
+			return res, fiber.NewError(fiber.StatusBadRequest, "Tanggal akhir harus setelah tanggal awal")
+		}
+		if to.Sub(from).Hours()/24 > helpers.NewConfig().GetFloat64("CERTIFICATE_MAX_RANGE_DAYS") {
+			return res, fiber.NewError(fiber.StatusBadRequest, "Rentang tanggal terlalu panjang")
+		}
+		dateFrom = pgtype.Timestamp{Time: from, Valid: true}
+		dateTo = pgtype.Timestamp{Time: to.AddDate(0, 0, 1), Valid: true}
+		periodFrom = pgtype.Date{Time: from, Valid: true}
+		periodTo = pgtype.Date{Time: to, Valid: true}
+	default:
+		return res, fiber.NewError(fiber.StatusBadRequest, "event_id atau from dan to wajib diisi")
+	}
+
+	events, err := s.Repository.GetVolunteerEvents(ctx, repositories.GetVolunteerEventsParams{
+		UserID:   userId,
+		EventID:  eventId,
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+	})
+	if err != nil {
+		slog.Error("Failed to get volunteer events", "err", err)
+		return res, err
+	}
+	if len(events) == 0 {
+		return res, fiber.NewError(fiber.StatusBadRequest, "Belum ada kehadiran event yang tercatat untuk sertifikat ini")
+	}
+
+	err = s.limitIssue(ctx, userId)
+	if err != nil {
+		return res, err
+	}
+
+	user, err := s.Repository.GetUserProfileStatistic(ctx, userId)
+	if err != nil {
+		slog.Error("Failed to get user", "err", err)
+		return res, err
+	}
+
+	var totalMinutes int32
+	for _, e := range events {
+		totalMinutes += max(e.CreditedMinutes, 0)
+	}
+
+	id, err := randomCertificateId()
+	if err != nil {
+		slog.Error("Failed to generate certificate code", "err", err)
+		return res, err
+	}
+	code := id + "-" + helpers.SignCode(certificatePayload(id, userId, eventId, periodFrom, periodTo, int32(len(events)), totalMinutes))
+	verifyUrl := verifyBaseUrl + code
+	issuedAt := time.Now()
+
+	image, err := renderCertificate(user.Name, events, periodFrom, periodTo, totalMinutes, code, verifyUrl, issuedAt)
+	if err != nil {
+		slog.Error("Failed to render certificate", "err", err)
+		return res, err
+	}
+
+	key := fmt.Sprintf("certificates/%d/%s.png", userId, code)
+	err = s.MediaService.UploadFile(ctx, key, "image/png", image)
+	if err != nil {
+		return res, err
+	}
+
+	certificate, err := s.Repository.CreateCertificate(ctx, repositories.CreateCertificateParams{
+		Code:         code,
+		UserID:       userId,
+		EventID:      eventId,
+		PeriodFrom:   periodFrom,
+		PeriodTo:     periodTo,
+		EventCount:   int32(len(events)),
+		TotalMinutes: totalMinutes,
+		ImageKey:     key,
+	})
+	if err != nil {
+		slog.Error("Failed to create certificate", "err", err)
+		return res, err
+	}
+
+	res = models.ResponseCertificate{
+		Code:       certificate.Code,
+		Holder:     user.Name,
+		EventCount: certificate.EventCount,
+		Hours:      minutesToHours(certificate.TotalMinutes),
+		ImageUrl:   helpers.NewConfig().GetString("AWS_URL") + key,
+		VerifyUrl:  verifyUrl,
+		IssuedAt:   issuedAt.Format("2006-01-02 15:04"),
+		Valid:      true,
+	}
+	if eventId.Valid {
+		res.EventName = events[0].Name
+	}
+	if periodFrom.Valid {
+		res.PeriodFrom = periodFrom.Time.Format("2006-01-02")
+		res.PeriodTo = periodTo.Time.Format("2006-01-02")
+	}
+	for _, e := range events {
+		res.Events = append(res.Events, models.ResponseCertificateEvent{
+			ID:       e.ID,
+			Name:     e.Name,
+			StartsAt: e.StartsAt.Time.Format("2006-01-02 15:04"),
+			Hours:    minutesToHours(e.CreditedMinutes),
+		})
+	}
+
+	return res, nil
+}
+
+// Verify looks a certificate up by its printed code and checks the signature
+// against the stored figures.
+func (s *CertificateService) Verify(ctx context.Context, code string) (models.ResponseCertificate, error) {
+	var res models.ResponseCertificate
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	row, err := s.Repository.GetCertificateByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return res, fiber.NewError(fiber.StatusNotFound, "Sertifikat tidak ditemukan")
+		}
+		slog.Error("Failed to get certificate", "err", err)
+		return res, err
+	}
+
+	id, signature, _ := strings.Cut(row.Code, "-")
+	expected := helpers.SignCode(certificatePayload(id, row.UserID, row.EventID, row.PeriodFrom, row.PeriodTo, row.EventCount, row.TotalMinutes))
+
+	res = models.ResponseCertificate{
+		Code:       row.Code,
+		Holder:     row.UserName,
+		EventName:  row.EventName.String,
+		EventCount: row.EventCount,
+		Hours:      minutesToHours(row.TotalMinutes),
+		ImageUrl:   helpers.NewConfig().GetString("AWS_URL") + row.ImageKey,
+		IssuedAt:   row.IssuedAt.Time.Format("2006-01-02 15:04"),
+		Valid:      signature == expected,
+	}
+	if row.PeriodFrom.Valid {
+		res.PeriodFrom = row.PeriodFrom.Time.Format("2006-01-02")
+		res.PeriodTo = row.PeriodTo.Time.Format("2006-01-02")
+	}
+
+	return res, nil
+}
+
+// limitIssue allows one certificate per CERTIFICATE_COOLDOWN_SECONDS and
+// CERTIFICATE_DAILY_LIMIT a day per user, every issue renders and uploads a
+// new image.
+func (s *CertificateService) limitIssue(ctx context.Context, userId int64) error {
+	cnf := helpers.NewConfig()
+
+	cooldownKey := fmt.Sprintf("user:%d:certificate_cooldown", userId)
+	countKey := fmt.Sprintf("user:%d:certificates", userId)
+
+	cooldown := time.Duration(cnf.GetInt("CERTIFICATE_COOLDOWN_SECONDS")) * time.Second
+	ok, err := s.Redis.SetNX(ctx, cooldownKey, 1, cooldown).Result()
+	if err != nil {
+		return fmt.Errorf("redis set cooldown failed: %w", err)
+	}
+	if !ok {
+		return fiber.NewError(fiber.StatusTooManyRequests, fmt.Sprintf("Tunggu %d detik sebelum membuat sertifikat lagi", cnf.GetInt("CERTIFICATE_COOLDOWN_SECONDS")))
+	}
+
+	count, err := s.Redis.Incr(ctx, countKey).Result()
+	if err != nil {
+		return fmt.Errorf("redis incr failed: %w", err)
+	}
+	if count == 1 {
+		err = s.Redis.ExpireAt(ctx, countKey, helpers.NextMidnight()).Err()
+		if err != nil {
+			return fmt.Errorf("redis expire failed: %w", err)
+		}
+	}
+
+	if count > cnf.GetInt64("CERTIFICATE_DAILY_LIMIT") {
+		return fiber.NewError(fiber.StatusTooManyRequests, "Batas pembuatan sertifikat hari ini sudah habis")
+	}
+
+	return nil
+}
+
+func certificatePayload(id string, userId int64, eventId pgtype.Int8, from pgtype.Date, to pgtype.Date, eventCount int32, totalMinutes int32) string {
+	return fmt.Sprintf("%s|%d|%d|%s|%s|%d|%d",
+		id, userId, eventId.Int64,
+		from.Time.Format("2006-01-02"), to.Time.Format("2006-01-02"),
+		eventCount, totalMinutes,
+	)
+}
+
+func randomCertificateId() (string, error) {
+	raw := make([]byte, 10)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+
+	id := make([]byte, len(raw))
+	for i, b := range raw {
+		id[i] = certificateCodeAlphabet[int(b)%len(certificateCodeAlphabet)]
+	}
+	return string(id), nil
+}
+
+func minutesToHours(minutes int32) float64 {
+	return math.Round(float64(minutes)/6) / 10
+}
+
+func formatHours(minutes int32) string {
+	return strconv.FormatFloat(minutesToHours(minutes), 'f', -1, 64)
+}
+
+func formatIndonesianDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[t.Month()-1], t.Year())
+}
+
+func loadCertificateFonts() error {
+	fontsOnce.Do(func() {
+		regularFont, fontsErr = opentype.Parse(goregular.TTF)
+		if fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+type certificateCanvas struct {
+	img *image.RGBA
+}
+
+func (c *certificateCanvas) text(f *opentype.Font, size float64, col color.Color, s string, x int, y int, centered bool) error {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face}
+	width := c.img.Bounds().Dx()
+	limit := width - 240
+	if !centered {
+		limit = width - x - 120
+	}
+
+	// names and event titles are cut to fit inside the border
+	for d.MeasureString(s).Ceil() > limit && len(s) > 1 {
+		runes := []rune(strings.TrimSuffix(s, "…"))
+		s = string(runes[:len(runes)-1]) + "…"
+	}
+
+	if centered {
+		x = (width - d.MeasureString(s).Ceil()) / 2
+	}
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+	return nil
+}
+
+func (c *certificateCanvas) rect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// renderCertificate draws the certificate as a landscape PNG with a QR code
+// linking to the public verification page.
+func renderCertificate(holder string, events []repositories.GetVolunteerEventsRow, from pgtype.Date, to pgtype.Date, totalMinutes int32, code string, verifyUrl string, issuedAt time.Time) ([]byte, error) {
+	err := loadCertificateFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	const width, height = 1600, 1130
+	c := &certificateCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+
+	c.rect(c.img.Bounds(), color.White)
+	c.rect(image.Rect(40, 40, width-40, height-40), certificateGreen)
+	c.rect(image.Rect(52, 52, width-52, height-52), color.White)
+	c.rect(image.Rect(70, 70, width-70, 76), certificateGreen)
+	c.rect(image.Rect(70, height-76, width-70, height-70), certificateGreen)
+
+	type line struct {
+		font     *opentype.Font
+		size     float64
+		col      color.Color
+		text     string
+		y        int
+		centered bool
+		x        int
+	}
+
+	lines := []line{
+		{boldFont, 60, certificateGreen, "SERTIFIKAT RELAWAN LINGKUNGAN", 200, true, 0},
+		{regularFont, 30, certificateMuted, "Diberikan kepada", 290, true, 0},
+		{boldFont, 68, certificateInk, holder, 390, true, 0},
+	}
+
+	if !from.Valid {
+		lines = append(lines,
+			line{regularFont, 30, certificateInk, "atas partisipasinya sebagai relawan dalam kegiatan", 480, true, 0},
+			line{boldFont, 40, certificateInk, events[0].Name, 545, true, 0},
+			line{regularFont, 30, certificateInk, fmt.Sprintf("pada %s dengan %s jam kegiatan relawan.", formatIndonesianDate(events[0].StartsAt.Time), formatHours(totalMinutes)), 610, true, 0},
+		)
+	} else {
+		lines = append(lines,
+			line{regularFont, 30, certificateInk, fmt.Sprintf("atas partisipasinya dalam %d kegiatan lingkungan dengan total %s jam kegiatan relawan", len(events), formatHours(totalMinutes)), 480, true, 0},
+			line{regularFont, 30, certificateInk, fmt.Sprintf("periode %s - %s:", formatIndonesianDate(from.Time), formatIndonesianDate(to.Time)), 530, true, 0},
+		)
+
+		shown := min(len(events), 6)
+		for i, e := range events[:shown] {
+			lines = append(lines, line{regularFont, 24, certificateInk,
+				fmt.Sprintf("•  %s  -  %s (%s jam)", formatIndonesianDate(e.StartsAt.Time), e.Name, formatHours(e.CreditedMinutes)),
+				590 + i*40, false, 260,
+			})
+		}
+		if len(events) > shown {
+			lines = append(lines, line{regularFont, 24, certificateMuted, fmt.Sprintf("dan %d kegiatan lainnya", len(events)-shown), 590 + shown*40, false, 260})
+		}
+	}
+
+	lines = append(lines,
+		line{regularFont, 24, certificateMuted, "Diterbitkan " + formatIndonesianDate(issuedAt), 960, false, 120},
+		line{regularFont, 24, certificateMuted, "Kode verifikasi", 1000, false, 120},
+		line{boldFont, 30, certificateInk, code, 1040, false, 120},
+	)
+
+	for _, l := range lines {
+		err = c.text(l.font, l.size, l.col, l.text, l.x, l.y, l.centered)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	qr, err := qrcode.New(verifyUrl, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	qrImage := qr.Image(200)
+	qrOrigin := image.Pt(width-120-200, height-100-200)
+	draw.Draw(c.img, image.Rectangle{Min: qrOrigin, Max: qrOrigin.Add(qrImage.Bounds().Size())}, qrImage, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	err = png.Encode(&buf, c.img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
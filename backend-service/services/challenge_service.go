@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ChallengeService struct {
+	Repository *repositories.Queries
+	*PointService
+	*JournalService
+	*NotificationService
+}
+
+func NewChallengeService(
+	rp *repositories.Queries,
+	ps *PointService,
+	js *JournalService,
+	ns *NotificationService,
+) *ChallengeService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("CHALLENGE_TOP_BONUS_POINTS", 50)
+	cnf.SetDefault("CHALLENGE_TOP_BONUS_WINNERS", 3)
+	cnf.SetDefault("CHALLENGE_TOP_BONUS_MIN_VOTES", 1)
+	cnf.SetDefault("CHALLENGE_BONUS_SETTLE_GRACE_HOURS", 48)
+
+	return &ChallengeService{
+		Repository:          rp,
+		PointService:        ps,
+		JournalService:      js,
+		NotificationService: ns,
+	}
+}
+
+// challengeClock is the current Asia/Jakarta wall time. Challenges are
+// published daily in Asia/Jakarta and their created_at is stored as local
+// time, so the clock is expressed the same way.
+func challengeClock() (time.Time, error) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		slog.Error("Failed to get current timezone", "err", err)
+		return time.Time{}, err
+	}
+
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.UTC), nil
+}
+
+// challengeToday is the start of the current challenge day.
+func challengeToday() (time.Time, error) {
+	now, err := challengeClock()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return now.Truncate(24 * time.Hour), nil
+}
+
+// IsOpen reports whether a challenge published at createdAt still takes
+// submissions and votes.
+func (s *ChallengeService) IsOpen(createdAt pgtype.Timestamp) (bool, error) {
+	today, err := challengeToday()
+	if err != nil {
+		return false, err
+	}
+
+	return createdAt.Time.Format("2006-01-02") == today.Format("2006-01-02"), nil
+}
+
+// bonusSettleable reports whether a closed challenge can be settled. Proofs
+// still waiting for moderation when the day closes could have ranked, so
+// settlement waits for them until the grace period after the day is over;
+// after that only the approved proofs compete and later approvals get no bonus.
+func bonusSettleable(challenge repositories.GetUnsettledChallengesRow, now time.Time, grace time.Duration) bool {
+	if challenge.PendingReviews == 0 {
+		return true
+	}
+
+	dayEnd := challenge.CreatedAt.Time.Truncate(24 * time.Hour).Add(24 * time.Hour)
+	return !now.Before(dayEnd.Add(grace))
+}
+
+// topVoted keeps the leading submissions that reached minVotes, top is
+// already ordered by votes.
+func topVoted(top []repositories.GetTopParticipationsRow, minVotes int32) []repositories.GetTopParticipationsRow {
+	for i, participation := range top {
+		if participation.Votes < minVotes {
+			return top[:i]
+		}
+	}
+
+	return top
+}
+
+// SettleClosedChallenges grants the top voted submission bonus of every
+// challenge whose day is over, it runs as a background job. Claiming the
+// challenge and paying the winners share a transaction, so a failed payout
+// leaves the challenge unsettled for the next run.
+func (s *ChallengeService) SettleClosedChallenges(ctx context.Context) error {
+	now, err := challengeClock()
+	if err != nil {
+		return err
+	}
+	today := now.Truncate(24 * time.Hour)
+
+	challenges, err := s.Repository.GetUnsettledChallenges(ctx, pgtype.Timestamp{Time: today, Valid: true})
+	if err != nil {
+		slog.Error("Failed to get unsettled challenges", "err", err)
+		return err
+	}
+
+	cnf := helpers.NewConfig()
+	bonus := cnf.GetInt32("CHALLENGE_TOP_BONUS_POINTS")
+	winners := cnf.GetInt32("CHALLENGE_TOP_BONUS_WINNERS")
+	minVotes := cnf.GetInt32("CHALLENGE_TOP_BONUS_MIN_VOTES")
+	grace := time.Duration(cnf.GetInt("CHALLENGE_BONUS_SETTLE_GRACE_HOURS")) * time.Hour
+
+	for _, challenge := range challenges {
+		if !bonusSettleable(challenge, now, grace) {
+			continue
+		}
+
+		err = s.settleChallenge(ctx, challenge, bonus, winners, minVotes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type topVotedAward struct {
+	participation repositories.GetTopParticipationsRow
+	rank          int
+	points        int64
+}
+
+func (s *ChallengeService) settleChallenge(ctx context.Context, challenge repositories.GetUnsettledChallengesRow, bonus int32, winners int32, minVotes int32) error {
+	var awards []topVotedAward
+	historyMsg := fmt.Sprintf("Bonus submission terpopuler challenge %s", challenge.Name)
+	err := s.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		claimed, err := q.SettleChallengeBonus(ctx, challenge.ChallengeID)
+		if err != nil {
+			return err
+		}
+		if claimed == 0 || bonus <= 0 || winners <= 0 {
+			return nil
+		}
+
+		top, err := q.GetTopParticipations(ctx, repositories.GetTopParticipationsParams{
+			ChallengeID: challenge.ChallengeID,
+			Limit:       winners,
+		})
+		if err != nil {
+			return err
+		}
+
+		for rank, participation := range topVoted(top, minVotes) {
+			profile, err := q.GetUserProfile(ctx, participation.UserID)
+			if err != nil {
+				return err
+			}
+
+			points, err := s.PointService.AddUserPoint(ctx, q, participation.UserID, int64(bonus), historyMsg, "challenge", int(profile.Level))
+			if err != nil {
+				return err
+			}
+
+			err = q.SetParticipationBonus(ctx, repositories.SetParticipationBonusParams{
+				BonusPoints: int32(points),
+				ID:          participation.ParticipationID,
+			})
+			if err != nil {
+				return err
+			}
+
+			awards = append(awards, topVotedAward{participation: participation, rank: rank + 1, points: points})
+		}
+
+		return nil
+	})
+	if err != nil {
+		slog.Error("Failed to settle challenge bonus", "err", err)
+		return err
+	}
+
+	for _, award := range awards {
+		err = s.announceTopVoted(ctx, challenge, award)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ChallengeService) announceTopVoted(ctx context.Context, challenge repositories.GetUnsettledChallengesRow, award topVotedAward) error {
+	participation := award.participation
+	err := s.PointService.IncrLeaderboard(participation.UserID, award.points)
+	if err != nil {
+		return err
+	}
+
+	logMsg := fmt.Sprintf("Submission challenge harian day-%v masuk peringkat %d terpopuler dengan %d vote, dan mendapatkan bonus poin sebesar %v", challenge.Day, award.rank, participation.Votes, award.points)
+	err = s.JournalService.AppendLog(&models.PostLogAppend{
+		Text:      logMsg,
+		IsSystem:  true,
+		IsPrivate: false,
+	}, int(participation.UserID))
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Submission kamu di challenge %s berada di peringkat %d dengan %d vote. Kamu mendapatkan bonus %d poin.", challenge.Name, award.rank, participation.Votes, award.points)
+	return s.NotificationService.Notify(ctx, participation.UserID, NotificationChallengeTopVoted, "Submission terpopuler", body)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"jirbthagoras/raksana-backend/repositories"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestBonusSettleable(t *testing.T) {
+	createdAt := pgtype.Timestamp{Time: time.Date(2025, 10, 9, 6, 0, 0, 0, time.UTC), Valid: true}
+	dayEnd := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	grace := 48 * time.Hour
+
+	tests := []struct {
+		name    string
+		pending int32
+		now     time.Time
+		want    bool
+	}{
+		{"nothing pending", 0, dayEnd, true},
+		{"pending within grace", 2, dayEnd.Add(47 * time.Hour), false},
+		{"pending at grace end", 2, dayEnd.Add(grace), true},
+		{"pending after grace", 1, dayEnd.Add(72 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			challenge := repositories.GetUnsettledChallengesRow{CreatedAt: createdAt, PendingReviews: tt.pending}
+			if got := bonusSettleable(challenge, tt.now, grace); got != tt.want {
+				t.Errorf("bonusSettleable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopVoted(t *testing.T) {
+	rows := func(votes ...int32) []repositories.GetTopParticipationsRow {
+		var top []repositories.GetTopParticipationsRow
+		for i, v := range votes {
+			top = append(top, repositories.GetTopParticipationsRow{ParticipationID: int64(i + 1), Votes: v})
+		}
+		return top
+	}
+
+	tests := []struct {
+		name     string
+		top      []repositories.GetTopParticipationsRow
+		minVotes int32
+		want     []int64
+	}{
+		{"all qualify", rows(5, 3, 1), 1, []int64{1, 2, 3}},
+		{"cut at threshold", rows(5, 3, 1), 3, []int64{1, 2}},
+		{"ties keep order", rows(4, 4, 2), 2, []int64{1, 2, 3}},
+		{"none qualify", rows(0, 0), 1, nil},
+		{"no submissions", nil, 1, nil},
+		{"zero threshold", rows(0), 0, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := topVoted(tt.top, tt.minVotes)
+			if len(got) != len(tt.want) {
+				t.Fatalf("topVoted() returned %d rows, want %d", len(got), len(tt.want))
+			}
+			for i, row := range got {
+				if row.ParticipationID != tt.want[i] {
+					t.Errorf("row %d = participation %d, want %d", i, row.ParticipationID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var dayNames = []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"}
+
+type DropoffService struct {
+	Repository *repositories.Queries
+}
+
+func NewDropoffService(
+	rp *repositories.Queries,
+) *DropoffService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("DROPOFF_DEFAULT_RADIUS", 5000)
+	cnf.SetDefault("DROPOFF_MATCH_RADIUS", 25000)
+	cnf.SetDefault("DROPOFF_TIMEZONE", "Asia/Jakarta")
+
+	return &DropoffService{
+		Repository: rp,
+	}
+}
+
+func (s *DropoffService) GetNearbyPoints(ctx context.Context, req *models.GetNearbyDropoff) ([]models.ResponseDropoffPoint, error) {
+	cnf := helpers.NewConfig()
+
+	radius := req.Radius
+	if radius == 0 {
+		radius = cnf.GetFloat64("DROPOFF_DEFAULT_RADIUS")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	rows, err := s.Repository.GetNearbyDropoffPoints(ctx, repositories.GetNearbyDropoffPointsParams{
+		Latitude:  *req.Latitude,
+		Longitude: *req.Longitude,
+		Radius:    radius,
+		Material:  req.Material,
+		PageLimit: int32(limit),
+	})
+	if err != nil {
+		slog.Error("Failed to get nearby drop-off points", "err", err)
+		return nil, err
+	}
+
+	points := []models.ResponseDropoffPoint{}
+	for _, row := range rows {
+		points = append(points, models.ResponseDropoffPoint{
+			ID:             row.ID,
+			Name:           row.Name,
+			Address:        row.Address,
+			Description:    row.Description.String,
+			Latitude:       row.Latitude,
+			Longitude:      row.Longitude,
+			Phone:          row.Phone.String,
+			DistanceMeters: math.Round(row.DistanceMeters),
+		})
+	}
+
+	err = s.attachDetails(ctx, points)
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (s *DropoffService) GetPointDetail(ctx context.Context, point repositories.DropoffPoint) (models.ResponseDropoffPoint, error) {
+	res := []models.ResponseDropoffPoint{{
+		ID:          point.ID,
+		Name:        point.Name,
+		Address:     point.Address,
+		Description: point.Description.String,
+		Latitude:    point.Latitude,
+		Longitude:   point.Longitude,
+		Phone:       point.Phone.String,
+	}}
+
+	err := s.attachDetails(ctx, res)
+	if err != nil {
+		return models.ResponseDropoffPoint{}, err
+	}
+
+	return res[0], nil
+}
+
+// NearestForMaterials returns the closest active drop-off point accepting
+// each of the given materials, keyed by material. Materials without a
+// drop-off point within DROPOFF_MATCH_RADIUS are left out.
+func (s *DropoffService) NearestForMaterials(ctx context.Context, latitude float64, longitude float64, materials []string) (map[string]models.ResponseDropoffPoint, error) {
+	nearest := map[string]models.ResponseDropoffPoint{}
+	if len(materials) == 0 {
+		return nearest, nil
+	}
+
+	cnf := helpers.NewConfig()
+
+	rows, err := s.Repository.GetNearestDropoffPerMaterial(ctx, repositories.GetNearestDropoffPerMaterialParams{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Materials: materials,
+		Radius:    cnf.GetFloat64("DROPOFF_MATCH_RADIUS"),
+	})
+	if err != nil {
+		slog.Error("Failed to get nearest drop-off points", "err", err)
+		return nil, err
+	}
+
+	points := []models.ResponseDropoffPoint{}
+	for _, row := range rows {
+		points = append(points, models.ResponseDropoffPoint{
+			ID:             row.ID,
+			Name:           row.Name,
+			Address:        row.Address,
+			Description:    row.Description.String,
+			Latitude:       row.Latitude,
+			Longitude:      row.Longitude,
+			Phone:          row.Phone.String,
+			DistanceMeters: math.Round(row.DistanceMeters),
+		})
+	}
+
+	err = s.attachDetails(ctx, points)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		nearest[row.Material] = points[i]
+	}
+
+	return nearest, nil
+}
+
+// attachDetails loads the accepted materials and opening hours of all the
+// given points in two queries and fills in whether they are open right now.
+func (s *DropoffService) attachDetails(ctx context.Context, points []models.ResponseDropoffPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var ids []int64
+	for _, p := range points {
+		ids = append(ids, p.ID)
+	}
+
+	materials, err := s.Repository.GetDropoffMaterialsByPointIds(ctx, ids)
+	if err != nil {
+		slog.Error("Failed to get drop-off materials", "err", err)
+		return err
+	}
+
+	hours, err := s.Repository.GetDropoffHoursByPointIds(ctx, ids)
+	if err != nil {
+		slog.Error("Failed to get drop-off hours", "err", err)
+		return err
+	}
+
+	materialsByPoint := map[int64][]string{}
+	for _, m := range materials {
+		materialsByPoint[m.DropoffPointID] = append(materialsByPoint[m.DropoffPointID], m.Material)
+	}
+
+	hoursByPoint := map[int64][]repositories.DropoffHour{}
+	for _, h := range hours {
+		hoursByPoint[h.DropoffPointID] = append(hoursByPoint[h.DropoffPointID], h)
+	}
+
+	now := s.now()
+	for i := range points {
+		points[i].Materials = materialsByPoint[points[i].ID]
+		if points[i].Materials == nil {
+			points[i].Materials = []string{}
+		}
+
+		points[i].OpeningHours = []models.ResponseDropoffHour{}
+		for _, h := range hoursByPoint[points[i].ID] {
+			// day_of_week is entered by hand, keep a bad value in range
+			day := (int(h.DayOfWeek)%7 + 7) % 7
+			points[i].OpeningHours = append(points[i].OpeningHours, models.ResponseDropoffHour{
+				Day:      day,
+				DayName:  dayNames[day],
+				OpensAt:  formatClock(h.OpensAt),
+				ClosesAt: formatClock(h.ClosesAt),
+			})
+
+			if isOpenAt(h, now) {
+				points[i].IsOpenNow = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *DropoffService) now() time.Time {
+	cnf := helpers.NewConfig()
+
+	loc, err := time.LoadLocation(cnf.GetString("DROPOFF_TIMEZONE"))
+	if err != nil {
+		slog.Error("Failed to load drop-off timezone", "err", err)
+		return time.Now()
+	}
+
+	return time.Now().In(loc)
+}
+
+func isOpenAt(h repositories.DropoffHour, t time.Time) bool {
+	if int(h.DayOfWeek) != int(t.Weekday()) {
+		return false
+	}
+
+	minute := int64(t.Hour()*60 + t.Minute())
+	return minute >= clockMinutes(h.OpensAt) && minute < clockMinutes(h.ClosesAt)
+}
+
+func clockMinutes(t pgtype.Time) int64 {
+	return t.Microseconds / int64(time.Minute/time.Microsecond)
+}
+
+func formatClock(t pgtype.Time) string {
+	minutes := clockMinutes(t)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
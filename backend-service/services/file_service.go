@@ -1,15 +1,27 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"jirbthagoras/raksana-backend/configs"
+	"jirbthagoras/raksana-backend/helpers"
+	"log/slog"
 	"path/filepath"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/gabriel-vasile/mimetype"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
+// sniffBytes is how much of an uploaded object is read to detect its type.
+const sniffBytes = 3072
+
 var allowedVideoTypes = []string{
 	"video/mp4",
 	"video/webm",
@@ -56,3 +68,64 @@ func (h *FileService) CreatePresignedURL(option string, userId string, filename
 
 	return presignedReq.URL, key, nil
 }
+
+// UploadedObject describes an object a client uploaded with a presigned URL.
+// ContentType is detected from the first bytes of the object, the header the
+// client sent with the upload isn't trusted.
+type UploadedObject struct {
+	ContentType string
+	Size        int64
+}
+
+// HeadUploadedObject confirms that a presigned upload happened and sniffs its
+// content type. It returns false without an error when nothing was uploaded
+// under the key.
+func (h *FileService) HeadUploadedObject(ctx context.Context, key string) (UploadedObject, bool, error) {
+	var object UploadedObject
+
+	cnf := helpers.NewConfig()
+	head, err := h.AWSClient.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(cnf.GetString("AWS_BUCKET")),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return object, false, nil
+		}
+		slog.Error("Failed to check object head", "key", key, "err", err)
+		return object, false, err
+	}
+
+	object.Size = aws.ToInt64(head.ContentLength)
+	if object.Size == 0 {
+		return object, true, nil
+	}
+
+	// mimetype only looks at the start of a file, no need to fetch all of it
+	part, err := h.AWSClient.S3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(cnf.GetString("AWS_BUCKET")),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", sniffBytes-1)),
+	})
+	if err != nil {
+		slog.Error("Failed to get object", "key", key, "err", err)
+		return object, false, err
+	}
+	defer part.Body.Close()
+
+	start, err := io.ReadAll(io.LimitReader(part.Body, sniffBytes))
+	if err != nil {
+		slog.Error("Failed to read object", "key", key, "err", err)
+		return object, false, err
+	}
+
+	object.ContentType = mimetype.Detect(start).String()
+	return object, true, nil
+}
+
+// DeleteUploadedObject removes an object uploaded with a presigned URL.
+func (h *FileService) DeleteUploadedObject(key string) error {
+	cnf := helpers.NewConfig()
+	return h.AWSClient.DeleteObject(cnf.GetString("AWS_BUCKET"), key)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ScanAccepted   = "accepted"
+	ScanFlagged    = "flagged"
+	ScanRejected   = "rejected"
+	ScanOverridden = "overridden"
+)
+
+type GeofenceService struct {
+	Repository *repositories.Queries
+}
+
+func NewGeofenceService(
+	rp *repositories.Queries,
+) *GeofenceService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("GEOFENCE_QUEST_RADIUS", 200)
+	cnf.SetDefault("GEOFENCE_EVENT_RADIUS", 300)
+	cnf.SetDefault("GEOFENCE_TREASURE_RADIUS", 100)
+	cnf.SetDefault("GEOFENCE_FLAG_FACTOR", 1.5)
+
+	return &GeofenceService{
+		Repository: rp,
+	}
+}
+
+// Verify checks the device location sent with an activity QR scan against
+// the location of the quest, event or treasure behind the code. Scans up to
+// the activity radius are accepted, scans up to GEOFENCE_FLAG_FACTOR times
+// the radius go through but are flagged for review, anything further away
+// is rejected. Activities without a location and users an admin granted an
+// override to are not checked. Every checked scan is recorded.
+func (s *GeofenceService) Verify(ctx context.Context, userId int64, activityType string, codeId string, latitude *float64, longitude *float64) error {
+	activity, err := s.Repository.GetActivityLocation(ctx, repositories.GetActivityLocationParams{
+		ActivityType: activityType,
+		CodeID:       codeId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the activity handler reports the missing activity itself
+			return nil
+		}
+		slog.Error("Failed to get activity location", "err", err)
+		return err
+	}
+
+	if !activity.Latitude.Valid || !activity.Longitude.Valid {
+		return nil
+	}
+
+	overridden, err := s.Repository.HasGeofenceOverride(ctx, repositories.HasGeofenceOverrideParams{
+		UserID:       userId,
+		ActivityType: activityType,
+		ActivityID:   activity.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to check geofence override", "err", err)
+		return err
+	}
+
+	scan := repositories.CreateActivityScanParams{
+		UserID:       userId,
+		ActivityType: activityType,
+		ActivityID:   activity.ID,
+	}
+	if latitude != nil && longitude != nil {
+		scan.Latitude = pgtype.Float8{Float64: *latitude, Valid: true}
+		scan.Longitude = pgtype.Float8{Float64: *longitude, Valid: true}
+	}
+
+	if overridden {
+		scan.Status = ScanOverridden
+		return s.record(ctx, scan)
+	}
+
+	if latitude == nil || longitude == nil {
+		scan.Status = ScanRejected
+		err = s.record(ctx, scan)
+		if err != nil {
+			return err
+		}
+		return fiber.NewError(fiber.StatusBadRequest, "Aktifkan lokasi untuk memindai QR ini")
+	}
+
+	distance, err := s.Repository.GetEarthDistance(ctx, repositories.GetEarthDistanceParams{
+		FromLatitude:  *latitude,
+		FromLongitude: *longitude,
+		ToLatitude:    activity.Latitude.Float64,
+		ToLongitude:   activity.Longitude.Float64,
+	})
+	if err != nil {
+		slog.Error("Failed to get distance", "err", err)
+		return err
+	}
+	scan.DistanceMeters = pgtype.Float8{Float64: distance, Valid: true}
+
+	cnf := helpers.NewConfig()
+	radius := cnf.GetFloat64(fmt.Sprintf("GEOFENCE_%s_RADIUS", strings.ToUpper(activityType)))
+	if activity.GeofenceRadius.Valid {
+		radius = float64(activity.GeofenceRadius.Int32)
+	}
+
+	switch {
+	case distance <= radius:
+		scan.Status = ScanAccepted
+	case distance <= radius*cnf.GetFloat64("GEOFENCE_FLAG_FACTOR"):
+		scan.Status = ScanFlagged
+	default:
+		scan.Status = ScanRejected
+	}
+
+	err = s.record(ctx, scan)
+	if err != nil {
+		return err
+	}
+
+	if scan.Status == ScanRejected {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"Anda berada %.0f meter dari lokasi, pindai QR dalam radius %.0f meter",
+			math.Round(distance), radius,
+		))
+	}
+
+	return nil
+}
+
+func (s *GeofenceService) record(ctx context.Context, scan repositories.CreateActivityScanParams) error {
+	err := s.Repository.CreateActivityScan(ctx, scan)
+	if err != nil {
+		slog.Error("Failed to record activity scan", "err", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type GreenprintService struct {
+	Repository *repositories.Queries
+}
+
+func NewGreenprintService(
+	rp *repositories.Queries,
+) *GreenprintService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("GREENPRINT_REUSE_MIN_RATING", 4)
+	cnf.SetDefault("GREENPRINT_REUSE_MIN_RATINGS", 3)
+
+	return &GreenprintService{
+		Repository: rp,
+	}
+}
+
+// FindReusable looks for a public greenprint made for an item with the same
+// name that is rated well enough to be handed out instead of generating a new one.
+// Names only match when they are equal ignoring case and surrounding spaces,
+// so "Botol Plastik" and "botol plastik bekas" are treated as different items.
+func (s *GreenprintService) FindReusable(ctx context.Context, itemName string) (int64, bool, error) {
+	cnf := helpers.NewConfig()
+
+	id, err := s.Repository.FindReusableGreenprint(ctx, repositories.FindReusableGreenprintParams{
+		ItemName:   itemName,
+		MinRating:  cnf.GetFloat64("GREENPRINT_REUSE_MIN_RATING"),
+		MinRatings: cnf.GetInt32("GREENPRINT_REUSE_MIN_RATINGS"),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		slog.Error("Failed to find reusable greenprint", "err", err)
+		return 0, false, err
+	}
+
+	return id, true, nil
+}
+
+// CopyGreenprint gives the item its own copy of the greenprint, steps,
+// materials and tools included, in a single transaction. The copy points
+// back to its source so ratings and usage are counted on the original.
+func (s *GreenprintService) CopyGreenprint(ctx context.Context, sourceId int64, itemId int64) (repositories.Greenprint, error) {
+	var gp repositories.Greenprint
+	err := s.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		var err error
+		gp, err = q.CopyGreenprint(ctx, repositories.CopyGreenprintParams{
+			ItemID:   itemId,
+			SourceID: sourceId,
+		})
+		if err != nil {
+			slog.Error("Failed to copy greenprint", "err", err)
+			return err
+		}
+
+		err = q.CopySteps(ctx, repositories.CopyStepsParams{
+			GreenprintID: gp.ID,
+			SourceID:     sourceId,
+		})
+		if err != nil {
+			slog.Error("Failed to copy steps", "err", err)
+			return err
+		}
+
+		err = q.CopyMaterials(ctx, repositories.CopyMaterialsParams{
+			GreenprintID: gp.ID,
+			SourceID:     sourceId,
+		})
+		if err != nil {
+			slog.Error("Failed to copy materials", "err", err)
+			return err
+		}
+
+		err = q.CopyTools(ctx, repositories.CopyToolsParams{
+			GreenprintID: gp.ID,
+			SourceID:     sourceId,
+		})
+		if err != nil {
+			slog.Error("Failed to copy tools", "err", err)
+			return err
+		}
+
+		return nil
+	})
+
+	return gp, err
+}
+
+// RootId returns the id ratings and usage are counted on.
+func (s *GreenprintService) RootId(gp repositories.Greenprint) int64 {
+	if gp.SourceID.Valid {
+		return gp.SourceID.Int64
+	}
+	return gp.ID
+}
+
+func (s *GreenprintService) GetGreenprintDetail(ctx context.Context, gp repositories.Greenprint) (models.AIResponseGreenprint, error) {
+	var res = models.AIResponseGreenprint{
+		ID:                  gp.ID,
+		SourceID:            gp.SourceID.Int64,
+		Title:               gp.Title,
+		Description:         gp.Description,
+		EstimatedTime:       gp.EstimatedTime,
+		SustainabilityScore: gp.SustainabilityScore,
+		IsPublic:            gp.IsPublic && !gp.SourceID.Valid,
+		CreatedAt:           gp.CreatedAt.Time.Format("2006-01-02 15:04"),
+		Tools:               []models.ResponseTool{},
+		Materials:           []models.ResponseMaterial{},
+		Steps:               []models.ResponseStep{},
+		Text:                "",
+	}
+
+	materialsRes, err := s.Repository.GetMaterials(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get materials", "err", err)
+		return res, err
+	}
+
+	toolsRes, err := s.Repository.GetTools(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get tools", "err", err)
+		return res, err
+	}
+
+	stepsRes, err := s.Repository.GetSteps(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get steps", "err", err)
+		return res, err
+	}
+
+	rating, err := s.Repository.GetGreenprintRating(ctx, s.RootId(gp))
+	if err != nil {
+		slog.Error("Failed to get greenprint rating", "err", err)
+		return res, err
+	}
+
+	res.Rating = rating.Rating
+	res.RatingCount = rating.RatingCount
+
+	for _, m := range materialsRes {
+		res.Materials = append(res.Materials, models.ResponseMaterial{
+			ID:          m.ID,
+			Name:        m.Name,
+			Description: m.Description,
+			Price:       m.Price,
+			Quantity:    m.Quantity,
+			StepID:      m.StepID.Int64,
+		})
+	}
+
+	for _, t := range toolsRes {
+		res.Tools = append(res.Tools, models.ResponseTool{
+			ID:          t.ID,
+			Name:        t.Name,
+			Description: t.Description,
+			Price:       t.Price,
+			StepID:      t.StepID.Int64,
+			Optional:    t.IsOptional,
+		})
+	}
+
+	for _, st := range stepsRes {
+		res.Steps = append(res.Steps, models.ResponseStep{
+			ID:          st.ID,
+			Description: st.Description,
+		})
+	}
+
+	return res, nil
+}
+
+// GetCostEstimate adds up what the greenprint costs. Materials cost price
+// times quantity, tools their price. Optional tools are left out of the
+// total and everything the user marked as owned is left out of what is
+// still to buy.
+func (s *GreenprintService) GetCostEstimate(ctx context.Context, gp repositories.Greenprint, userId int64) (models.ResponseGreenprintCost, error) {
+	res := models.ResponseGreenprintCost{
+		GreenprintID: gp.ID,
+		Title:        gp.Title,
+		Currency:     "IDR",
+		Steps:        []models.ResponseStepCost{},
+		Materials:    []models.ResponseCostMaterial{},
+		Tools:        []models.ResponseCostTool{},
+	}
+
+	stepsRes, err := s.Repository.GetSteps(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get steps", "err", err)
+		return res, err
+	}
+
+	materialsRes, err := s.Repository.GetMaterials(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get materials", "err", err)
+		return res, err
+	}
+
+	toolsRes, err := s.Repository.GetTools(ctx, gp.ID)
+	if err != nil {
+		slog.Error("Failed to get tools", "err", err)
+		return res, err
+	}
+
+	ownedMaterials, err := s.Repository.GetOwnedMaterialIds(ctx, repositories.GetOwnedMaterialIdsParams{
+		UserID:       userId,
+		GreenprintID: gp.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to get owned materials", "err", err)
+		return res, err
+	}
+
+	ownedTools, err := s.Repository.GetOwnedToolIds(ctx, repositories.GetOwnedToolIdsParams{
+		UserID:       userId,
+		GreenprintID: gp.ID,
+	})
+	if err != nil {
+		slog.Error("Failed to get owned tools", "err", err)
+		return res, err
+	}
+
+	stepIndex := map[int64]int{}
+	for i, st := range stepsRes {
+		stepIndex[st.ID] = i
+		res.Steps = append(res.Steps, models.ResponseStepCost{
+			StepID:      st.ID,
+			Position:    i + 1,
+			Description: st.Description,
+		})
+	}
+
+	addToStep := func(stepId pgtype.Int8, cost int64) {
+		if i, ok := stepIndex[stepId.Int64]; ok && stepId.Valid {
+			res.Steps[i].Cost += cost
+			return
+		}
+		res.Unassigned += cost
+	}
+
+	for _, m := range materialsRes {
+		subtotal := int64(m.Price) * int64(m.Quantity)
+		owned := slices.Contains(ownedMaterials, m.ID)
+
+		res.MaterialsTotal += subtotal
+		if owned {
+			res.OwnedTotal += subtotal
+		}
+		addToStep(m.StepID, subtotal)
+
+		res.Materials = append(res.Materials, models.ResponseCostMaterial{
+			ID:       m.ID,
+			Name:     m.Name,
+			Price:    m.Price,
+			Quantity: m.Quantity,
+			Subtotal: subtotal,
+			StepID:   m.StepID.Int64,
+			Owned:    owned,
+		})
+	}
+
+	for _, t := range toolsRes {
+		price := int64(t.Price)
+		owned := slices.Contains(ownedTools, t.ID)
+
+		if t.IsOptional {
+			res.OptionalToolsTotal += price
+		} else {
+			res.RequiredToolsTotal += price
+			if owned {
+				res.OwnedTotal += price
+			}
+			addToStep(t.StepID, price)
+		}
+
+		res.Tools = append(res.Tools, models.ResponseCostTool{
+			ID:       t.ID,
+			Name:     t.Name,
+			Price:    t.Price,
+			StepID:   t.StepID.Int64,
+			Optional: t.IsOptional,
+			Owned:    owned,
+		})
+	}
+
+	res.Total = res.MaterialsTotal + res.RequiredToolsTotal
+	res.ToBuyTotal = res.Total - res.OwnedTotal
+
+	return res, nil
+}
+
+// GetShoppingList keeps what the user still has to buy from the cost
+// estimate, required items first.
+func (s *GreenprintService) GetShoppingList(cost models.ResponseGreenprintCost) models.ResponseShoppingList {
+	list := models.ResponseShoppingList{
+		GreenprintID: cost.GreenprintID,
+		Title:        cost.Title,
+		Currency:     cost.Currency,
+		Items:        []models.ResponseShoppingItem{},
+	}
+
+	for _, m := range cost.Materials {
+		if m.Owned {
+			continue
+		}
+		list.Items = append(list.Items, models.ResponseShoppingItem{
+			Type:     "material",
+			Name:     m.Name,
+			Quantity: m.Quantity,
+			Price:    m.Price,
+			Subtotal: m.Subtotal,
+		})
+		list.Total += m.Subtotal
+	}
+
+	var optional []models.ResponseShoppingItem
+	for _, t := range cost.Tools {
+		if t.Owned {
+			continue
+		}
+		item := models.ResponseShoppingItem{
+			Type:     "tool",
+			Name:     t.Name,
+			Quantity: 1,
+			Price:    t.Price,
+			Subtotal: int64(t.Price),
+			Optional: t.Optional,
+		}
+		if t.Optional {
+			optional = append(optional, item)
+			list.OptionalTotal += item.Subtotal
+			continue
+		}
+		list.Items = append(list.Items, item)
+		list.Total += item.Subtotal
+	}
+	list.Items = append(list.Items, optional...)
+
+	return list
+}
+
+func (s *GreenprintService) ShoppingListText(list models.ResponseShoppingList) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Daftar belanja: %s\n\n", list.Title)
+	for _, item := range list.Items {
+		label := ""
+		if item.Optional {
+			label = " (opsional)"
+		}
+		fmt.Fprintf(&b, "- %dx %s%s @ %s = %s\n", item.Quantity, item.Name, label, helpers.FormatRupiah(int64(item.Price)), helpers.FormatRupiah(item.Subtotal))
+	}
+	if len(list.Items) == 0 {
+		b.WriteString("Semua bahan dan alat sudah dimiliki\n")
+	}
+
+	fmt.Fprintf(&b, "\nTotal: %s\n", helpers.FormatRupiah(list.Total))
+	if list.OptionalTotal > 0 {
+		fmt.Fprintf(&b, "Alat opsional: %s\n", helpers.FormatRupiah(list.OptionalTotal))
+	}
+
+	return b.String()
+}
+
+func (s *GreenprintService) ShoppingListCSV(list models.ResponseShoppingList) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.Write([]string{"type", "name", "quantity", "price", "subtotal", "optional"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range list.Items {
+		err = w.Write([]string{
+			item.Type,
+			helpers.CSVCell(item.Name),
+			strconv.Itoa(int(item.Quantity)),
+			strconv.Itoa(int(item.Price)),
+			strconv.FormatInt(item.Subtotal, 10),
+			strconv.FormatBool(item.Optional),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
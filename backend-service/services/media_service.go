@@ -0,0 +1,207 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"jirbthagoras/raksana-backend/configs"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"log/slog"
+	"mime/multipart"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/disintegration/imaging"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
+)
+
+var allowedImageTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+type MediaService struct {
+	*configs.AWSClient
+}
+
+func NewMediaService(
+	aws *configs.AWSClient,
+) *MediaService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("MEDIA_MAX_UPLOAD_BYTES", 10<<20)
+	cnf.SetDefault("MEDIA_MAX_DIMENSION", 2048)
+	cnf.SetDefault("MEDIA_MAX_PIXELS", 50_000_000)
+	cnf.SetDefault("MEDIA_THUMBNAIL_SIZE", 320)
+	cnf.SetDefault("MEDIA_JPEG_QUALITY", 85)
+
+	return &MediaService{
+		AWSClient: aws,
+	}
+}
+
+// UploadImage validates an uploaded image, normalizes it and stores it
+// together with a thumbnail under <folder>/<userId>/<uuid>.
+// Re-encoding the image drops every EXIF tag, GPS location included, after
+// the orientation tag has been applied to the pixels.
+func (s *MediaService) UploadImage(ctx context.Context, file *multipart.FileHeader, folder string, userId int) (models.UploadedImage, error) {
+	var uploaded models.UploadedImage
+	cnf := helpers.NewConfig()
+	maxBytes := cnf.GetInt64("MEDIA_MAX_UPLOAD_BYTES")
+
+	if file.Size > maxBytes {
+		return uploaded, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Ukuran gambar maksimal %d MB", maxBytes>>20))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		slog.Error("Failed to open the image", "err", err)
+		return uploaded, err
+	}
+	defer src.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		slog.Error("Failed to read the image", "err", err)
+		return uploaded, err
+	}
+	if int64(len(raw)) > maxBytes {
+		return uploaded, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Ukuran gambar maksimal %d MB", maxBytes>>20))
+	}
+
+	mime := mimetype.Detect(raw)
+	if !mimetype.EqualsAny(mime.String(), allowedImageTypes...) {
+		return uploaded, fiber.NewError(fiber.StatusUnsupportedMediaType, "Allowed content type: image/jpeg, image/png and image/webp")
+	}
+
+	// a small file can declare huge dimensions, check them before the
+	// decoder allocates the pixels
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		slog.Error("Failed to decode the image config", "err", err)
+		return uploaded, fiber.NewError(fiber.StatusBadRequest, "Gambar tidak dapat dibaca")
+	}
+	if int64(config.Width)*int64(config.Height) > cnf.GetInt64("MEDIA_MAX_PIXELS") {
+		return uploaded, fiber.NewError(fiber.StatusRequestEntityTooLarge, "Resolusi gambar terlalu besar")
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(raw), imaging.AutoOrientation(true))
+	if err != nil {
+		slog.Error("Failed to decode the image", "err", err)
+		return uploaded, fiber.NewError(fiber.StatusBadRequest, "Gambar tidak dapat dibaca")
+	}
+
+	maxDimension := cnf.GetInt("MEDIA_MAX_DIMENSION")
+	bounds := img.Bounds()
+	if bounds.Dx() > maxDimension || bounds.Dy() > maxDimension {
+		img = imaging.Fit(img, maxDimension, maxDimension, imaging.Lanczos)
+	}
+
+	// PNG is kept to preserve transparency, everything else becomes JPEG.
+	contentType, ext := "image/jpeg", ".jpg"
+	if mime.Is("image/png") {
+		contentType, ext = "image/png", ".png"
+	}
+
+	data, err := encodeImage(img, contentType)
+	if err != nil {
+		slog.Error("Failed to encode the image", "err", err)
+		return uploaded, err
+	}
+
+	thumbSize := cnf.GetInt("MEDIA_THUMBNAIL_SIZE")
+	thumbnail, err := encodeImage(imaging.Fit(img, thumbSize, thumbSize, imaging.Lanczos), "image/jpeg")
+	if err != nil {
+		slog.Error("Failed to encode the thumbnail", "err", err)
+		return uploaded, err
+	}
+
+	key := fmt.Sprintf("%s/%d/%s%s", folder, userId, uuid.New().String(), ext)
+	thumbnailKey := helpers.ThumbnailKey(key)
+
+	err = s.putObject(ctx, key, contentType, data)
+	if err != nil {
+		return uploaded, err
+	}
+
+	err = s.putObject(ctx, thumbnailKey, "image/jpeg", thumbnail)
+	if err != nil {
+		// do not leave an image behind that no record points to
+		_ = s.AWSClient.DeleteObject(cnf.GetString("AWS_BUCKET"), key)
+		return uploaded, err
+	}
+
+	bounds = img.Bounds()
+	uploaded = models.UploadedImage{
+		Key:          key,
+		ThumbnailKey: thumbnailKey,
+		ContentType:  contentType,
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+		Data:         data,
+	}
+
+	return uploaded, nil
+}
+
+// DeleteImage removes an image uploaded through UploadImage and its thumbnail.
+func (s *MediaService) DeleteImage(key string) error {
+	cnf := helpers.NewConfig()
+	bucketName := cnf.GetString("AWS_BUCKET")
+
+	err := s.AWSClient.DeleteObject(bucketName, key)
+	if err != nil {
+		return err
+	}
+
+	return s.AWSClient.DeleteObject(bucketName, helpers.ThumbnailKey(key))
+}
+
+// UploadFile stores generated content, like QR codes, under the given key as is.
+func (s *MediaService) UploadFile(ctx context.Context, key string, contentType string, data []byte) error {
+	return s.putObject(ctx, key, contentType, data)
+}
+
+func (s *MediaService) putObject(ctx context.Context, key string, contentType string, data []byte) error {
+	cnf := helpers.NewConfig()
+	bucketName := cnf.GetString("AWS_BUCKET")
+
+	_, err := s.AWSClient.S3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		slog.Error("Failed to upload to S3", "key", key, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch contentType {
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		quality := helpers.NewConfig().GetInt("MEDIA_JPEG_QUALITY")
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
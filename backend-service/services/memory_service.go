@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"jirbthagoras/raksana-backend/models"
 	"jirbthagoras/raksana-backend/repositories"
 	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type MemoryService struct {
@@ -19,10 +22,21 @@ func NewMemoryService(
 }
 
 func (s *MemoryService) CreateMemory(description string, fileKey string, userId int) (int, error) {
+	return s.createMemory(description, fileKey, pgtype.Text{}, userId)
+}
+
+// CreateImageMemory stores a memory whose image was uploaded through the
+// MediaService, so it also has a thumbnail.
+func (s *MemoryService) CreateImageMemory(description string, image models.UploadedImage, userId int) (int, error) {
+	return s.createMemory(description, image.Key, pgtype.Text{String: image.ThumbnailKey, Valid: true}, userId)
+}
+
+func (s *MemoryService) createMemory(description string, fileKey string, thumbnailKey pgtype.Text, userId int) (int, error) {
 	id, err := s.Repository.CreateMemory(context.Background(), repositories.CreateMemoryParams{
-		UserID:      int64(userId),
-		Description: description,
-		FileKey:     fileKey,
+		UserID:       int64(userId),
+		Description:  description,
+		FileKey:      fileKey,
+		ThumbnailKey: thumbnailKey,
 	})
 	if err != nil {
 		slog.Error("Failed to create memory", "err", err)
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+)
+
+const (
+	NotificationEventRegistered = "event_registered"
+	NotificationEventWaitlisted = "event_waitlisted"
+	NotificationEventPromoted   = "event_promoted"
+	NotificationEventCancelled  = "event_cancelled"
+
+	NotificationChallengeApproved = "challenge_approved"
+	NotificationChallengeRejected = "challenge_rejected"
+	NotificationChallengeTopVoted = "challenge_top_voted"
+)
+
+type NotificationService struct {
+	Repository *repositories.Queries
+}
+
+func NewNotificationService(
+	rp *repositories.Queries,
+) *NotificationService {
+	return &NotificationService{
+		Repository: rp,
+	}
+}
+
+// Notify stores an in-app notification for the user, read through GET /notification.
+func (s *NotificationService) Notify(ctx context.Context, userId int64, notificationType string, title string, body string) error {
+	err := s.Repository.CreateNotification(ctx, repositories.CreateNotificationParams{
+		UserID: userId,
+		Type:   notificationType,
+		Title:  title,
+		Body:   body,
+	})
+	if err != nil {
+		slog.Error("Failed to create notification", "err", err)
+		return err
+	}
+
+	return nil
+}
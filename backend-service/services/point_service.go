@@ -56,3 +56,37 @@ func (s *PointService) UpdateUserPoint(userId int64, pointGain int64, name strin
 
 	return profile, nil
 }
+
+// AddUserPoint books the points and the history entry through q, so they can
+// be part of the caller's transaction. The leaderboard is not touched, call
+// IncrLeaderboard with the returned points once the transaction committed.
+func (s *PointService) AddUserPoint(ctx context.Context, q *repositories.Queries, userId int64, pointGain int64, name string, category string, userLevel int) (int64, error) {
+	multiplier := helpers.GetMultiplier(userLevel)
+	realPoint := int64(float64(pointGain) * multiplier)
+	_, err := q.IncreaseUserPoints(ctx, repositories.IncreaseUserPointsParams{
+		UserID: userId,
+		Points: realPoint,
+	})
+	if err != nil {
+		slog.Error("Failed to increase user points", "err", err)
+		return 0, err
+	}
+
+	err = q.AppendHistry(ctx, repositories.AppendHistryParams{
+		UserID:   userId,
+		Amount:   int32(realPoint),
+		Type:     "input",
+		Category: category,
+		Name:     name,
+	})
+	if err != nil {
+		slog.Error("Failed to append history", "err", err)
+		return 0, err
+	}
+
+	return realPoint, nil
+}
+
+func (s *PointService) IncrLeaderboard(userId int64, points int64) error {
+	return s.LeaderboardService.IncrPoint(strconv.Itoa(int(userId)), float64(points))
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// hintBands goes from the furthest to the closest band.
+var hintBands = []string{"cold", "cool", "warm", "hot"}
+
+type TreasureHintService struct {
+	Redis      *redis.Client
+	Repository *repositories.Queries
+}
+
+func NewTreasureHintService(
+	r *redis.Client,
+	rp *repositories.Queries,
+) *TreasureHintService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("TREASURE_HINT_WARM_RADIUS", 500)
+	cnf.SetDefault("TREASURE_HINT_COOL_RADIUS", 2000)
+	cnf.SetDefault("TREASURE_HINT_COOLDOWN_SECONDS", 30)
+	cnf.SetDefault("TREASURE_HINT_DAILY_LIMIT", 20)
+
+	return &TreasureHintService{
+		Redis:      r,
+		Repository: rp,
+	}
+}
+
+// Hint tells how close the user is to a treasure as a distance band, "hot"
+// meaning close enough to claim it, without giving away the distance itself.
+// Hints per treasure are rate limited with a cooldown and a daily limit so
+// the location can't be triangulated from many requests.
+func (s *TreasureHintService) Hint(ctx context.Context, userId int64, treasureId int64, req *models.GetTreasureHint) (models.ResponseTreasureHint, error) {
+	var res models.ResponseTreasureHint
+	cnf := helpers.NewConfig()
+
+	treasure, err := s.Repository.GetHuntableTreasureById(ctx, treasureId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return res, fiber.NewError(fiber.StatusNotFound, "Treasure tidak ditemukan")
+		}
+		slog.Error("Failed to get treasure", "err", err)
+		return res, err
+	}
+
+	cooldownKey := fmt.Sprintf("user:%d:treasure:%d:hint_cooldown", userId, treasureId)
+	countKey := fmt.Sprintf("user:%d:treasure:%d:hints", userId, treasureId)
+	lastBandKey := fmt.Sprintf("user:%d:treasure:%d:last_band", userId, treasureId)
+
+	cooldown := time.Duration(cnf.GetInt("TREASURE_HINT_COOLDOWN_SECONDS")) * time.Second
+	ok, err := s.Redis.SetNX(ctx, cooldownKey, 1, cooldown).Result()
+	if err != nil {
+		return res, fmt.Errorf("redis set cooldown failed: %w", err)
+	}
+	if !ok {
+		return res, fiber.NewError(fiber.StatusTooManyRequests, fmt.Sprintf("Tunggu %d detik sebelum meminta petunjuk lagi", cnf.GetInt("TREASURE_HINT_COOLDOWN_SECONDS")))
+	}
+
+	count, err := s.Redis.Incr(ctx, countKey).Result()
+	if err != nil {
+		return res, fmt.Errorf("redis incr failed: %w", err)
+	}
+	if count == 1 {
+		err = s.Redis.ExpireAt(ctx, countKey, helpers.NextMidnight()).Err()
+		if err != nil {
+			return res, fmt.Errorf("redis expire failed: %w", err)
+		}
+	}
+
+	limit := cnf.GetInt64("TREASURE_HINT_DAILY_LIMIT")
+	if count > limit {
+		return res, fiber.NewError(fiber.StatusTooManyRequests, "Batas petunjuk untuk treasure ini hari ini sudah habis")
+	}
+
+	distance, err := s.Repository.GetEarthDistance(ctx, repositories.GetEarthDistanceParams{
+		FromLatitude:  *req.Latitude,
+		FromLongitude: *req.Longitude,
+		ToLatitude:    treasure.Latitude,
+		ToLongitude:   treasure.Longitude,
+	})
+	if err != nil {
+		slog.Error("Failed to get distance", "err", err)
+		return res, err
+	}
+
+	radius := cnf.GetFloat64("GEOFENCE_TREASURE_RADIUS")
+	if treasure.GeofenceRadius.Valid {
+		radius = float64(treasure.GeofenceRadius.Int32)
+	}
+
+	band := hintBand(distance, radius, cnf.GetFloat64("TREASURE_HINT_WARM_RADIUS"), cnf.GetFloat64("TREASURE_HINT_COOL_RADIUS"))
+
+	res = models.ResponseTreasureHint{
+		TreasureID: treasure.ID,
+		Band:       band,
+		InRange:    band == "hot",
+		HintsLeft:  int(limit - count),
+	}
+
+	last, err := s.Redis.Get(ctx, lastBandKey).Result()
+	if err != nil && err != redis.Nil {
+		return res, fmt.Errorf("redis get failed: %w", err)
+	}
+	res.Trend = hintTrend(last, band)
+
+	err = s.Redis.Set(ctx, lastBandKey, slices.Index(hintBands, band), time.Until(helpers.NextMidnight())).Err()
+	if err != nil {
+		return res, fmt.Errorf("redis set last band failed: %w", err)
+	}
+
+	return res, nil
+}
+
+// hintBand maps a distance in meters to its band. Within the claim radius
+// is always hot, whatever the warm radius is set to.
+func hintBand(distance float64, radius float64, warmRadius float64, coolRadius float64) string {
+	switch {
+	case distance <= radius:
+		return "hot"
+	case distance <= warmRadius:
+		return "warm"
+	case distance <= coolRadius:
+		return "cool"
+	default:
+		return "cold"
+	}
+}
+
+// hintTrend compares a band to the index of the last band stored in redis.
+// It is empty for the first hint of the day.
+func hintTrend(last string, band string) string {
+	lastIndex, err := strconv.Atoi(last)
+	if err != nil {
+		return ""
+	}
+
+	index := slices.Index(hintBands, band)
+	switch {
+	case index > lastIndex:
+		return "warmer"
+	case index < lastIndex:
+		return "colder"
+	default:
+		return "same"
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import "testing"
+
+func TestHintBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		distance float64
+		radius   float64
+		want     string
+	}{
+		{name: "on the treasure", distance: 0, radius: 50, want: "hot"},
+		{name: "edge of the claim radius", distance: 50, radius: 50, want: "hot"},
+		{name: "just outside the claim radius", distance: 50.1, radius: 50, want: "warm"},
+		{name: "claim radius wider than warm", distance: 800, radius: 1000, want: "hot"},
+		{name: "edge of warm", distance: 500, radius: 50, want: "warm"},
+		{name: "cool", distance: 1500, radius: 50, want: "cool"},
+		{name: "cold", distance: 2000.5, radius: 50, want: "cold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hintBand(tt.distance, tt.radius, 500, 2000); got != tt.want {
+				t.Errorf("hintBand(%v) = %q, want %q", tt.distance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHintTrend(t *testing.T) {
+	tests := []struct {
+		last string
+		band string
+		want string
+	}{
+		{last: "", band: "cold", want: ""},
+		{last: "0", band: "warm", want: "warmer"},
+		{last: "3", band: "cool", want: "colder"},
+		{last: "2", band: "warm", want: "same"},
+	}
+
+	for _, tt := range tests {
+		if got := hintTrend(tt.last, tt.band); got != tt.want {
+			t.Errorf("hintTrend(%q, %q) = %q, want %q", tt.last, tt.band, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/exceptions"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	FeatureTrashScan    = "trash_scan"
+	FeatureTrashVision  = "trash_vision"
+	FeatureGreenprint   = "greenprint"
+	FeatureEcoach       = "ecoach"
+	FeatureRecapWeekly  = "recap_weekly"
+	FeatureRecapMonthly = "recap_monthly"
+	FeatureTotal        = "total"
+)
+
+var AIFeatures = []string{
+	FeatureTrashScan,
+	FeatureTrashVision,
+	FeatureGreenprint,
+	FeatureEcoach,
+	FeatureRecapWeekly,
+	FeatureRecapMonthly,
+}
+
+type UsageService struct {
+	Repository *repositories.Queries
+}
+
+func NewUsageService(
+	rp *repositories.Queries,
+) *UsageService {
+	return &UsageService{
+		Repository: rp,
+	}
+}
+
+// UsageReservation is a quota slot taken by Reserve. It counts as used until
+// the call is finished with SendMessage or given back with Release.
+type UsageReservation struct {
+	id       int64
+	finished bool
+}
+
+// Reserve takes one slot of the daily and monthly allowance of the feature,
+// and of all AI features combined, before the AI call is made. The counts
+// and the insert run as a single statement under a per-user lock, so
+// concurrent requests can not go over the quota. A limit of 0 means unlimited.
+func (s *UsageService) Reserve(userId int64, feature string) (*UsageReservation, error) {
+	ctx := context.Background()
+
+	quotas, err := s.getUserQuotas(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	window := newUsageWindow(time.Now())
+	daily, monthly := s.getLimits(feature, quotas)
+	totalDaily, totalMonthly := s.getLimits(FeatureTotal, quotas)
+
+	var id int64
+	err = s.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		err := q.LockUserAiUsage(ctx, int32(userId))
+		if err != nil {
+			return err
+		}
+
+		id, err = q.ReserveAiUsage(ctx, repositories.ReserveAiUsageParams{
+			UserID:            userId,
+			Feature:           feature,
+			Provider:          "gemini",
+			Model:             helpers.NewConfig().GetString("MODEL"),
+			CreatedAt:         timestamp(window.now),
+			DayStart:          timestamp(window.dayStart),
+			MonthStart:        timestamp(window.monthStart),
+			DailyLimit:        int32(daily),
+			MonthlyLimit:      int32(monthly),
+			TotalDailyLimit:   int32(totalDaily),
+			TotalMonthlyLimit: int32(totalMonthly),
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.quotaError(ctx, userId, feature, quotas, window)
+		}
+		slog.Error("Failed to reserve ai usage", "err", err)
+		return nil, err
+	}
+
+	return &UsageReservation{id: id}, nil
+}
+
+// Release gives the slot back when the request ended before the AI call was
+// made. It is a no-op once the call was finished, so it can be deferred.
+func (s *UsageService) Release(reservation *UsageReservation) {
+	if reservation == nil || reservation.finished {
+		return
+	}
+
+	err := s.Repository.DeleteAiUsage(context.Background(), reservation.id)
+	if err != nil {
+		slog.Error("Failed to release ai usage", "err", err)
+	}
+}
+
+// SendMessage sends the parts through the chat session and fills the
+// reserved usage with the token counts reported by Gemini. A failed call
+// gives the slot back.
+func (s *UsageService) SendMessage(
+	ctx context.Context,
+	session *genai.ChatSession,
+	reservation *UsageReservation,
+	parts ...genai.Part,
+) (*genai.GenerateContentResponse, error) {
+	start := time.Now()
+	resp, err := session.SendMessage(ctx, parts...)
+
+	usage := models.AIUsageRecord{
+		Provider: "gemini",
+		Latency:  time.Since(start),
+		Err:      err,
+	}
+	if resp != nil && resp.UsageMetadata != nil {
+		usage.PromptTokens = int(resp.UsageMetadata.PromptTokenCount)
+		usage.CompletionTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+		usage.TotalTokens = int(resp.UsageMetadata.TotalTokenCount)
+	}
+	if err == nil && (resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil) {
+		err = fmt.Errorf("gemini returned no candidates")
+		usage.Err = err
+	}
+
+	s.finish(reservation, usage)
+
+	return resp, err
+}
+
+func (s *UsageService) finish(reservation *UsageReservation, usage models.AIUsageRecord) {
+	reservation.finished = true
+	status, errMsg := usageStatus(usage)
+
+	err := s.Repository.FinishAiUsage(context.Background(), repositories.FinishAiUsageParams{
+		ID:               reservation.id,
+		PromptTokens:     int32(usage.PromptTokens),
+		CompletionTokens: int32(usage.CompletionTokens),
+		TotalTokens:      int32(usage.TotalTokens),
+		LatencyMs:        int32(usage.Latency.Milliseconds()),
+		Cost:             calculateCost(usage),
+		Status:           status,
+		Error:            errMsg,
+	})
+	if err != nil {
+		slog.Error("Failed to record ai usage", "err", err)
+	}
+}
+
+// Record stores a single AI call. Failing to store it is only logged so the
+// user request itself is not affected.
+func (s *UsageService) Record(userId int64, usage models.AIUsageRecord) {
+	status, errMsg := usageStatus(usage)
+
+	err := s.Repository.CreateAiUsage(context.Background(), repositories.CreateAiUsageParams{
+		UserID:           userId,
+		Feature:          usage.Feature,
+		Provider:         usage.Provider,
+		Model:            usage.Model,
+		PromptTokens:     int32(usage.PromptTokens),
+		CompletionTokens: int32(usage.CompletionTokens),
+		TotalTokens:      int32(usage.TotalTokens),
+		LatencyMs:        int32(usage.Latency.Milliseconds()),
+		Cost:             calculateCost(usage),
+		Status:           status,
+		Error:            errMsg,
+		CreatedAt:        timestamp(time.Now()),
+	})
+	if err != nil {
+		slog.Error("Failed to record ai usage", "err", err)
+	}
+}
+
+func usageStatus(usage models.AIUsageRecord) (string, pgtype.Text) {
+	if usage.Err != nil {
+		return "failed", pgtype.Text{String: usage.Err.Error(), Valid: true}
+	}
+	return "success", pgtype.Text{}
+}
+
+func (s *UsageService) GetUsageSummary(userId int64) (models.ResponseUsageSummary, error) {
+	ctx := context.Background()
+	var summary models.ResponseUsageSummary
+
+	quotas, err := s.getUserQuotas(ctx, userId)
+	if err != nil {
+		return summary, err
+	}
+
+	window := newUsageWindow(time.Now())
+	rows, err := s.Repository.GetUserAiUsageSummary(ctx, repositories.GetUserAiUsageSummaryParams{
+		UserID:     userId,
+		DayStart:   timestamp(window.dayStart),
+		MonthStart: timestamp(window.monthStart),
+	})
+	if err != nil {
+		slog.Error("Failed to get ai usage summary", "err", err)
+		return summary, err
+	}
+
+	used := map[string]repositories.GetUserAiUsageSummaryRow{}
+	var total repositories.GetUserAiUsageSummaryRow
+	for _, row := range rows {
+		used[row.Feature] = row
+		total.Tokens += row.Tokens
+		total.Cost += row.Cost
+		if row.Feature == FeatureTrashVision {
+			continue
+		}
+		total.Daily += row.Daily
+		total.Monthly += row.Monthly
+	}
+	used[FeatureTotal] = total
+
+	summary.DailyResetsAt = window.dailyResetsAt().Format("2006-01-02 15:04")
+	summary.MonthlyResetsAt = window.monthlyResetsAt().Format("2006-01-02 15:04")
+	summary.Features = []models.ResponseFeatureUsage{}
+	for _, feature := range append(AIFeatures, FeatureTotal) {
+		daily, monthly := s.getLimits(feature, quotas)
+		row := used[feature]
+		summary.Features = append(summary.Features, models.ResponseFeatureUsage{
+			Feature:      feature,
+			DailyUsed:    int(row.Daily),
+			DailyLimit:   daily,
+			MonthlyUsed:  int(row.Monthly),
+			MonthlyLimit: monthly,
+			Tokens:       row.Tokens,
+			Cost:         row.Cost,
+		})
+	}
+
+	return summary, nil
+}
+
+func (s *UsageService) getUserQuotas(ctx context.Context, userId int64) (map[string]repositories.AiQuota, error) {
+	res, err := s.Repository.GetUserAiQuotas(ctx, userId)
+	if err != nil {
+		slog.Error("Failed to get user ai quotas", "err", err)
+		return nil, err
+	}
+
+	quotas := map[string]repositories.AiQuota{}
+	for _, q := range res {
+		quotas[q.Feature] = q
+	}
+
+	return quotas, nil
+}
+
+// getLimits reads the default limits from AI_QUOTA_<FEATURE>_DAILY and
+// AI_QUOTA_<FEATURE>_MONTHLY, then applies the per-user override if any.
+func (s *UsageService) getLimits(feature string, quotas map[string]repositories.AiQuota) (int, int) {
+	cnf := helpers.NewConfig()
+	key := strings.ToUpper(feature)
+	daily := cnf.GetInt(fmt.Sprintf("AI_QUOTA_%s_DAILY", key))
+	monthly := cnf.GetInt(fmt.Sprintf("AI_QUOTA_%s_MONTHLY", key))
+
+	if quota, ok := quotas[feature]; ok {
+		if quota.DailyLimit.Valid {
+			daily = int(quota.DailyLimit.Int32)
+		}
+		if quota.MonthlyLimit.Valid {
+			monthly = int(quota.MonthlyLimit.Int32)
+		}
+	}
+
+	return daily, monthly
+}
+
+// quotaError tells which limit stopped the reservation.
+func (s *UsageService) quotaError(
+	ctx context.Context,
+	userId int64,
+	feature string,
+	quotas map[string]repositories.AiQuota,
+	window usageWindow,
+) error {
+	usage, err := s.Repository.CountUserAiUsage(ctx, repositories.CountUserAiUsageParams{
+		UserID:     userId,
+		Feature:    feature,
+		DayStart:   timestamp(window.dayStart),
+		MonthStart: timestamp(window.monthStart),
+	})
+	if err != nil {
+		slog.Error("Failed to count ai usage", "err", err)
+		return err
+	}
+
+	daily, monthly := s.getLimits(feature, quotas)
+	err = checkLimits(feature, daily, monthly, usage.Daily, usage.Monthly, window)
+	if err != nil {
+		return err
+	}
+
+	total, err := s.Repository.CountUserTotalAiUsage(ctx, repositories.CountUserTotalAiUsageParams{
+		UserID:     userId,
+		DayStart:   timestamp(window.dayStart),
+		MonthStart: timestamp(window.monthStart),
+	})
+	if err != nil {
+		slog.Error("Failed to count total ai usage", "err", err)
+		return err
+	}
+
+	totalDaily, totalMonthly := s.getLimits(FeatureTotal, quotas)
+	err = checkLimits(FeatureTotal, totalDaily, totalMonthly, total.Daily, total.Monthly, window)
+	if err != nil {
+		return err
+	}
+
+	// A concurrent request gave its slot back in the meantime.
+	return exceptions.NewQuotaExceededError(feature, "daily", daily, window.dailyResetsAt())
+}
+
+func checkLimits(feature string, daily int, monthly int, dailyUsed int64, monthlyUsed int64, window usageWindow) error {
+	if monthly > 0 && monthlyUsed >= int64(monthly) {
+		return exceptions.NewQuotaExceededError(feature, "monthly", monthly, window.monthlyResetsAt())
+	}
+
+	if daily > 0 && dailyUsed >= int64(daily) {
+		return exceptions.NewQuotaExceededError(feature, "daily", daily, window.dailyResetsAt())
+	}
+
+	return nil
+}
+
+// usageWindow holds the start of the current day and month. The counts and
+// the reset times are both taken from it, so they always use the same clock.
+type usageWindow struct {
+	now        time.Time
+	dayStart   time.Time
+	monthStart time.Time
+}
+
+func newUsageWindow(now time.Time) usageWindow {
+	return usageWindow{
+		now:        now,
+		dayStart:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		monthStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+	}
+}
+
+func (w usageWindow) dailyResetsAt() time.Time {
+	return w.dayStart.AddDate(0, 0, 1)
+}
+
+func (w usageWindow) monthlyResetsAt() time.Time {
+	return w.monthStart.AddDate(0, 1, 0)
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+// calculateCost uses the per million token prices for Gemini and the per
+// image price for Rekognition, both in USD.
+func calculateCost(usage models.AIUsageRecord) float64 {
+	cnf := helpers.NewConfig()
+
+	switch usage.Provider {
+	case "gemini":
+		input := cnf.GetFloat64("GEMINI_INPUT_PRICE") * float64(usage.PromptTokens)
+		output := cnf.GetFloat64("GEMINI_OUTPUT_PRICE") * float64(usage.CompletionTokens)
+		return (input + output) / 1_000_000
+	case "rekognition":
+		if usage.Err != nil {
+			return 0
+		}
+		return cnf.GetFloat64("REKOGNITION_PRICE")
+	default:
+		return 0
+	}
+}
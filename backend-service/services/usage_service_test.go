@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"jirbthagoras/raksana-backend/exceptions"
+	"testing"
+	"time"
+)
+
+func TestUsageWindow(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		dayStart     time.Time
+		dailyReset   time.Time
+		monthlyReset time.Time
+	}{
+		{
+			name:         "middle of the month",
+			now:          time.Date(2025, 9, 15, 13, 30, 0, 0, jakarta),
+			dayStart:     time.Date(2025, 9, 15, 0, 0, 0, 0, jakarta),
+			dailyReset:   time.Date(2025, 9, 16, 0, 0, 0, 0, jakarta),
+			monthlyReset: time.Date(2025, 10, 1, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:         "last day of the month",
+			now:          time.Date(2025, 9, 30, 23, 59, 59, 0, jakarta),
+			dayStart:     time.Date(2025, 9, 30, 0, 0, 0, 0, jakarta),
+			dailyReset:   time.Date(2025, 10, 1, 0, 0, 0, 0, jakarta),
+			monthlyReset: time.Date(2025, 10, 1, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:         "last day of the year",
+			now:          time.Date(2025, 12, 31, 8, 0, 0, 0, jakarta),
+			dayStart:     time.Date(2025, 12, 31, 0, 0, 0, 0, jakarta),
+			dailyReset:   time.Date(2026, 1, 1, 0, 0, 0, 0, jakarta),
+			monthlyReset: time.Date(2026, 1, 1, 0, 0, 0, 0, jakarta),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := newUsageWindow(tt.now)
+			if !window.dayStart.Equal(tt.dayStart) {
+				t.Errorf("dayStart = %v, want %v", window.dayStart, tt.dayStart)
+			}
+			if !window.dailyResetsAt().Equal(tt.dailyReset) {
+				t.Errorf("dailyResetsAt = %v, want %v", window.dailyResetsAt(), tt.dailyReset)
+			}
+			if !window.monthlyResetsAt().Equal(tt.monthlyReset) {
+				t.Errorf("monthlyResetsAt = %v, want %v", window.monthlyResetsAt(), tt.monthlyReset)
+			}
+		})
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	window := newUsageWindow(time.Date(2025, 9, 15, 13, 30, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		daily       int
+		monthly     int
+		dailyUsed   int64
+		monthlyUsed int64
+		wantPeriod  string
+	}{
+		{name: "unlimited", daily: 0, monthly: 0, dailyUsed: 1000, monthlyUsed: 1000},
+		{name: "under both limits", daily: 5, monthly: 100, dailyUsed: 4, monthlyUsed: 99},
+		{name: "daily used up", daily: 5, monthly: 100, dailyUsed: 5, monthlyUsed: 20, wantPeriod: "daily"},
+		{name: "monthly used up", daily: 5, monthly: 100, dailyUsed: 1, monthlyUsed: 100, wantPeriod: "monthly"},
+		{name: "monthly wins over daily", daily: 5, monthly: 100, dailyUsed: 5, monthlyUsed: 100, wantPeriod: "monthly"},
+		{name: "only daily limited", daily: 3, monthly: 0, dailyUsed: 3, monthlyUsed: 500, wantPeriod: "daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLimits(FeatureTrashScan, tt.daily, tt.monthly, tt.dailyUsed, tt.monthlyUsed, window)
+			if tt.wantPeriod == "" {
+				if err != nil {
+					t.Fatalf("checkLimits() = %v, want nil", err)
+				}
+				return
+			}
+
+			var quotaErr exceptions.QuotaExceededError
+			if !errors.As(err, &quotaErr) {
+				t.Fatalf("checkLimits() = %v, want QuotaExceededError", err)
+			}
+			if quotaErr.Period != tt.wantPeriod {
+				t.Errorf("period = %q, want %q", quotaErr.Period, tt.wantPeriod)
+			}
+
+			wantReset := window.dailyResetsAt()
+			if tt.wantPeriod == "monthly" {
+				wantReset = window.monthlyResetsAt()
+			}
+			if !quotaErr.ResetsAt.Equal(wantReset) {
+				t.Errorf("resets at = %v, want %v", quotaErr.ResetsAt, wantReset)
+			}
+		})
+	}
+}
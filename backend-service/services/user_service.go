@@ -100,6 +100,7 @@ func (s *UserService) GetUserDetail(id int) (models.ResponseGetUserProfileStatis
 		CurrentStreak:          streak,
 		Badges:                 s.CheckBadges(res),
 		TreeGrown:              res.TreeGrown,
+		VolunteerHours:         math.Round(float64(res.VolunteerMinutes)/6) / 10,
 	}
 
 	return profile, nil
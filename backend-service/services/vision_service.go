@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jirbthagoras/raksana-backend/configs"
+	"jirbthagoras/raksana-backend/helpers"
+	"jirbthagoras/raksana-backend/models"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rekognition"
+	"github.com/aws/aws-sdk-go-v2/service/rekognition/types"
+	"github.com/google/generative-ai-go/genai"
+)
+
+const (
+	VisionRekognition = "rekognition"
+	VisionGemini      = "gemini"
+	VisionFixture     = "fixture"
+)
+
+// VisionBackend turns an image into labels. The returned usage only needs
+// the provider, model and token counts, the rest is filled by VisionService.
+type VisionBackend interface {
+	DetectLabels(ctx context.Context, image []byte, mimeType string) ([]models.RequestScannedObject, models.AIUsageRecord, error)
+}
+
+type VisionService struct {
+	Backend       VisionBackend
+	MinConfidence float32
+	MaxLabels     int
+	*UsageService
+}
+
+// NewVisionService picks the backend from VISION_BACKEND (rekognition,
+// gemini or fixture), defaulting to rekognition.
+func NewVisionService(
+	aws *configs.AWSClient,
+	ai *configs.AIClient,
+	us *UsageService,
+) *VisionService {
+	cnf := helpers.NewConfig()
+	cnf.SetDefault("VISION_BACKEND", VisionRekognition)
+	cnf.SetDefault("VISION_MIN_CONFIDENCE", 75)
+	cnf.SetDefault("VISION_MAX_LABELS", 10)
+	cnf.SetDefault("VISION_FIXTURE_DIR", "fixtures/vision")
+
+	minConfidence := float32(cnf.GetFloat64("VISION_MIN_CONFIDENCE"))
+	maxLabels := cnf.GetInt("VISION_MAX_LABELS")
+
+	var backend VisionBackend
+	switch cnf.GetString("VISION_BACKEND") {
+	case VisionGemini:
+		backend = &geminiVision{
+			Genai: ai.Genai,
+		}
+	case VisionFixture:
+		backend = &fixtureVision{
+			Dir: cnf.GetString("VISION_FIXTURE_DIR"),
+		}
+	case VisionRekognition:
+		backend = &rekognitionVision{
+			Client:        aws.RekognitionClient,
+			MinConfidence: minConfidence,
+			MaxLabels:     maxLabels,
+		}
+	default:
+		panic(fmt.Sprintf("unknown VISION_BACKEND %q", cnf.GetString("VISION_BACKEND")))
+	}
+
+	slog.Info("Using vision backend", "backend", cnf.GetString("VISION_BACKEND"))
+	return &VisionService{
+		Backend:       backend,
+		MinConfidence: minConfidence,
+		MaxLabels:     maxLabels,
+		UsageService:  us,
+	}
+}
+
+// DetectLabels runs the configured backend, records the call for the user
+// and drops labels under the configured confidence threshold.
+func (s *VisionService) DetectLabels(ctx context.Context, userId int64, image []byte, mimeType string) ([]models.RequestScannedObject, error) {
+	start := time.Now()
+	labels, usage, err := s.Backend.DetectLabels(ctx, image, mimeType)
+
+	usage.Feature = FeatureTrashVision
+	usage.Latency = time.Since(start)
+	usage.Err = err
+	s.UsageService.Record(userId, usage)
+
+	if err != nil {
+		slog.Error("Failed to detect labels", "provider", usage.Provider, "err", err)
+		return nil, err
+	}
+
+	sort.SliceStable(labels, func(i, j int) bool {
+		return labels[i].Confidence > labels[j].Confidence
+	})
+
+	result := []models.RequestScannedObject{}
+	for _, label := range labels {
+		if label.Confidence < s.MinConfidence {
+			continue
+		}
+		if s.MaxLabels > 0 && len(result) >= s.MaxLabels {
+			break
+		}
+		result = append(result, label)
+	}
+
+	return result, nil
+}
+
+type rekognitionVision struct {
+	Client        *rekognition.Client
+	MinConfidence float32
+	MaxLabels     int
+}
+
+func (v *rekognitionVision) DetectLabels(ctx context.Context, image []byte, mimeType string) ([]models.RequestScannedObject, models.AIUsageRecord, error) {
+	usage := models.AIUsageRecord{
+		Provider: VisionRekognition,
+		Model:    "detect-labels",
+	}
+
+	output, err := v.Client.DetectLabels(ctx, &rekognition.DetectLabelsInput{
+		Image: &types.Image{
+			Bytes: image,
+		},
+		MaxLabels:     aws.Int32(int32(v.MaxLabels)),
+		MinConfidence: aws.Float32(v.MinConfidence),
+	})
+	if err != nil {
+		return nil, usage, err
+	}
+
+	var labels []models.RequestScannedObject
+	for _, l := range output.Labels {
+		var categories []models.Category
+		for _, c := range l.Categories {
+			categories = append(categories, models.Category{Name: aws.ToString(c.Name)})
+		}
+
+		labels = append(labels, models.RequestScannedObject{
+			Name:       aws.ToString(l.Name),
+			Confidence: aws.ToFloat32(l.Confidence),
+			Categories: categories,
+		})
+	}
+
+	return labels, usage, nil
+}
+
+type geminiVision struct {
+	Genai *genai.Client
+}
+
+func (v *geminiVision) DetectLabels(ctx context.Context, image []byte, mimeType string) ([]models.RequestScannedObject, models.AIUsageRecord, error) {
+	cnf := helpers.NewConfig()
+	usage := models.AIUsageRecord{
+		Provider: VisionGemini,
+		Model:    cnf.GetString("MODEL"),
+	}
+
+	model, err := configs.InitModel(v.Genai, cnf, configs.Vision)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	resp, err := model.GenerateContent(ctx,
+		genai.Blob{MIMEType: mimeType, Data: image},
+		genai.Text("List the objects visible in this image. Give each one a confidence between 0 and 100 and its general categories."),
+	)
+	if resp != nil && resp.UsageMetadata != nil {
+		usage.PromptTokens = int(resp.UsageMetadata.PromptTokenCount)
+		usage.CompletionTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+		usage.TotalTokens = int(resp.UsageMetadata.TotalTokenCount)
+	}
+	if err != nil {
+		return nil, usage, err
+	}
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return nil, usage, errors.New("gemini returned no candidates")
+	}
+
+	responseMsg := ""
+	for _, part := range resp.Candidates[0].Content.Parts {
+		responseMsg += fmt.Sprintf("%v\n", part)
+	}
+
+	var visionResponse models.AIResponseVision
+	err = json.Unmarshal([]byte(responseMsg), &visionResponse)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	var labels []models.RequestScannedObject
+	for _, l := range visionResponse.Labels {
+		var categories []models.Category
+		for _, c := range l.Categories {
+			categories = append(categories, models.Category{Name: c})
+		}
+
+		labels = append(labels, models.RequestScannedObject{
+			Name:       l.Name,
+			Confidence: l.Confidence,
+			Categories: categories,
+		})
+	}
+
+	return labels, usage, nil
+}
+
+// fixtureVision answers from JSON files instead of calling a provider.
+// It looks for <sha256 of the image>.json first and falls back to default.json.
+type fixtureVision struct {
+	Dir string
+}
+
+func (v *fixtureVision) DetectLabels(ctx context.Context, image []byte, mimeType string) ([]models.RequestScannedObject, models.AIUsageRecord, error) {
+	usage := models.AIUsageRecord{
+		Provider: VisionFixture,
+		Model:    "fixture",
+	}
+
+	sum := sha256.Sum256(image)
+	path := filepath.Join(v.Dir, hex.EncodeToString(sum[:])+".json")
+	if _, err := os.Stat(path); err != nil {
+		path = filepath.Join(v.Dir, "default.json")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	var labels []models.RequestScannedObject
+	err = json.Unmarshal(content, &labels)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	return labels, usage, nil
+}
+
+// MergeLabels combines the labels detected on several photos of the same
+// pile. Labels with the same name are kept once with their highest confidence
+// and the indexes of every image they were seen on.
+func MergeLabels(perImage [][]models.RequestScannedObject) []models.RequestScannedObject {
+	merged := []models.RequestScannedObject{}
+	index := map[string]int{}
+
+	for i, labels := range perImage {
+		for _, label := range labels {
+			name := strings.ToLower(strings.TrimSpace(label.Name))
+			pos, ok := index[name]
+			if !ok {
+				label.Images = []int{i}
+				index[name] = len(merged)
+				merged = append(merged, label)
+				continue
+			}
+
+			existing := &merged[pos]
+			if label.Confidence > existing.Confidence {
+				existing.Confidence = label.Confidence
+			}
+			if !slices.Contains(existing.Images, i) {
+				existing.Images = append(existing.Images, i)
+			}
+		}
+	}
+
+	return merged
+}
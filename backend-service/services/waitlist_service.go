@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/repositories"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type WaitlistService struct {
+	Repository *repositories.Queries
+}
+
+func NewWaitlistService(
+	rp *repositories.Queries,
+) *WaitlistService {
+	return &WaitlistService{
+		Repository: rp,
+	}
+}
+
+// PromoteNext gives a free seat of the event to the user that has waited the
+// longest. The entry is taken off the waitlist and registered in a single
+// transaction, so when the seat is gone by then the user keeps their place.
+// It reports whether the waitlist moved and returns the promoted entry, which
+// is empty when a stale entry of a user already holding a seat was dropped.
+func (s *WaitlistService) PromoteNext(ctx context.Context, eventId int64) (repositories.EventWaitlist, bool, error) {
+	var entry repositories.EventWaitlist
+
+	err := s.Repository.Tx(ctx, func(q *repositories.Queries) error {
+		var err error
+		entry, err = q.PopEventWaitlist(ctx, eventId)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.RegisterForEvent(ctx, repositories.RegisterForEventParams{
+			EventID:       eventId,
+			FromWaitlist:  true,
+			UserID:        entry.UserID,
+			ContactNumber: entry.ContactNumber,
+		})
+		return err
+	})
+	if err == nil {
+		return entry, true, nil
+	}
+
+	// empty waitlist or no seat left
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repositories.EventWaitlist{}, false, nil
+	}
+
+	// already holds a seat, the stale entry is simply dropped
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		_, err = s.Repository.DeleteEventWaitlist(ctx, repositories.DeleteEventWaitlistParams{
+			EventID: entry.EventID,
+			UserID:  entry.UserID,
+		})
+		if err != nil {
+			slog.Error("Failed to drop waitlist entry", "err", err)
+			return repositories.EventWaitlist{}, false, err
+		}
+		return repositories.EventWaitlist{}, true, nil
+	}
+
+	slog.Error("Failed to promote waitlist entry", "err", err)
+	return repositories.EventWaitlist{}, false, err
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"jirbthagoras/raksana-backend/repositories"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// eventState is what the waitlist queries of one event touch.
+type eventState struct {
+	seats     int
+	attendees map[int64]bool
+	waitlist  []repositories.EventWaitlist
+}
+
+func (s eventState) clone() eventState {
+	return eventState{
+		seats:     s.seats,
+		attendees: maps.Clone(s.attendees),
+		waitlist:  slices.Clone(s.waitlist),
+	}
+}
+
+// waitlistDB fakes the queries PromoteNext runs. A transaction works on a
+// copy of the state that only replaces it on commit.
+type waitlistDB struct {
+	state *eventState
+}
+
+func (db *waitlistDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	working := db.state.clone()
+	return &waitlistTx{db: waitlistDB{state: &working}, committed: db.state}, nil
+}
+
+func (db *waitlistDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if !strings.Contains(sql, "name: DeleteEventWaitlist") {
+		return pgconn.CommandTag{}, errors.New("unexpected exec: " + sql)
+	}
+
+	userId := args[1].(int64)
+	db.state.waitlist = slices.DeleteFunc(db.state.waitlist, func(e repositories.EventWaitlist) bool { return e.UserID == userId })
+	return pgconn.NewCommandTag("DELETE 1"), nil
+}
+
+func (db *waitlistDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query: " + sql)
+}
+
+func (db *waitlistDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	switch {
+	case strings.Contains(sql, "name: PopEventWaitlist"):
+		return rowFunc(func(dest ...any) error {
+			if len(db.state.waitlist) == 0 {
+				return pgx.ErrNoRows
+			}
+			entry := db.state.waitlist[0]
+			db.state.waitlist = db.state.waitlist[1:]
+			*dest[0].(*int64) = entry.ID
+			*dest[1].(*int64) = entry.EventID
+			*dest[2].(*int64) = entry.UserID
+			*dest[3].(*string) = entry.ContactNumber
+			return nil
+		})
+	case strings.Contains(sql, "name: RegisterForEvent"):
+		return rowFunc(func(dest ...any) error {
+			userId := args[2].(int64)
+			if len(db.state.attendees) >= db.state.seats {
+				return pgx.ErrNoRows
+			}
+			if db.state.attendees[userId] {
+				return &pgconn.PgError{Code: "23505"}
+			}
+			db.state.attendees[userId] = true
+			return nil
+		})
+	}
+	return rowFunc(func(dest ...any) error { return errors.New("unexpected query row: " + sql) })
+}
+
+type waitlistTx struct {
+	pgx.Tx
+	db        waitlistDB
+	committed *eventState
+}
+
+func (tx *waitlistTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx *waitlistTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx *waitlistTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+func (tx *waitlistTx) Commit(ctx context.Context) error {
+	*tx.committed = *tx.db.state
+	return nil
+}
+
+func (tx *waitlistTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+func TestPromoteNext(t *testing.T) {
+	waiting := func(userIds ...int64) []repositories.EventWaitlist {
+		var entries []repositories.EventWaitlist
+		for i, userId := range userIds {
+			entries = append(entries, repositories.EventWaitlist{ID: int64(i + 1), EventID: 1, UserID: userId})
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name         string
+		state        eventState
+		wantUser     int64
+		wantPromoted bool
+		wantWaiting  []int64
+	}{
+		{
+			name:        "empty waitlist",
+			state:       eventState{seats: 2, attendees: map[int64]bool{}},
+			wantWaiting: nil,
+		},
+		{
+			name:         "longest waiting user gets the seat",
+			state:        eventState{seats: 2, attendees: map[int64]bool{10: true}, waitlist: waiting(20, 30)},
+			wantUser:     20,
+			wantPromoted: true,
+			wantWaiting:  []int64{30},
+		},
+		{
+			name:        "no free seat keeps the user's place",
+			state:       eventState{seats: 1, attendees: map[int64]bool{10: true}, waitlist: waiting(20, 30)},
+			wantWaiting: []int64{20, 30},
+		},
+		{
+			name:         "stale entry of an attendee is dropped",
+			state:        eventState{seats: 2, attendees: map[int64]bool{20: true}, waitlist: waiting(20, 30)},
+			wantPromoted: true,
+			wantWaiting:  []int64{30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.state
+			s := NewWaitlistService(repositories.New(&waitlistDB{state: &state}))
+
+			entry, promoted, err := s.PromoteNext(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("PromoteNext() error = %v", err)
+			}
+			if promoted != tt.wantPromoted || entry.UserID != tt.wantUser {
+				t.Errorf("PromoteNext() = user %d, %v, want user %d, %v", entry.UserID, promoted, tt.wantUser, tt.wantPromoted)
+			}
+
+			var gotWaiting []int64
+			for _, e := range state.waitlist {
+				gotWaiting = append(gotWaiting, e.UserID)
+			}
+			if !slices.Equal(gotWaiting, tt.wantWaiting) {
+				t.Errorf("waitlist = %v, want %v", gotWaiting, tt.wantWaiting)
+			}
+			if tt.wantUser != 0 && !state.attendees[tt.wantUser] {
+				t.Errorf("user %d has no seat", tt.wantUser)
+			}
+		})
+	}
+}